@@ -39,7 +39,14 @@ var (
 	createSchemaCmd = &cobra.Command{
 		Use:   "create-schema",
 		Short: "Create schemas for multinode dashboard databases",
-		RunE:  cmdCreateSchema,
+		Long: "Create schemas for multinode dashboard databases.\n\n" +
+			"The schema can only be created in an empty database. Databases created before\n" +
+			"the alerts table was added have to be upgraded by hand, by running the\n" +
+			"CREATE TABLE alerts, CREATE TABLE node_accesses and CREATE INDEX alerts_*\n" +
+			"statements from multinode/multinodedb/dbx/multinodedb.dbx.pgx.sql and\n" +
+			"ALTER TABLE members ADD COLUMN role integer NOT NULL DEFAULT 1 (existing\n" +
+			"members become owners) and ALTER TABLE nodes ADD COLUMN revoked_api_secret bytea.",
+		RunE: cmdCreateSchema,
 	}
 	setupCmd = &cobra.Command{
 		Use:         "setup",
@@ -112,3 +112,24 @@ func unpackBinary(ctx context.Context, archive, target string) (err error) {
 	}
 	return nil
 }
+
+// copyBinary copies the executable binary to target.
+func copyBinary(source, target string) (err error) {
+	sourceFile, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, sourceFile.Close()) }()
+
+	targetFile, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(0755))
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, targetFile.Close()) }()
+
+	_, err = io.Copy(targetFile, sourceFile)
+	if err != nil {
+		return errs.Combine(err, os.Remove(target))
+	}
+	return nil
+}
@@ -57,6 +57,8 @@ var (
 
 		BinaryLocation string `help:"the storage node executable binary location" default:"storagenode"`
 		ServiceName    string `help:"storage node OS service name" default:"storagenode"`
+		HealthCheck    HealthCheckConfig
+		RollbackLog    string `help:"path of the log recording updates rolled back after failed health checks" default:"$CONFDIR/storagenode-updater-rollbacks.log"`
 		// deprecated
 		Log string `help:"deprecated, use --log.output" default:""`
 	}
@@ -111,6 +113,10 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 
 	ctx, _ := process.Ctx(cmd)
 
+	if err := process.InitMetricsWithCertPath(ctx, zap.L(), nil, runCfg.Identity.CertPath); err != nil {
+		zap.L().Warn("Failed to initialize telemetry batcher.", zap.Error(err))
+	}
+
 	switch {
 	case runCfg.Version.CheckInterval <= 0:
 		err = loopFunc(ctx)
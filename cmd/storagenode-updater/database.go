@@ -0,0 +1,90 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/private/tagsql"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+// databaseDir returns the directory of the storage node databases.
+func databaseDir() string {
+	return filepath.Dir(runCfg.DatabaseConfig().Info2)
+}
+
+// databaseVersions returns the migration version of every storage node
+// database in dir, keyed by the file name of the database.
+//
+// The storage node migrates its databases when it starts, after which the
+// previous binary can't open them anymore, so an update is only rolled back
+// when the versions didn't change.
+func databaseVersions(ctx context.Context, dir string) (_ map[string]int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.db"))
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+
+	versions := make(map[string]int, len(paths))
+	for _, path := range paths {
+		version, ok, err := databaseVersion(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			versions[filepath.Base(path)] = version
+		}
+	}
+	return versions, nil
+}
+
+// databaseVersion returns the migration version of the database at path and
+// false when the database doesn't have a versions table.
+func databaseVersion(ctx context.Context, path string) (version int, ok bool, err error) {
+	db, err := tagsql.Open(ctx, "sqlite3", "file:"+path+"?mode=ro&_busy_timeout=10000")
+	if err != nil {
+		return 0, false, errs.New("unable to open database %q: %v", path, err)
+	}
+	defer func() { err = errs.Combine(err, db.Close()) }()
+
+	var tables int
+	err = db.QueryRow(ctx, `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`, storagenodedb.VersionTable).Scan(&tables)
+	if err != nil {
+		return 0, false, errs.New("unable to read database %q: %v", path, err)
+	}
+	if tables == 0 {
+		return 0, false, nil
+	}
+
+	var latest sql.NullInt64
+	/* #nosec G202 */ // the table name is a constant
+	err = db.QueryRow(ctx, `SELECT MAX(version) FROM `+storagenodedb.VersionTable).Scan(&latest)
+	if err != nil {
+		return 0, false, errs.New("unable to read version of database %q: %v", path, err)
+	}
+	if !latest.Valid {
+		return -1, true, nil
+	}
+	return int(latest.Int64), true, nil
+}
+
+// sameVersions returns whether the database versions are equal.
+func sameVersions(a, b map[string]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, version := range a {
+		if other, ok := b[name]; !ok || other != version {
+			return false
+		}
+	}
+	return true
+}
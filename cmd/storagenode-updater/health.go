@@ -0,0 +1,190 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/cookiejar"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/sync2"
+	"storj.io/private/version"
+	"storj.io/storj/storagenode/console/consoleserver"
+)
+
+// HealthCheckConfig defines how the storage node is verified after an update.
+type HealthCheckConfig struct {
+	Address        string        `help:"address of the storage node dashboard used to verify the node after an update, defaults to console.address" default:""`
+	StartupTimeout time.Duration `help:"how long to wait for the storage node dashboard to come up after an update, the node migrates its databases before it starts the dashboard" default:"1h0m0s"`
+	Attempts       int           `help:"number of failed health checks after which an update is rolled back, 0 disables the verification" default:"6"`
+	Interval       time.Duration `help:"how long to wait before each health check after an update" default:"10s"`
+	Timeout        time.Duration `help:"timeout of a single health check" default:"5s"`
+}
+
+// healthChecker checks the storage node through its dashboard api.
+type healthChecker struct {
+	address string
+	timeout time.Duration
+	auth    consoleserver.AuthConfig
+	client  *http.Client
+}
+
+// newHealthChecker creates a health checker for the dashboard at address,
+// which signs in with auth when the dashboard requires authentication.
+func newHealthChecker(address string, timeout time.Duration, auth consoleserver.AuthConfig) *healthChecker {
+	// the jar keeps the session, so the checker doesn't sign in for every check.
+	jar, _ := cookiejar.New(nil)
+	return &healthChecker{
+		address: address,
+		timeout: timeout,
+		auth:    auth,
+		client:  &http.Client{Jar: jar},
+	}
+}
+
+// verifyUpdate waits for the restarted storage node to start and then checks
+// its health until it passes or the configured number of checks fail.
+func verifyUpdate(ctx context.Context, config HealthCheckConfig, checker *healthChecker, expected version.SemVer) (err error) {
+	if err := checker.waitStarted(ctx, config.StartupTimeout, config.Interval); err != nil {
+		return err
+	}
+
+	for attempt := 1; attempt <= config.Attempts; attempt++ {
+		err = checker.check(ctx, expected)
+		if err == nil {
+			return nil
+		}
+
+		zap.L().Warn("Health check failed.",
+			zap.Int("Attempt", attempt),
+			zap.Int("Attempts", config.Attempts),
+			zap.Error(err),
+		)
+
+		if attempt < config.Attempts && !sync2.Sleep(ctx, config.Interval) {
+			return ctx.Err()
+		}
+	}
+	return err
+}
+
+// waitStarted waits until the dashboard responds. The storage node migrates
+// its databases before starting the dashboard, which may take a while, so the
+// health checks only start counting once it's up.
+func (checker *healthChecker) waitStarted(ctx context.Context, timeout, interval time.Duration) (err error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		if !sync2.Sleep(ctx, interval) {
+			return ctx.Err()
+		}
+
+		if _, _, err := checker.get(ctx); err == nil {
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			return errs.New("node did not start within %s", timeout)
+		}
+	}
+}
+
+// check checks that the storage node dashboard responds and that it runs the
+// expected version.
+func (checker *healthChecker) check(ctx context.Context, expected version.SemVer) (err error) {
+	status, body, err := checker.get(ctx)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusUnauthorized && checker.auth.Token == "" && checker.auth.Password != "" {
+		// the node restarted or the session expired, sign in again.
+		if err := checker.login(ctx); err != nil {
+			return err
+		}
+		status, body, err = checker.get(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch status {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return errs.New("unable to read the node version, the dashboard requires authentication: %s", http.StatusText(status))
+	default:
+		return errs.New("bad status: %s", http.StatusText(status))
+	}
+
+	var dashboard struct {
+		Version version.SemVer `json:"version"`
+	}
+	if err := json.Unmarshal(body, &dashboard); err != nil {
+		return errs.Wrap(err)
+	}
+
+	if dashboard.Version.Compare(expected) != 0 {
+		return errs.New("node runs version %s, expected %s", dashboard.Version.String(), expected.String())
+	}
+	return nil
+}
+
+// get requests the dashboard, authorized with the configured token or the
+// session of the last sign in.
+func (checker *healthChecker) get(ctx context.Context) (status int, body []byte, err error) {
+	ctx, cancel := context.WithTimeout(ctx, checker.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+checker.address+"/api/sno/", nil)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	if checker.auth.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+checker.auth.Token)
+	}
+
+	resp, err := checker.client.Do(req)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, resp.Body.Close()) }()
+
+	body, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, errs.Wrap(err)
+	}
+	return resp.StatusCode, body, nil
+}
+
+// login signs in to the dashboard with the configured password.
+func (checker *healthChecker) login(ctx context.Context) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, checker.timeout)
+	defer cancel()
+
+	body, err := json.Marshal(map[string]string{"password": checker.auth.Password})
+	if err != nil {
+		return errs.Wrap(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+checker.address+"/api/auth/login", bytes.NewReader(body))
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := checker.client.Do(req)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, resp.Body.Close()) }()
+
+	if resp.StatusCode != http.StatusOK {
+		return errs.New("unable to sign in to the dashboard: %s", resp.Status)
+	}
+	return nil
+}
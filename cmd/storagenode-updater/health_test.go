@@ -0,0 +1,199 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/private/version"
+	"storj.io/storj/private/tagsql"
+	"storj.io/storj/storagenode/console/consoleserver"
+)
+
+const newVersionForTest = "v0.19.5"
+
+func TestCheckHealth(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	expected, err := version.NewSemVer(newVersionForTest)
+	require.NoError(t, err)
+
+	status, body := http.StatusOK, `{"version":"0.19.5"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/sno/", r.URL.Path)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+	checker := newHealthChecker(strings.TrimPrefix(server.URL, "http://"), time.Second, consoleserver.AuthConfig{})
+
+	require.NoError(t, checker.check(ctx, expected))
+
+	body = `{"version":"0.19.0"}`
+	require.Error(t, checker.check(ctx, expected))
+
+	// without the version the node can't be verified.
+	status, body = http.StatusUnauthorized, `{"error":"unauthorized"}`
+	require.Error(t, checker.check(ctx, expected))
+
+	status, body = http.StatusInternalServerError, `{"error":"internal"}`
+	require.Error(t, checker.check(ctx, expected))
+
+	server.Close()
+	require.Error(t, checker.check(ctx, expected))
+}
+
+func TestCheckHealthAuth(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	expected, err := version.NewSemVer(newVersionForTest)
+	require.NoError(t, err)
+
+	var logins int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth/login":
+			var login struct {
+				Password string `json:"password"`
+			}
+			if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&login)) || login.Password != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			logins++
+			http.SetCookie(w, &http.Cookie{Name: "_session", Value: "session", Path: "/"})
+		case "/api/sno/":
+			if cookie, err := r.Cookie("_session"); (err != nil || cookie.Value != "session") && r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"version":"0.19.5"}`))
+		default:
+			assert.Fail(t, "unexpected path", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	checker := newHealthChecker(address, time.Second, consoleserver.AuthConfig{Token: "token"})
+	require.NoError(t, checker.check(ctx, expected))
+	require.Zero(t, logins)
+
+	checker = newHealthChecker(address, time.Second, consoleserver.AuthConfig{Password: "secret"})
+	require.NoError(t, checker.check(ctx, expected))
+	require.NoError(t, checker.check(ctx, expected))
+	require.Equal(t, 1, logins)
+
+	checker = newHealthChecker(address, time.Second, consoleserver.AuthConfig{Password: "wrong"})
+	require.Error(t, checker.check(ctx, expected))
+}
+
+func TestVerifyUpdate(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	expected, err := version.NewSemVer(newVersionForTest)
+	require.NoError(t, err)
+
+	var checks int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		checks++
+		if checks < 4 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"version":"0.19.5"}`))
+	}))
+	defer server.Close()
+	checker := newHealthChecker(strings.TrimPrefix(server.URL, "http://"), time.Second, consoleserver.AuthConfig{})
+
+	// the first request only waits for the node to start.
+	config := HealthCheckConfig{StartupTimeout: time.Second, Attempts: 2, Interval: time.Millisecond}
+	require.Error(t, verifyUpdate(ctx, config, checker, expected))
+	require.Equal(t, 3, checks)
+
+	checks = 0
+	config.Attempts = 3
+	require.NoError(t, verifyUpdate(ctx, config, checker, expected))
+	require.Equal(t, 4, checks)
+
+	// a node which doesn't start fails once the startup timeout elapses.
+	server.Close()
+	config.StartupTimeout = 10 * time.Millisecond
+	require.Error(t, verifyUpdate(ctx, config, checker, expected))
+}
+
+func TestDatabaseVersions(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	dir := ctx.Dir("storage")
+
+	versions, err := databaseVersions(ctx, dir)
+	require.NoError(t, err)
+	require.Empty(t, versions)
+
+	db, err := tagsql.Open(ctx, "sqlite3", "file:"+filepath.Join(dir, "bandwidth.db"))
+	require.NoError(t, err)
+	defer ctx.Check(db.Close)
+
+	_, err = db.Exec(ctx, `CREATE TABLE versions (version int, commited_at text)`) //nolint:misspell
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, `INSERT INTO versions (version) VALUES (1), (2)`)
+	require.NoError(t, err)
+
+	before, err := databaseVersions(ctx, dir)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"bandwidth.db": 2}, before)
+
+	_, err = db.Exec(ctx, `INSERT INTO versions (version) VALUES (3)`)
+	require.NoError(t, err)
+
+	after, err := databaseVersions(ctx, dir)
+	require.NoError(t, err)
+	require.True(t, sameVersions(before, before))
+	require.False(t, sameVersions(before, after))
+}
+
+func TestRollbackLog(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	path := ctx.File("rollbacks.log")
+
+	failed, err := version.NewSemVer(newVersionForTest)
+	require.NoError(t, err)
+
+	skip, err := rolledBack(path, "storagenode", failed)
+	require.NoError(t, err)
+	require.False(t, skip)
+
+	require.NoError(t, recordRollback(path, rollbackEvent{
+		Service:     "storagenode",
+		FromVersion: failed.String(),
+		ToVersion:   "v0.19.0",
+		Reason:      "health check failed",
+		RolledBack:  time.Now(),
+	}))
+
+	skip, err = rolledBack(path, "storagenode", failed)
+	require.NoError(t, err)
+	require.True(t, skip)
+
+	skip, err = rolledBack(path, updaterServiceName, failed)
+	require.NoError(t, err)
+	require.False(t, skip)
+}
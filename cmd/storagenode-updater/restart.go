@@ -13,6 +13,10 @@ import (
 	"github.com/zeebo/errs"
 )
 
+// serviceRestarts reports whether the updated service runs the new binary
+// after restartService. restartService only replaces the binary, the service is not restarted.
+const serviceRestarts = false
+
 func cmdRestart(cmd *cobra.Command, args []string) error {
 	return nil
 }
@@ -16,6 +16,10 @@ import (
 	"github.com/zeebo/errs"
 )
 
+// serviceRestarts reports whether the updated service runs the new binary
+// after restartService. restartService stops the service, which systemd starts again with the new binary.
+const serviceRestarts = true
+
 func cmdRestart(cmd *cobra.Command, args []string) error {
 	return nil
 }
@@ -22,6 +22,10 @@ import (
 
 var unrecoverableErr = errs.Class("unable to recover binary from backup")
 
+// serviceRestarts reports whether the updated service runs the new binary
+// after restartService. restartService starts the service with the new binary.
+const serviceRestarts = true
+
 func cmdRestart(cmd *cobra.Command, args []string) (err error) {
 	ctx, _ := process.Ctx(cmd)
 
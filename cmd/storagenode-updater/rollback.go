@@ -0,0 +1,89 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/private/version"
+)
+
+var mon = monkit.Package()
+
+// rollbackEvent is an update which was rolled back because the updated
+// service did not pass the health checks.
+type rollbackEvent struct {
+	Service     string    `json:"service"`
+	FromVersion string    `json:"fromVersion"`
+	ToVersion   string    `json:"toVersion"`
+	Reason      string    `json:"reason"`
+	RolledBack  time.Time `json:"rolledBack"`
+}
+
+// recordRollback appends the event to the rollback log and reports it.
+func recordRollback(path string, event rollbackEvent) (err error) {
+	mon.Event("update_rolled_back")
+
+	zap.L().Error("Update rolled back.",
+		zap.String("Service", event.Service),
+		zap.String("From Version", event.FromVersion),
+		zap.String("To Version", event.ToVersion),
+		zap.String("Reason", event.Reason),
+	)
+
+	if path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, file.Close()) }()
+
+	_, err = file.Write(append(data, '\n'))
+	return errs.Wrap(err)
+}
+
+// rolledBack returns whether an update of the service to the version was
+// rolled back before, so that the updater does not retry a broken version.
+func rolledBack(path, service string, ver version.SemVer) (_ bool, err error) {
+	if path == "" {
+		return false, nil
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errs.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, file.Close()) }()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event rollbackEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			// skip entries damaged by an interrupted write.
+			continue
+		}
+		if event.Service == service && event.FromVersion == ver.String() {
+			return true, nil
+		}
+	}
+	return false, errs.Wrap(scanner.Err())
+}
@@ -6,10 +6,12 @@ package main
 import (
 	"context"
 	"os"
+	"time"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
+	"storj.io/common/errs2"
 	"storj.io/private/version"
 )
 
@@ -39,6 +41,18 @@ func update(ctx context.Context, serviceName, binaryLocation string, ver version
 		return nil
 	}
 
+	skip, err := rolledBack(runCfg.RollbackLog, serviceName, suggestedVersion)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	if skip {
+		zap.L().Info("Update to version was rolled back before, skipping.",
+			zap.String("Service", serviceName),
+			zap.String("Version", suggestedVersion.String()),
+		)
+		return nil
+	}
+
 	newVersionPath := prependExtension(binaryLocation, ver.Suggested.Version)
 
 	if err = downloadBinary(ctx, parseDownloadURL(ver.Suggested.URL), newVersionPath); err != nil {
@@ -66,6 +80,21 @@ func update(ctx context.Context, serviceName, binaryLocation string, ver version
 		backupPath = prependExtension(binaryLocation, "old."+currentVersion.String())
 	}
 
+	// the updater can't verify itself and without a restart the old binary
+	// keeps running, so there's nothing to verify.
+	verify := serviceName != updaterServiceName && serviceRestarts && runCfg.HealthCheck.Attempts > 0
+
+	var versions map[string]int
+	if verify {
+		versions, err = databaseVersions(ctx, databaseDir())
+		if err != nil {
+			zap.L().Warn("Unable to read database versions, a failed update won't be rolled back.",
+				zap.String("Service", serviceName),
+				zap.Error(err),
+			)
+		}
+	}
+
 	zap.L().Info("Restarting service.", zap.String("Service", serviceName))
 
 	if err = restartService(ctx, serviceName, binaryLocation, newVersionPath, backupPath); err != nil {
@@ -73,5 +102,70 @@ func update(ctx context.Context, serviceName, binaryLocation string, ver version
 	}
 
 	zap.L().Info("Service restarted successfully.", zap.String("Service", serviceName))
-	return nil
+
+	if !verify {
+		return nil
+	}
+
+	address := runCfg.HealthCheck.Address
+	if address == "" {
+		address = runCfg.Console.Address
+	}
+	checker := newHealthChecker(address, runCfg.HealthCheck.Timeout, runCfg.Console.Auth)
+
+	err = verifyUpdate(ctx, runCfg.HealthCheck, checker, suggestedVersion)
+	if err == nil {
+		zap.L().Info("Service passed health check.", zap.String("Service", serviceName))
+		return nil
+	}
+	if errs2.IsCanceled(err) {
+		return err
+	}
+
+	// the previous binary can't run on databases migrated by the new one.
+	if versions == nil {
+		return errs.Combine(err, errs.New("not rolling back, database versions are unknown"))
+	}
+	current, verr := databaseVersions(ctx, databaseDir())
+	if verr != nil {
+		return errs.Combine(err, errs.New("not rolling back, unable to read database versions: %v", verr))
+	}
+	if !sameVersions(versions, current) {
+		return errs.Combine(err, errs.New("not rolling back, version %s migrated the databases", suggestedVersion.String()))
+	}
+
+	return rollback(ctx, serviceName, binaryLocation, backupPath, currentVersion, suggestedVersion, err)
+}
+
+// rollback restores the previous binary after the updated service failed the
+// health checks. The failed binary and the backup of the previous binary are kept.
+func rollback(ctx context.Context, serviceName, binaryLocation, backupPath string, previousVersion, failedVersion version.SemVer, reason error) error {
+	zap.L().Warn("Rolling back update.",
+		zap.String("Service", serviceName),
+		zap.String("Version", failedVersion.String()),
+		zap.Error(reason),
+	)
+
+	// restarting consumes the binary it starts, so restart with a copy to keep the backup.
+	rollbackPath := prependExtension(binaryLocation, "rollback."+previousVersion.String())
+	if err := copyBinary(backupPath, rollbackPath); err != nil {
+		return errs.Combine(reason, errs.New("unable to roll back: %v", err))
+	}
+
+	failedPath := prependExtension(binaryLocation, "failed."+failedVersion.String())
+	if err := os.Remove(failedPath); err != nil && !os.IsNotExist(err) {
+		return errs.Combine(reason, errs.New("unable to roll back: %v", err), os.Remove(rollbackPath))
+	}
+
+	if err := restartService(ctx, serviceName, binaryLocation, rollbackPath, failedPath); err != nil {
+		return errs.Combine(reason, errs.New("unable to roll back: %v", err))
+	}
+
+	return recordRollback(runCfg.RollbackLog, rollbackEvent{
+		Service:     serviceName,
+		FromVersion: failedVersion.String(),
+		ToVersion:   previousVersion.String(),
+		Reason:      reason.Error(),
+		RolledBack:  time.Now().UTC(),
+	})
 }
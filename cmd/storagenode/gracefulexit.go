@@ -6,11 +6,15 @@ package main
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/zeebo/errs"
@@ -22,6 +26,8 @@ import (
 	"storj.io/private/process"
 	"storj.io/storj/private/date"
 	"storj.io/storj/private/prompt"
+	"storj.io/storj/storagenode/console/consoleapi"
+	"storj.io/storj/storagenode/console/consoleserver"
 	"storj.io/storj/storagenode/internalpb"
 )
 
@@ -182,9 +188,79 @@ func cmdGracefulExitStatus(cmd *cobra.Command, args []string) (err error) {
 	}()
 
 	displayExitProgress(w, progresses.GetProgress())
+
+	// transfer statistics are served by the console api of the running node
+	transfers, err := getTransferProgress(ctx, diagCfg.Console)
+	if err != nil {
+		fmt.Fprintf(w, "\nTransfer statistics are not available: %v\n", err)
+		return nil
+	}
+	displayTransferProgress(w, transfers)
 	return nil
 }
 
+// getTransferProgress fetches the graceful exit transfer statistics from the console api.
+func getTransferProgress(ctx context.Context, console consoleserver.Config) (_ []consoleapi.GracefulExitProgress, err error) {
+	endpoint := url.URL{
+		Scheme: "http",
+		Host:   console.Address,
+		Path:   "/api/gracefulexit/progress",
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	if err := authorizeConsoleRequest(ctx, request, console.Address, console.Auth); err != nil {
+		return nil, err
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, errs.New("could not reach the storage node console at %s: %v", console.Address, err)
+	}
+	defer func() { err = errs.Combine(err, response.Body.Close()) }()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errs.New("unexpected status from the storage node console: %s", response.Status)
+	}
+
+	var progress []consoleapi.GracefulExitProgress
+	if err := json.NewDecoder(response.Body).Decode(&progress); err != nil {
+		return nil, errs.New("invalid response from the storage node console: %v", err)
+	}
+	return progress, nil
+}
+
+func displayTransferProgress(w io.Writer, progresses []consoleapi.GracefulExitProgress) {
+	for _, progress := range progresses {
+		if progress.FinishedAt != nil {
+			continue
+		}
+
+		fmt.Fprintf(w, "\nTransfers for %s: %d pieces (%s) transferred, %d failed, %s/s\n",
+			progress.SatelliteID, progress.PiecesTransferred, memory.Size(progress.BytesTransferred),
+			progress.PiecesFailed, memory.Size(progress.Throughput))
+
+		if len(progress.Stats) > 0 {
+			fmt.Fprintln(w, "Result\tPieces\tSize\tAverage Duration\tLast Error")
+			for _, stat := range progress.Stats {
+				var average time.Duration
+				if stat.Pieces > 0 {
+					average = stat.Duration / time.Duration(stat.Pieces)
+				}
+				fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t\n", stat.Reason, stat.Pieces, memory.Size(stat.Bytes), average.Round(time.Millisecond), stat.LastError)
+			}
+		}
+
+		if len(progress.Failures) > 0 {
+			fmt.Fprintln(w, "\nFailed At\tPiece ID\tReason\tError")
+			for _, failure := range progress.Failures {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n", failure.FailedAt.Format(time.RFC3339), failure.PieceID, failure.Reason, failure.Error)
+			}
+		}
+	}
+}
+
 func displayExitProgress(w io.Writer, progresses []*internalpb.ExitProgress) {
 	fmt.Fprintln(w, "\nDomain Name\tNode ID\tPercent Complete\tSuccessful\tCompletion Receipt")
 
@@ -25,6 +25,7 @@ import (
 	_ "storj.io/storj/private/version" // This attaches version information during release builds.
 	"storj.io/storj/storagenode"
 	"storj.io/storj/storagenode/apikeys"
+	"storj.io/storj/storagenode/storagemigration"
 	"storj.io/storj/storagenode/storagenodedb"
 )
 
@@ -91,6 +92,75 @@ var (
 		Short: "Issue apikey for mnd",
 		RunE:  cmdIssue,
 	}
+	retainReportCmd = &cobra.Command{
+		Use:         "retain-report",
+		Short:       "Display reports of retain requests received from satellites",
+		RunE:        cmdRetainReport,
+		Annotations: map[string]string{"type": "helper"},
+	}
+	migrateStorageCmd = &cobra.Command{
+		Use:   "migrate-storage",
+		Short: "Migrate pieces and databases to a new location",
+		Long: "Copy pieces from the previous data path to the configured storage.path while the storage node is running.\n" +
+			"The storage node must be running with --storage2.migration-source set to the previous data path, " +
+			"so that it reads the pieces which have not been migrated yet from there. " +
+			"Databases are migrated when --from-database-dir is set. The migration can be resumed after an interruption.",
+		RunE:        cmdMigrateStorage,
+		Annotations: map[string]string{"type": "helper"},
+	}
+	payoutReportCmd = &cobra.Command{
+		Use:   "payout-report",
+		Short: "Compare the usage measured by the storage node with the paystubs of satellites as CSV",
+		Long: "Compare egress, repair egress, audit egress and storage measured by the storage node with the usage the satellites paid for.\n" +
+			"Discrepancies are percentages of the difference between the satellite and the local value relative to the local value.",
+		RunE:        cmdPayoutReport,
+		Annotations: map[string]string{"type": "helper"},
+	}
+	ordersCmd = &cobra.Command{
+		Use:         "orders",
+		Short:       "Inspect the orders files",
+		Annotations: map[string]string{"type": "helper"},
+	}
+	ordersUnsentCmd = &cobra.Command{
+		Use:         "unsent",
+		Short:       "List the windows of orders which have not been sent to satellites yet",
+		RunE:        cmdOrdersUnsent,
+		Annotations: map[string]string{"type": "helper"},
+	}
+	ordersArchivedCmd = &cobra.Command{
+		Use:         "archived",
+		Short:       "Display the status of orders sent to satellites",
+		RunE:        cmdOrdersArchived,
+		Annotations: map[string]string{"type": "helper"},
+	}
+	ordersSendCmd = &cobra.Command{
+		Use:   "send",
+		Short: "Send the unsent orders of a satellite immediately",
+		Long: "Ask the running storage node to send the unsent orders of the satellite now, instead of waiting for the next sending interval.\n" +
+			"Only windows which don't accept new orders anymore are sent.",
+		RunE:        cmdOrdersSend,
+		Annotations: map[string]string{"type": "helper"},
+	}
+	ordersExportCmd = &cobra.Command{
+		Use:         "export",
+		Short:       "Export archived orders as CSV",
+		RunE:        cmdOrdersExport,
+		Annotations: map[string]string{"type": "helper"},
+	}
+	ordersVerifyCmd = &cobra.Command{
+		Use:         "verify",
+		Short:       "Scan the orders directory for corrupt orders files",
+		RunE:        cmdOrdersVerify,
+		Annotations: map[string]string{"type": "helper"},
+	}
+	ordersDowngradeCmd = &cobra.Command{
+		Use:   "downgrade",
+		Short: "Convert the orders files to the format read by previous releases",
+		Long: "Convert v2 orders files to v1 before rolling back to a release which doesn't support v2 orders files.\n" +
+			"The storage node must be stopped while the orders files are converted.",
+		RunE:        cmdOrdersDowngrade,
+		Annotations: map[string]string{"type": "helper"},
+	}
 
 	runCfg       StorageNodeFlags
 	setupCfg     StorageNodeFlags
@@ -98,6 +168,43 @@ var (
 	dashboardCfg struct {
 		Address string `default:"127.0.0.1:7778" help:"address for dashboard service"`
 	}
+	retainReportCfg struct {
+		storagenode.Config
+
+		Satellite string `default:"" help:"only display reports of this satellite"`
+		Limit     int    `default:"20" help:"maximum number of reports to display"`
+	}
+	migrateStorageCfg struct {
+		storagenode.Config
+
+		From            string `default:"" help:"data path which pieces are migrated from, defaults to storage2.migration-source"`
+		FromDatabaseDir string `default:"" help:"directory which databases are migrated from, databases are not migrated when empty"`
+		Migration       storagemigration.Config
+	}
+	payoutReportCfg struct {
+		storagenode.Config
+
+		Satellite   string `default:"" help:"only report payouts of this satellite"`
+		PeriodStart string `default:"" help:"first period of the report in format yyyy-mm, defaults to the previous month"`
+		PeriodEnd   string `default:"" help:"last period of the report in format yyyy-mm, defaults to the period start"`
+		Output      string `default:"-" help:"path of the CSV file to write, - for stdout"`
+	}
+	ordersCfg struct {
+		storagenode.Config
+
+		Satellite string `default:"" help:"only display orders of this satellite"`
+	}
+	ordersSendCfg struct {
+		storagenode.Config
+
+		Satellite string `default:"" help:"satellite to send the orders to"`
+	}
+	ordersExportCfg struct {
+		storagenode.Config
+
+		Satellite string `default:"" help:"only export orders of this satellite"`
+		Output    string `default:"-" help:"path of the CSV file to write, - for stdout"`
+	}
 	defaultDiagDir string
 	confDir        string
 	identityDir    string
@@ -126,6 +233,16 @@ func init() {
 	rootCmd.AddCommand(gracefulExitInitCmd)
 	rootCmd.AddCommand(gracefulExitStatusCmd)
 	rootCmd.AddCommand(issueAPITokenCmd)
+	rootCmd.AddCommand(retainReportCmd)
+	rootCmd.AddCommand(migrateStorageCmd)
+	rootCmd.AddCommand(payoutReportCmd)
+	rootCmd.AddCommand(ordersCmd)
+	ordersCmd.AddCommand(ordersUnsentCmd)
+	ordersCmd.AddCommand(ordersArchivedCmd)
+	ordersCmd.AddCommand(ordersSendCmd)
+	ordersCmd.AddCommand(ordersExportCmd)
+	ordersCmd.AddCommand(ordersVerifyCmd)
+	ordersCmd.AddCommand(ordersDowngradeCmd)
 	process.Bind(runCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(setupCmd, &setupCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir), cfgstruct.SetupMode())
 	process.Bind(configCmd, &setupCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir), cfgstruct.SetupMode())
@@ -134,6 +251,15 @@ func init() {
 	process.Bind(gracefulExitInitCmd, &diagCfg, defaults, cfgstruct.ConfDir(defaultDiagDir))
 	process.Bind(gracefulExitStatusCmd, &diagCfg, defaults, cfgstruct.ConfDir(defaultDiagDir))
 	process.Bind(issueAPITokenCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(retainReportCmd, &retainReportCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(migrateStorageCmd, &migrateStorageCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(payoutReportCmd, &payoutReportCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(ordersUnsentCmd, &ordersCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(ordersArchivedCmd, &ordersCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(ordersSendCmd, &ordersSendCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(ordersExportCmd, &ordersExportCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(ordersVerifyCmd, &ordersCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(ordersDowngradeCmd, &ordersCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 }
 
 func cmdRun(cmd *cobra.Command, args []string) (err error) {
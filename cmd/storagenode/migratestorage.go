@@ -0,0 +1,129 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/private/process"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storagenode/storagemigration"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+func cmdMigrateStorage(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+	log := zap.L()
+
+	from := migrateStorageCfg.From
+	if from == "" {
+		from = migrateStorageCfg.Storage2.MigrationSource
+	}
+	if from == "" && migrateStorageCfg.FromDatabaseDir == "" {
+		return errs.New("Nothing to migrate: neither --from nor --from-database-dir is set")
+	}
+
+	identity, err := migrateStorageCfg.Identity.Load()
+	if err != nil {
+		return errs.New("Failed to load identity: %v", err)
+	}
+
+	dbConfig := migrateStorageCfg.DatabaseConfig()
+	if migrateStorageCfg.FromDatabaseDir != "" {
+		if err := migrateDatabases(cmd, migrateStorageCfg.FromDatabaseDir, filepath.Dir(dbConfig.Info2)); err != nil {
+			return err
+		}
+	}
+
+	if from == "" {
+		return nil
+	}
+	if samePath(from, migrateStorageCfg.Storage.Path) {
+		return errs.New("Source and destination of the migration are the same: %s", from)
+	}
+	// the storage node only finds the pieces which aren't migrated yet when it
+	// reads them from the migration source.
+	if !samePath(from, migrateStorageCfg.Storage2.MigrationSource) {
+		return errs.New("The storage node has to run with --storage2.migration-source=%s while pieces are migrated", from)
+	}
+
+	fromDir, err := filestore.OpenDir(log.Named("from"), from)
+	if err != nil {
+		return errs.New("Failed to open source storage directory: %v", err)
+	}
+	fromBlobs := filestore.New(log.Named("from"), fromDir, migrateStorageCfg.Filestore)
+	defer func() { err = errs.Combine(err, fromBlobs.Close()) }()
+
+	toDir, err := filestore.NewDir(log.Named("to"), migrateStorageCfg.Storage.Path)
+	if err != nil {
+		return errs.New("Failed to open destination storage directory: %v", err)
+	}
+	toBlobs := filestore.New(log.Named("to"), toDir, migrateStorageCfg.Filestore)
+	defer func() { err = errs.Combine(err, toBlobs.Close()) }()
+
+	// the migrator accesses both locations directly, the database is only needed for v0 pieces.
+	dbConfig.PiecesMigrationSource = ""
+	db, err := storagenodedb.OpenExisting(ctx, log.Named("db"), dbConfig)
+	if err != nil {
+		return errs.New("Error starting master database on storage node: %v", err)
+	}
+	defer func() { err = errs.Combine(err, db.Close()) }()
+
+	migrator := storagemigration.NewMigrator(log.Named("migration"), fromBlobs, toBlobs, db.V0PieceInfo(), migrateStorageCfg.Migration)
+	if err := migrator.MigrateVerificationFile(ctx, identity.ID); err != nil {
+		return err
+	}
+
+	err = migrator.Run(ctx)
+
+	stats := migrator.Stats()
+	fmt.Printf("Migrated %d pieces (%s), %d already migrated, %d converted from storage format v0, %d failed.\n",
+		stats.Migrated, memory.Size(stats.Bytes).Base10String(), stats.Skipped, stats.ConvertedV0, stats.Failed)
+	if err != nil {
+		return errs.New("Migration interrupted, run the command again to resume: %v", err)
+	}
+	if stats.Failed > 0 {
+		fmt.Println("Some pieces failed to migrate, run the command again to retry them.")
+	}
+	return nil
+}
+
+// migrateDatabases copies the databases from the previous database directory.
+func migrateDatabases(cmd *cobra.Command, fromDir, toDir string) error {
+	ctx, _ := process.Ctx(cmd)
+
+	if samePath(fromDir, toDir) {
+		return errs.New("Source and destination of the database migration are the same: %s", fromDir)
+	}
+
+	migrated, err := storagenodedb.MigrateDatabaseDir(ctx, zap.L().Named("db"), fromDir, toDir)
+	if err != nil {
+		return errs.New("Failed to migrate databases: %v", err)
+	}
+
+	fmt.Printf("Migrated %d databases to %s.\n", len(migrated), toDir)
+	if len(migrated) > 0 {
+		fmt.Println("Restart the storage node with the new database directory, changes made to the old databases from now on are not migrated.")
+	}
+	return nil
+}
+
+// samePath returns whether both paths refer to the same location.
+func samePath(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	if errA != nil || errB != nil {
+		return filepath.Clean(a) == filepath.Clean(b)
+	}
+	return absA == absB
+}
@@ -0,0 +1,435 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/private/process"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/console/consoleserver"
+	"storj.io/storj/storagenode/orders"
+	"storj.io/storj/storagenode/orders/ordersfile"
+)
+
+func openOrdersStore(config storagenode.Config) (*orders.FileStore, error) {
+	store, err := orders.NewFileStore(zap.L().Named("ordersfilestore"), config.Storage2.Orders.Path, config.Storage2.OrderLimitGracePeriod)
+	if err != nil {
+		return nil, errs.New("Error opening orders directory %q: %v", config.Storage2.Orders.Path, err)
+	}
+	return store, nil
+}
+
+// parseSatelliteFilter parses the satellite ID flag, which may be empty.
+func parseSatelliteFilter(value string) (storj.NodeID, error) {
+	if value == "" {
+		return storj.NodeID{}, nil
+	}
+	satelliteID, err := storj.NodeIDFromString(value)
+	if err != nil {
+		return storj.NodeID{}, errs.New("Invalid satellite ID: %v", err)
+	}
+	return satelliteID, nil
+}
+
+func cmdOrdersUnsent(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	satelliteID, err := parseSatelliteFilter(ordersCfg.Satellite)
+	if err != nil {
+		return err
+	}
+
+	store, err := openOrdersStore(ordersCfg.Config)
+	if err != nil {
+		return err
+	}
+
+	windows, err := store.ListUnsentWindows(ctx, time.Now())
+	if err != nil {
+		return errs.New("Error while listing unsent orders: %v", err)
+	}
+
+	if len(windows) == 0 {
+		fmt.Println("No unsent orders.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer func() { err = errs.Combine(err, w.Flush()) }()
+
+	fmt.Fprintln(w, "Satellite\tWindow\tStatus\tOrders\tAmount\tBy Action\tCorrupt\t")
+	for _, window := range windows {
+		if !satelliteID.IsZero() && window.SatelliteID != satelliteID {
+			continue
+		}
+
+		status := "accepting orders"
+		if window.Ready {
+			status = "ready to send"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\t%d\t\n",
+			window.SatelliteID,
+			window.CreatedAtHour.UTC().Format(time.RFC3339),
+			status,
+			window.Count,
+			memory.Size(window.Amount).Base10String(),
+			formatActionAmounts(window.Amounts),
+			window.Lost,
+		)
+	}
+
+	return nil
+}
+
+// formatActionAmounts formats amounts by piece action, ordered by action.
+func formatActionAmounts(amounts map[pb.PieceAction]int64) string {
+	actions := make([]pb.PieceAction, 0, len(amounts))
+	for action := range amounts {
+		actions = append(actions, action)
+	}
+	sort.Slice(actions, func(i, k int) bool { return actions[i] < actions[k] })
+
+	formatted := make([]string, 0, len(actions))
+	for _, action := range actions {
+		formatted = append(formatted, action.String()+" "+memory.Size(amounts[action]).Base10String())
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// archivedWindow is a summary of the orders of a window archived together.
+type archivedWindow struct {
+	SatelliteID   storj.NodeID
+	CreatedAtHour time.Time
+	ArchivedAt    time.Time
+	Status        orders.Status
+	Count         int
+	Amount        int64
+}
+
+func cmdOrdersArchived(cmd *cobra.Command, args []string) (err error) {
+	satelliteID, err := parseSatelliteFilter(ordersCfg.Satellite)
+	if err != nil {
+		return err
+	}
+
+	store, err := openOrdersStore(ordersCfg.Config)
+	if err != nil {
+		return err
+	}
+
+	archived, err := store.ListArchived()
+	if err != nil {
+		return errs.New("Error while listing archived orders: %v", err)
+	}
+
+	type windowKey struct {
+		satelliteID   storj.NodeID
+		createdAtHour int64
+		archivedAt    int64
+		status        orders.Status
+	}
+	byWindow := make(map[windowKey]*archivedWindow)
+	for _, info := range archived {
+		if !satelliteID.IsZero() && info.Limit.SatelliteId != satelliteID {
+			continue
+		}
+
+		createdAtHour := info.Limit.OrderCreation.Truncate(time.Hour)
+		key := windowKey{
+			satelliteID:   info.Limit.SatelliteId,
+			createdAtHour: createdAtHour.UnixNano(),
+			archivedAt:    info.ArchivedAt.UnixNano(),
+			status:        info.Status,
+		}
+		window, ok := byWindow[key]
+		if !ok {
+			window = &archivedWindow{
+				SatelliteID:   info.Limit.SatelliteId,
+				CreatedAtHour: createdAtHour,
+				ArchivedAt:    info.ArchivedAt,
+				Status:        info.Status,
+			}
+			byWindow[key] = window
+		}
+		window.Count++
+		window.Amount += info.Order.Amount
+	}
+
+	if len(byWindow) == 0 {
+		fmt.Println("No archived orders.")
+		return nil
+	}
+
+	windows := make([]*archivedWindow, 0, len(byWindow))
+	for _, window := range byWindow {
+		windows = append(windows, window)
+	}
+	sort.Slice(windows, func(i, k int) bool {
+		if !windows[i].ArchivedAt.Equal(windows[k].ArchivedAt) {
+			return windows[i].ArchivedAt.After(windows[k].ArchivedAt)
+		}
+		return windows[i].SatelliteID.Less(windows[k].SatelliteID)
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer func() { err = errs.Combine(err, w.Flush()) }()
+
+	fmt.Fprintln(w, "Archived\tSatellite\tWindow\tStatus\tOrders\tAmount\t")
+	for _, window := range windows {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t\n",
+			window.ArchivedAt.UTC().Format(time.RFC3339),
+			window.SatelliteID,
+			window.CreatedAtHour.UTC().Format(time.RFC3339),
+			window.Status,
+			window.Count,
+			memory.Size(window.Amount).Base10String(),
+		)
+	}
+
+	return nil
+}
+
+func cmdOrdersSend(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	if ordersSendCfg.Satellite == "" {
+		return errs.New("--satellite is required")
+	}
+	satelliteID, err := parseSatelliteFilter(ordersSendCfg.Satellite)
+	if err != nil {
+		return err
+	}
+
+	endpoint := url.URL{
+		Scheme: "http",
+		Host:   ordersSendCfg.Console.Address,
+		Path:   "/api/orders/send/" + satelliteID.String(),
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), nil)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	if err := authorizeConsoleRequest(ctx, request, ordersSendCfg.Console.Address, ordersSendCfg.Console.Auth); err != nil {
+		return err
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return errs.New("Could not reach the storage node at %s, is it running? %v", ordersSendCfg.Console.Address, err)
+	}
+	defer func() { err = errs.Combine(err, response.Body.Close()) }()
+
+	var result struct {
+		Windows int    `json:"windows"`
+		Error   string `json:"error"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return errs.New("Invalid response from the storage node: %v", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return errs.New("Sending orders failed: %s", result.Error)
+	}
+
+	fmt.Printf("Sent %d windows of orders to %s.\n", result.Windows, satelliteID)
+	return nil
+}
+
+func cmdOrdersExport(cmd *cobra.Command, args []string) (err error) {
+	satelliteID, err := parseSatelliteFilter(ordersExportCfg.Satellite)
+	if err != nil {
+		return err
+	}
+
+	store, err := openOrdersStore(ordersExportCfg.Config)
+	if err != nil {
+		return err
+	}
+
+	archived, err := store.ListArchived()
+	if err != nil {
+		return errs.New("Error while listing archived orders: %v", err)
+	}
+
+	var output io.Writer = os.Stdout
+	if ordersExportCfg.Output != "-" {
+		file, err := os.Create(filepath.Clean(ordersExportCfg.Output))
+		if err != nil {
+			return errs.New("Error creating %q: %v", ordersExportCfg.Output, err)
+		}
+		defer func() { err = errs.Combine(err, file.Close()) }()
+		output = file
+	}
+
+	w := csv.NewWriter(output)
+	err = w.Write([]string{"satellite", "serial", "action", "amount", "limit", "window", "archived_at", "status"})
+	if err != nil {
+		return errs.Wrap(err)
+	}
+
+	for _, info := range archived {
+		if !satelliteID.IsZero() && info.Limit.SatelliteId != satelliteID {
+			continue
+		}
+
+		err := w.Write([]string{
+			info.Limit.SatelliteId.String(),
+			info.Limit.SerialNumber.String(),
+			info.Limit.Action.String(),
+			strconv.FormatInt(info.Order.Amount, 10),
+			strconv.FormatInt(info.Limit.Limit, 10),
+			info.Limit.OrderCreation.Truncate(time.Hour).UTC().Format(time.RFC3339),
+			info.ArchivedAt.UTC().Format(time.RFC3339),
+			info.Status.String(),
+		})
+		if err != nil {
+			return errs.Wrap(err)
+		}
+	}
+
+	w.Flush()
+	return errs.Wrap(w.Error())
+}
+
+func cmdOrdersVerify(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	store, err := openOrdersStore(ordersCfg.Config)
+	if err != nil {
+		return err
+	}
+
+	verifications, verifyErr := store.Verify(ctx)
+
+	var corrupt []orders.FileVerification
+	var lost int
+	for _, verification := range verifications {
+		if verification.Lost > 0 || verification.HeaderErr != nil {
+			corrupt = append(corrupt, verification)
+			lost += verification.Lost
+		}
+	}
+
+	if len(corrupt) > 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "File\tSatellite\tWindow\tVersion\tValid\tLost\tHeader\t")
+		for _, verification := range corrupt {
+			header := "ok"
+			if verification.HeaderErr != nil {
+				header = verification.HeaderErr.Error()
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\t%s\t\n",
+				filepath.Base(verification.Path),
+				verification.SatelliteID,
+				verification.CreatedAtHour.UTC().Format(time.RFC3339),
+				verification.Version,
+				verification.Valid,
+				verification.Lost,
+				header,
+			)
+		}
+		if err := w.Flush(); err != nil {
+			return errs.Combine(verifyErr, err)
+		}
+		fmt.Println()
+	}
+
+	fmt.Printf("Verified %d orders files: %d corrupt, %d orders lost.\n", len(verifications), len(corrupt), lost)
+	if verifyErr != nil {
+		return errs.New("Error while verifying orders files: %v", verifyErr)
+	}
+	if len(corrupt) > 0 {
+		return errs.New("found %d corrupt orders files", len(corrupt))
+	}
+	return nil
+}
+
+func cmdOrdersDowngrade(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	store, err := openOrdersStore(ordersCfg.Config)
+	if err != nil {
+		return err
+	}
+
+	downgrades, downgradeErr := store.Downgrade(ctx)
+
+	var lost int
+	for _, downgrade := range downgrades {
+		if downgrade.Lost > 0 {
+			fmt.Printf("%s: %d corrupt orders were not converted.\n", filepath.Base(downgrade.Path), downgrade.Lost)
+		}
+		lost += downgrade.Lost
+	}
+
+	fmt.Printf("Converted %d orders files to %s, %d orders lost.\n", len(downgrades), ordersfile.V1, lost)
+	if downgradeErr != nil {
+		return errs.New("Error while converting orders files: %v", downgradeErr)
+	}
+	return nil
+}
+
+// authorizeConsoleRequest authorizes a request for the console api with the
+// configured admin token or, when only a password is configured, with a session
+// from signing in to the console at address.
+func authorizeConsoleRequest(ctx context.Context, request *http.Request, address string, config consoleserver.AuthConfig) (err error) {
+	if config.Token != "" {
+		request.Header.Set("Authorization", "Bearer "+config.Token)
+		return nil
+	}
+	if config.Password == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(map[string]string{"password": config.Password})
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	endpoint := url.URL{
+		Scheme: "http",
+		Host:   address,
+		Path:   "/api/auth/login",
+	}
+	login, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	login.Header.Set("Content-Type", "application/json")
+
+	response, err := http.DefaultClient.Do(login)
+	if err != nil {
+		return errs.New("could not reach the storage node console at %s: %v", address, err)
+	}
+	defer func() { err = errs.Combine(err, response.Body.Close()) }()
+
+	if response.StatusCode != http.StatusOK {
+		return errs.New("could not sign in to the storage node console: %s", response.Status)
+	}
+	for _, cookie := range response.Cookies() {
+		request.AddCookie(cookie)
+	}
+	return nil
+}
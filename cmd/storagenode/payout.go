@@ -0,0 +1,117 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/private/process"
+	"storj.io/storj/storagenode/payout"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+func cmdPayoutReport(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	satelliteID, err := parseSatelliteFilter(payoutReportCfg.Satellite)
+	if err != nil {
+		return err
+	}
+
+	periodStart := payoutReportCfg.PeriodStart
+	if periodStart == "" {
+		periodStart = time.Now().UTC().AddDate(0, -1, 0).Format("2006-01")
+	}
+	periodEnd := payoutReportCfg.PeriodEnd
+	if periodEnd == "" {
+		periodEnd = periodStart
+	}
+
+	db, err := storagenodedb.OpenExisting(ctx, zap.L().Named("db"), payoutReportCfg.DatabaseConfig())
+	if err != nil {
+		return errs.New("Error starting master database on storage node: %v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	service, err := payout.NewService(zap.L().Named("payout"), db.Payout(), db.Reputation(), db.Satellites(), db.Bandwidth(), db.StorageUsage(), nil)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+
+	reconciliations, err := service.Reconcile(ctx, satelliteID, periodStart, periodEnd)
+	if err != nil {
+		return errs.New("Error while reconciling payouts: %v", err)
+	}
+
+	var output io.Writer = os.Stdout
+	if payoutReportCfg.Output != "-" {
+		file, err := os.Create(filepath.Clean(payoutReportCfg.Output))
+		if err != nil {
+			return errs.New("Error creating %q: %v", payoutReportCfg.Output, err)
+		}
+		defer func() { err = errs.Combine(err, file.Close()) }()
+		output = file
+	}
+
+	return writePayoutReport(output, reconciliations)
+}
+
+// writePayoutReport writes reconciliations as CSV.
+func writePayoutReport(output io.Writer, reconciliations []payout.Reconciliation) error {
+	w := csv.NewWriter(output)
+	err := w.Write([]string{
+		"satellite", "period",
+		"egress_local", "egress_satellite", "egress_discrepancy",
+		"repair_egress_local", "repair_egress_satellite", "repair_egress_discrepancy",
+		"audit_egress_local", "audit_egress_satellite", "audit_egress_discrepancy",
+		"storage_byte_hours_local", "storage_byte_hours_satellite", "storage_byte_hours_discrepancy",
+		"held", "owed", "disposed", "paid",
+	})
+	if err != nil {
+		return errs.Wrap(err)
+	}
+
+	formatFloat := func(value float64) string {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	formatDiscrepancy := func(discrepancy payout.Discrepancy) []string {
+		return []string{
+			formatFloat(discrepancy.Local),
+			formatFloat(discrepancy.Satellite),
+			strconv.FormatFloat(discrepancy.Percent, 'f', 2, 64),
+		}
+	}
+
+	for _, reconciliation := range reconciliations {
+		record := []string{reconciliation.SatelliteID.String(), reconciliation.Period}
+		record = append(record, formatDiscrepancy(reconciliation.Egress)...)
+		record = append(record, formatDiscrepancy(reconciliation.RepairEgress)...)
+		record = append(record, formatDiscrepancy(reconciliation.AuditEgress)...)
+		record = append(record, formatDiscrepancy(reconciliation.StorageByteHours)...)
+		record = append(record,
+			strconv.FormatInt(reconciliation.Held, 10),
+			strconv.FormatInt(reconciliation.Owed, 10),
+			strconv.FormatInt(reconciliation.Disposed, 10),
+			strconv.FormatInt(reconciliation.Paid, 10),
+		)
+
+		if err := w.Write(record); err != nil {
+			return errs.Wrap(err)
+		}
+	}
+
+	w.Flush()
+	return errs.Wrap(w.Error())
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/private/process"
+	"storj.io/storj/storagenode/retain"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+func cmdRetainReport(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	var satelliteID storj.NodeID
+	if retainReportCfg.Satellite != "" {
+		satelliteID, err = storj.NodeIDFromString(retainReportCfg.Satellite)
+		if err != nil {
+			return errs.New("Invalid satellite ID: %v", err)
+		}
+	}
+
+	db, err := storagenodedb.OpenExisting(ctx, zap.L().Named("db"), retainReportCfg.DatabaseConfig())
+	if err != nil {
+		return errs.New("Error starting master database on storage node: %v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	var reports []retain.Report
+	if satelliteID.IsZero() {
+		reports, err = db.RetainReports().List(ctx, retainReportCfg.Limit)
+	} else {
+		reports, err = db.RetainReports().ListBySatellite(ctx, satelliteID, retainReportCfg.Limit)
+	}
+	if err != nil {
+		return errs.New("Error while listing retain reports: %v", err)
+	}
+
+	if len(reports) == 0 {
+		fmt.Println("No retain requests received.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer func() { err = errs.Combine(err, w.Flush()) }()
+
+	fmt.Fprintln(w, "Received\tSatellite\tStatus\tCreated Before\tFilter Size\tFalse Positive Rate\tChecked\tTo Trash\tTrashed\tCompleted\t")
+	for _, report := range reports {
+		completed := "in progress"
+		if report.CompletedAt != nil {
+			completed = report.CompletedAt.Format(time.RFC3339)
+		}
+		if report.Error != "" {
+			completed = "failed: " + report.Error
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%.6f\t%d\t%d (%s)\t%d (%s)\t%s\t\n",
+			report.ReceivedAt.Format(time.RFC3339),
+			report.SatelliteID,
+			report.Status.String(),
+			report.CreatedBefore.Format(time.RFC3339),
+			memory.Size(report.FilterSize).Base10String(),
+			report.FalsePositiveRate,
+			report.PiecesChecked,
+			report.PiecesToTrash, memory.Size(report.BytesToTrash).Base10String(),
+			report.PiecesTrashed, memory.Size(report.BytesTrashed).Base10String(),
+			completed,
+		)
+	}
+
+	return nil
+}
@@ -4,9 +4,11 @@
 package main
 
 import (
+	"encoding/base64"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -34,9 +36,31 @@ var (
 		RunE:        cmdSetup,
 		Annotations: map[string]string{"type": "setup"},
 	}
+	keygenCmd = &cobra.Command{
+		Use:   "keygen <key path>",
+		Short: "Generate the key the release manifests are signed with",
+		Args:  cobra.ExactArgs(1),
+		RunE:  cmdKeygen,
+	}
+	signCmd = &cobra.Command{
+		Use:   "sign-manifest <channel> <manifest path>",
+		Short: "Sign the release manifest of a channel with the configured versions",
+		Long: "Sign the release manifest of a channel with the configured versions.\n\n" +
+			"The manifest is meant to be signed offline and served as-is with " +
+			"--signing.stable-manifest or --signing.beta-manifest. Rollout cursors " +
+			"are not part of the manifest and can be changed without signing it again.",
+		Args: cobra.ExactArgs(2),
+		RunE: cmdSign,
+	}
 
 	runCfg   versioncontrol.Config
 	setupCfg versioncontrol.Config
+	signCfg  struct {
+		versioncontrol.Config
+
+		KeyPath  string        `help:"path to the base64 encoded ed25519 private key created with the keygen command" default:""`
+		ValidFor time.Duration `help:"how long the signed manifest is valid" default:"720h0m0s"`
+	}
 
 	confDir string
 )
@@ -51,7 +75,10 @@ func init() {
 	defaults := cfgstruct.DefaultsFlag(rootCmd)
 	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(setupCmd)
+	rootCmd.AddCommand(keygenCmd)
+	rootCmd.AddCommand(signCmd)
 	process.Bind(runCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir))
+	process.Bind(signCmd, &signCfg, defaults, cfgstruct.ConfDir(confDir))
 	process.Bind(setupCmd, &setupCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.SetupMode())
 }
 
@@ -93,6 +120,42 @@ func cmdSetup(cmd *cobra.Command, args []string) (err error) {
 		process.SaveConfigWithOverrides(overrides))
 }
 
+func cmdKeygen(cmd *cobra.Command, args []string) (err error) {
+	publicKey, err := versioncontrol.GenerateSigningKey(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Signing key saved to %s.\n", args[0])
+	fmt.Printf("Public key for clients: %s\n", base64.StdEncoding.EncodeToString(publicKey))
+	return nil
+}
+
+func cmdSign(cmd *cobra.Command, args []string) (err error) {
+	channel, path := args[0], args[1]
+
+	key, err := versioncontrol.LoadSigningKey(signCfg.KeyPath)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := versioncontrol.NewManifest(&signCfg.Config, channel, time.Now().Add(signCfg.ValidFor))
+	if err != nil {
+		return err
+	}
+
+	signed, err := versioncontrol.SignManifest(key, manifest)
+	if err != nil {
+		return err
+	}
+	if err := versioncontrol.SaveManifest(path, signed); err != nil {
+		return err
+	}
+
+	fmt.Printf("Manifest of release channel %s valid until %s saved to %s.\n", channel, manifest.ValidUntil.Format(time.RFC3339), path)
+	return nil
+}
+
 func main() {
 	process.Exec(rootCmd)
 }
@@ -0,0 +1,84 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package alerts
+
+import (
+	"context"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is an error class for alerts error.
+	Error = errs.Class("alerts error")
+)
+
+// DB exposes access to the alert history.
+//
+// architecture: Database
+type DB interface {
+	// Create stores a new alert.
+	Create(ctx context.Context, alert Alert) error
+	// Resolve marks the alert as resolved.
+	Resolve(ctx context.Context, id uuid.UUID) error
+	// Active returns all alerts which are not resolved.
+	Active(ctx context.Context) ([]Alert, error)
+	// List returns alerts, newest first.
+	List(ctx context.Context, limit int, offset int64) ([]Alert, error)
+}
+
+// Kind is the kind of problem an alert is raised for.
+type Kind string
+
+const (
+	// KindOffline is raised when the node does not respond.
+	KindOffline Kind = "offline"
+	// KindDisqualified is raised when the node is disqualified on a satellite.
+	KindDisqualified Kind = "disqualified"
+	// KindSuspended is raised when the node is suspended for unknown audit errors on a satellite.
+	KindSuspended Kind = "suspended"
+	// KindOfflineSuspended is raised when the node is suspended for being offline on a satellite.
+	KindOfflineSuspended Kind = "offline-suspended"
+	// KindDiskFull is raised when the node has almost no free space left.
+	KindDiskFull Kind = "disk-full"
+	// KindOutdated is raised when the node runs a version older than the minimum version.
+	KindOutdated Kind = "outdated"
+)
+
+// Alert is a problem with a node which was detected at CreatedAt and
+// disappeared at ResolvedAt.
+type Alert struct {
+	ID     uuid.UUID    `json:"id"`
+	NodeID storj.NodeID `json:"nodeId"`
+	Kind   Kind         `json:"kind"`
+	// SatelliteID is the satellite the problem is on, zero for problems of the node itself.
+	SatelliteID storj.NodeID `json:"satelliteId"`
+	Message     string       `json:"message"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	ResolvedAt  *time.Time   `json:"resolvedAt"`
+}
+
+// Resolved returns whether the problem has disappeared.
+func (alert Alert) Resolved() bool {
+	return alert.ResolvedAt != nil
+}
+
+// key identifies the problem an alert is raised for.
+type key struct {
+	nodeID      storj.NodeID
+	kind        Kind
+	satelliteID storj.NodeID
+}
+
+// key returns what identifies the problem of the alert.
+func (alert Alert) key() key {
+	return key{nodeID: alert.NodeID, kind: alert.Kind, satelliteID: alert.SatelliteID}
+}
@@ -0,0 +1,270 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+	"storj.io/common/uuid"
+	"storj.io/private/version"
+	"storj.io/storj/multinode/nodes"
+	"storj.io/storj/private/webhook"
+)
+
+// Config contains configuration for polling the health of nodes.
+type Config struct {
+	Interval       time.Duration `help:"how often the health of all nodes is checked" default:"5m"`
+	DiskFull       float64       `help:"fraction of the allocated disk space in use above which a node is considered full" default:"0.95"`
+	MinimumVersion string        `help:"nodes running an older version are reported as outdated, disabled when empty" default:""`
+
+	Webhook WebhookConfig
+}
+
+// Chore polls the health of all nodes and records when problems appear and disappear.
+//
+// architecture: Chore
+type Chore struct {
+	log     *zap.Logger
+	nodes   *nodes.Service
+	db      DB
+	webhook *webhook.Client
+	queue   chan Alert
+	config  Config
+
+	minimumVersion version.SemVer
+
+	Loop *sync2.Cycle
+}
+
+// NewChore creates a new health polling chore.
+func NewChore(log *zap.Logger, nodes *nodes.Service, db DB, config Config) (*Chore, error) {
+	chore := &Chore{
+		log:    log,
+		nodes:  nodes,
+		db:     db,
+		config: config,
+
+		Loop: sync2.NewCycle(config.Interval),
+	}
+
+	if config.MinimumVersion != "" {
+		minimumVersion, err := version.NewSemVer(config.MinimumVersion)
+		if err != nil {
+			return nil, Error.New("invalid minimum version %q: %v", config.MinimumVersion, err)
+		}
+		chore.minimumVersion = minimumVersion
+	}
+
+	if config.Webhook.URL != "" {
+		chore.webhook = webhook.New(webhook.Options{
+			URL:           config.Webhook.URL,
+			Timeout:       config.Webhook.Timeout,
+			Retries:       config.Webhook.Retries,
+			RetryInterval: config.Webhook.RetryInterval,
+		})
+
+		queueSize := config.Webhook.QueueSize
+		if queueSize <= 0 {
+			queueSize = 1
+		}
+		chore.queue = make(chan Alert, queueSize)
+	}
+
+	return chore, nil
+}
+
+// Run starts the chore.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	group, ctx := errgroup.WithContext(ctx)
+	group.Go(func() error {
+		return chore.Loop.Run(ctx, func(ctx context.Context) error {
+			if err := chore.Poll(ctx); err != nil {
+				chore.log.Error("failed to poll node health", zap.Error(err))
+			}
+			return nil
+		})
+	})
+	if chore.webhook != nil {
+		// alerts are posted separately, so that retrying an unreachable
+		// webhook doesn't delay polling.
+		group.Go(func() error {
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case alert := <-chore.queue:
+					if err := chore.webhook.Post(ctx, alert); err != nil {
+						chore.log.Warn("failed to deliver alert to webhook", zap.Stringer("Node ID", alert.NodeID), zap.Error(err))
+					}
+				}
+			}
+		})
+	}
+	return group.Wait()
+}
+
+// Poll checks the health of all nodes once, raises alerts for new problems
+// and resolves alerts of problems which have disappeared.
+func (chore *Chore) Poll(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	health, err := chore.nodes.Health(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	active, err := chore.db.Active(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	raised, resolved := chore.transitions(health, active)
+
+	for _, alert := range raised {
+		alert.ID, err = uuid.New()
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		if err := chore.db.Create(ctx, alert); err != nil {
+			return Error.Wrap(err)
+		}
+		chore.log.Info("node needs attention",
+			zap.Stringer("Node ID", alert.NodeID),
+			zap.String("Kind", string(alert.Kind)),
+			zap.String("Message", alert.Message))
+		chore.deliver(alert)
+	}
+
+	now := time.Now().UTC()
+	for _, alert := range resolved {
+		if err := chore.db.Resolve(ctx, alert.ID); err != nil {
+			return Error.Wrap(err)
+		}
+		alert.ResolvedAt = &now
+		chore.log.Info("node problem resolved",
+			zap.Stringer("Node ID", alert.NodeID),
+			zap.String("Kind", string(alert.Kind)))
+		chore.deliver(alert)
+	}
+
+	return nil
+}
+
+// transitions compares the problems of nodes with the active alerts and
+// returns the alerts to raise for new problems and the active alerts to
+// resolve because their problem has disappeared. Problems of nodes which
+// are offline are unknown, so their alerts other than offline stay active.
+func (chore *Chore) transitions(health []nodes.Health, active []Alert) (raised, resolved []Alert) {
+	known := map[storj.NodeID]bool{}
+	online := map[storj.NodeID]bool{}
+	current := map[key]Alert{}
+	for _, node := range health {
+		known[node.Node.ID] = true
+		online[node.Node.ID] = node.Online
+		for _, problem := range chore.problems(node) {
+			current[problem.key()] = problem
+		}
+	}
+
+	activeKeys := map[key]bool{}
+	for _, alert := range active {
+		activeKeys[alert.key()] = true
+
+		if _, ok := current[alert.key()]; ok {
+			continue
+		}
+		if known[alert.NodeID] && !online[alert.NodeID] && alert.Kind != KindOffline {
+			continue
+		}
+		resolved = append(resolved, alert)
+	}
+
+	for _, node := range health {
+		for _, problem := range chore.problems(node) {
+			if !activeKeys[problem.key()] {
+				raised = append(raised, problem)
+			}
+		}
+	}
+
+	return raised, resolved
+}
+
+// problems returns an alert for every problem of the node.
+func (chore *Chore) problems(node nodes.Health) []Alert {
+	name := node.Node.Name
+	if name == "" {
+		name = node.Node.ID.String()
+	}
+	problem := func(kind Kind, satelliteID storj.NodeID, format string, args ...interface{}) Alert {
+		return Alert{
+			NodeID:      node.Node.ID,
+			Kind:        kind,
+			SatelliteID: satelliteID,
+			Message:     name + ": " + fmt.Sprintf(format, args...),
+		}
+	}
+
+	if !node.Online {
+		reason := "unknown error"
+		if node.Error != nil {
+			reason = node.Error.Error()
+		}
+		return []Alert{problem(KindOffline, storj.NodeID{}, "node is offline: %s", reason)}
+	}
+
+	var problems []Alert
+	for _, satellite := range node.Satellites {
+		if satellite.Disqualified {
+			problems = append(problems, problem(KindDisqualified, satellite.SatelliteID, "node is disqualified on satellite %s", satellite.SatelliteID))
+		}
+		if satellite.Suspended {
+			problems = append(problems, problem(KindSuspended, satellite.SatelliteID, "node is suspended on satellite %s", satellite.SatelliteID))
+		}
+		if satellite.OfflineSuspended {
+			problems = append(problems, problem(KindOfflineSuspended, satellite.SatelliteID, "node is suspended for being offline on satellite %s", satellite.SatelliteID))
+		}
+	}
+
+	diskSpace := node.DiskSpace
+	if diskSpace.Allocated > 0 && float64(diskSpace.Used+diskSpace.Trash) >= chore.config.DiskFull*float64(diskSpace.Allocated) {
+		problems = append(problems, problem(KindDiskFull, storj.NodeID{}, "node uses %d of %d allocated bytes", diskSpace.Used+diskSpace.Trash, diskSpace.Allocated))
+	}
+
+	if !chore.minimumVersion.IsZero() {
+		current, err := version.NewSemVer(node.Version)
+		if err != nil || current.Compare(chore.minimumVersion) < 0 {
+			problems = append(problems, problem(KindOutdated, storj.NodeID{}, "node runs version %q, minimum is %s", node.Version, chore.minimumVersion.String()))
+		}
+	}
+
+	return problems
+}
+
+// deliver queues the alert for posting to the webhook when it is configured.
+func (chore *Chore) deliver(alert Alert) {
+	if chore.webhook == nil {
+		return
+	}
+	select {
+	case chore.queue <- alert:
+	default:
+		chore.log.Warn("alert queue is full, dropping alert", zap.Stringer("Node ID", alert.NodeID), zap.String("Kind", string(alert.Kind)))
+	}
+}
+
+// Close stops the chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package alerts
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testrand"
+	"storj.io/storj/multinode/nodes"
+)
+
+func TestTransitions(t *testing.T) {
+	chore, err := NewChore(nil, nil, nil, Config{DiskFull: 0.9, MinimumVersion: "v1.10.0"})
+	require.NoError(t, err)
+
+	healthy := nodes.Node{ID: testrand.NodeID(), Name: "healthy"}
+	offline := nodes.Node{ID: testrand.NodeID(), Name: "offline"}
+	troubled := nodes.Node{ID: testrand.NodeID(), Name: "troubled"}
+	satellite := testrand.NodeID()
+
+	health := []nodes.Health{
+		{
+			Node:      healthy,
+			Online:    true,
+			Version:   "v1.10.1",
+			DiskSpace: nodes.DiskSpace{Allocated: 100, Used: 50},
+			Satellites: []nodes.SatelliteHealth{
+				{SatelliteID: satellite},
+			},
+		},
+		{
+			Node:  offline,
+			Error: errors.New("dial timeout"),
+		},
+		{
+			Node:      troubled,
+			Online:    true,
+			Version:   "v1.9.3",
+			DiskSpace: nodes.DiskSpace{Allocated: 100, Used: 85, Trash: 10},
+			Satellites: []nodes.SatelliteHealth{
+				{SatelliteID: satellite, Suspended: true},
+			},
+		},
+	}
+
+	removedNode := testrand.NodeID()
+	active := []Alert{
+		// problem has disappeared.
+		{ID: testrand.UUID(), NodeID: healthy.ID, Kind: KindDisqualified, SatelliteID: satellite},
+		// state of an offline node is unknown.
+		{ID: testrand.UUID(), NodeID: offline.ID, Kind: KindDiskFull},
+		// problem remains.
+		{ID: testrand.UUID(), NodeID: troubled.ID, Kind: KindSuspended, SatelliteID: satellite},
+		// node is no longer managed.
+		{ID: testrand.UUID(), NodeID: removedNode, Kind: KindOffline},
+	}
+
+	raised, resolved := chore.transitions(health, active)
+
+	raisedKeys := map[key]bool{}
+	for _, alert := range raised {
+		raisedKeys[alert.key()] = true
+		assert.NotEmpty(t, alert.Message)
+	}
+	assert.Equal(t, map[key]bool{
+		{nodeID: offline.ID, kind: KindOffline}:   true,
+		{nodeID: troubled.ID, kind: KindDiskFull}: true,
+		{nodeID: troubled.ID, kind: KindOutdated}: true,
+	}, raisedKeys)
+
+	require.Len(t, resolved, 2)
+	assert.Equal(t, active[0].ID, resolved[0].ID)
+	assert.Equal(t, active[3].ID, resolved[1].ID)
+
+	// the offline node came back with its disk still full.
+	health[1] = nodes.Health{
+		Node:      offline,
+		Online:    true,
+		Version:   "v1.10.0",
+		DiskSpace: nodes.DiskSpace{Allocated: 100, Used: 95},
+	}
+	active = []Alert{
+		{ID: testrand.UUID(), NodeID: offline.ID, Kind: KindOffline},
+		{ID: testrand.UUID(), NodeID: offline.ID, Kind: KindDiskFull},
+	}
+
+	raised, resolved = chore.transitions(health[1:2], active)
+	assert.Empty(t, raised)
+	require.Len(t, resolved, 1)
+	assert.Equal(t, active[0].ID, resolved[0].ID)
+}
+
+func TestNewChoreInvalidVersion(t *testing.T) {
+	_, err := NewChore(nil, nil, nil, Config{MinimumVersion: "latest"})
+	require.Error(t, err)
+}
+
+func TestDeliverDoesNotBlock(t *testing.T) {
+	chore, err := NewChore(zaptest.NewLogger(t), nil, nil, Config{
+		Webhook: WebhookConfig{URL: "http://127.0.0.1:1", QueueSize: 1},
+	})
+	require.NoError(t, err)
+
+	// alerts are dropped once the queue is full instead of waiting for the webhook.
+	chore.deliver(Alert{NodeID: testrand.NodeID(), Kind: KindOffline})
+	chore.deliver(Alert{NodeID: testrand.NodeID(), Kind: KindOffline})
+	require.Len(t, chore.queue, 1)
+}
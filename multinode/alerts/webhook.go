@@ -0,0 +1,17 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package alerts
+
+import (
+	"time"
+)
+
+// WebhookConfig defines the HTTP endpoint which raised and resolved alerts are posted to as JSON.
+type WebhookConfig struct {
+	URL           string        `help:"URL which raised and resolved alerts are posted to as JSON, disabled when empty" default:""`
+	Timeout       time.Duration `help:"timeout of posting an alert" default:"10s"`
+	Retries       int           `help:"number of times posting an alert is retried" default:"3"`
+	RetryInterval time.Duration `help:"how long to wait before posting an alert again, doubled with every retry" default:"10s"`
+	QueueSize     int           `help:"maximum number of alerts waiting to be posted" default:"100"`
+}
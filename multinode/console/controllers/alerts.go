@@ -0,0 +1,127 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/multinode/alerts"
+)
+
+var (
+	// ErrAlerts is an internal error type for alerts web api controller.
+	ErrAlerts = errs.Class("alerts web api controller error")
+)
+
+// defaultAlertsLimit is the number of alerts returned when the limit is not specified.
+const defaultAlertsLimit = 100
+
+// Alerts is a web api controller.
+type Alerts struct {
+	log *zap.Logger
+	db  alerts.DB
+}
+
+// NewAlerts is a constructor for Alerts.
+func NewAlerts(log *zap.Logger, db alerts.DB) *Alerts {
+	return &Alerts{
+		log: log,
+		db:  db,
+	}
+}
+
+// List handles retrieving the alert history, newest first.
+func (controller *Alerts) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	limit := defaultAlertsLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			controller.serveError(w, http.StatusBadRequest, ErrAlerts.New("invalid limit %q", value))
+			return
+		}
+	}
+
+	var offset int64
+	if value := r.URL.Query().Get("offset"); value != "" {
+		offset, err = strconv.ParseInt(value, 10, 64)
+		if err != nil || offset < 0 {
+			controller.serveError(w, http.StatusBadRequest, ErrAlerts.New("invalid offset %q", value))
+			return
+		}
+	}
+
+	list, err := controller.db.List(ctx, limit, offset)
+	if err != nil {
+		controller.log.Error("alerts internal error", zap.Error(err))
+		controller.serveError(w, http.StatusInternalServerError, ErrAlerts.Wrap(err))
+		return
+	}
+	list = visibleAlerts(ctx, list)
+
+	if err = json.NewEncoder(w).Encode(list); err != nil {
+		controller.log.Error("failed to write json response", zap.Error(err))
+		return
+	}
+}
+
+// Active handles retrieving the alerts which are not resolved.
+func (controller *Alerts) Active(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	active, err := controller.db.Active(ctx)
+	if err != nil {
+		controller.log.Error("alerts internal error", zap.Error(err))
+		controller.serveError(w, http.StatusInternalServerError, ErrAlerts.Wrap(err))
+		return
+	}
+	active = visibleAlerts(ctx, active)
+
+	if err = json.NewEncoder(w).Encode(active); err != nil {
+		controller.log.Error("failed to write json response", zap.Error(err))
+		return
+	}
+}
+
+// visibleAlerts returns the alerts of nodes the signed in member may view.
+func visibleAlerts(ctx context.Context, list []alerts.Alert) []alerts.Alert {
+	visible := make([]alerts.Alert, 0, len(list))
+	for _, alert := range list {
+		if canView(ctx, alert.NodeID) {
+			visible = append(visible, alert)
+		}
+	}
+	return visible
+}
+
+// serveError set http statuses and send json error.
+func (controller *Alerts) serveError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		controller.log.Error("failed to write json error response", zap.Error(err))
+	}
+}
@@ -0,0 +1,284 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/multinode/console"
+)
+
+var (
+	// ErrAuth is an internal error type for auth web api controller.
+	ErrAuth = errs.Class("auth web api controller error")
+)
+
+// SessionCookieName is the name of the cookie holding the session token.
+const SessionCookieName = "_session"
+
+// Auth is a web api controller.
+type Auth struct {
+	log     *zap.Logger
+	service *console.Service
+}
+
+// NewAuth is a constructor for Auth.
+func NewAuth(log *zap.Logger, service *console.Service) *Auth {
+	return &Auth{
+		log:     log,
+		service: service,
+	}
+}
+
+// Status handles retrieving whether the owner has registered and whether the request is signed in.
+func (controller *Auth) Status(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	var response struct {
+		Registered bool `json:"registered"`
+		SignedIn   bool `json:"signedIn"`
+	}
+
+	response.Registered, err = controller.service.Registered(ctx)
+	if err != nil {
+		controller.log.Error("auth status internal error", zap.Error(err))
+		controller.serveError(w, http.StatusInternalServerError, ErrAuth.Wrap(err))
+		return
+	}
+	if token := SessionToken(r); token != "" {
+		_, err := controller.service.Authenticate(ctx, token)
+		response.SignedIn = err == nil
+	}
+
+	if err = json.NewEncoder(w).Encode(response); err != nil {
+		controller.log.Error("failed to write json response", zap.Error(err))
+		return
+	}
+}
+
+// Register handles creating the owner account.
+func (controller *Auth) Register(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	var payload struct {
+		Email    string `json:"email"`
+		Name     string `json:"name"`
+		Password string `json:"password"`
+	}
+
+	if err = json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		controller.serveError(w, http.StatusBadRequest, ErrAuth.Wrap(err))
+		return
+	}
+
+	member, err := controller.service.Register(ctx, payload.Email, payload.Name, payload.Password)
+	if err != nil {
+		controller.serveServiceError(w, err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(member); err != nil {
+		controller.log.Error("failed to write json response", zap.Error(err))
+		return
+	}
+}
+
+// Login handles signing in and sets the session cookie.
+func (controller *Auth) Login(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	var payload struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+
+	if err = json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		controller.serveError(w, http.StatusBadRequest, ErrAuth.Wrap(err))
+		return
+	}
+
+	token, expiresAt, err := controller.service.Login(ctx, payload.Email, payload.Password)
+	if err != nil {
+		if console.ErrUnauthenticated.Has(err) {
+			controller.log.Warn("failed sign in attempt", zap.String("Remote Address", r.RemoteAddr))
+		}
+		controller.serveServiceError(w, err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    token,
+		Path:     "/",
+		Expires:  expiresAt,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	var response struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expiresAt"`
+	}
+	response.Token = token
+	response.ExpiresAt = expiresAt
+
+	if err = json.NewEncoder(w).Encode(response); err != nil {
+		controller.log.Error("failed to write json response", zap.Error(err))
+		return
+	}
+}
+
+// Logout handles ending the session and removes the session cookie.
+func (controller *Auth) Logout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	if token := SessionToken(r); token != "" {
+		controller.service.Logout(token)
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    "",
+		Path:     "/",
+		Expires:  time.Unix(0, 0),
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Account handles retrieving the signed in member and its access to nodes.
+func (controller *Auth) Account(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	account, ok := console.GetAccount(ctx)
+	if !ok {
+		controller.serveError(w, http.StatusUnauthorized, ErrAuth.New("not signed in"))
+		return
+	}
+
+	var response struct {
+		Member console.Member       `json:"member"`
+		Access []console.NodeAccess `json:"access"`
+	}
+	response.Member = account.Member
+	response.Access = []console.NodeAccess{}
+	if account.Member.Role != console.RoleOwner {
+		response.Access, err = controller.service.Access(ctx, account.Member.ID)
+		if err != nil {
+			controller.log.Error("account access internal error", zap.Error(err))
+			controller.serveError(w, http.StatusInternalServerError, ErrAuth.Wrap(err))
+			return
+		}
+	}
+
+	if err = json.NewEncoder(w).Encode(response); err != nil {
+		controller.log.Error("failed to write json response", zap.Error(err))
+		return
+	}
+}
+
+// ChangePassword handles changing the password of the signed in member.
+func (controller *Auth) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	account, ok := console.GetAccount(ctx)
+	if !ok {
+		controller.serveError(w, http.StatusUnauthorized, ErrAuth.New("not signed in"))
+		return
+	}
+
+	var payload struct {
+		Password    string `json:"password"`
+		NewPassword string `json:"newPassword"`
+	}
+
+	if err = json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		controller.serveError(w, http.StatusBadRequest, ErrAuth.Wrap(err))
+		return
+	}
+
+	if err = controller.service.ChangePassword(ctx, account.Member.ID, payload.Password, payload.NewPassword); err != nil {
+		controller.serveServiceError(w, err)
+		return
+	}
+}
+
+// serveServiceError maps errors of the console service to http statuses.
+func (controller *Auth) serveServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case console.ErrValidation.Has(err):
+		controller.serveError(w, http.StatusBadRequest, ErrAuth.Wrap(err))
+	case console.ErrUnauthenticated.Has(err):
+		controller.serveError(w, http.StatusUnauthorized, ErrAuth.Wrap(err))
+	case console.ErrForbidden.Has(err):
+		controller.serveError(w, http.StatusForbidden, ErrAuth.Wrap(err))
+	default:
+		controller.log.Error("auth internal error", zap.Error(err))
+		controller.serveError(w, http.StatusInternalServerError, ErrAuth.Wrap(err))
+	}
+}
+
+// serveError set http statuses and send json error.
+func (controller *Auth) serveError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		controller.log.Error("failed to write json error response", zap.Error(err))
+	}
+}
+
+// SessionToken returns the session token of the request from the bearer
+// authorization header or from the session cookie.
+func SessionToken(r *http.Request) string {
+	if header := r.Header.Get("Authorization"); header != "" {
+		token := strings.TrimPrefix(header, "Bearer ")
+		if token == header {
+			return ""
+		}
+		return token
+	}
+
+	cookie, err := r.Cookie(SessionCookieName)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
@@ -4,11 +4,15 @@
 package controllers
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 
 	"github.com/spacemonkeygo/monkit/v3"
 	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/multinode/console"
 )
 
 var (
@@ -43,3 +47,21 @@ func (handler *NotFound) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		handler.log.Error("failed to write json error response", zap.Error(err))
 	}
 }
+
+// isOwner returns whether the signed in member is an owner.
+func isOwner(ctx context.Context) bool {
+	account, ok := console.GetAccount(ctx)
+	return ok && account.Member.Role == console.RoleOwner
+}
+
+// canManage returns whether the signed in member may rename and remove the node.
+func canManage(ctx context.Context, id storj.NodeID) bool {
+	account, ok := console.GetAccount(ctx)
+	return ok && account.CanManage(id)
+}
+
+// canView returns whether the signed in member may view the node.
+func canView(ctx context.Context, id storj.NodeID) bool {
+	account, ok := console.GetAccount(ctx)
+	return ok && account.CanView(id)
+}
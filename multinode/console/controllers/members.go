@@ -0,0 +1,227 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/storj/multinode/console"
+)
+
+var (
+	// ErrMembers is an internal error type for members web api controller.
+	ErrMembers = errs.Class("members web api controller error")
+)
+
+// Members is a web api controller. Only owners may use it.
+type Members struct {
+	log     *zap.Logger
+	service *console.Service
+}
+
+// NewMembers is a constructor for Members.
+func NewMembers(log *zap.Logger, service *console.Service) *Members {
+	return &Members{
+		log:     log,
+		service: service,
+	}
+}
+
+// List handles retrieving all members.
+func (controller *Members) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	if !isOwner(ctx) {
+		controller.serveError(w, http.StatusForbidden, ErrMembers.New("only owners may manage members"))
+		return
+	}
+
+	members, err := controller.service.Members(ctx)
+	if err != nil {
+		controller.serveServiceError(w, err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(members); err != nil {
+		controller.log.Error("failed to write json response", zap.Error(err))
+		return
+	}
+}
+
+// Add handles creating a member account.
+func (controller *Members) Add(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	if !isOwner(ctx) {
+		controller.serveError(w, http.StatusForbidden, ErrMembers.New("only owners may manage members"))
+		return
+	}
+
+	var payload struct {
+		Email    string       `json:"email"`
+		Name     string       `json:"name"`
+		Password string       `json:"password"`
+		Role     console.Role `json:"role"`
+	}
+
+	if err = json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		controller.serveError(w, http.StatusBadRequest, ErrMembers.Wrap(err))
+		return
+	}
+
+	member, err := controller.service.AddMember(ctx, payload.Email, payload.Name, payload.Password, payload.Role)
+	if err != nil {
+		controller.serveServiceError(w, err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(member); err != nil {
+		controller.log.Error("failed to write json response", zap.Error(err))
+		return
+	}
+}
+
+// Remove handles removing a member.
+func (controller *Members) Remove(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	if !isOwner(ctx) {
+		controller.serveError(w, http.StatusForbidden, ErrMembers.New("only owners may manage members"))
+		return
+	}
+
+	id, err := uuid.FromString(mux.Vars(r)["id"])
+	if err != nil {
+		controller.serveError(w, http.StatusBadRequest, ErrMembers.Wrap(err))
+		return
+	}
+
+	if err = controller.service.RemoveMember(ctx, id); err != nil {
+		controller.serveServiceError(w, err)
+		return
+	}
+}
+
+// Access handles retrieving the permissions of a member on nodes.
+func (controller *Members) Access(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	if !isOwner(ctx) {
+		controller.serveError(w, http.StatusForbidden, ErrMembers.New("only owners may manage members"))
+		return
+	}
+
+	id, err := uuid.FromString(mux.Vars(r)["id"])
+	if err != nil {
+		controller.serveError(w, http.StatusBadRequest, ErrMembers.Wrap(err))
+		return
+	}
+
+	access, err := controller.service.Access(ctx, id)
+	if err != nil {
+		controller.serveServiceError(w, err)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(access); err != nil {
+		controller.log.Error("failed to write json response", zap.Error(err))
+		return
+	}
+}
+
+// SetAccess handles changing the permission of a member on a node.
+func (controller *Members) SetAccess(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	if !isOwner(ctx) {
+		controller.serveError(w, http.StatusForbidden, ErrMembers.New("only owners may manage members"))
+		return
+	}
+
+	vars := mux.Vars(r)
+
+	id, err := uuid.FromString(vars["id"])
+	if err != nil {
+		controller.serveError(w, http.StatusBadRequest, ErrMembers.Wrap(err))
+		return
+	}
+
+	nodeID, err := storj.NodeIDFromString(vars["nodeId"])
+	if err != nil {
+		controller.serveError(w, http.StatusBadRequest, ErrMembers.Wrap(err))
+		return
+	}
+
+	var payload struct {
+		Permission console.Permission `json:"permission"`
+	}
+
+	if err = json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		controller.serveError(w, http.StatusBadRequest, ErrMembers.Wrap(err))
+		return
+	}
+
+	if err = controller.service.SetAccess(ctx, id, nodeID, payload.Permission); err != nil {
+		controller.serveServiceError(w, err)
+		return
+	}
+}
+
+// serveServiceError maps errors of the console service to http statuses.
+func (controller *Members) serveServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case console.ErrValidation.Has(err):
+		controller.serveError(w, http.StatusBadRequest, ErrMembers.Wrap(err))
+	case console.ErrNoMember.Has(err):
+		controller.serveError(w, http.StatusNotFound, ErrMembers.Wrap(err))
+	case console.ErrForbidden.Has(err):
+		controller.serveError(w, http.StatusForbidden, ErrMembers.Wrap(err))
+	default:
+		controller.log.Error("members internal error", zap.Error(err))
+		controller.serveError(w, http.StatusInternalServerError, ErrMembers.Wrap(err))
+	}
+}
+
+// serveError set http statuses and send json error.
+func (controller *Members) serveError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		controller.log.Error("failed to write json error response", zap.Error(err))
+	}
+}
@@ -6,6 +6,7 @@ package controllers
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/zeebo/errs"
@@ -42,6 +43,11 @@ func (controller *Nodes) Add(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Add("Content-Type", "application/json")
 
+	if !isOwner(ctx) {
+		controller.serveError(w, http.StatusForbidden, ErrNodes.New("only owners may add nodes"))
+		return
+	}
+
 	var payload struct {
 		ID            string `json:"id"`
 		APISecret     string `json:"apiSecret"`
@@ -73,6 +79,74 @@ func (controller *Nodes) Add(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Import handles adding nodes in bulk from a JSON array or, when the
+// content type is text/csv, from CSV.
+func (controller *Nodes) Import(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	if !isOwner(ctx) {
+		controller.serveError(w, http.StatusForbidden, ErrNodes.New("only owners may add nodes"))
+		return
+	}
+
+	var imports []nodes.Import
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "text/csv") {
+		imports, err = nodes.ParseImportCSV(r.Body)
+	} else {
+		imports, err = nodes.ParseImportJSON(r.Body)
+	}
+	if err != nil {
+		controller.serveError(w, http.StatusBadRequest, ErrNodes.Wrap(err))
+		return
+	}
+
+	results, err := controller.service.Import(ctx, imports)
+	if err != nil {
+		controller.log.Error("import nodes internal error", zap.Error(err))
+		controller.serveError(w, http.StatusInternalServerError, ErrNodes.Wrap(err))
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(results); err != nil {
+		controller.log.Error("failed to write json response", zap.Error(err))
+		return
+	}
+}
+
+// RotateSecret handles replacing the api secret of a node.
+func (controller *Nodes) RotateSecret(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	id, err := storj.NodeIDFromString(mux.Vars(r)["id"])
+	if err != nil {
+		controller.serveError(w, http.StatusBadRequest, ErrNodes.Wrap(err))
+		return
+	}
+
+	if !canManage(ctx, id) {
+		controller.serveError(w, http.StatusForbidden, ErrNodes.New("no permission to manage node %s", id))
+		return
+	}
+
+	if err = controller.service.RotateAPISecret(ctx, id); err != nil {
+		if nodes.ErrNoNode.Has(err) {
+			controller.serveError(w, http.StatusNotFound, ErrNodes.Wrap(err))
+			return
+		}
+		controller.log.Error("rotate node api secret internal error", zap.Error(err))
+		controller.serveError(w, http.StatusInternalServerError, ErrNodes.Wrap(err))
+		return
+	}
+}
+
 // UpdateName is an endpoint to update node name.
 func (controller *Nodes) UpdateName(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -95,6 +169,11 @@ func (controller *Nodes) UpdateName(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !canManage(ctx, id) {
+		controller.serveError(w, http.StatusForbidden, ErrNodes.New("no permission to manage node %s", id))
+		return
+	}
+
 	var payload struct {
 		Name string `json:"name"`
 	}
@@ -150,14 +229,38 @@ func (controller *Nodes) List(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Add("Content-Type", "application/json")
 
-	nodes, err := controller.service.List(ctx)
+	list, err := controller.service.List(ctx)
+	if nodes.ErrNoNode.Has(err) {
+		list, err = []nodes.Node{}, nil
+	}
 	if err != nil {
 		controller.log.Error("list nodes internal error", zap.Error(err))
 		controller.serveError(w, http.StatusInternalServerError, ErrNodes.Wrap(err))
 		return
 	}
 
-	if err = json.NewEncoder(w).Encode(nodes); err != nil {
+	if err = json.NewEncoder(w).Encode(list); err != nil {
+		controller.log.Error("failed to write json response", zap.Error(err))
+		return
+	}
+}
+
+// Statuses handles retrieving online status of all nodes.
+func (controller *Nodes) Statuses(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	statuses, err := controller.service.Statuses(ctx)
+	if err != nil {
+		controller.log.Error("node statuses internal error", zap.Error(err))
+		controller.serveError(w, http.StatusInternalServerError, ErrNodes.Wrap(err))
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(statuses); err != nil {
 		controller.log.Error("failed to write json response", zap.Error(err))
 		return
 	}
@@ -185,6 +288,11 @@ func (controller *Nodes) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !canManage(ctx, id) {
+		controller.serveError(w, http.StatusForbidden, ErrNodes.New("no permission to manage node %s", id))
+		return
+	}
+
 	if err = controller.service.Remove(ctx, id); err != nil {
 		// TODO: add more error checks in future, like not found if node is missing.
 		controller.log.Error("delete node internal error", zap.Error(err))
@@ -0,0 +1,170 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package controllers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/multinode/nodes"
+)
+
+var (
+	// ErrPayouts is an internal error type for payouts web api controller.
+	ErrPayouts = errs.Class("payouts web api controller error")
+)
+
+// periodLayout is the layout of payout periods.
+const periodLayout = "2006-01"
+
+// Payouts is a web api controller.
+type Payouts struct {
+	log     *zap.Logger
+	service *nodes.Service
+}
+
+// NewPayouts is a constructor for Payouts.
+func NewPayouts(log *zap.Logger, service *nodes.Service) *Payouts {
+	return &Payouts{
+		log:     log,
+		service: service,
+	}
+}
+
+// Summary handles retrieving earnings of all nodes per node, satellite and wallet.
+// The optional periodStart and periodEnd query parameters are in the yyyy-mm
+// format and default to the last twelve months.
+func (controller *Payouts) Summary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	periodStart, periodEnd, err := periodRange(r)
+	if err != nil {
+		controller.serveError(w, http.StatusBadRequest, ErrPayouts.Wrap(err))
+		return
+	}
+
+	payouts, err := controller.service.Payouts(ctx, periodStart, periodEnd)
+	if err != nil {
+		controller.log.Error("payouts internal error", zap.Error(err))
+		controller.serveError(w, http.StatusInternalServerError, ErrPayouts.Wrap(err))
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(payouts); err != nil {
+		controller.log.Error("failed to write json response", zap.Error(err))
+		return
+	}
+}
+
+// CSV handles exporting the paystubs of all nodes as csv, one row per node, satellite and period.
+// It accepts the same query parameters as Summary.
+func (controller *Payouts) CSV(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	periodStart, periodEnd, err := periodRange(r)
+	if err != nil {
+		w.Header().Add("Content-Type", "application/json")
+		controller.serveError(w, http.StatusBadRequest, ErrPayouts.Wrap(err))
+		return
+	}
+
+	payouts, err := controller.service.Payouts(ctx, periodStart, periodEnd)
+	if err != nil {
+		controller.log.Error("payouts internal error", zap.Error(err))
+		w.Header().Add("Content-Type", "application/json")
+		controller.serveError(w, http.StatusInternalServerError, ErrPayouts.Wrap(err))
+		return
+	}
+
+	w.Header().Add("Content-Type", "text/csv")
+	w.Header().Add("Content-Disposition", `attachment; filename="payouts-`+periodStart+`-`+periodEnd+`.csv"`)
+
+	writer := csv.NewWriter(w)
+	if err = writer.Write([]string{"period", "node id", "node name", "wallet", "satellite id", "earned", "surge", "held", "disposed", "owed", "paid"}); err != nil {
+		controller.log.Error("failed to write csv response", zap.Error(err))
+		return
+	}
+
+	for _, paystub := range payouts.Paystubs {
+		err = writer.Write([]string{
+			paystub.Period,
+			paystub.NodeID.String(),
+			paystub.Name,
+			paystub.Wallet,
+			paystub.SatelliteID.String(),
+			strconv.FormatInt(paystub.Earned, 10),
+			strconv.FormatInt(paystub.Surge, 10),
+			strconv.FormatInt(paystub.Held, 10),
+			strconv.FormatInt(paystub.Disposed, 10),
+			strconv.FormatInt(paystub.Owed, 10),
+			strconv.FormatInt(paystub.Paid, 10),
+		})
+		if err != nil {
+			controller.log.Error("failed to write csv response", zap.Error(err))
+			return
+		}
+	}
+
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		controller.log.Error("failed to write csv response", zap.Error(err))
+		return
+	}
+}
+
+// periodRange returns the period start and period end query parameters.
+func periodRange(r *http.Request) (periodStart, periodEnd string, err error) {
+	query := r.URL.Query()
+
+	now := time.Now().UTC()
+	end := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if value := query.Get("periodEnd"); value != "" {
+		end, err = time.Parse(periodLayout, value)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	start := end.AddDate(0, -11, 0)
+	if value := query.Get("periodStart"); value != "" {
+		start, err = time.Parse(periodLayout, value)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	if start.Format(periodLayout) > end.Format(periodLayout) {
+		return "", "", errs.New("period start is after period end")
+	}
+
+	return start.Format(periodLayout), end.Format(periodLayout), nil
+}
+
+// serveError set http statuses and send json error.
+func (controller *Payouts) serveError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		controller.log.Error("failed to write json error response", zap.Error(err))
+	}
+}
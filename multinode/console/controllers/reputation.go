@@ -0,0 +1,70 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/multinode/nodes"
+)
+
+var (
+	// ErrReputation is an internal error type for reputation web api controller.
+	ErrReputation = errs.Class("reputation web api controller error")
+)
+
+// Reputation is a web api controller.
+type Reputation struct {
+	log     *zap.Logger
+	service *nodes.Service
+}
+
+// NewReputation is a constructor for Reputation.
+func NewReputation(log *zap.Logger, service *nodes.Service) *Reputation {
+	return &Reputation{
+		log:     log,
+		service: service,
+	}
+}
+
+// Satellites handles retrieving reputation of all nodes summarized per satellite.
+func (controller *Reputation) Satellites(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	summary, err := controller.service.Reputation(ctx)
+	if err != nil {
+		controller.log.Error("reputation internal error", zap.Error(err))
+		controller.serveError(w, http.StatusInternalServerError, ErrReputation.Wrap(err))
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(summary); err != nil {
+		controller.log.Error("failed to write json response", zap.Error(err))
+		return
+	}
+}
+
+// serveError set http statuses and send json error.
+func (controller *Reputation) serveError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		controller.log.Error("failed to write json error response", zap.Error(err))
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/multinode/nodes"
+)
+
+var (
+	// ErrStorage is an internal error type for storage web api controller.
+	ErrStorage = errs.Class("storage web api controller error")
+)
+
+// Storage is a web api controller.
+type Storage struct {
+	log     *zap.Logger
+	service *nodes.Service
+}
+
+// NewStorage is a constructor for Storage.
+func NewStorage(log *zap.Logger, service *nodes.Service) *Storage {
+	return &Storage{
+		log:     log,
+		service: service,
+	}
+}
+
+// DiskSpace handles retrieving disk space of all nodes.
+func (controller *Storage) DiskSpace(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	diskSpace, err := controller.service.DiskSpace(ctx)
+	if err != nil {
+		controller.log.Error("disk space internal error", zap.Error(err))
+		controller.serveError(w, http.StatusInternalServerError, ErrStorage.Wrap(err))
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(diskSpace); err != nil {
+		controller.log.Error("failed to write json response", zap.Error(err))
+		return
+	}
+}
+
+// Usage handles retrieving daily storage usage combined across all nodes.
+// The optional from and to query parameters are RFC3339 timestamps and default
+// to the last 30 days, the optional satelliteId narrows the usage to a single satellite.
+func (controller *Storage) Usage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	query := r.URL.Query()
+
+	to := time.Now().UTC()
+	if value := query.Get("to"); value != "" {
+		to, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			controller.serveError(w, http.StatusBadRequest, ErrStorage.Wrap(err))
+			return
+		}
+	}
+
+	from := to.AddDate(0, 0, -30)
+	if value := query.Get("from"); value != "" {
+		from, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			controller.serveError(w, http.StatusBadRequest, ErrStorage.Wrap(err))
+			return
+		}
+	}
+
+	if from.After(to) {
+		controller.serveError(w, http.StatusBadRequest, ErrStorage.New("from is after to"))
+		return
+	}
+
+	var satelliteID storj.NodeID
+	if value := query.Get("satelliteId"); value != "" {
+		satelliteID, err = storj.NodeIDFromString(value)
+		if err != nil {
+			controller.serveError(w, http.StatusBadRequest, ErrStorage.Wrap(err))
+			return
+		}
+	}
+
+	usage, err := controller.service.DailyStorageUsage(ctx, satelliteID, from, to)
+	if err != nil {
+		controller.log.Error("storage usage internal error", zap.Error(err))
+		controller.serveError(w, http.StatusInternalServerError, ErrStorage.Wrap(err))
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(usage); err != nil {
+		controller.log.Error("failed to write json response", zap.Error(err))
+		return
+	}
+}
+
+// serveError set http statuses and send json error.
+func (controller *Storage) serveError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		controller.log.Error("failed to write json error response", zap.Error(err))
+	}
+}
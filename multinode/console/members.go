@@ -5,9 +5,12 @@ package console
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/zeebo/errs"
 
+	"storj.io/common/storj"
 	"storj.io/common/uuid"
 )
 
@@ -17,6 +20,9 @@ import (
 type Members interface {
 	// Invite will create empty row in membersDB.
 	Invite(ctx context.Context, member Member) error
+	// InviteFirst creates the member only when there are no members yet,
+	// otherwise it returns ErrMembersExist.
+	InviteFirst(ctx context.Context, member Member) error
 	// Update updates all updatable fields of member.
 	Update(ctx context.Context, member Member) error
 	// Remove deletes member from membersDB.
@@ -25,16 +31,124 @@ type Members interface {
 	GetByEmail(ctx context.Context, email string) (Member, error)
 	// GetByID will return member with specified id.
 	GetByID(ctx context.Context, id uuid.UUID) (Member, error)
+	// List returns all members in the order they were added.
+	List(ctx context.Context) ([]Member, error)
+
+	// SetAccess grants member the permission on the node, replacing the previous one.
+	SetAccess(ctx context.Context, memberID uuid.UUID, nodeID storj.NodeID, permission Permission) error
+	// RevokeAccess removes the permission of member on the node.
+	RevokeAccess(ctx context.Context, memberID uuid.UUID, nodeID storj.NodeID) error
+	// Access returns the permissions of member on nodes.
+	Access(ctx context.Context, memberID uuid.UUID) ([]NodeAccess, error)
 }
 
 // ErrNoMember is a special error type that indicates about absence of member in MembersDB.
 var ErrNoMember = errs.Class("no such member")
 
+// ErrMembersExist is returned when creating the first member while there already are members.
+var ErrMembersExist = errs.Class("members exist")
+
 // Member represents some person that is invited to the MND by node owner.
 // Member will have configurable access privileges that will define which functions and which nodes are available for him.
 type Member struct {
-	ID           uuid.UUID
-	Email        string
-	Name         string
-	PasswordHash []byte
+	ID           uuid.UUID `json:"id"`
+	Email        string    `json:"email"`
+	Name         string    `json:"name"`
+	PasswordHash []byte    `json:"-"`
+	Role         Role      `json:"role"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// Role defines what a member may do in the MND apart from the nodes it has access to.
+type Role int
+
+const (
+	// RoleMember may only work with the nodes it was granted access to.
+	RoleMember Role = 0
+	// RoleOwner has full access to all nodes and manages members.
+	RoleOwner Role = 1
+)
+
+// String returns the name of the role.
+func (role Role) String() string {
+	if role == RoleOwner {
+		return "owner"
+	}
+	return "member"
+}
+
+// MarshalJSON implements json.Marshaler.
+func (role Role) MarshalJSON() ([]byte, error) {
+	return json.Marshal(role.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (role *Role) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	switch name {
+	case "owner":
+		*role = RoleOwner
+	case "member":
+		*role = RoleMember
+	default:
+		return errs.New("unknown role %q", name)
+	}
+	return nil
+}
+
+// Permission is the level of access of a member to a node.
+type Permission int
+
+const (
+	// PermissionNone grants no access to the node.
+	PermissionNone Permission = 0
+	// PermissionView allows viewing the node and its statistics.
+	PermissionView Permission = 1
+	// PermissionManage additionally allows renaming and removing the node.
+	PermissionManage Permission = 2
+)
+
+// String returns the name of the permission.
+func (permission Permission) String() string {
+	switch permission {
+	case PermissionView:
+		return "view"
+	case PermissionManage:
+		return "manage"
+	default:
+		return "none"
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (permission Permission) MarshalJSON() ([]byte, error) {
+	return json.Marshal(permission.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (permission *Permission) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	switch name {
+	case "none":
+		*permission = PermissionNone
+	case "view":
+		*permission = PermissionView
+	case "manage":
+		*permission = PermissionManage
+	default:
+		return errs.New("unknown permission %q", name)
+	}
+	return nil
+}
+
+// NodeAccess is the permission of a member on a node.
+type NodeAccess struct {
+	NodeID     storj.NodeID `json:"nodeId"`
+	Permission Permission   `json:"permission"`
 }
@@ -4,12 +4,15 @@
 package console_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"github.com/zeebo/assert"
+	"golang.org/x/sync/errgroup"
 
 	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
 	"storj.io/common/uuid"
 	"storj.io/storj/multinode"
 	"storj.io/storj/multinode/console"
@@ -62,3 +65,36 @@ func TestMembersDB(t *testing.T) {
 		assert.Equal(t, true, console.ErrNoMember.Has(err))
 	})
 }
+
+func TestMembersDBInviteFirst(t *testing.T) {
+	multinodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db multinode.DB) {
+		members := db.Members()
+
+		const concurrency = 4
+		var group errgroup.Group
+		for i := 0; i < concurrency; i++ {
+			member := console.Member{
+				ID:           testrand.UUID(),
+				Email:        fmt.Sprintf("owner%d@example.com", i),
+				PasswordHash: []byte{0},
+				Role:         console.RoleOwner,
+			}
+			group.Go(func() error {
+				err := members.InviteFirst(ctx, member)
+				if console.ErrMembersExist.Has(err) {
+					return nil
+				}
+				return err
+			})
+		}
+		require.NoError(t, group.Wait())
+
+		// only one of the concurrent calls created a member.
+		list, err := members.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+
+		err = members.InviteFirst(ctx, console.Member{ID: testrand.UUID(), Email: "late@example.com", PasswordHash: []byte{0}})
+		require.True(t, console.ErrMembersExist.Has(err))
+	})
+}
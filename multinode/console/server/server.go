@@ -5,16 +5,21 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"net"
 	"net/http"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
+	"storj.io/storj/multinode/alerts"
+	"storj.io/storj/multinode/console"
 	"storj.io/storj/multinode/console/controllers"
 	"storj.io/storj/multinode/nodes"
+	"storj.io/storj/private/web"
 )
 
 var (
@@ -26,6 +31,8 @@ var (
 type Config struct {
 	Address   string `json:"address" help:"server address of the api gateway and frontend app" default:"127.0.0.1:15002"`
 	StaticDir string `help:"path to static resources" default:""`
+
+	RateLimit web.IPRateLimiterConfig
 }
 
 // Server represents Multinode Dashboard http server.
@@ -34,41 +41,147 @@ type Config struct {
 type Server struct {
 	log *zap.Logger
 
-	config Config
-	nodes  *nodes.Service
+	config  Config
+	console *console.Service
+	nodes   *nodes.Service
+	alerts  alerts.DB
 
-	listener net.Listener
-	http     http.Server
+	listener    net.Listener
+	http        http.Server
+	rateLimiter *web.IPRateLimiter
 }
 
 // NewServer returns new instance of Multinode Dashboard http server.
-func NewServer(log *zap.Logger, config Config, nodes *nodes.Service, listener net.Listener) (*Server, error) {
+func NewServer(log *zap.Logger, config Config, console *console.Service, nodes *nodes.Service, alerts alerts.DB, listener net.Listener) (*Server, error) {
 	server := Server{
 		log:      log,
 		config:   config,
+		console:  console,
 		nodes:    nodes,
+		alerts:   alerts,
 		listener: listener,
+
+		rateLimiter: web.NewIPRateLimiter(config.RateLimit),
 	}
 
 	router := mux.NewRouter()
 	apiRouter := router.PathPrefix("/api/v0").Subrouter()
 	apiRouter.NotFoundHandler = controllers.NewNotFound(server.log)
 
+	authController := controllers.NewAuth(server.log, server.console)
+	authRouter := apiRouter.PathPrefix("/auth").Subrouter()
+	authRouter.HandleFunc("", authController.Status).Methods(http.MethodGet)
+	authRouter.Handle("/register", server.rateLimiter.Limit(http.HandlerFunc(authController.Register))).Methods(http.MethodPost)
+	authRouter.Handle("/login", server.rateLimiter.Limit(http.HandlerFunc(authController.Login))).Methods(http.MethodPost)
+	authRouter.HandleFunc("/logout", authController.Logout).Methods(http.MethodPost)
+
+	accountRouter := apiRouter.PathPrefix("/account").Subrouter()
+	accountRouter.HandleFunc("", authController.Account).Methods(http.MethodGet)
+	accountRouter.HandleFunc("/password", authController.ChangePassword).Methods(http.MethodPut)
+
+	membersController := controllers.NewMembers(server.log, server.console)
+	membersRouter := apiRouter.PathPrefix("/members").Subrouter()
+	membersRouter.HandleFunc("", membersController.List).Methods(http.MethodGet)
+	membersRouter.HandleFunc("", membersController.Add).Methods(http.MethodPost)
+	membersRouter.HandleFunc("/{id}", membersController.Remove).Methods(http.MethodDelete)
+	membersRouter.HandleFunc("/{id}/access", membersController.Access).Methods(http.MethodGet)
+	membersRouter.HandleFunc("/{id}/access/{nodeId}", membersController.SetAccess).Methods(http.MethodPut)
+
 	nodesController := controllers.NewNodes(server.log, server.nodes)
 	nodesRouter := apiRouter.PathPrefix("/nodes").Subrouter()
 	nodesRouter.HandleFunc("", nodesController.Add).Methods(http.MethodPost)
 	nodesRouter.HandleFunc("", nodesController.List).Methods(http.MethodGet)
+	nodesRouter.HandleFunc("/status", nodesController.Statuses).Methods(http.MethodGet)
+	nodesRouter.HandleFunc("/import", nodesController.Import).Methods(http.MethodPost)
+	nodesRouter.HandleFunc("/{id}/rotate-secret", nodesController.RotateSecret).Methods(http.MethodPost)
 	nodesRouter.HandleFunc("/{id}", nodesController.Get).Methods(http.MethodGet)
 	nodesRouter.HandleFunc("/{id}", nodesController.UpdateName).Methods(http.MethodPatch)
 	nodesRouter.HandleFunc("/{id}", nodesController.Delete).Methods(http.MethodDelete)
 
+	storageController := controllers.NewStorage(server.log, server.nodes)
+	storageRouter := apiRouter.PathPrefix("/storage").Subrouter()
+	storageRouter.HandleFunc("/disk-space", storageController.DiskSpace).Methods(http.MethodGet)
+	storageRouter.HandleFunc("/usage", storageController.Usage).Methods(http.MethodGet)
+
+	reputationController := controllers.NewReputation(server.log, server.nodes)
+	reputationRouter := apiRouter.PathPrefix("/reputation").Subrouter()
+	reputationRouter.HandleFunc("/satellites", reputationController.Satellites).Methods(http.MethodGet)
+
+	payoutsController := controllers.NewPayouts(server.log, server.nodes)
+	payoutsRouter := apiRouter.PathPrefix("/payouts").Subrouter()
+	payoutsRouter.HandleFunc("", payoutsController.Summary).Methods(http.MethodGet)
+	payoutsRouter.HandleFunc("/csv", payoutsController.CSV).Methods(http.MethodGet)
+
+	alertsController := controllers.NewAlerts(server.log, server.alerts)
+	alertsRouter := apiRouter.PathPrefix("/alerts").Subrouter()
+	alertsRouter.HandleFunc("", alertsController.List).Methods(http.MethodGet)
+	alertsRouter.HandleFunc("/active", alertsController.Active).Methods(http.MethodGet)
+
 	server.http = http.Server{
-		Handler: router,
+		Handler: server.authenticate(router),
 	}
 
 	return &server, nil
 }
 
+// authenticate rejects api requests without a valid session, except for the
+// auth endpoints. The account of the session is added to the request context
+// and restricts the nodes service to the nodes the member may view.
+func (server *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !requiresAuth(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := r.Context()
+
+		account, err := server.console.Authenticate(ctx, controllers.SessionToken(r))
+		if err != nil {
+			if !console.ErrUnauthenticated.Has(err) {
+				server.log.Error("failed to authenticate request", zap.Error(err))
+				server.serveError(w, http.StatusInternalServerError, Error.Wrap(err))
+				return
+			}
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			server.serveError(w, http.StatusUnauthorized, Error.Wrap(err))
+			return
+		}
+
+		ctx = console.WithAccount(ctx, account)
+		if account.Member.Role != console.RoleOwner {
+			ctx = nodes.WithFilter(ctx, account.CanView)
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// requiresAuth returns whether the path is protected.
+func requiresAuth(path string) bool {
+	if path == "/api/v0/auth" || strings.HasPrefix(path, "/api/v0/auth/") {
+		return false
+	}
+	return strings.HasPrefix(path, "/api/")
+}
+
+// serveError writes JSON error to response output stream.
+func (server *Server) serveError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		server.log.Error("failed to write json error response", zap.Error(err))
+	}
+}
+
 // Run starts the server that host webapp and api endpoints.
 func (server *Server) Run(ctx context.Context) (err error) {
 	ctx, cancel := context.WithCancel(ctx)
@@ -79,6 +192,10 @@ func (server *Server) Run(ctx context.Context) (err error) {
 		<-ctx.Done()
 		return Error.Wrap(server.http.Shutdown(context.Background()))
 	})
+	group.Go(func() error {
+		server.rateLimiter.Run(ctx)
+		return nil
+	})
 	group.Go(func() error {
 		defer cancel()
 		return Error.Wrap(server.http.Serve(server.listener))
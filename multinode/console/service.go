@@ -0,0 +1,382 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is an error class for console service error.
+	Error = errs.Class("console service error")
+	// ErrUnauthenticated is returned when the credentials or the session are not valid.
+	ErrUnauthenticated = errs.Class("unauthenticated")
+	// ErrForbidden is returned when the account lacks the permission for an action.
+	ErrForbidden = errs.Class("forbidden")
+	// ErrValidation is returned when the member details are not valid.
+	ErrValidation = errs.Class("validation error")
+)
+
+// minPasswordLength is the minimum number of characters of a member password.
+const minPasswordLength = 8
+
+// Config contains configuration for member accounts.
+type Config struct {
+	SessionDuration time.Duration `help:"how long a session is valid after signing in" default:"24h0m0s"`
+	PasswordCost    int           `help:"password hashing cost (0=automatic)" internal:"true" default:"0"`
+}
+
+// session is a signed in member.
+type session struct {
+	memberID  uuid.UUID
+	expiresAt time.Time
+}
+
+// Account is an authenticated member together with its access to nodes.
+type Account struct {
+	Member Member
+	access map[storj.NodeID]Permission
+}
+
+// Permission returns the permission of the account on the node.
+func (account Account) Permission(nodeID storj.NodeID) Permission {
+	if account.Member.Role == RoleOwner {
+		return PermissionManage
+	}
+	return account.access[nodeID]
+}
+
+// CanView returns whether the account may view the node.
+func (account Account) CanView(nodeID storj.NodeID) bool {
+	return account.Permission(nodeID) >= PermissionView
+}
+
+// CanManage returns whether the account may rename and remove the node.
+func (account Account) CanManage(nodeID storj.NodeID) bool {
+	return account.Permission(nodeID) >= PermissionManage
+}
+
+type accountKey struct{}
+
+// WithAccount returns a context carrying the authenticated account.
+func WithAccount(ctx context.Context, account Account) context.Context {
+	return context.WithValue(ctx, accountKey{}, account)
+}
+
+// GetAccount returns the authenticated account of the context.
+func GetAccount(ctx context.Context) (Account, bool) {
+	account, ok := ctx.Value(accountKey{}).(Account)
+	return account, ok
+}
+
+// Service handles member accounts, their sessions and access to nodes.
+//
+// architecture: Service
+type Service struct {
+	log     *zap.Logger
+	members Members
+	config  Config
+
+	mu       sync.Mutex
+	sessions map[string]session
+}
+
+// NewService creates a new instance of Service.
+func NewService(log *zap.Logger, members Members, config Config) *Service {
+	if config.PasswordCost == 0 {
+		config.PasswordCost = bcrypt.DefaultCost
+	}
+	return &Service{
+		log:      log,
+		members:  members,
+		config:   config,
+		sessions: map[string]session{},
+	}
+}
+
+// Registered returns whether the owner account has been created.
+func (service *Service) Registered(ctx context.Context) (_ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	members, err := service.members.List(ctx)
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+	return len(members) > 0, nil
+}
+
+// Register creates the owner account. It is only possible while there are no members.
+func (service *Service) Register(ctx context.Context, email, name, password string) (_ Member, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	member, err := service.newMember(email, name, password, RoleOwner)
+	if err != nil {
+		return Member{}, err
+	}
+
+	// checking for members and creating the owner happen in the same
+	// transaction, so that concurrent registrations can't both succeed.
+	if err := service.members.InviteFirst(ctx, member); err != nil {
+		if ErrMembersExist.Has(err) {
+			return Member{}, ErrForbidden.New("owner is already registered")
+		}
+		return Member{}, Error.Wrap(err)
+	}
+
+	member, err = service.members.GetByID(ctx, member.ID)
+	return member, Error.Wrap(err)
+}
+
+// AddMember creates a new member account.
+func (service *Service) AddMember(ctx context.Context, email, name, password string, role Role) (_ Member, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	member, err := service.newMember(email, name, password, role)
+	if err != nil {
+		return Member{}, err
+	}
+
+	_, err = service.members.GetByEmail(ctx, member.Email)
+	switch {
+	case err == nil:
+		return Member{}, ErrValidation.New("member with email %q already exists", member.Email)
+	case !ErrNoMember.Has(err):
+		return Member{}, Error.Wrap(err)
+	}
+
+	if err := service.members.Invite(ctx, member); err != nil {
+		return Member{}, Error.Wrap(err)
+	}
+
+	member, err = service.members.GetByID(ctx, member.ID)
+	return member, Error.Wrap(err)
+}
+
+// newMember validates member details and returns the member with a hashed password.
+func (service *Service) newMember(email, name, password string, role Role) (_ Member, err error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return Member{}, ErrValidation.New("email is required")
+	}
+	if len(password) < minPasswordLength {
+		return Member{}, ErrValidation.New("password must be at least %d characters", minPasswordLength)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), service.config.PasswordCost)
+	if err != nil {
+		return Member{}, Error.Wrap(err)
+	}
+
+	id, err := uuid.New()
+	if err != nil {
+		return Member{}, Error.Wrap(err)
+	}
+
+	return Member{
+		ID:           id,
+		Email:        email,
+		Name:         name,
+		PasswordHash: hash,
+		Role:         role,
+	}, nil
+}
+
+// ChangePassword replaces the password of the member after verifying the current one.
+func (service *Service) ChangePassword(ctx context.Context, id uuid.UUID, current, password string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	member, err := service.members.GetByID(ctx, id)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if bcrypt.CompareHashAndPassword(member.PasswordHash, []byte(current)) != nil {
+		return ErrUnauthenticated.New("invalid password")
+	}
+	if len(password) < minPasswordLength {
+		return ErrValidation.New("password must be at least %d characters", minPasswordLength)
+	}
+
+	member.PasswordHash, err = bcrypt.GenerateFromPassword([]byte(password), service.config.PasswordCost)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	return Error.Wrap(service.members.Update(ctx, member))
+}
+
+// Login verifies the credentials and starts a new session.
+func (service *Service) Login(ctx context.Context, email, password string) (token string, expiresAt time.Time, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	member, err := service.members.GetByEmail(ctx, strings.TrimSpace(email))
+	if err != nil {
+		if ErrNoMember.Has(err) {
+			return "", time.Time{}, ErrUnauthenticated.New("invalid email or password")
+		}
+		return "", time.Time{}, Error.Wrap(err)
+	}
+	if bcrypt.CompareHashAndPassword(member.PasswordHash, []byte(password)) != nil {
+		return "", time.Time{}, ErrUnauthenticated.New("invalid email or password")
+	}
+
+	var secret [32]byte
+	if _, err := rand.Read(secret[:]); err != nil {
+		return "", time.Time{}, Error.Wrap(err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(secret[:])
+
+	now := time.Now()
+	expiresAt = now.Add(service.config.SessionDuration)
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	for token, session := range service.sessions {
+		if !now.Before(session.expiresAt) {
+			delete(service.sessions, token)
+		}
+	}
+	service.sessions[token] = session{memberID: member.ID, expiresAt: expiresAt}
+
+	return token, expiresAt, nil
+}
+
+// Logout ends the session.
+func (service *Service) Logout(token string) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	delete(service.sessions, token)
+}
+
+// Authenticate returns the account of the session. The member and its access
+// are loaded for every call, so changes apply to existing sessions immediately.
+func (service *Service) Authenticate(ctx context.Context, token string) (_ Account, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	service.mu.Lock()
+	session, ok := service.sessions[token]
+	if ok && !time.Now().Before(session.expiresAt) {
+		delete(service.sessions, token)
+		ok = false
+	}
+	service.mu.Unlock()
+
+	if !ok {
+		return Account{}, ErrUnauthenticated.New("session expired or invalid")
+	}
+
+	member, err := service.members.GetByID(ctx, session.memberID)
+	if err != nil {
+		if ErrNoMember.Has(err) {
+			service.Logout(token)
+			return Account{}, ErrUnauthenticated.New("member was removed")
+		}
+		return Account{}, Error.Wrap(err)
+	}
+
+	account := Account{
+		Member: member,
+		access: map[storj.NodeID]Permission{},
+	}
+	if member.Role != RoleOwner {
+		access, err := service.members.Access(ctx, member.ID)
+		if err != nil {
+			return Account{}, Error.Wrap(err)
+		}
+		for _, nodeAccess := range access {
+			account.access[nodeAccess.NodeID] = nodeAccess.Permission
+		}
+	}
+
+	return account, nil
+}
+
+// Members returns all members.
+func (service *Service) Members(ctx context.Context) (_ []Member, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	members, err := service.members.List(ctx)
+	return members, Error.Wrap(err)
+}
+
+// RemoveMember removes the member, its access to nodes and ends its sessions.
+func (service *Service) RemoveMember(ctx context.Context, id uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	member, err := service.members.GetByID(ctx, id)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if member.Role == RoleOwner {
+		owners := 0
+		members, err := service.members.List(ctx)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		for _, other := range members {
+			if other.Role == RoleOwner {
+				owners++
+			}
+		}
+		if owners <= 1 {
+			return ErrForbidden.New("the last owner cannot be removed")
+		}
+	}
+
+	if err := service.members.Remove(ctx, id); err != nil {
+		return Error.Wrap(err)
+	}
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	for token, session := range service.sessions {
+		if session.memberID == id {
+			delete(service.sessions, token)
+		}
+	}
+
+	return nil
+}
+
+// Access returns the permissions of the member on nodes.
+func (service *Service) Access(ctx context.Context, memberID uuid.UUID) (_ []NodeAccess, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	access, err := service.members.Access(ctx, memberID)
+	return access, Error.Wrap(err)
+}
+
+// SetAccess changes the permission of the member on the node, PermissionNone revokes the access.
+func (service *Service) SetAccess(ctx context.Context, memberID uuid.UUID, nodeID storj.NodeID, permission Permission) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if _, err := service.members.GetByID(ctx, memberID); err != nil {
+		return Error.Wrap(err)
+	}
+
+	switch permission {
+	case PermissionNone:
+		return Error.Wrap(service.members.RevokeAccess(ctx, memberID, nodeID))
+	case PermissionView, PermissionManage:
+		return Error.Wrap(service.members.SetAccess(ctx, memberID, nodeID, permission))
+	default:
+		return ErrValidation.New("unknown permission %d", permission)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/crypto/bcrypt"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/multinode"
+	"storj.io/storj/multinode/console"
+	"storj.io/storj/multinode/multinodedb/multinodedbtest"
+)
+
+func TestService(t *testing.T) {
+	multinodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db multinode.DB) {
+		service := console.NewService(zaptest.NewLogger(t), db.Members(), console.Config{
+			SessionDuration: time.Hour,
+			PasswordCost:    bcrypt.MinCost,
+		})
+
+		registered, err := service.Registered(ctx)
+		require.NoError(t, err)
+		require.False(t, registered)
+
+		_, err = service.Register(ctx, "owner@example.com", "Owner", "short")
+		require.True(t, console.ErrValidation.Has(err))
+
+		owner, err := service.Register(ctx, "owner@example.com", "Owner", "owner-password")
+		require.NoError(t, err)
+		assert.Equal(t, console.RoleOwner, owner.Role)
+
+		_, err = service.Register(ctx, "other@example.com", "Other", "other-password")
+		require.True(t, console.ErrForbidden.Has(err))
+
+		_, _, err = service.Login(ctx, "owner@example.com", "wrong-password")
+		require.True(t, console.ErrUnauthenticated.Has(err))
+
+		token, _, err := service.Login(ctx, "owner@example.com", "owner-password")
+		require.NoError(t, err)
+
+		account, err := service.Authenticate(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, owner.ID, account.Member.ID)
+		assert.True(t, account.CanManage(testrand.NodeID()))
+
+		contractor, err := service.AddMember(ctx, "contractor@example.com", "Contractor", "contractor-password", console.RoleMember)
+		require.NoError(t, err)
+
+		_, err = service.AddMember(ctx, "contractor@example.com", "Contractor", "contractor-password", console.RoleMember)
+		require.True(t, console.ErrValidation.Has(err))
+
+		viewed, managed := testrand.NodeID(), testrand.NodeID()
+		require.NoError(t, db.Nodes().Add(ctx, viewed, []byte("secret"), "127.0.0.1:1"))
+		require.NoError(t, db.Nodes().Add(ctx, managed, []byte("secret"), "127.0.0.1:2"))
+		require.NoError(t, service.SetAccess(ctx, contractor.ID, viewed, console.PermissionView))
+		require.NoError(t, service.SetAccess(ctx, contractor.ID, managed, console.PermissionView))
+		require.NoError(t, service.SetAccess(ctx, contractor.ID, managed, console.PermissionManage))
+
+		token, _, err = service.Login(ctx, "contractor@example.com", "contractor-password")
+		require.NoError(t, err)
+
+		account, err = service.Authenticate(ctx, token)
+		require.NoError(t, err)
+		assert.True(t, account.CanView(viewed))
+		assert.False(t, account.CanManage(viewed))
+		assert.True(t, account.CanManage(managed))
+		assert.False(t, account.CanView(testrand.NodeID()))
+
+		require.NoError(t, service.SetAccess(ctx, contractor.ID, viewed, console.PermissionNone))
+		account, err = service.Authenticate(ctx, token)
+		require.NoError(t, err)
+		assert.False(t, account.CanView(viewed))
+
+		require.NoError(t, service.ChangePassword(ctx, contractor.ID, "contractor-password", "new-contractor-password"))
+		_, _, err = service.Login(ctx, "contractor@example.com", "new-contractor-password")
+		require.NoError(t, err)
+
+		err = service.RemoveMember(ctx, owner.ID)
+		require.True(t, console.ErrForbidden.Has(err))
+
+		require.NoError(t, service.RemoveMember(ctx, contractor.ID))
+		_, err = service.Authenticate(ctx, token)
+		require.True(t, console.ErrUnauthenticated.Has(err))
+
+		service.Logout(token)
+		_, err = service.Authenticate(ctx, token)
+		require.True(t, console.ErrUnauthenticated.Has(err))
+	})
+}
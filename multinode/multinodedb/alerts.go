@@ -0,0 +1,125 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package multinodedb
+
+import (
+	"context"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/storj/multinode/alerts"
+	"storj.io/storj/multinode/multinodedb/dbx"
+)
+
+// ErrAlertsDB indicates about internal AlertsDB error.
+var ErrAlertsDB = errs.Class("AlertsDB error")
+
+// ensures that alertsdb implements alerts.DB.
+var _ alerts.DB = (*alertsdb)(nil)
+
+// alertsdb exposes needed by MND AlertsDB functionality.
+// dbx implementation of alerts.DB.
+//
+// architecture: Database
+type alertsdb struct {
+	methods dbx.Methods
+}
+
+// Create stores a new alert.
+func (a *alertsdb) Create(ctx context.Context, alert alerts.Alert) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = a.methods.CreateNoReturn_Alert(
+		ctx,
+		dbx.Alert_Id(alert.ID[:]),
+		dbx.Alert_NodeId(alert.NodeID.Bytes()),
+		dbx.Alert_Kind(string(alert.Kind)),
+		dbx.Alert_SatelliteId(alert.SatelliteID.Bytes()),
+		dbx.Alert_Message(alert.Message),
+		dbx.Alert_Resolved(false),
+	)
+
+	return ErrAlertsDB.Wrap(err)
+}
+
+// Resolve marks the alert as resolved.
+func (a *alertsdb) Resolve(ctx context.Context, id uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = a.methods.UpdateNoReturn_Alert_By_Id(ctx, dbx.Alert_Id(id[:]), dbx.Alert_Update_Fields{
+		Resolved: dbx.Alert_Resolved(true),
+	})
+
+	return ErrAlertsDB.Wrap(err)
+}
+
+// Active returns all alerts which are not resolved.
+func (a *alertsdb) Active(ctx context.Context) (_ []alerts.Alert, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dbxAlerts, err := a.methods.All_Alert_By_Resolved(ctx, dbx.Alert_Resolved(false))
+	if err != nil {
+		return nil, ErrAlertsDB.Wrap(err)
+	}
+
+	return fromDBXAlerts(dbxAlerts)
+}
+
+// List returns alerts, newest first.
+func (a *alertsdb) List(ctx context.Context, limit int, offset int64) (_ []alerts.Alert, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dbxAlerts, err := a.methods.Limited_Alert_OrderBy_Desc_CreatedAt(ctx, limit, offset)
+	if err != nil {
+		return nil, ErrAlertsDB.Wrap(err)
+	}
+
+	return fromDBXAlerts(dbxAlerts)
+}
+
+// fromDBXAlerts converts dbx.Alert rows to alerts.Alert.
+func fromDBXAlerts(dbxAlerts []*dbx.Alert) ([]alerts.Alert, error) {
+	list := make([]alerts.Alert, 0, len(dbxAlerts))
+	for _, dbxAlert := range dbxAlerts {
+		alert, err := fromDBXAlert(dbxAlert)
+		if err != nil {
+			return nil, ErrAlertsDB.Wrap(err)
+		}
+		list = append(list, alert)
+	}
+	return list, nil
+}
+
+// fromDBXAlert converts dbx.Alert to alerts.Alert.
+func fromDBXAlert(dbxAlert *dbx.Alert) (_ alerts.Alert, err error) {
+	id, err := uuid.FromBytes(dbxAlert.Id)
+	if err != nil {
+		return alerts.Alert{}, err
+	}
+	nodeID, err := storj.NodeIDFromBytes(dbxAlert.NodeId)
+	if err != nil {
+		return alerts.Alert{}, err
+	}
+	satelliteID, err := storj.NodeIDFromBytes(dbxAlert.SatelliteId)
+	if err != nil {
+		return alerts.Alert{}, err
+	}
+
+	alert := alerts.Alert{
+		ID:          id,
+		NodeID:      nodeID,
+		Kind:        alerts.Kind(dbxAlert.Kind),
+		SatelliteID: satelliteID,
+		Message:     dbxAlert.Message,
+		CreatedAt:   dbxAlert.CreatedAt,
+	}
+	if dbxAlert.Resolved {
+		resolvedAt := dbxAlert.UpdatedAt
+		alert.ResolvedAt = &resolvedAt
+	}
+
+	return alert, nil
+}
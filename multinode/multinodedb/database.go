@@ -11,6 +11,7 @@ import (
 	"go.uber.org/zap"
 
 	"storj.io/storj/multinode"
+	"storj.io/storj/multinode/alerts"
 	"storj.io/storj/multinode/console"
 	"storj.io/storj/multinode/multinodedb/dbx"
 	"storj.io/storj/multinode/nodes"
@@ -90,6 +91,14 @@ func (db *multinodeDB) Nodes() nodes.DB {
 func (db *multinodeDB) Members() console.Members {
 	return &members{
 		methods: db,
+		db:      db.DB.DB,
+	}
+}
+
+// Alerts returns alerts database.
+func (db *multinodeDB) Alerts() alerts.DB {
+	return &alertsdb{
+		methods: db,
 	}
 }
 
@@ -269,11 +269,23 @@ func newpgx(db *DB) *pgxDB {
 }
 
 func (obj *pgxDB) Schema() string {
-	return `CREATE TABLE members (
+	return `CREATE TABLE alerts (
+	id bytea NOT NULL,
+	node_id bytea NOT NULL,
+	kind text NOT NULL,
+	satellite_id bytea NOT NULL,
+	message text NOT NULL,
+	resolved boolean NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+);
+CREATE TABLE members (
 	id bytea NOT NULL,
 	email text NOT NULL,
 	name text NOT NULL,
 	password_hash bytea NOT NULL,
+	role integer NOT NULL,
 	created_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id )
 );
@@ -282,8 +294,17 @@ CREATE TABLE nodes (
 	name text NOT NULL,
 	public_address text NOT NULL,
 	api_secret bytea NOT NULL,
+	revoked_api_secret bytea,
 	PRIMARY KEY ( id )
-);`
+);
+CREATE TABLE node_accesses (
+	member_id bytea NOT NULL REFERENCES members( id ) ON DELETE CASCADE,
+	node_id bytea NOT NULL REFERENCES nodes( id ) ON DELETE CASCADE,
+	permission integer NOT NULL,
+	PRIMARY KEY ( member_id, node_id )
+);
+CREATE INDEX alerts_resolved_index ON alerts ( resolved );
+CREATE INDEX alerts_created_at_index ON alerts ( created_at );`
 }
 
 func (obj *pgxDB) wrapTx(tx tagsql.Tx) txMethods {
@@ -346,11 +367,181 @@ nextval:
 	fmt.Fprint(f, "]")
 }
 
+type Alert struct {
+	Id          []byte
+	NodeId      []byte
+	Kind        string
+	SatelliteId []byte
+	Message     string
+	Resolved    bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (Alert) _Table() string { return "alerts" }
+
+type Alert_Update_Fields struct {
+	Resolved Alert_Resolved_Field
+}
+
+type Alert_Id_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func Alert_Id(v []byte) Alert_Id_Field {
+	return Alert_Id_Field{_set: true, _value: v}
+}
+
+func (f Alert_Id_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Alert_Id_Field) _Column() string { return "id" }
+
+type Alert_NodeId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func Alert_NodeId(v []byte) Alert_NodeId_Field {
+	return Alert_NodeId_Field{_set: true, _value: v}
+}
+
+func (f Alert_NodeId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Alert_NodeId_Field) _Column() string { return "node_id" }
+
+type Alert_Kind_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func Alert_Kind(v string) Alert_Kind_Field {
+	return Alert_Kind_Field{_set: true, _value: v}
+}
+
+func (f Alert_Kind_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Alert_Kind_Field) _Column() string { return "kind" }
+
+type Alert_SatelliteId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func Alert_SatelliteId(v []byte) Alert_SatelliteId_Field {
+	return Alert_SatelliteId_Field{_set: true, _value: v}
+}
+
+func (f Alert_SatelliteId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Alert_SatelliteId_Field) _Column() string { return "satellite_id" }
+
+type Alert_Message_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func Alert_Message(v string) Alert_Message_Field {
+	return Alert_Message_Field{_set: true, _value: v}
+}
+
+func (f Alert_Message_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Alert_Message_Field) _Column() string { return "message" }
+
+type Alert_Resolved_Field struct {
+	_set   bool
+	_null  bool
+	_value bool
+}
+
+func Alert_Resolved(v bool) Alert_Resolved_Field {
+	return Alert_Resolved_Field{_set: true, _value: v}
+}
+
+func (f Alert_Resolved_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Alert_Resolved_Field) _Column() string { return "resolved" }
+
+type Alert_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func Alert_CreatedAt(v time.Time) Alert_CreatedAt_Field {
+	return Alert_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f Alert_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Alert_CreatedAt_Field) _Column() string { return "created_at" }
+
+type Alert_UpdatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func Alert_UpdatedAt(v time.Time) Alert_UpdatedAt_Field {
+	return Alert_UpdatedAt_Field{_set: true, _value: v}
+}
+
+func (f Alert_UpdatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Alert_UpdatedAt_Field) _Column() string { return "updated_at" }
+
 type Member struct {
 	Id           []byte
 	Email        string
 	Name         string
 	PasswordHash []byte
+	Role         int
 	CreatedAt    time.Time
 }
 
@@ -360,6 +551,7 @@ type Member_Update_Fields struct {
 	Email        Member_Email_Field
 	Name         Member_Name_Field
 	PasswordHash Member_PasswordHash_Field
+	Role         Member_Role_Field
 }
 
 type Member_Id_Field struct {
@@ -438,6 +630,25 @@ func (f Member_PasswordHash_Field) value() interface{} {
 
 func (Member_PasswordHash_Field) _Column() string { return "password_hash" }
 
+type Member_Role_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func Member_Role(v int) Member_Role_Field {
+	return Member_Role_Field{_set: true, _value: v}
+}
+
+func (f Member_Role_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Member_Role_Field) _Column() string { return "role" }
+
 type Member_CreatedAt_Field struct {
 	_set   bool
 	_null  bool
@@ -458,16 +669,23 @@ func (f Member_CreatedAt_Field) value() interface{} {
 func (Member_CreatedAt_Field) _Column() string { return "created_at" }
 
 type Node struct {
-	Id            []byte
-	Name          string
-	PublicAddress string
-	ApiSecret     []byte
+	Id               []byte
+	Name             string
+	PublicAddress    string
+	ApiSecret        []byte
+	RevokedApiSecret []byte
 }
 
 func (Node) _Table() string { return "nodes" }
 
+type Node_Create_Fields struct {
+	RevokedApiSecret Node_RevokedApiSecret_Field
+}
+
 type Node_Update_Fields struct {
-	Name Node_Name_Field
+	Name             Node_Name_Field
+	ApiSecret        Node_ApiSecret_Field
+	RevokedApiSecret Node_RevokedApiSecret_Field
 }
 
 type Node_Id_Field struct {
@@ -546,6 +764,107 @@ func (f Node_ApiSecret_Field) value() interface{} {
 
 func (Node_ApiSecret_Field) _Column() string { return "api_secret" }
 
+type Node_RevokedApiSecret_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func Node_RevokedApiSecret(v []byte) Node_RevokedApiSecret_Field {
+	return Node_RevokedApiSecret_Field{_set: true, _value: v}
+}
+
+func Node_RevokedApiSecret_Raw(v []byte) Node_RevokedApiSecret_Field {
+	if v == nil {
+		return Node_RevokedApiSecret_Null()
+	}
+	return Node_RevokedApiSecret(v)
+}
+
+func Node_RevokedApiSecret_Null() Node_RevokedApiSecret_Field {
+	return Node_RevokedApiSecret_Field{_set: true, _null: true}
+}
+
+func (f Node_RevokedApiSecret_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f Node_RevokedApiSecret_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_RevokedApiSecret_Field) _Column() string { return "revoked_api_secret" }
+
+type NodeAccess struct {
+	MemberId   []byte
+	NodeId     []byte
+	Permission int
+}
+
+func (NodeAccess) _Table() string { return "node_accesses" }
+
+type NodeAccess_Update_Fields struct {
+	Permission NodeAccess_Permission_Field
+}
+
+type NodeAccess_MemberId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func NodeAccess_MemberId(v []byte) NodeAccess_MemberId_Field {
+	return NodeAccess_MemberId_Field{_set: true, _value: v}
+}
+
+func (f NodeAccess_MemberId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeAccess_MemberId_Field) _Column() string { return "member_id" }
+
+type NodeAccess_NodeId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func NodeAccess_NodeId(v []byte) NodeAccess_NodeId_Field {
+	return NodeAccess_NodeId_Field{_set: true, _value: v}
+}
+
+func (f NodeAccess_NodeId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeAccess_NodeId_Field) _Column() string { return "node_id" }
+
+type NodeAccess_Permission_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func NodeAccess_Permission(v int) NodeAccess_Permission_Field {
+	return NodeAccess_Permission_Field{_set: true, _value: v}
+}
+
+func (f NodeAccess_Permission_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeAccess_Permission_Field) _Column() string { return "permission" }
+
 func toUTC(t time.Time) time.Time {
 	return t.UTC()
 }
@@ -970,24 +1289,26 @@ func (obj *pgxImpl) Create_Node(ctx context.Context,
 	node_id Node_Id_Field,
 	node_name Node_Name_Field,
 	node_public_address Node_PublicAddress_Field,
-	node_api_secret Node_ApiSecret_Field) (
+	node_api_secret Node_ApiSecret_Field,
+	optional Node_Create_Fields) (
 	node *Node, err error) {
 	defer mon.Task()(&ctx)(&err)
 	__id_val := node_id.value()
 	__name_val := node_name.value()
 	__public_address_val := node_public_address.value()
 	__api_secret_val := node_api_secret.value()
+	__revoked_api_secret_val := optional.RevokedApiSecret.value()
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO nodes ( id, name, public_address, api_secret ) VALUES ( ?, ?, ?, ? ) RETURNING nodes.id, nodes.name, nodes.public_address, nodes.api_secret")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO nodes ( id, name, public_address, api_secret, revoked_api_secret ) VALUES ( ?, ?, ?, ?, ? ) RETURNING nodes.id, nodes.name, nodes.public_address, nodes.api_secret, nodes.revoked_api_secret")
 
 	var __values []interface{}
-	__values = append(__values, __id_val, __name_val, __public_address_val, __api_secret_val)
+	__values = append(__values, __id_val, __name_val, __public_address_val, __api_secret_val, __revoked_api_secret_val)
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	node = &Node{}
-	err = obj.driver.QueryRowContext(ctx, __stmt, __values...).Scan(&node.Id, &node.Name, &node.PublicAddress, &node.ApiSecret)
+	err = obj.driver.QueryRowContext(ctx, __stmt, __values...).Scan(&node.Id, &node.Name, &node.PublicAddress, &node.ApiSecret, &node.RevokedApiSecret)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -999,7 +1320,8 @@ func (obj *pgxImpl) Create_Member(ctx context.Context,
 	member_id Member_Id_Field,
 	member_email Member_Email_Field,
 	member_name Member_Name_Field,
-	member_password_hash Member_PasswordHash_Field) (
+	member_password_hash Member_PasswordHash_Field,
+	member_role Member_Role_Field) (
 	member *Member, err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -1008,18 +1330,19 @@ func (obj *pgxImpl) Create_Member(ctx context.Context,
 	__email_val := member_email.value()
 	__name_val := member_name.value()
 	__password_hash_val := member_password_hash.value()
+	__role_val := member_role.value()
 	__created_at_val := __now
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO members ( id, email, name, password_hash, created_at ) VALUES ( ?, ?, ?, ?, ? ) RETURNING members.id, members.email, members.name, members.password_hash, members.created_at")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO members ( id, email, name, password_hash, role, created_at ) VALUES ( ?, ?, ?, ?, ?, ? ) RETURNING members.id, members.email, members.name, members.password_hash, members.role, members.created_at")
 
 	var __values []interface{}
-	__values = append(__values, __id_val, __email_val, __name_val, __password_hash_val, __created_at_val)
+	__values = append(__values, __id_val, __email_val, __name_val, __password_hash_val, __role_val, __created_at_val)
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	member = &Member{}
-	err = obj.driver.QueryRowContext(ctx, __stmt, __values...).Scan(&member.Id, &member.Email, &member.Name, &member.PasswordHash, &member.CreatedAt)
+	err = obj.driver.QueryRowContext(ctx, __stmt, __values...).Scan(&member.Id, &member.Email, &member.Name, &member.PasswordHash, &member.Role, &member.CreatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -1027,12 +1350,74 @@ func (obj *pgxImpl) Create_Member(ctx context.Context,
 
 }
 
+func (obj *pgxImpl) CreateNoReturn_Alert(ctx context.Context,
+	alert_id Alert_Id_Field,
+	alert_node_id Alert_NodeId_Field,
+	alert_kind Alert_Kind_Field,
+	alert_satellite_id Alert_SatelliteId_Field,
+	alert_message Alert_Message_Field,
+	alert_resolved Alert_Resolved_Field) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
+	__id_val := alert_id.value()
+	__node_id_val := alert_node_id.value()
+	__kind_val := alert_kind.value()
+	__satellite_id_val := alert_satellite_id.value()
+	__message_val := alert_message.value()
+	__resolved_val := alert_resolved.value()
+	__created_at_val := __now
+	__updated_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO alerts ( id, node_id, kind, satellite_id, message, resolved, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ? )")
+
+	var __values []interface{}
+	__values = append(__values, __id_val, __node_id_val, __kind_val, __satellite_id_val, __message_val, __resolved_val, __created_at_val, __updated_at_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+
+}
+
+func (obj *pgxImpl) ReplaceNoReturn_NodeAccess(ctx context.Context,
+	node_access_member_id NodeAccess_MemberId_Field,
+	node_access_node_id NodeAccess_NodeId_Field,
+	node_access_permission NodeAccess_Permission_Field) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+	__member_id_val := node_access_member_id.value()
+	__node_id_val := node_access_node_id.value()
+	__permission_val := node_access_permission.value()
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO node_accesses ( member_id, node_id, permission ) VALUES ( ?, ?, ? ) ON CONFLICT ( member_id, node_id ) DO UPDATE SET member_id = EXCLUDED.member_id, node_id = EXCLUDED.node_id, permission = EXCLUDED.permission")
+
+	var __values []interface{}
+	__values = append(__values, __member_id_val, __node_id_val, __permission_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+
+}
+
 func (obj *pgxImpl) Get_Node_By_Id(ctx context.Context,
 	node_id Node_Id_Field) (
 	node *Node, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.name, nodes.public_address, nodes.api_secret FROM nodes WHERE nodes.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.name, nodes.public_address, nodes.api_secret, nodes.revoked_api_secret FROM nodes WHERE nodes.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, node_id.value())
@@ -1041,7 +1426,7 @@ func (obj *pgxImpl) Get_Node_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	node = &Node{}
-	err = obj.driver.QueryRowContext(ctx, __stmt, __values...).Scan(&node.Id, &node.Name, &node.PublicAddress, &node.ApiSecret)
+	err = obj.driver.QueryRowContext(ctx, __stmt, __values...).Scan(&node.Id, &node.Name, &node.PublicAddress, &node.ApiSecret, &node.RevokedApiSecret)
 	if err != nil {
 		return (*Node)(nil), obj.makeErr(err)
 	}
@@ -1053,7 +1438,7 @@ func (obj *pgxImpl) All_Node(ctx context.Context) (
 	rows []*Node, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.name, nodes.public_address, nodes.api_secret FROM nodes")
+	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.name, nodes.public_address, nodes.api_secret, nodes.revoked_api_secret FROM nodes")
 
 	var __values []interface{}
 
@@ -1068,7 +1453,7 @@ func (obj *pgxImpl) All_Node(ctx context.Context) (
 
 	for __rows.Next() {
 		node := &Node{}
-		err = __rows.Scan(&node.Id, &node.Name, &node.PublicAddress, &node.ApiSecret)
+		err = __rows.Scan(&node.Id, &node.Name, &node.PublicAddress, &node.ApiSecret, &node.RevokedApiSecret)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -1086,7 +1471,7 @@ func (obj *pgxImpl) Get_Member_By_Email(ctx context.Context,
 	member *Member, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT members.id, members.email, members.name, members.password_hash, members.created_at FROM members WHERE members.email = ? LIMIT 2")
+	var __embed_stmt = __sqlbundle_Literal("SELECT members.id, members.email, members.name, members.password_hash, members.role, members.created_at FROM members WHERE members.email = ? LIMIT 2")
 
 	var __values []interface{}
 	__values = append(__values, member_email.value())
@@ -1108,7 +1493,7 @@ func (obj *pgxImpl) Get_Member_By_Email(ctx context.Context,
 	}
 
 	member = &Member{}
-	err = __rows.Scan(&member.Id, &member.Email, &member.Name, &member.PasswordHash, &member.CreatedAt)
+	err = __rows.Scan(&member.Id, &member.Email, &member.Name, &member.PasswordHash, &member.Role, &member.CreatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -1130,7 +1515,7 @@ func (obj *pgxImpl) Get_Member_By_Id(ctx context.Context,
 	member *Member, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT members.id, members.email, members.name, members.password_hash, members.created_at FROM members WHERE members.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT members.id, members.email, members.name, members.password_hash, members.role, members.created_at FROM members WHERE members.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, member_id.value())
@@ -1139,7 +1524,7 @@ func (obj *pgxImpl) Get_Member_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	member = &Member{}
-	err = obj.driver.QueryRowContext(ctx, __stmt, __values...).Scan(&member.Id, &member.Email, &member.Name, &member.PasswordHash, &member.CreatedAt)
+	err = obj.driver.QueryRowContext(ctx, __stmt, __values...).Scan(&member.Id, &member.Email, &member.Name, &member.PasswordHash, &member.Role, &member.CreatedAt)
 	if err != nil {
 		return (*Member)(nil), obj.makeErr(err)
 	}
@@ -1147,6 +1532,141 @@ func (obj *pgxImpl) Get_Member_By_Id(ctx context.Context,
 
 }
 
+func (obj *pgxImpl) All_Member_OrderBy_Asc_CreatedAt(ctx context.Context) (
+	rows []*Member, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT members.id, members.email, members.name, members.password_hash, members.role, members.created_at FROM members ORDER BY members.created_at")
+
+	var __values []interface{}
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		member := &Member{}
+		err = __rows.Scan(&member.Id, &member.Email, &member.Name, &member.PasswordHash, &member.Role, &member.CreatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, member)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
+func (obj *pgxImpl) All_Alert_By_Resolved(ctx context.Context,
+	alert_resolved Alert_Resolved_Field) (
+	rows []*Alert, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT alerts.id, alerts.node_id, alerts.kind, alerts.satellite_id, alerts.message, alerts.resolved, alerts.created_at, alerts.updated_at FROM alerts WHERE alerts.resolved = ?")
+
+	var __values []interface{}
+	__values = append(__values, alert_resolved.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		alert := &Alert{}
+		err = __rows.Scan(&alert.Id, &alert.NodeId, &alert.Kind, &alert.SatelliteId, &alert.Message, &alert.Resolved, &alert.CreatedAt, &alert.UpdatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, alert)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
+func (obj *pgxImpl) Limited_Alert_OrderBy_Desc_CreatedAt(ctx context.Context,
+	limit int, offset int64) (
+	rows []*Alert, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT alerts.id, alerts.node_id, alerts.kind, alerts.satellite_id, alerts.message, alerts.resolved, alerts.created_at, alerts.updated_at FROM alerts ORDER BY alerts.created_at DESC LIMIT ? OFFSET ?")
+
+	var __values []interface{}
+
+	__values = append(__values, limit, offset)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		alert := &Alert{}
+		err = __rows.Scan(&alert.Id, &alert.NodeId, &alert.Kind, &alert.SatelliteId, &alert.Message, &alert.Resolved, &alert.CreatedAt, &alert.UpdatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, alert)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
+func (obj *pgxImpl) All_NodeAccess_By_MemberId(ctx context.Context,
+	node_access_member_id NodeAccess_MemberId_Field) (
+	rows []*NodeAccess, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT node_accesses.member_id, node_accesses.node_id, node_accesses.permission FROM node_accesses WHERE node_accesses.member_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, node_access_member_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		node_access := &NodeAccess{}
+		err = __rows.Scan(&node_access.MemberId, &node_access.NodeId, &node_access.Permission)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, node_access)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
 func (obj *pgxImpl) Update_Node_By_Id(ctx context.Context,
 	node_id Node_Id_Field,
 	update Node_Update_Fields) (
@@ -1154,7 +1674,7 @@ func (obj *pgxImpl) Update_Node_By_Id(ctx context.Context,
 	defer mon.Task()(&ctx)(&err)
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE nodes SET "), __sets, __sqlbundle_Literal(" WHERE nodes.id = ? RETURNING nodes.id, nodes.name, nodes.public_address, nodes.api_secret")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE nodes SET "), __sets, __sqlbundle_Literal(" WHERE nodes.id = ? RETURNING nodes.id, nodes.name, nodes.public_address, nodes.api_secret, nodes.revoked_api_secret")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
@@ -1165,6 +1685,16 @@ func (obj *pgxImpl) Update_Node_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("name = ?"))
 	}
 
+	if update.ApiSecret._set {
+		__values = append(__values, update.ApiSecret.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("api_secret = ?"))
+	}
+
+	if update.RevokedApiSecret._set {
+		__values = append(__values, update.RevokedApiSecret.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("revoked_api_secret = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -1178,7 +1708,7 @@ func (obj *pgxImpl) Update_Node_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	node = &Node{}
-	err = obj.driver.QueryRowContext(ctx, __stmt, __values...).Scan(&node.Id, &node.Name, &node.PublicAddress, &node.ApiSecret)
+	err = obj.driver.QueryRowContext(ctx, __stmt, __values...).Scan(&node.Id, &node.Name, &node.PublicAddress, &node.ApiSecret, &node.RevokedApiSecret)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -1206,6 +1736,16 @@ func (obj *pgxImpl) UpdateNoReturn_Node_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("name = ?"))
 	}
 
+	if update.ApiSecret._set {
+		__values = append(__values, update.ApiSecret.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("api_secret = ?"))
+	}
+
+	if update.RevokedApiSecret._set {
+		__values = append(__values, update.RevokedApiSecret.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("revoked_api_secret = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return emptyUpdate()
 	}
@@ -1232,7 +1772,7 @@ func (obj *pgxImpl) Update_Member_By_Id(ctx context.Context,
 	defer mon.Task()(&ctx)(&err)
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE members SET "), __sets, __sqlbundle_Literal(" WHERE members.id = ? RETURNING members.id, members.email, members.name, members.password_hash, members.created_at")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE members SET "), __sets, __sqlbundle_Literal(" WHERE members.id = ? RETURNING members.id, members.email, members.name, members.password_hash, members.role, members.created_at")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
@@ -1253,6 +1793,11 @@ func (obj *pgxImpl) Update_Member_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("password_hash = ?"))
 	}
 
+	if update.Role._set {
+		__values = append(__values, update.Role.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("role = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -1266,7 +1811,7 @@ func (obj *pgxImpl) Update_Member_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	member = &Member{}
-	err = obj.driver.QueryRowContext(ctx, __stmt, __values...).Scan(&member.Id, &member.Email, &member.Name, &member.PasswordHash, &member.CreatedAt)
+	err = obj.driver.QueryRowContext(ctx, __stmt, __values...).Scan(&member.Id, &member.Email, &member.Name, &member.PasswordHash, &member.Role, &member.CreatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -1276,6 +1821,44 @@ func (obj *pgxImpl) Update_Member_By_Id(ctx context.Context,
 	return member, nil
 }
 
+func (obj *pgxImpl) UpdateNoReturn_Alert_By_Id(ctx context.Context,
+	alert_id Alert_Id_Field,
+	update Alert_Update_Fields) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE alerts SET "), __sets, __sqlbundle_Literal(" WHERE alerts.id = ?")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.Resolved._set {
+		__values = append(__values, update.Resolved.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("resolved = ?"))
+	}
+
+	__now := obj.db.Hooks.Now().UTC()
+
+	__values = append(__values, __now)
+	__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("updated_at = ?"))
+
+	__args = append(__args, alert_id.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+}
+
 func (obj *pgxImpl) Delete_Node_By_Id(ctx context.Context,
 	node_id Node_Id_Field) (
 	deleted bool, err error) {
@@ -1330,6 +1913,34 @@ func (obj *pgxImpl) Delete_Member_By_Id(ctx context.Context,
 
 }
 
+func (obj *pgxImpl) Delete_NodeAccess_By_MemberId_And_NodeId(ctx context.Context,
+	node_access_member_id NodeAccess_MemberId_Field,
+	node_access_node_id NodeAccess_NodeId_Field) (
+	deleted bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM node_accesses WHERE node_accesses.member_id = ? AND node_accesses.node_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, node_access_member_id.value(), node_access_node_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	__count, err := __res.RowsAffected()
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	return __count > 0, nil
+
+}
+
 func (impl pgxImpl) isConstraintError(err error) (
 	constraint string, ok bool) {
 	if e, ok := err.(*pgconn.PgError); ok {
@@ -1344,6 +1955,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 	var __res sql.Result
 	var __count int64
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM node_accesses;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
 	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM nodes;")
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -1359,6 +1980,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM alerts;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -1411,6 +2042,25 @@ func (rx *Rx) Rollback() (err error) {
 	return err
 }
 
+func (rx *Rx) All_Alert_By_Resolved(ctx context.Context,
+	alert_resolved Alert_Resolved_Field) (
+	rows []*Alert, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.All_Alert_By_Resolved(ctx, alert_resolved)
+}
+
+func (rx *Rx) All_Member_OrderBy_Asc_CreatedAt(ctx context.Context) (
+	rows []*Member, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.All_Member_OrderBy_Asc_CreatedAt(ctx)
+}
+
 func (rx *Rx) All_Node(ctx context.Context) (
 	rows []*Node, err error) {
 	var tx *Tx
@@ -1420,17 +2070,44 @@ func (rx *Rx) All_Node(ctx context.Context) (
 	return tx.All_Node(ctx)
 }
 
+func (rx *Rx) All_NodeAccess_By_MemberId(ctx context.Context,
+	node_access_member_id NodeAccess_MemberId_Field) (
+	rows []*NodeAccess, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.All_NodeAccess_By_MemberId(ctx, node_access_member_id)
+}
+
+func (rx *Rx) CreateNoReturn_Alert(ctx context.Context,
+	alert_id Alert_Id_Field,
+	alert_node_id Alert_NodeId_Field,
+	alert_kind Alert_Kind_Field,
+	alert_satellite_id Alert_SatelliteId_Field,
+	alert_message Alert_Message_Field,
+	alert_resolved Alert_Resolved_Field) (
+	err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.CreateNoReturn_Alert(ctx, alert_id, alert_node_id, alert_kind, alert_satellite_id, alert_message, alert_resolved)
+
+}
+
 func (rx *Rx) Create_Member(ctx context.Context,
 	member_id Member_Id_Field,
 	member_email Member_Email_Field,
 	member_name Member_Name_Field,
-	member_password_hash Member_PasswordHash_Field) (
+	member_password_hash Member_PasswordHash_Field,
+	member_role Member_Role_Field) (
 	member *Member, err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.Create_Member(ctx, member_id, member_email, member_name, member_password_hash)
+	return tx.Create_Member(ctx, member_id, member_email, member_name, member_password_hash, member_role)
 
 }
 
@@ -1438,13 +2115,14 @@ func (rx *Rx) Create_Node(ctx context.Context,
 	node_id Node_Id_Field,
 	node_name Node_Name_Field,
 	node_public_address Node_PublicAddress_Field,
-	node_api_secret Node_ApiSecret_Field) (
+	node_api_secret Node_ApiSecret_Field,
+	optional Node_Create_Fields) (
 	node *Node, err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.Create_Node(ctx, node_id, node_name, node_public_address, node_api_secret)
+	return tx.Create_Node(ctx, node_id, node_name, node_public_address, node_api_secret, optional)
 
 }
 
@@ -1458,6 +2136,17 @@ func (rx *Rx) Delete_Member_By_Id(ctx context.Context,
 	return tx.Delete_Member_By_Id(ctx, member_id)
 }
 
+func (rx *Rx) Delete_NodeAccess_By_MemberId_And_NodeId(ctx context.Context,
+	node_access_member_id NodeAccess_MemberId_Field,
+	node_access_node_id NodeAccess_NodeId_Field) (
+	deleted bool, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Delete_NodeAccess_By_MemberId_And_NodeId(ctx, node_access_member_id, node_access_node_id)
+}
+
 func (rx *Rx) Delete_Node_By_Id(ctx context.Context,
 	node_id Node_Id_Field) (
 	deleted bool, err error) {
@@ -1498,6 +2187,40 @@ func (rx *Rx) Get_Node_By_Id(ctx context.Context,
 	return tx.Get_Node_By_Id(ctx, node_id)
 }
 
+func (rx *Rx) Limited_Alert_OrderBy_Desc_CreatedAt(ctx context.Context,
+	limit int, offset int64) (
+	rows []*Alert, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Limited_Alert_OrderBy_Desc_CreatedAt(ctx, limit, offset)
+}
+
+func (rx *Rx) ReplaceNoReturn_NodeAccess(ctx context.Context,
+	node_access_member_id NodeAccess_MemberId_Field,
+	node_access_node_id NodeAccess_NodeId_Field,
+	node_access_permission NodeAccess_Permission_Field) (
+	err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.ReplaceNoReturn_NodeAccess(ctx, node_access_member_id, node_access_node_id, node_access_permission)
+
+}
+
+func (rx *Rx) UpdateNoReturn_Alert_By_Id(ctx context.Context,
+	alert_id Alert_Id_Field,
+	update Alert_Update_Fields) (
+	err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.UpdateNoReturn_Alert_By_Id(ctx, alert_id, update)
+}
+
 func (rx *Rx) UpdateNoReturn_Node_By_Id(ctx context.Context,
 	node_id Node_Id_Field,
 	update Node_Update_Fields) (
@@ -1532,27 +2255,54 @@ func (rx *Rx) Update_Node_By_Id(ctx context.Context,
 }
 
 type Methods interface {
+	All_Alert_By_Resolved(ctx context.Context,
+		alert_resolved Alert_Resolved_Field) (
+		rows []*Alert, err error)
+
+	All_Member_OrderBy_Asc_CreatedAt(ctx context.Context) (
+		rows []*Member, err error)
+
 	All_Node(ctx context.Context) (
 		rows []*Node, err error)
 
+	All_NodeAccess_By_MemberId(ctx context.Context,
+		node_access_member_id NodeAccess_MemberId_Field) (
+		rows []*NodeAccess, err error)
+
+	CreateNoReturn_Alert(ctx context.Context,
+		alert_id Alert_Id_Field,
+		alert_node_id Alert_NodeId_Field,
+		alert_kind Alert_Kind_Field,
+		alert_satellite_id Alert_SatelliteId_Field,
+		alert_message Alert_Message_Field,
+		alert_resolved Alert_Resolved_Field) (
+		err error)
+
 	Create_Member(ctx context.Context,
 		member_id Member_Id_Field,
 		member_email Member_Email_Field,
 		member_name Member_Name_Field,
-		member_password_hash Member_PasswordHash_Field) (
+		member_password_hash Member_PasswordHash_Field,
+		member_role Member_Role_Field) (
 		member *Member, err error)
 
 	Create_Node(ctx context.Context,
 		node_id Node_Id_Field,
 		node_name Node_Name_Field,
 		node_public_address Node_PublicAddress_Field,
-		node_api_secret Node_ApiSecret_Field) (
+		node_api_secret Node_ApiSecret_Field,
+		optional Node_Create_Fields) (
 		node *Node, err error)
 
 	Delete_Member_By_Id(ctx context.Context,
 		member_id Member_Id_Field) (
 		deleted bool, err error)
 
+	Delete_NodeAccess_By_MemberId_And_NodeId(ctx context.Context,
+		node_access_member_id NodeAccess_MemberId_Field,
+		node_access_node_id NodeAccess_NodeId_Field) (
+		deleted bool, err error)
+
 	Delete_Node_By_Id(ctx context.Context,
 		node_id Node_Id_Field) (
 		deleted bool, err error)
@@ -1569,6 +2319,21 @@ type Methods interface {
 		node_id Node_Id_Field) (
 		node *Node, err error)
 
+	Limited_Alert_OrderBy_Desc_CreatedAt(ctx context.Context,
+		limit int, offset int64) (
+		rows []*Alert, err error)
+
+	ReplaceNoReturn_NodeAccess(ctx context.Context,
+		node_access_member_id NodeAccess_MemberId_Field,
+		node_access_node_id NodeAccess_NodeId_Field,
+		node_access_permission NodeAccess_Permission_Field) (
+		err error)
+
+	UpdateNoReturn_Alert_By_Id(ctx context.Context,
+		alert_id Alert_Id_Field,
+		update Alert_Update_Fields) (
+		err error)
+
 	UpdateNoReturn_Node_By_Id(ctx context.Context,
 		node_id Node_Id_Field,
 		update Node_Update_Fields) (
@@ -1,10 +1,22 @@
 -- AUTOGENERATED BY storj.io/dbx
 -- DO NOT EDIT
+CREATE TABLE alerts (
+	id bytea NOT NULL,
+	node_id bytea NOT NULL,
+	kind text NOT NULL,
+	satellite_id bytea NOT NULL,
+	message text NOT NULL,
+	resolved boolean NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE members (
 	id bytea NOT NULL,
 	email text NOT NULL,
 	name text NOT NULL,
 	password_hash bytea NOT NULL,
+	role integer NOT NULL,
 	created_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id )
 );
@@ -13,5 +25,14 @@ CREATE TABLE nodes (
 	name text NOT NULL,
 	public_address text NOT NULL,
 	api_secret bytea NOT NULL,
+	revoked_api_secret bytea,
 	PRIMARY KEY ( id )
 );
+CREATE TABLE node_accesses (
+	member_id bytea NOT NULL REFERENCES members( id ) ON DELETE CASCADE,
+	node_id bytea NOT NULL REFERENCES nodes( id ) ON DELETE CASCADE,
+	permission integer NOT NULL,
+	PRIMARY KEY ( member_id, node_id )
+);
+CREATE INDEX alerts_resolved_index ON alerts ( resolved );
+CREATE INDEX alerts_created_at_index ON alerts ( created_at );
@@ -7,12 +7,16 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/zeebo/errs"
 
+	"storj.io/common/storj"
 	"storj.io/common/uuid"
 	"storj.io/storj/multinode/console"
 	"storj.io/storj/multinode/multinodedb/dbx"
+	"storj.io/storj/private/dbutil/txutil"
+	"storj.io/storj/private/tagsql"
 )
 
 // MembersDBError indicates about internal MembersDB error.
@@ -27,18 +31,56 @@ var _ console.Members = (*members)(nil)
 // architecture: Database
 type members struct {
 	methods dbx.Methods
+	db      tagsql.DB
 }
 
 // Invite will create empty row in membersDB.
 func (m *members) Invite(ctx context.Context, member console.Member) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	id, err := uuid.New()
-	if err != nil {
-		return MembersDBError.Wrap(err)
+	id := member.ID
+	if id.IsZero() {
+		id, err = uuid.New()
+		if err != nil {
+			return MembersDBError.Wrap(err)
+		}
+	}
+
+	_, err = m.methods.Create_Member(ctx, dbx.Member_Id(id[:]), dbx.Member_Email(member.Email), dbx.Member_Name(member.Name), dbx.Member_PasswordHash(member.PasswordHash), dbx.Member_Role(int(member.Role)))
+
+	return MembersDBError.Wrap(err)
+}
+
+// InviteFirst creates the member only when there are no members yet.
+func (m *members) InviteFirst(ctx context.Context, member console.Member) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	id := member.ID
+	if id.IsZero() {
+		id, err = uuid.New()
+		if err != nil {
+			return MembersDBError.Wrap(err)
+		}
 	}
 
-	_, err = m.methods.Create_Member(ctx, dbx.Member_Id(id[:]), dbx.Member_Email(member.Email), dbx.Member_Name(member.Name), dbx.Member_PasswordHash(member.PasswordHash))
+	// with serializable isolation one of two concurrent transactions fails
+	// and is retried, and then finds the member created by the other.
+	err = txutil.WithTx(ctx, m.db, &sql.TxOptions{Isolation: sql.LevelSerializable}, func(ctx context.Context, tx tagsql.Tx) error {
+		var exists bool
+		err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM members)`).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return console.ErrMembersExist.New("")
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO members (id, email, name, password_hash, role, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, id[:], member.Email, member.Name, member.PasswordHash, int(member.Role), time.Now().UTC())
+		return err
+	})
 
 	return MembersDBError.Wrap(err)
 }
@@ -51,6 +93,7 @@ func (m *members) Update(ctx context.Context, member console.Member) (err error)
 		Email:        dbx.Member_Email(member.Email),
 		Name:         dbx.Member_Name(member.Name),
 		PasswordHash: dbx.Member_PasswordHash(member.PasswordHash),
+		Role:         dbx.Member_Role(int(member.Role)),
 	})
 
 	return MembersDBError.Wrap(err)
@@ -99,6 +142,76 @@ func (m *members) GetByID(ctx context.Context, id uuid.UUID) (_ console.Member,
 	return member, MembersDBError.Wrap(err)
 }
 
+// List returns all members in the order they were added.
+func (m *members) List(ctx context.Context) (_ []console.Member, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	membersDbx, err := m.methods.All_Member_OrderBy_Asc_CreatedAt(ctx)
+	if err != nil {
+		return nil, MembersDBError.Wrap(err)
+	}
+
+	list := make([]console.Member, 0, len(membersDbx))
+	for _, memberDbx := range membersDbx {
+		member, err := fromDBXMember(memberDbx)
+		if err != nil {
+			return nil, MembersDBError.Wrap(err)
+		}
+		list = append(list, member)
+	}
+
+	return list, nil
+}
+
+// SetAccess grants member the permission on the node, replacing the previous one.
+func (m *members) SetAccess(ctx context.Context, memberID uuid.UUID, nodeID storj.NodeID, permission console.Permission) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = m.methods.ReplaceNoReturn_NodeAccess(ctx,
+		dbx.NodeAccess_MemberId(memberID[:]),
+		dbx.NodeAccess_NodeId(nodeID.Bytes()),
+		dbx.NodeAccess_Permission(int(permission)),
+	)
+
+	return MembersDBError.Wrap(err)
+}
+
+// RevokeAccess removes the permission of member on the node.
+func (m *members) RevokeAccess(ctx context.Context, memberID uuid.UUID, nodeID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = m.methods.Delete_NodeAccess_By_MemberId_And_NodeId(ctx,
+		dbx.NodeAccess_MemberId(memberID[:]),
+		dbx.NodeAccess_NodeId(nodeID.Bytes()),
+	)
+
+	return MembersDBError.Wrap(err)
+}
+
+// Access returns the permissions of member on nodes.
+func (m *members) Access(ctx context.Context, memberID uuid.UUID) (_ []console.NodeAccess, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	accessDbx, err := m.methods.All_NodeAccess_By_MemberId(ctx, dbx.NodeAccess_MemberId(memberID[:]))
+	if err != nil {
+		return nil, MembersDBError.Wrap(err)
+	}
+
+	access := make([]console.NodeAccess, 0, len(accessDbx))
+	for _, row := range accessDbx {
+		nodeID, err := storj.NodeIDFromBytes(row.NodeId)
+		if err != nil {
+			return nil, MembersDBError.Wrap(err)
+		}
+		access = append(access, console.NodeAccess{
+			NodeID:     nodeID,
+			Permission: console.Permission(row.Permission),
+		})
+	}
+
+	return access, nil
+}
+
 // fromDBXMember converts dbx.Member to console.Member.
 func fromDBXMember(member *dbx.Member) (_ console.Member, err error) {
 	id, err := uuid.FromBytes(member.Id)
@@ -111,6 +224,8 @@ func fromDBXMember(member *dbx.Member) (_ console.Member, err error) {
 		Email:        member.Email,
 		Name:         member.Name,
 		PasswordHash: member.PasswordHash,
+		Role:         console.Role(member.Role),
+		CreatedAt:    member.CreatedAt,
 	}
 
 	return result, nil
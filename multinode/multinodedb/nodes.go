@@ -80,6 +80,7 @@ func (n *nodesdb) Add(ctx context.Context, id storj.NodeID, apiSecret []byte, pu
 		dbx.Node_Name(""),
 		dbx.Node_PublicAddress(publicAddress),
 		dbx.Node_ApiSecret(apiSecret),
+		dbx.Node_Create_Fields{},
 	)
 
 	return ErrNodesDB.Wrap(err)
@@ -105,6 +106,25 @@ func (n *nodesdb) UpdateName(ctx context.Context, id storj.NodeID, name string)
 	return ErrNodesDB.Wrap(err)
 }
 
+// UpdateAPISecret will update api secret of the specified node in database,
+// together with the replaced api secret which is not yet revoked on the node.
+func (n *nodesdb) UpdateAPISecret(ctx context.Context, id storj.NodeID, apiSecret, revokedAPISecret []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	node, err := n.methods.Update_Node_By_Id(ctx, dbx.Node_Id(id.Bytes()), dbx.Node_Update_Fields{
+		ApiSecret:        dbx.Node_ApiSecret(apiSecret),
+		RevokedApiSecret: dbx.Node_RevokedApiSecret_Raw(revokedAPISecret),
+	})
+	if err != nil {
+		return ErrNodesDB.Wrap(err)
+	}
+	if node == nil {
+		return nodes.ErrNoNode.New("%s", id)
+	}
+
+	return nil
+}
+
 // fromDBXNode converts dbx.Node to console.Node.
 func fromDBXNode(ctx context.Context, node *dbx.Node) (_ nodes.Node, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -115,10 +135,11 @@ func fromDBXNode(ctx context.Context, node *dbx.Node) (_ nodes.Node, err error)
 	}
 
 	result := nodes.Node{
-		ID:            id,
-		APISecret:     node.ApiSecret,
-		Name:          node.Name,
-		PublicAddress: node.PublicAddress,
+		ID:               id,
+		APISecret:        node.ApiSecret,
+		RevokedAPISecret: node.RevokedApiSecret,
+		Name:             node.Name,
+		PublicAddress:    node.PublicAddress,
 	}
 
 	return result, nil
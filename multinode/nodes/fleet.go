@@ -0,0 +1,457 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nodes
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/rpc"
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+	"storj.io/storj/multinodepb"
+)
+
+// NodeError describes a node which could not be reached or failed to respond.
+type NodeError struct {
+	NodeID storj.NodeID `json:"nodeId"`
+	Name   string       `json:"name"`
+	Error  string       `json:"error"`
+}
+
+// DiskSpace is the disk space of a node or the combined disk space of several nodes.
+type DiskSpace struct {
+	Allocated int64 `json:"allocated"`
+	Used      int64 `json:"used"`
+	Trash     int64 `json:"trash"`
+	Free      int64 `json:"free"`
+	Overused  int64 `json:"overused"`
+}
+
+// add adds the disk space of another node.
+func (space *DiskSpace) add(other DiskSpace) {
+	space.Allocated += other.Allocated
+	space.Used += other.Used
+	space.Trash += other.Trash
+	space.Free += other.Free
+	space.Overused += other.Overused
+}
+
+// NodeDiskSpace is the disk space of a single node.
+type NodeDiskSpace struct {
+	NodeID storj.NodeID `json:"nodeId"`
+	Name   string       `json:"name"`
+	DiskSpace
+}
+
+// FleetDiskSpace is the disk space of all nodes which responded.
+type FleetDiskSpace struct {
+	Total  DiskSpace       `json:"total"`
+	Nodes  []NodeDiskSpace `json:"nodes"`
+	Errors []NodeError     `json:"errors"`
+}
+
+// Score summarizes a score across nodes.
+type Score struct {
+	Min     float64 `json:"min"`
+	Average float64 `json:"average"`
+}
+
+// SatelliteReputation summarizes the reputation of all nodes on a satellite.
+type SatelliteReputation struct {
+	SatelliteID      storj.NodeID `json:"satelliteId"`
+	Nodes            int          `json:"nodes"`
+	Disqualified     int          `json:"disqualified"`
+	Suspended        int          `json:"suspended"`
+	OfflineSuspended int          `json:"offlineSuspended"`
+	AuditScore       Score        `json:"auditScore"`
+	SuspensionScore  Score        `json:"suspensionScore"`
+	OnlineScore      Score        `json:"onlineScore"`
+}
+
+// ReputationSummary summarizes the reputation of all nodes which responded per satellite.
+type ReputationSummary struct {
+	Satellites []SatelliteReputation `json:"satellites"`
+	Errors     []NodeError           `json:"errors"`
+}
+
+// NodeStatus describes whether a node is online.
+type NodeStatus struct {
+	NodeID        storj.NodeID `json:"nodeId"`
+	Name          string       `json:"name"`
+	PublicAddress string       `json:"publicAddress"`
+	Online        bool         `json:"online"`
+	Version       string       `json:"version"`
+	StartedAt     *time.Time   `json:"startedAt"`
+	LastContact   *time.Time   `json:"lastContact"`
+	Error         string       `json:"error,omitempty"`
+}
+
+// DailyStorageUsage is the storage usage of all nodes on a day.
+type DailyStorageUsage struct {
+	Date        time.Time `json:"date"`
+	AtRestTotal float64   `json:"atRestTotal"`
+}
+
+// StorageUsage is the daily storage usage combined across all nodes which responded.
+type StorageUsage struct {
+	Daily  []DailyStorageUsage `json:"daily"`
+	Errors []NodeError         `json:"errors"`
+}
+
+// DiskSpace returns the disk space of each node and the total of all nodes.
+func (service *Service) DiskSpace(ctx context.Context) (_ FleetDiskSpace, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodes, err := service.listNodes(ctx)
+	if err != nil {
+		return FleetDiskSpace{}, Error.Wrap(err)
+	}
+
+	spaces := make([]DiskSpace, len(nodes))
+	failures := service.forEachNode(ctx, nodes, func(ctx context.Context, conn *rpc.Conn, i int) error {
+		response, err := multinodepb.NewDRPCNodeDiskSpaceClient(conn).GetDiskSpace(ctx, &multinodepb.GetDiskSpaceRequest{})
+		if err != nil {
+			return err
+		}
+		spaces[i] = diskSpaceFromPB(response.GetDiskSpace())
+		return nil
+	})
+
+	fleet := FleetDiskSpace{
+		Nodes:  []NodeDiskSpace{},
+		Errors: nodeErrors(nodes, failures),
+	}
+	for i, node := range nodes {
+		if failures[i] != nil {
+			continue
+		}
+		fleet.Total.add(spaces[i])
+		fleet.Nodes = append(fleet.Nodes, NodeDiskSpace{
+			NodeID:    node.ID,
+			Name:      node.Name,
+			DiskSpace: spaces[i],
+		})
+	}
+
+	return fleet, nil
+}
+
+// diskSpaceFromPB converts disk space reported by a node.
+func diskSpaceFromPB(space *multinodepb.DiskSpace) DiskSpace {
+	if space == nil {
+		return DiskSpace{}
+	}
+
+	diskSpace := DiskSpace{
+		Allocated: space.Available,
+		Used:      space.Used,
+		Trash:     space.Trash,
+		Overused:  space.Overused,
+	}
+	if free := space.Available - space.Used - space.Trash; free > 0 {
+		diskSpace.Free = free
+	}
+	return diskSpace
+}
+
+// Reputation returns the reputation of all nodes summarized per satellite.
+func (service *Service) Reputation(ctx context.Context) (_ ReputationSummary, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodes, err := service.listNodes(ctx)
+	if err != nil {
+		return ReputationSummary{}, Error.Wrap(err)
+	}
+
+	reputations := make([][]*multinodepb.GetBySatelliteIDResponse, len(nodes))
+	failures := service.forEachNode(ctx, nodes, func(ctx context.Context, conn *rpc.Conn, i int) error {
+		response, err := multinodepb.NewDRPCReputationClient(conn).All(ctx, &multinodepb.AllRequest{})
+		if err != nil {
+			return err
+		}
+		reputations[i] = response.GetReputation()
+		return nil
+	})
+
+	var responded [][]*multinodepb.GetBySatelliteIDResponse
+	for i := range nodes {
+		if failures[i] == nil {
+			responded = append(responded, reputations[i])
+		}
+	}
+
+	return ReputationSummary{
+		Satellites: summarizeReputation(responded),
+		Errors:     nodeErrors(nodes, failures),
+	}, nil
+}
+
+// summarizeReputation combines the reputation reported by nodes per satellite.
+func summarizeReputation(reputations [][]*multinodepb.GetBySatelliteIDResponse) []SatelliteReputation {
+	type scores struct{ audit, suspension, online []float64 }
+
+	bySatellite := map[storj.NodeID]*SatelliteReputation{}
+	scoresBySatellite := map[storj.NodeID]*scores{}
+	for _, node := range reputations {
+		for _, reputation := range node {
+			summary, ok := bySatellite[reputation.SatelliteId]
+			if !ok {
+				summary = &SatelliteReputation{SatelliteID: reputation.SatelliteId}
+				bySatellite[reputation.SatelliteId] = summary
+				scoresBySatellite[reputation.SatelliteId] = &scores{}
+			}
+
+			summary.Nodes++
+			if reputation.Disqualified != nil {
+				summary.Disqualified++
+			}
+			if reputation.Suspended != nil {
+				summary.Suspended++
+			}
+			if reputation.OfflineSuspended != nil {
+				summary.OfflineSuspended++
+			}
+
+			satelliteScores := scoresBySatellite[reputation.SatelliteId]
+			satelliteScores.audit = append(satelliteScores.audit, reputation.GetAuditCheck().GetReputationScore())
+			satelliteScores.suspension = append(satelliteScores.suspension, reputation.GetAuditCheck().GetUnknownReputationScore())
+			satelliteScores.online = append(satelliteScores.online, reputation.OnlineScore)
+		}
+	}
+
+	summaries := make([]SatelliteReputation, 0, len(bySatellite))
+	for satelliteID, summary := range bySatellite {
+		satelliteScores := scoresBySatellite[satelliteID]
+		summary.AuditScore = summarizeScores(satelliteScores.audit)
+		summary.SuspensionScore = summarizeScores(satelliteScores.suspension)
+		summary.OnlineScore = summarizeScores(satelliteScores.online)
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, k int) bool {
+		return summaries[i].SatelliteID.Less(summaries[k].SatelliteID)
+	})
+
+	return summaries
+}
+
+// summarizeScores returns the minimum and average of scores.
+func summarizeScores(values []float64) Score {
+	if len(values) == 0 {
+		return Score{}
+	}
+
+	score := Score{Min: values[0]}
+	var sum float64
+	for _, value := range values {
+		if value < score.Min {
+			score.Min = value
+		}
+		sum += value
+	}
+	score.Average = sum / float64(len(values))
+	return score
+}
+
+// Statuses returns whether each node is online, its version and when it was last contacted.
+func (service *Service) Statuses(ctx context.Context) (_ []NodeStatus, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodes, err := service.listNodes(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	responses := make([]*multinodepb.GetResponse, len(nodes))
+	failures := service.forEachNode(ctx, nodes, func(ctx context.Context, conn *rpc.Conn, i int) error {
+		response, err := multinodepb.NewDRPCStatusClient(conn).Get(ctx, &multinodepb.GetRequest{})
+		if err != nil {
+			return err
+		}
+		responses[i] = response
+		return nil
+	})
+
+	statuses := make([]NodeStatus, 0, len(nodes))
+	for i, node := range nodes {
+		status := NodeStatus{
+			NodeID:        node.ID,
+			Name:          node.Name,
+			PublicAddress: node.PublicAddress,
+			Online:        failures[i] == nil,
+			LastContact:   service.LastContact(node.ID),
+		}
+		if failures[i] != nil {
+			status.Error = failures[i].Error()
+		} else {
+			startedAt := responses[i].StartedAt
+			status.StartedAt = &startedAt
+			status.Version = responses[i].Version
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// DailyStorageUsage returns the daily storage usage of a satellite, or of all
+// satellites when satelliteID is zero, combined across all nodes.
+func (service *Service) DailyStorageUsage(ctx context.Context, satelliteID storj.NodeID, from, to time.Time) (_ StorageUsage, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodes, err := service.listNodes(ctx)
+	if err != nil {
+		return StorageUsage{}, Error.Wrap(err)
+	}
+
+	usages := make([][]*multinodepb.DailyStorageUsageResponse_StorageUsage, len(nodes))
+	failures := service.forEachNode(ctx, nodes, func(ctx context.Context, conn *rpc.Conn, i int) error {
+		response, err := multinodepb.NewDRPCNodeDiskSpaceClient(conn).DailyStorageUsage(ctx, &multinodepb.DailyStorageUsageRequest{
+			From:        from,
+			To:          to,
+			SatelliteId: satelliteID,
+		})
+		if err != nil {
+			return err
+		}
+		usages[i] = response.GetDailyStorageUsage()
+		return nil
+	})
+
+	var responded [][]*multinodepb.DailyStorageUsageResponse_StorageUsage
+	for i := range nodes {
+		if failures[i] == nil {
+			responded = append(responded, usages[i])
+		}
+	}
+
+	return StorageUsage{
+		Daily:  combineDailyStorageUsage(responded),
+		Errors: nodeErrors(nodes, failures),
+	}, nil
+}
+
+// combineDailyStorageUsage sums the storage usage reported by nodes per day.
+func combineDailyStorageUsage(usages [][]*multinodepb.DailyStorageUsageResponse_StorageUsage) []DailyStorageUsage {
+	byDay := map[time.Time]float64{}
+	for _, node := range usages {
+		for _, usage := range node {
+			day := usage.Timestamp.UTC().Truncate(24 * time.Hour)
+			byDay[day] += usage.AtRestTotal
+		}
+	}
+
+	daily := make([]DailyStorageUsage, 0, len(byDay))
+	for day, atRestTotal := range byDay {
+		daily = append(daily, DailyStorageUsage{Date: day, AtRestTotal: atRestTotal})
+	}
+	sort.Slice(daily, func(i, k int) bool {
+		return daily[i].Date.Before(daily[k].Date)
+	})
+
+	return daily
+}
+
+// LastContact returns when the node last responded, nil when it has not
+// responded since the dashboard was started.
+func (service *Service) LastContact(id storj.NodeID) *time.Time {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	lastContact, ok := service.lastContact[id]
+	if !ok {
+		return nil
+	}
+	return &lastContact
+}
+
+// listNodes returns the added nodes visible to the context, an empty list when
+// there are none.
+func (service *Service) listNodes(ctx context.Context) (_ []Node, err error) {
+	nodes, err := service.nodes.List(ctx)
+	if err != nil {
+		if ErrNoNode.Has(err) {
+			return []Node{}, nil
+		}
+		return nil, err
+	}
+	return filterNodes(ctx, nodes), nil
+}
+
+// forEachNode dials every node in parallel and calls fn with a connection to
+// the node at index i and a context which authenticates requests with the api
+// secret of the node. Every node gets at most the configured timeout, so that
+// unresponsive nodes do not delay the others. The returned errors are aligned
+// with nodes and nil for nodes which responded.
+func (service *Service) forEachNode(ctx context.Context, nodes []Node, fn func(ctx context.Context, conn *rpc.Conn, i int) error) []error {
+	failures := make([]error, len(nodes))
+
+	concurrency := service.config.Concurrency
+	if concurrency <= 0 {
+		concurrency = len(nodes)
+	}
+
+	limiter := sync2.NewLimiter(concurrency)
+	for i := range nodes {
+		i := i
+		if !limiter.Go(ctx, func() {
+			failures[i] = service.contact(ctx, nodes[i], func(ctx context.Context, conn *rpc.Conn) error {
+				return fn(ctx, conn, i)
+			})
+		}) {
+			failures[i] = ctx.Err()
+		}
+	}
+	limiter.Wait()
+
+	return failures
+}
+
+// contact dials the node and calls fn with the connection.
+func (service *Service) contact(ctx context.Context, node Node, fn func(ctx context.Context, conn *rpc.Conn) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if service.config.Timeout > 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, service.config.Timeout)
+		defer cancel()
+	}
+
+	conn, err := service.dialer.DialNodeURL(ctx, storj.NodeURL{
+		ID:      node.ID,
+		Address: node.PublicAddress,
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, conn.Close()) }()
+
+	if err := fn(multinodepb.WithAPIKey(ctx, node.APISecret), conn); err != nil {
+		return Error.Wrap(err)
+	}
+
+	service.mu.Lock()
+	service.lastContact[node.ID] = time.Now()
+	service.mu.Unlock()
+
+	return nil
+}
+
+// nodeErrors returns the nodes which failed along with their errors.
+func nodeErrors(nodes []Node, failures []error) []NodeError {
+	errors := []NodeError{}
+	for i, node := range nodes {
+		if failures[i] != nil {
+			errors = append(errors, NodeError{
+				NodeID: node.ID,
+				Name:   node.Name,
+				Error:  failures[i].Error(),
+			})
+		}
+	}
+	return errors
+}
@@ -0,0 +1,154 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nodes
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/identity/testidentity"
+	"storj.io/common/peertls/tlsopts"
+	"storj.io/common/rpc"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/multinodepb"
+)
+
+func TestSummarizeReputation(t *testing.T) {
+	satellite1, satellite2 := testrand.NodeID(), testrand.NodeID()
+	now := time.Now()
+
+	reputation := func(satelliteID storj.NodeID, audit, online float64) *multinodepb.GetBySatelliteIDResponse {
+		return &multinodepb.GetBySatelliteIDResponse{
+			SatelliteId: satelliteID,
+			AuditCheck:  &multinodepb.ReputationStats{ReputationScore: audit, UnknownReputationScore: 1},
+			OnlineScore: online,
+		}
+	}
+
+	suspended := reputation(satellite1, 0.5, 0.9)
+	suspended.Suspended = &now
+
+	summaries := summarizeReputation([][]*multinodepb.GetBySatelliteIDResponse{
+		{reputation(satellite1, 1, 1), reputation(satellite2, 0.8, 1)},
+		{suspended},
+	})
+	require.Len(t, summaries, 2)
+
+	bySatellite := map[storj.NodeID]SatelliteReputation{}
+	for _, summary := range summaries {
+		bySatellite[summary.SatelliteID] = summary
+	}
+
+	require.Equal(t, 2, bySatellite[satellite1].Nodes)
+	require.Equal(t, 1, bySatellite[satellite1].Suspended)
+	require.Equal(t, Score{Min: 0.5, Average: 0.75}, bySatellite[satellite1].AuditScore)
+	require.Equal(t, Score{Min: 1, Average: 1}, bySatellite[satellite1].SuspensionScore)
+	require.Equal(t, 0.9, bySatellite[satellite1].OnlineScore.Min)
+
+	require.Equal(t, 1, bySatellite[satellite2].Nodes)
+	require.Equal(t, Score{Min: 0.8, Average: 0.8}, bySatellite[satellite2].AuditScore)
+}
+
+func TestCombineDailyStorageUsage(t *testing.T) {
+	day := time.Date(2020, 11, 2, 0, 0, 0, 0, time.UTC)
+
+	daily := combineDailyStorageUsage([][]*multinodepb.DailyStorageUsageResponse_StorageUsage{
+		{
+			{AtRestTotal: 100, Timestamp: day.Add(3 * time.Hour)},
+			{AtRestTotal: 50, Timestamp: day.Add(-21 * time.Hour)},
+		},
+		{
+			{AtRestTotal: 200, Timestamp: day},
+		},
+	})
+
+	require.Equal(t, []DailyStorageUsage{
+		{Date: day.Add(-24 * time.Hour), AtRestTotal: 50},
+		{Date: day, AtRestTotal: 300},
+	}, daily)
+}
+
+func TestDiskSpaceFromPB(t *testing.T) {
+	require.Equal(t, DiskSpace{Allocated: 100, Used: 60, Trash: 10, Free: 30},
+		diskSpaceFromPB(&multinodepb.DiskSpace{Available: 100, Used: 60, Trash: 10}))
+	require.Equal(t, DiskSpace{Allocated: 100, Used: 120, Trash: 10, Overused: 30},
+		diskSpaceFromPB(&multinodepb.DiskSpace{Available: 100, Used: 120, Trash: 10, Overused: 30}))
+	require.Equal(t, DiskSpace{}, diskSpaceFromPB(nil))
+}
+
+func TestForEachNodeTimeout(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	// the listener accepts connections but never completes the handshake.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx.Go(func() error {
+		var conns []net.Conn
+		defer func() {
+			for _, conn := range conns {
+				_ = conn.Close()
+			}
+		}()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return nil
+			}
+			conns = append(conns, conn)
+		}
+	})
+	defer ctx.Check(listener.Close)
+
+	ident, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+	tlsOptions, err := tlsopts.NewOptions(ident, tlsopts.Config{PeerIDVersions: "0"}, nil)
+	require.NoError(t, err)
+
+	service := NewService(zaptest.NewLogger(t), rpc.NewDefaultDialer(tlsOptions), nil, Config{
+		Timeout:     100 * time.Millisecond,
+		Concurrency: 2,
+	})
+
+	nodes := []Node{
+		{ID: testrand.NodeID(), PublicAddress: listener.Addr().String()},
+		{ID: testrand.NodeID(), PublicAddress: listener.Addr().String()},
+		{ID: testrand.NodeID(), PublicAddress: listener.Addr().String()},
+	}
+
+	start := time.Now()
+	failures := service.forEachNode(ctx, nodes, func(ctx context.Context, conn *rpc.Conn, i int) error {
+		return nil
+	})
+	require.Less(t, int64(time.Since(start)), int64(5*time.Second))
+
+	require.Len(t, failures, len(nodes))
+	for _, failure := range failures {
+		require.Error(t, failure)
+	}
+	require.Len(t, nodeErrors(nodes, failures), len(nodes))
+	require.Nil(t, service.LastContact(nodes[0].ID))
+}
+
+func TestFilterNodes(t *testing.T) {
+	visibleNode := Node{ID: testrand.NodeID()}
+	hiddenNode := Node{ID: testrand.NodeID()}
+	all := []Node{visibleNode, hiddenNode}
+
+	ctx := context.Background()
+	require.Equal(t, all, filterNodes(ctx, all))
+	require.True(t, visible(ctx, hiddenNode.ID))
+
+	ctx = WithFilter(ctx, func(id storj.NodeID) bool { return id == visibleNode.ID })
+	require.Equal(t, []Node{visibleNode}, filterNodes(ctx, all))
+	require.True(t, visible(ctx, visibleNode.ID))
+	require.False(t, visible(ctx, hiddenNode.ID))
+}
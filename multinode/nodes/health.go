@@ -0,0 +1,80 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nodes
+
+import (
+	"context"
+
+	"storj.io/common/rpc"
+	"storj.io/common/storj"
+	"storj.io/storj/multinodepb"
+)
+
+// SatelliteHealth is the state of a node on a satellite.
+type SatelliteHealth struct {
+	SatelliteID      storj.NodeID
+	Disqualified     bool
+	Suspended        bool
+	OfflineSuspended bool
+}
+
+// Health is the state of a node which needs the attention of its operator when it is not healthy.
+type Health struct {
+	Node       Node
+	Online     bool
+	Error      error
+	Version    string
+	DiskSpace  DiskSpace
+	Satellites []SatelliteHealth
+}
+
+// Health returns the status, disk space and reputation of all nodes.
+// Nodes which could not be contacted are reported as offline.
+func (service *Service) Health(ctx context.Context) (_ []Health, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodes, err := service.listNodes(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	health := make([]Health, len(nodes))
+	failures := service.forEachNode(ctx, nodes, func(ctx context.Context, conn *rpc.Conn, i int) error {
+		status, err := multinodepb.NewDRPCStatusClient(conn).Get(ctx, &multinodepb.GetRequest{})
+		if err != nil {
+			return err
+		}
+		diskSpace, err := multinodepb.NewDRPCNodeDiskSpaceClient(conn).GetDiskSpace(ctx, &multinodepb.GetDiskSpaceRequest{})
+		if err != nil {
+			return err
+		}
+		reputation, err := multinodepb.NewDRPCReputationClient(conn).All(ctx, &multinodepb.AllRequest{})
+		if err != nil {
+			return err
+		}
+
+		health[i].Version = status.Version
+		health[i].DiskSpace = diskSpaceFromPB(diskSpace.GetDiskSpace())
+		for _, satellite := range reputation.GetReputation() {
+			health[i].Satellites = append(health[i].Satellites, SatelliteHealth{
+				SatelliteID:      satellite.SatelliteId,
+				Disqualified:     satellite.Disqualified != nil,
+				Suspended:        satellite.Suspended != nil,
+				OfflineSuspended: satellite.OfflineSuspended != nil,
+			})
+		}
+		return nil
+	})
+
+	for i, node := range nodes {
+		if failures[i] != nil {
+			health[i] = Health{Node: node, Error: failures[i]}
+			continue
+		}
+		health[i].Node = node
+		health[i].Online = true
+	}
+
+	return health, nil
+}
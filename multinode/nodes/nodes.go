@@ -28,6 +28,9 @@ type DB interface {
 	Remove(ctx context.Context, id storj.NodeID) error
 	// UpdateName will update name of the specified node in database.
 	UpdateName(ctx context.Context, id storj.NodeID, name string) error
+	// UpdateAPISecret will update api secret of the specified node in database,
+	// together with the replaced api secret which is not yet revoked on the node.
+	UpdateAPISecret(ctx context.Context, id storj.NodeID, apiSecret, revokedAPISecret []byte) error
 }
 
 // ErrNoNode is a special error type that indicates about absence of node in NodesDB.
@@ -37,9 +40,11 @@ var ErrNoNode = errs.Class("no such node")
 type Node struct {
 	ID storj.NodeID
 	// APISecret is a secret issued by storagenode, that will be main auth mechanism in MND <-> SNO api.
-	APISecret     []byte
-	PublicAddress string
-	Name          string
+	APISecret []byte
+	// RevokedAPISecret is a replaced api secret which is not yet revoked on the node, nil when there is none.
+	RevokedAPISecret []byte
+	PublicAddress    string
+	Name             string
 }
 
 // APISecretFromBase64 decodes API secret from base 64 string.
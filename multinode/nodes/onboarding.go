@@ -0,0 +1,276 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nodes
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/rpc"
+	"storj.io/common/storj"
+	"storj.io/storj/multinodepb"
+)
+
+// Import is a node to add to the system in bulk.
+type Import struct {
+	ID            storj.NodeID
+	PublicAddress string
+	APISecret     []byte
+	Name          string
+}
+
+// ImportResult is the outcome of importing a single node.
+type ImportResult struct {
+	NodeID   storj.NodeID `json:"nodeId"`
+	Name     string       `json:"name"`
+	Imported bool         `json:"imported"`
+	Error    string       `json:"error,omitempty"`
+}
+
+// importRecord is the JSON and CSV representation of an imported node,
+// matching the payload of adding a single node.
+type importRecord struct {
+	ID            string `json:"id"`
+	PublicAddress string `json:"publicAddress"`
+	APISecret     string `json:"apiSecret"`
+	Name          string `json:"name"`
+}
+
+// toImport validates the record.
+func (record importRecord) toImport() (Import, error) {
+	id, err := storj.NodeIDFromString(strings.TrimSpace(record.ID))
+	if err != nil {
+		return Import{}, Error.New("invalid node id %q: %v", record.ID, err)
+	}
+	apiSecret, err := APISecretFromBase64(strings.TrimSpace(record.APISecret))
+	if err != nil || len(apiSecret) == 0 {
+		return Import{}, Error.New("invalid api secret of node %s", id)
+	}
+	address := strings.TrimSpace(record.PublicAddress)
+	if address == "" {
+		return Import{}, Error.New("public address of node %s is missing", id)
+	}
+
+	return Import{
+		ID:            id,
+		PublicAddress: address,
+		APISecret:     apiSecret,
+		Name:          strings.TrimSpace(record.Name),
+	}, nil
+}
+
+// ParseImportJSON reads a JSON array of nodes with the fields id,
+// publicAddress, apiSecret and name.
+func ParseImportJSON(r io.Reader) (_ []Import, err error) {
+	var records []importRecord
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	imports := make([]Import, 0, len(records))
+	for _, record := range records {
+		node, err := record.toImport()
+		if err != nil {
+			return nil, err
+		}
+		imports = append(imports, node)
+	}
+	return imports, nil
+}
+
+// ParseImportCSV reads nodes from CSV with a header naming the columns id,
+// publicAddress, apiSecret and optionally name, in any order.
+func ParseImportCSV(r io.Reader) (_ []Import, err error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, Error.New("missing csv header: %v", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"id", "publicaddress", "apisecret"} {
+		if _, ok := columns[required]; !ok {
+			return nil, Error.New("csv header is missing column %q", required)
+		}
+	}
+	column := func(row []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return row[i]
+	}
+
+	var imports []Import
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		node, err := importRecord{
+			ID:            column(row, "id"),
+			PublicAddress: column(row, "publicaddress"),
+			APISecret:     column(row, "apisecret"),
+			Name:          column(row, "name"),
+		}.toImport()
+		if err != nil {
+			return nil, err
+		}
+		imports = append(imports, node)
+	}
+	return imports, nil
+}
+
+// Import adds nodes in bulk. Every node is contacted first to verify that it
+// answers at the address with the expected node id and accepts the api
+// secret, nodes which fail the check or are already added are skipped.
+func (service *Service) Import(ctx context.Context, imports []Import) (_ []ImportResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	results := make([]ImportResult, len(imports))
+	candidates := make([]Node, 0, len(imports))
+	candidateResults := make([]int, 0, len(imports))
+
+	seen := map[storj.NodeID]bool{}
+	for i, node := range imports {
+		results[i] = ImportResult{NodeID: node.ID, Name: node.Name}
+
+		if seen[node.ID] {
+			results[i].Error = "node is listed more than once"
+			continue
+		}
+		seen[node.ID] = true
+
+		_, err := service.nodes.Get(ctx, node.ID)
+		switch {
+		case err == nil:
+			results[i].Error = "node is already added"
+			continue
+		case !ErrNoNode.Has(err):
+			return nil, Error.Wrap(err)
+		}
+
+		candidates = append(candidates, Node{
+			ID:            node.ID,
+			APISecret:     node.APISecret,
+			PublicAddress: node.PublicAddress,
+			Name:          node.Name,
+		})
+		candidateResults = append(candidateResults, i)
+	}
+
+	failures := service.forEachNode(ctx, candidates, func(ctx context.Context, conn *rpc.Conn, i int) error {
+		_, err := multinodepb.NewDRPCStatusClient(conn).Get(ctx, &multinodepb.GetRequest{})
+		return err
+	})
+
+	for k, node := range candidates {
+		result := &results[candidateResults[k]]
+		if failures[k] != nil {
+			result.Error = failures[k].Error()
+			continue
+		}
+
+		if err := service.nodes.Add(ctx, node.ID, node.APISecret, node.PublicAddress); err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		if node.Name != "" {
+			if err := service.nodes.UpdateName(ctx, node.ID, node.Name); err != nil {
+				result.Error = err.Error()
+				continue
+			}
+		}
+		result.Imported = true
+	}
+
+	return results, nil
+}
+
+// RotateAPISecret replaces the api secret of the node with a new one in two
+// phases: the node issues a new secret while the previous one stays valid, and
+// only after the new secret is stored is the previous one revoked with it.
+//
+// The previous secret is stored until it's revoked, so a failed revocation is
+// retried before the secret is rotated again.
+func (service *Service) RotateAPISecret(ctx context.Context, id storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	node, err := service.nodes.Get(ctx, id)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if node.RevokedAPISecret != nil {
+		if err := service.revokeReplacedAPISecret(ctx, node); err != nil {
+			return Error.New("unable to revoke the api secret replaced by the previous rotation: %w", err)
+		}
+		node.RevokedAPISecret = nil
+	}
+
+	var apiSecret []byte
+	err = service.contact(ctx, node, func(ctx context.Context, conn *rpc.Conn) error {
+		response, err := multinodepb.NewDRPCApiKeysClient(conn).Rotate(ctx, &multinodepb.RotateRequest{})
+		if err != nil {
+			return err
+		}
+		apiSecret = response.ApiKey
+		return nil
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if err := service.nodes.UpdateAPISecret(ctx, id, apiSecret, node.APISecret); err != nil {
+		// the previous secret is still valid, so give up the new one.
+		revokeErr := service.revokeAPISecret(ctx, node, apiSecret)
+		return Error.Wrap(errs.Combine(err, revokeErr))
+	}
+
+	node.APISecret, node.RevokedAPISecret = apiSecret, node.APISecret
+	if err := service.revokeReplacedAPISecret(ctx, node); err != nil {
+		// the new secret is stored and works, the previous one stays valid
+		// until revoking it is retried by the next rotation.
+		service.log.Warn("failed to revoke the previous api secret, it is revoked when the secret is rotated again",
+			zap.Stringer("Node ID", id), zap.Error(err))
+		return Error.New("new api secret is in use, but revoking the previous one failed, rotate again to retry: %w", err)
+	}
+
+	return nil
+}
+
+// revokeReplacedAPISecret revokes the replaced api secret of node and forgets it once it's revoked.
+func (service *Service) revokeReplacedAPISecret(ctx context.Context, node Node) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := service.revokeAPISecret(ctx, node, node.RevokedAPISecret); err != nil {
+		return err
+	}
+	return service.nodes.UpdateAPISecret(ctx, node.ID, node.APISecret, nil)
+}
+
+// revokeAPISecret asks the node to revoke apiSecret, authenticated with the api secret of node.
+func (service *Service) revokeAPISecret(ctx context.Context, node Node, apiSecret []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return service.contact(ctx, node, func(ctx context.Context, conn *rpc.Conn) error {
+		_, err := multinodepb.NewDRPCApiKeysClient(conn).Revoke(ctx, &multinodepb.RevokeRequest{ApiKey: apiSecret})
+		return err
+	})
+}
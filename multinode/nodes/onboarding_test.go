@@ -0,0 +1,144 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nodes
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/identity/testidentity"
+	"storj.io/common/peertls/tlsopts"
+	"storj.io/common/rpc"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+)
+
+func TestParseImport(t *testing.T) {
+	id1, id2 := testrand.NodeID(), testrand.NodeID()
+	secret := base64.URLEncoding.EncodeToString([]byte("secret"))
+
+	csvImports, err := ParseImportCSV(strings.NewReader(
+		"name,id,publicAddress,apiSecret\n" +
+			"first," + id1.String() + ",127.0.0.1:28967," + secret + "\n" +
+			"," + id2.String() + ", 127.0.0.2:28967 ," + secret + "\n",
+	))
+	require.NoError(t, err)
+	require.Equal(t, []Import{
+		{ID: id1, PublicAddress: "127.0.0.1:28967", APISecret: []byte("secret"), Name: "first"},
+		{ID: id2, PublicAddress: "127.0.0.2:28967", APISecret: []byte("secret")},
+	}, csvImports)
+
+	jsonImports, err := ParseImportJSON(strings.NewReader(
+		`[{"id":"` + id1.String() + `","publicAddress":"127.0.0.1:28967","apiSecret":"` + secret + `","name":"first"},
+		  {"id":"` + id2.String() + `","publicAddress":"127.0.0.2:28967","apiSecret":"` + secret + `"}]`,
+	))
+	require.NoError(t, err)
+	require.Equal(t, csvImports, jsonImports)
+
+	_, err = ParseImportCSV(strings.NewReader("id,apiSecret\n" + id1.String() + "," + secret + "\n"))
+	require.Error(t, err)
+
+	_, err = ParseImportCSV(strings.NewReader("id,publicAddress,apiSecret\n" + id1.String() + ",127.0.0.1:28967,\n"))
+	require.Error(t, err)
+
+	_, err = ParseImportJSON(strings.NewReader(`[{"id":"invalid","publicAddress":"127.0.0.1:28967","apiSecret":"` + secret + `"}]`))
+	require.Error(t, err)
+}
+
+func TestImport(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	// reserve an address where nobody listens.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	unreachable := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	ident, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+	tlsOptions, err := tlsopts.NewOptions(ident, tlsopts.Config{PeerIDVersions: "0"}, nil)
+	require.NoError(t, err)
+
+	existing := Node{ID: testrand.NodeID(), APISecret: []byte("secret"), PublicAddress: unreachable}
+	db := &memoryDB{nodes: map[storj.NodeID]Node{existing.ID: existing}}
+
+	service := NewService(zaptest.NewLogger(t), rpc.NewDefaultDialer(tlsOptions), db, Config{
+		Timeout:     time.Second,
+		Concurrency: 2,
+	})
+
+	offline := Import{ID: testrand.NodeID(), PublicAddress: unreachable, APISecret: []byte("secret"), Name: "offline"}
+	results, err := service.Import(ctx, []Import{
+		offline,
+		offline,
+		{ID: existing.ID, PublicAddress: unreachable, APISecret: []byte("secret")},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	require.False(t, results[0].Imported)
+	require.NotEmpty(t, results[0].Error)
+	require.Equal(t, "node is listed more than once", results[1].Error)
+	require.Equal(t, "node is already added", results[2].Error)
+
+	require.Len(t, db.nodes, 1)
+}
+
+// memoryDB is an in-memory nodes database.
+type memoryDB struct {
+	nodes map[storj.NodeID]Node
+}
+
+func (db *memoryDB) Get(ctx context.Context, id storj.NodeID) (Node, error) {
+	node, ok := db.nodes[id]
+	if !ok {
+		return Node{}, ErrNoNode.New("%s", id)
+	}
+	return node, nil
+}
+
+func (db *memoryDB) List(ctx context.Context) ([]Node, error) {
+	if len(db.nodes) == 0 {
+		return nil, ErrNoNode.New("no nodes")
+	}
+	var nodes []Node
+	for _, node := range db.nodes {
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+func (db *memoryDB) Add(ctx context.Context, id storj.NodeID, apiSecret []byte, publicAddress string) error {
+	db.nodes[id] = Node{ID: id, APISecret: apiSecret, PublicAddress: publicAddress}
+	return nil
+}
+
+func (db *memoryDB) Remove(ctx context.Context, id storj.NodeID) error {
+	delete(db.nodes, id)
+	return nil
+}
+
+func (db *memoryDB) UpdateName(ctx context.Context, id storj.NodeID, name string) error {
+	node := db.nodes[id]
+	node.Name = name
+	db.nodes[id] = node
+	return nil
+}
+
+func (db *memoryDB) UpdateAPISecret(ctx context.Context, id storj.NodeID, apiSecret, revokedAPISecret []byte) error {
+	node := db.nodes[id]
+	node.APISecret = apiSecret
+	node.RevokedAPISecret = revokedAPISecret
+	db.nodes[id] = node
+	return nil
+}
@@ -0,0 +1,284 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nodes
+
+import (
+	"context"
+	"sort"
+
+	"storj.io/common/rpc"
+	"storj.io/common/storj"
+	"storj.io/storj/multinodepb"
+)
+
+// Earnings are the amounts from paystubs, in the same units as the paystubs of the storage node.
+type Earnings struct {
+	Earned   int64 `json:"earned"`
+	Surge    int64 `json:"surge"`
+	Held     int64 `json:"held"`
+	Disposed int64 `json:"disposed"`
+	Owed     int64 `json:"owed"`
+	Paid     int64 `json:"paid"`
+}
+
+// add adds the earnings of a paystub.
+func (earnings *Earnings) add(other Earnings) {
+	earnings.Earned += other.Earned
+	earnings.Surge += other.Surge
+	earnings.Held += other.Held
+	earnings.Disposed += other.Disposed
+	earnings.Owed += other.Owed
+	earnings.Paid += other.Paid
+}
+
+// Estimated is the estimated payout of the current month.
+type Estimated struct {
+	Payout float64 `json:"payout"`
+	Held   float64 `json:"held"`
+}
+
+// add adds another estimation.
+func (estimated *Estimated) add(other Estimated) {
+	estimated.Payout += other.Payout
+	estimated.Held += other.Held
+}
+
+// HeldTotal is the amount held and disposed since the node joined.
+type HeldTotal struct {
+	Held     int64 `json:"held"`
+	Disposed int64 `json:"disposed"`
+}
+
+// add adds another held total.
+func (held *HeldTotal) add(other HeldTotal) {
+	held.Held += other.Held
+	held.Disposed += other.Disposed
+}
+
+// Paystub are the earnings of a node from a satellite for a period.
+type Paystub struct {
+	NodeID      storj.NodeID `json:"nodeId"`
+	Name        string       `json:"name"`
+	Wallet      string       `json:"wallet"`
+	SatelliteID storj.NodeID `json:"satelliteId"`
+	Period      string       `json:"period"`
+	Earnings
+}
+
+// NodePayout are the earnings of a node.
+type NodePayout struct {
+	NodeID    storj.NodeID `json:"nodeId"`
+	Name      string       `json:"name"`
+	Wallet    string       `json:"wallet"`
+	Earnings  Earnings     `json:"earnings"`
+	HeldTotal HeldTotal    `json:"heldTotal"`
+	Estimated Estimated    `json:"estimated"`
+}
+
+// SatellitePayout are the earnings of all nodes from a satellite.
+type SatellitePayout struct {
+	SatelliteID storj.NodeID `json:"satelliteId"`
+	Earnings    Earnings     `json:"earnings"`
+	HeldTotal   HeldTotal    `json:"heldTotal"`
+	Estimated   Estimated    `json:"estimated"`
+}
+
+// WalletPayout are the earnings of all nodes paid to a wallet.
+type WalletPayout struct {
+	Wallet    string    `json:"wallet"`
+	Nodes     int       `json:"nodes"`
+	Earnings  Earnings  `json:"earnings"`
+	HeldTotal HeldTotal `json:"heldTotal"`
+	Estimated Estimated `json:"estimated"`
+}
+
+// Payouts are the earnings of all nodes which responded for a range of periods.
+// Held totals and estimations are not limited to the range of periods.
+type Payouts struct {
+	PeriodStart string            `json:"periodStart"`
+	PeriodEnd   string            `json:"periodEnd"`
+	Total       Earnings          `json:"total"`
+	HeldTotal   HeldTotal         `json:"heldTotal"`
+	Estimated   Estimated         `json:"estimated"`
+	Nodes       []NodePayout      `json:"nodes"`
+	Satellites  []SatellitePayout `json:"satellites"`
+	Wallets     []WalletPayout    `json:"wallets"`
+	Paystubs    []Paystub         `json:"paystubs"`
+	Errors      []NodeError       `json:"errors"`
+}
+
+// nodePayouts is the payout data reported by a single node.
+type nodePayouts struct {
+	wallet    string
+	paystubs  []*multinodepb.Paystub
+	held      []*multinodepb.SatelliteHeldHistory
+	estimated []*multinodepb.EstimatedSatellitePayout
+}
+
+// Payouts returns the earnings of all nodes per node, satellite and wallet for
+// the periods between period start and period end inclusive, both in the yyyy-mm format.
+func (service *Service) Payouts(ctx context.Context, periodStart, periodEnd string) (_ Payouts, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodes, err := service.listNodes(ctx)
+	if err != nil {
+		return Payouts{}, Error.Wrap(err)
+	}
+
+	reported := make([]nodePayouts, len(nodes))
+	failures := service.forEachNode(ctx, nodes, func(ctx context.Context, conn *rpc.Conn, i int) error {
+		client := multinodepb.NewDRPCPayoutClient(conn)
+
+		wallet, err := client.Wallet(ctx, &multinodepb.WalletRequest{})
+		if err != nil {
+			return err
+		}
+		paystubs, err := client.Paystubs(ctx, &multinodepb.PaystubsRequest{
+			PeriodStart: periodStart,
+			PeriodEnd:   periodEnd,
+		})
+		if err != nil {
+			return err
+		}
+		held, err := client.HeldHistory(ctx, &multinodepb.HeldHistoryRequest{})
+		if err != nil {
+			return err
+		}
+		estimated, err := client.EstimatedPayout(ctx, &multinodepb.EstimatedPayoutRequest{})
+		if err != nil {
+			return err
+		}
+
+		reported[i] = nodePayouts{
+			wallet:    wallet.GetWallet(),
+			paystubs:  paystubs.GetPaystubs(),
+			held:      held.GetHistory(),
+			estimated: estimated.GetSatellites(),
+		}
+		return nil
+	})
+
+	payouts := aggregatePayouts(nodes, reported, failures)
+	payouts.PeriodStart = periodStart
+	payouts.PeriodEnd = periodEnd
+	return payouts, nil
+}
+
+// aggregatePayouts sums the payout data reported by nodes which responded per node, satellite and wallet.
+func aggregatePayouts(nodes []Node, reported []nodePayouts, failures []error) Payouts {
+	payouts := Payouts{
+		Nodes:    []NodePayout{},
+		Paystubs: []Paystub{},
+		Errors:   nodeErrors(nodes, failures),
+	}
+
+	satellites := map[storj.NodeID]*SatellitePayout{}
+	satellite := func(id storj.NodeID) *SatellitePayout {
+		payout, ok := satellites[id]
+		if !ok {
+			payout = &SatellitePayout{SatelliteID: id}
+			satellites[id] = payout
+		}
+		return payout
+	}
+
+	for i, node := range nodes {
+		if failures[i] != nil {
+			continue
+		}
+
+		nodePayout := NodePayout{
+			NodeID: node.ID,
+			Name:   node.Name,
+			Wallet: reported[i].wallet,
+		}
+
+		for _, paystub := range reported[i].paystubs {
+			earnings := paystubEarnings(paystub)
+			nodePayout.Earnings.add(earnings)
+			satellite(paystub.SatelliteId).Earnings.add(earnings)
+
+			payouts.Paystubs = append(payouts.Paystubs, Paystub{
+				NodeID:      node.ID,
+				Name:        node.Name,
+				Wallet:      nodePayout.Wallet,
+				SatelliteID: paystub.SatelliteId,
+				Period:      paystub.Period,
+				Earnings:    earnings,
+			})
+		}
+
+		for _, history := range reported[i].held {
+			held := HeldTotal{Held: history.TotalHeld, Disposed: history.TotalDisposed}
+			nodePayout.HeldTotal.add(held)
+			satellite(history.SatelliteId).HeldTotal.add(held)
+		}
+
+		for _, estimation := range reported[i].estimated {
+			estimated := Estimated{Payout: estimation.CurrentMonthPayout, Held: estimation.CurrentMonthHeld}
+			nodePayout.Estimated.add(estimated)
+			satellite(estimation.SatelliteId).Estimated.add(estimated)
+		}
+
+		payouts.Total.add(nodePayout.Earnings)
+		payouts.HeldTotal.add(nodePayout.HeldTotal)
+		payouts.Estimated.add(nodePayout.Estimated)
+		payouts.Nodes = append(payouts.Nodes, nodePayout)
+	}
+
+	payouts.Satellites = make([]SatellitePayout, 0, len(satellites))
+	for _, payout := range satellites {
+		payouts.Satellites = append(payouts.Satellites, *payout)
+	}
+	sort.Slice(payouts.Satellites, func(i, k int) bool {
+		return payouts.Satellites[i].SatelliteID.Less(payouts.Satellites[k].SatelliteID)
+	})
+
+	wallets := map[string]*WalletPayout{}
+	for _, nodePayout := range payouts.Nodes {
+		wallet, ok := wallets[nodePayout.Wallet]
+		if !ok {
+			wallet = &WalletPayout{Wallet: nodePayout.Wallet}
+			wallets[nodePayout.Wallet] = wallet
+		}
+		wallet.Nodes++
+		wallet.Earnings.add(nodePayout.Earnings)
+		wallet.HeldTotal.add(nodePayout.HeldTotal)
+		wallet.Estimated.add(nodePayout.Estimated)
+	}
+
+	payouts.Wallets = make([]WalletPayout, 0, len(wallets))
+	for _, wallet := range wallets {
+		payouts.Wallets = append(payouts.Wallets, *wallet)
+	}
+	sort.Slice(payouts.Wallets, func(i, k int) bool {
+		return payouts.Wallets[i].Wallet < payouts.Wallets[k].Wallet
+	})
+
+	sort.SliceStable(payouts.Paystubs, func(i, k int) bool {
+		return payouts.Paystubs[i].Period < payouts.Paystubs[k].Period
+	})
+
+	return payouts
+}
+
+// paystubEarnings returns the earnings of a paystub.
+func paystubEarnings(paystub *multinodepb.Paystub) Earnings {
+	earned := paystub.CompAtRest + paystub.CompGet + paystub.CompGetRepair + paystub.CompGetAudit
+
+	// a paystub without surge pays the earned amount, like on the node dashboard.
+	surgePercent := paystub.SurgePercent
+	if surgePercent == 0 {
+		surgePercent = 100
+	}
+
+	return Earnings{
+		Earned:   earned,
+		Surge:    earned * surgePercent / 100,
+		Held:     paystub.Held,
+		Disposed: paystub.Disposed,
+		Owed:     paystub.Owed,
+		Paid:     paystub.Paid,
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nodes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testrand"
+	"storj.io/storj/multinodepb"
+)
+
+func TestAggregatePayouts(t *testing.T) {
+	satellite1, satellite2 := testrand.NodeID(), testrand.NodeID()
+	nodes := []Node{
+		{ID: testrand.NodeID(), Name: "first"},
+		{ID: testrand.NodeID(), Name: "second"},
+		{ID: testrand.NodeID(), Name: "offline"},
+	}
+
+	reported := []nodePayouts{
+		{
+			wallet: "0xaaaa",
+			paystubs: []*multinodepb.Paystub{
+				{SatelliteId: satellite1, Period: "2020-10", CompAtRest: 100, CompGet: 50, Held: 75, Paid: 75},
+				{SatelliteId: satellite2, Period: "2020-11", CompGet: 200, SurgePercent: 50, Paid: 200},
+			},
+			held: []*multinodepb.SatelliteHeldHistory{
+				{SatelliteId: satellite1, TotalHeld: 75},
+			},
+			estimated: []*multinodepb.EstimatedSatellitePayout{
+				{SatelliteId: satellite1, CurrentMonthPayout: 1.5, CurrentMonthHeld: 0.5},
+			},
+		},
+		{
+			wallet: "0xaaaa",
+			paystubs: []*multinodepb.Paystub{
+				{SatelliteId: satellite1, Period: "2020-10", CompAtRest: 10, SurgePercent: 0, Paid: 10},
+			},
+		},
+		{},
+	}
+	failures := []error{nil, nil, Error.New("offline")}
+
+	payouts := aggregatePayouts(nodes, reported, failures)
+
+	require.Equal(t, Earnings{Earned: 360, Surge: 260, Held: 75, Paid: 285}, payouts.Total)
+	require.Equal(t, HeldTotal{Held: 75}, payouts.HeldTotal)
+	require.Equal(t, Estimated{Payout: 1.5, Held: 0.5}, payouts.Estimated)
+
+	require.Len(t, payouts.Nodes, 2)
+	require.Equal(t, int64(350), payouts.Nodes[0].Earnings.Earned)
+	require.Equal(t, int64(250), payouts.Nodes[0].Earnings.Surge)
+	require.Equal(t, Earnings{Earned: 10, Surge: 10, Paid: 10}, payouts.Nodes[1].Earnings)
+
+	require.Len(t, payouts.Satellites, 2)
+	for _, satellite := range payouts.Satellites {
+		switch satellite.SatelliteID {
+		case satellite1:
+			// paystubs without surge percent pay the earned amount.
+			require.Equal(t, Earnings{Earned: 160, Surge: 160, Held: 75, Paid: 85}, satellite.Earnings)
+			require.Equal(t, int64(75), satellite.HeldTotal.Held)
+		case satellite2:
+			require.Equal(t, Earnings{Earned: 200, Surge: 100, Paid: 200}, satellite.Earnings)
+		}
+	}
+
+	require.Equal(t, []WalletPayout{{
+		Wallet:    "0xaaaa",
+		Nodes:     2,
+		Earnings:  payouts.Total,
+		HeldTotal: payouts.HeldTotal,
+		Estimated: payouts.Estimated,
+	}}, payouts.Wallets)
+
+	require.Len(t, payouts.Paystubs, 3)
+	require.Equal(t, "2020-11", payouts.Paystubs[2].Period)
+
+	require.Len(t, payouts.Errors, 1)
+	require.Equal(t, nodes[2].ID, payouts.Errors[0].NodeID)
+}
@@ -5,11 +5,14 @@ package nodes
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
+	"storj.io/common/rpc"
 	"storj.io/common/storj"
 )
 
@@ -20,19 +23,33 @@ var (
 	Error = errs.Class("nodes service error")
 )
 
+// Config contains configuration for communicating with nodes.
+type Config struct {
+	Timeout     time.Duration `help:"how long to wait for a node to respond before treating it as offline" default:"10s"`
+	Concurrency int           `help:"how many nodes are contacted in parallel" default:"20"`
+}
+
 // Service exposes all nodes related logic.
 //
 // architecture: Service
 type Service struct {
-	log   *zap.Logger
-	nodes DB
+	log    *zap.Logger
+	dialer rpc.Dialer
+	nodes  DB
+	config Config
+
+	mu          sync.Mutex
+	lastContact map[storj.NodeID]time.Time
 }
 
 // NewService creates new instance of Service.
-func NewService(log *zap.Logger, nodes DB) *Service {
+func NewService(log *zap.Logger, dialer rpc.Dialer, nodes DB, config Config) *Service {
 	return &Service{
-		log:   log,
-		nodes: nodes,
+		log:         log,
+		dialer:      dialer,
+		nodes:       nodes,
+		config:      config,
+		lastContact: map[storj.NodeID]time.Time{},
 	}
 }
 
@@ -52,6 +69,10 @@ func (service *Service) UpdateName(ctx context.Context, id storj.NodeID, name st
 func (service *Service) Get(ctx context.Context, id storj.NodeID) (_ Node, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	if !visible(ctx, id) {
+		return Node{}, ErrNoNode.New("%s", id)
+	}
+
 	node, err := service.nodes.Get(ctx, id)
 	if err != nil {
 		return Node{}, Error.Wrap(err)
@@ -70,6 +91,11 @@ func (service *Service) List(ctx context.Context) (_ []Node, err error) {
 		return nil, Error.Wrap(err)
 	}
 
+	nodes = filterNodes(ctx, nodes)
+	if len(nodes) == 0 {
+		return nil, ErrNoNode.New("no nodes")
+	}
+
 	return nodes, nil
 }
 
@@ -78,3 +104,35 @@ func (service *Service) Remove(ctx context.Context, id storj.NodeID) (err error)
 	defer mon.Task()(&ctx)(&err)
 	return Error.Wrap(service.nodes.Remove(ctx, id))
 }
+
+// Filter decides whether a node is visible.
+type Filter func(id storj.NodeID) bool
+
+type filterKey struct{}
+
+// WithFilter returns a context in which the service only works with the
+// nodes accepted by the filter.
+func WithFilter(ctx context.Context, filter Filter) context.Context {
+	return context.WithValue(ctx, filterKey{}, filter)
+}
+
+// visible returns whether the node is accepted by the filter of the context.
+func visible(ctx context.Context, id storj.NodeID) bool {
+	filter, ok := ctx.Value(filterKey{}).(Filter)
+	return !ok || filter(id)
+}
+
+// filterNodes returns the nodes accepted by the filter of the context.
+func filterNodes(ctx context.Context, nodes []Node) []Node {
+	if _, ok := ctx.Value(filterKey{}).(Filter); !ok {
+		return nodes
+	}
+
+	visibleNodes := make([]Node, 0, len(nodes))
+	for _, node := range nodes {
+		if visible(ctx, node.ID) {
+			visibleNodes = append(visibleNodes, node)
+		}
+	}
+	return visibleNodes
+}
@@ -8,11 +8,15 @@ import (
 	"net"
 
 	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
 	"storj.io/common/identity"
+	"storj.io/common/peertls/tlsopts"
+	"storj.io/common/rpc"
 	"storj.io/private/debug"
+	"storj.io/storj/multinode/alerts"
 	"storj.io/storj/multinode/console"
 	"storj.io/storj/multinode/console/server"
 	"storj.io/storj/multinode/nodes"
@@ -31,6 +35,8 @@ type DB interface {
 	Nodes() nodes.DB
 	// Members returns members database.
 	Members() console.Members
+	// Alerts returns alerts database.
+	Alerts() alerts.DB
 
 	// Close closes the database.
 	Close() error
@@ -43,7 +49,10 @@ type Config struct {
 	Identity identity.Config
 	Debug    debug.Config
 
-	Console server.Config
+	Nodes    nodes.Config
+	Alerts   alerts.Config
+	Accounts console.Config
+	Console  server.Config
 }
 
 // Peer is the a Multinode Dashboard application itself.
@@ -54,19 +63,27 @@ type Peer struct {
 	Log      *zap.Logger
 	Identity *identity.FullIdentity
 	DB       DB
+	Dialer   rpc.Dialer
 
 	// contains logic of nodes domain.
 	Nodes struct {
 		Service *nodes.Service
 	}
 
+	// polls health of nodes and records alerts.
+	Alerts struct {
+		Chore *alerts.Chore
+	}
+
 	// Web server with web UI.
 	Console struct {
 		Listener net.Listener
+		Service  *console.Service
 		Endpoint *server.Server
 	}
 
-	Servers *lifecycle.Group
+	Servers  *lifecycle.Group
+	Services *lifecycle.Group
 }
 
 // New creates a new instance of Multinode Dashboard application.
@@ -76,25 +93,66 @@ func New(log *zap.Logger, full *identity.FullIdentity, config Config, db DB) (_
 		Identity: full,
 		DB:       db,
 		Servers:  lifecycle.NewGroup(log.Named("servers")),
+		Services: lifecycle.NewGroup(log.Named("services")),
+	}
+
+	{ // setup dialer
+		tlsOptions, err := tlsopts.NewOptions(peer.Identity, tlsopts.Config{
+			UsePeerCAWhitelist: false,
+			PeerIDVersions:     "0",
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		peer.Dialer = rpc.NewDefaultDialer(tlsOptions)
 	}
 
 	{ // nodes setup
 		peer.Nodes.Service = nodes.NewService(
 			peer.Log.Named("nodes:service"),
+			peer.Dialer,
 			peer.DB.Nodes(),
+			config.Nodes,
 		)
 	}
 
+	{ // alerts setup
+		peer.Alerts.Chore, err = alerts.NewChore(
+			peer.Log.Named("alerts:chore"),
+			peer.Nodes.Service,
+			peer.DB.Alerts(),
+			config.Alerts,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		peer.Services.Add(lifecycle.Item{
+			Name:  "alerts:chore",
+			Run:   peer.Alerts.Chore.Run,
+			Close: peer.Alerts.Chore.Close,
+		})
+	}
+
 	{ // console setup
 		peer.Console.Listener, err = net.Listen("tcp", config.Console.Address)
 		if err != nil {
 			return nil, err
 		}
 
+		peer.Console.Service = console.NewService(
+			peer.Log.Named("console:service"),
+			peer.DB.Members(),
+			config.Accounts,
+		)
+
 		peer.Console.Endpoint, err = server.NewServer(
 			peer.Log.Named("console:endpoint"),
 			config.Console,
+			peer.Console.Service,
 			peer.Nodes.Service,
+			peer.DB.Alerts(),
 			peer.Console.Listener,
 		)
 		if err != nil {
@@ -118,11 +176,15 @@ func (peer *Peer) Run(ctx context.Context) (err error) {
 	group, ctx := errgroup.WithContext(ctx)
 
 	peer.Servers.Run(ctx, group)
+	peer.Services.Run(ctx, group)
 
 	return group.Wait()
 }
 
 // Close closes all the resources.
 func (peer *Peer) Close() error {
-	return peer.Servers.Close()
+	return errs.Combine(
+		peer.Servers.Close(),
+		peer.Services.Close(),
+	)
 }
@@ -0,0 +1,38 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package multinodepb
+
+import (
+	"context"
+	"encoding/base64"
+
+	"storj.io/drpc/drpcmetadata"
+)
+
+// APIKeyMetadataKey is the key of the request metadata holding the api key of the node.
+const APIKeyMetadataKey = "multinode-api-key"
+
+// WithAPIKey returns a context which sends the api key with every request made with it.
+func WithAPIKey(ctx context.Context, apiKey []byte) context.Context {
+	return drpcmetadata.Add(ctx, APIKeyMetadataKey, base64.URLEncoding.EncodeToString(apiKey))
+}
+
+// APIKeyFromContext returns the api key sent with the request, false when there is none.
+func APIKeyFromContext(ctx context.Context) ([]byte, bool) {
+	metadata, ok := drpcmetadata.Get(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	encoded, ok := metadata[APIKeyMetadataKey]
+	if !ok {
+		return nil, false
+	}
+
+	apiKey, err := base64.URLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, false
+	}
+	return apiKey, true
+}
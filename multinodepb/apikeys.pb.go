@@ -0,0 +1,295 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: apikeys.proto
+
+package multinodepb
+
+import (
+	context "context"
+	fmt "fmt"
+	math "math"
+
+	proto "github.com/gogo/protobuf/proto"
+
+	drpc "storj.io/drpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+type RotateRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RotateRequest) Reset()         { *m = RotateRequest{} }
+func (m *RotateRequest) String() string { return proto.CompactTextString(m) }
+func (*RotateRequest) ProtoMessage()    {}
+func (*RotateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc9fd134f5d4c8eb, []int{0}
+}
+func (m *RotateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RotateRequest.Unmarshal(m, b)
+}
+func (m *RotateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RotateRequest.Marshal(b, m, deterministic)
+}
+func (m *RotateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RotateRequest.Merge(m, src)
+}
+func (m *RotateRequest) XXX_Size() int {
+	return xxx_messageInfo_RotateRequest.Size(m)
+}
+func (m *RotateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RotateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RotateRequest proto.InternalMessageInfo
+
+type RotateResponse struct {
+	ApiKey               []byte   `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RotateResponse) Reset()         { *m = RotateResponse{} }
+func (m *RotateResponse) String() string { return proto.CompactTextString(m) }
+func (*RotateResponse) ProtoMessage()    {}
+func (*RotateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc9fd134f5d4c8eb, []int{1}
+}
+func (m *RotateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RotateResponse.Unmarshal(m, b)
+}
+func (m *RotateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RotateResponse.Marshal(b, m, deterministic)
+}
+func (m *RotateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RotateResponse.Merge(m, src)
+}
+func (m *RotateResponse) XXX_Size() int {
+	return xxx_messageInfo_RotateResponse.Size(m)
+}
+func (m *RotateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RotateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RotateResponse proto.InternalMessageInfo
+
+func (m *RotateResponse) GetApiKey() []byte {
+	if m != nil {
+		return m.ApiKey
+	}
+	return nil
+}
+
+type RevokeRequest struct {
+	ApiKey               []byte   `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeRequest) Reset()         { *m = RevokeRequest{} }
+func (m *RevokeRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeRequest) ProtoMessage()    {}
+func (*RevokeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc9fd134f5d4c8eb, []int{2}
+}
+func (m *RevokeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeRequest.Unmarshal(m, b)
+}
+func (m *RevokeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeRequest.Marshal(b, m, deterministic)
+}
+func (m *RevokeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeRequest.Merge(m, src)
+}
+func (m *RevokeRequest) XXX_Size() int {
+	return xxx_messageInfo_RevokeRequest.Size(m)
+}
+func (m *RevokeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeRequest proto.InternalMessageInfo
+
+func (m *RevokeRequest) GetApiKey() []byte {
+	if m != nil {
+		return m.ApiKey
+	}
+	return nil
+}
+
+type RevokeResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeResponse) Reset()         { *m = RevokeResponse{} }
+func (m *RevokeResponse) String() string { return proto.CompactTextString(m) }
+func (*RevokeResponse) ProtoMessage()    {}
+func (*RevokeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc9fd134f5d4c8eb, []int{3}
+}
+func (m *RevokeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeResponse.Unmarshal(m, b)
+}
+func (m *RevokeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeResponse.Marshal(b, m, deterministic)
+}
+func (m *RevokeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeResponse.Merge(m, src)
+}
+func (m *RevokeResponse) XXX_Size() int {
+	return xxx_messageInfo_RevokeResponse.Size(m)
+}
+func (m *RevokeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*RotateRequest)(nil), "apikeys.RotateRequest")
+	proto.RegisterType((*RotateResponse)(nil), "apikeys.RotateResponse")
+	proto.RegisterType((*RevokeRequest)(nil), "apikeys.RevokeRequest")
+	proto.RegisterType((*RevokeResponse)(nil), "apikeys.RevokeResponse")
+}
+
+func init() { proto.RegisterFile("apikeys.proto", fileDescriptor_fc9fd134f5d4c8eb) }
+
+var fileDescriptor_fc9fd134f5d4c8eb = []byte{
+	// 187 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x4d, 0x2c, 0xc8, 0xcc,
+	0x4e, 0xad, 0x2c, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x87, 0x72, 0x95, 0xf8, 0xb9,
+	0x78, 0x83, 0xf2, 0x4b, 0x12, 0x4b, 0x52, 0x83, 0x52, 0x0b, 0x4b, 0x53, 0x8b, 0x4b, 0x94, 0x34,
+	0xb9, 0xf8, 0x60, 0x02, 0xc5, 0x05, 0xf9, 0x79, 0xc5, 0xa9, 0x42, 0xe2, 0x5c, 0x20, 0xd5, 0xf1,
+	0xd9, 0xa9, 0x95, 0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x3c, 0x41, 0x6c, 0x89, 0x05, 0x99, 0xde, 0xa9,
+	0x95, 0x4a, 0x1a, 0x5c, 0xbc, 0x41, 0xa9, 0x65, 0xf9, 0xd9, 0x30, 0xbd, 0xb8, 0x55, 0x0a, 0x70,
+	0xf1, 0xc1, 0x54, 0x42, 0x0c, 0x35, 0xaa, 0xe7, 0x62, 0x77, 0x04, 0xcb, 0x15, 0x0b, 0x59, 0x72,
+	0xb1, 0x41, 0x6c, 0x14, 0x12, 0xd3, 0x83, 0xb9, 0x12, 0xc5, 0x4d, 0x52, 0xe2, 0x18, 0xe2, 0x50,
+	0xa7, 0x81, 0xb4, 0x82, 0xcd, 0x45, 0xd6, 0x8a, 0xec, 0x24, 0x29, 0x71, 0x0c, 0x71, 0x88, 0x56,
+	0x27, 0x99, 0x28, 0xa9, 0xe2, 0x92, 0xfc, 0xa2, 0x2c, 0xbd, 0xcc, 0x7c, 0x7d, 0x30, 0x43, 0x3f,
+	0xb7, 0x34, 0xa7, 0x24, 0x33, 0x2f, 0x3f, 0x25, 0xb5, 0x20, 0x29, 0x89, 0x0d, 0x1c, 0x4c, 0xc6,
+	0x80, 0x01, 0x00, 0xda, 0xe8, 0xf2, 0x9a, 0x37, 0x01, 0x00, 0x00,
+}
+
+// --- DRPC BEGIN ---
+
+type DRPCApiKeysClient interface {
+	DRPCConn() drpc.Conn
+
+	Rotate(ctx context.Context, in *RotateRequest) (*RotateResponse, error)
+	Revoke(ctx context.Context, in *RevokeRequest) (*RevokeResponse, error)
+}
+
+type drpcApiKeysClient struct {
+	cc drpc.Conn
+}
+
+func NewDRPCApiKeysClient(cc drpc.Conn) DRPCApiKeysClient {
+	return &drpcApiKeysClient{cc}
+}
+
+func (c *drpcApiKeysClient) DRPCConn() drpc.Conn { return c.cc }
+
+func (c *drpcApiKeysClient) Rotate(ctx context.Context, in *RotateRequest) (*RotateResponse, error) {
+	out := new(RotateResponse)
+	err := c.cc.Invoke(ctx, "/apikeys.ApiKeys/Rotate", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcApiKeysClient) Revoke(ctx context.Context, in *RevokeRequest) (*RevokeResponse, error) {
+	out := new(RevokeResponse)
+	err := c.cc.Invoke(ctx, "/apikeys.ApiKeys/Revoke", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type DRPCApiKeysServer interface {
+	Rotate(context.Context, *RotateRequest) (*RotateResponse, error)
+	Revoke(context.Context, *RevokeRequest) (*RevokeResponse, error)
+}
+
+type DRPCApiKeysDescription struct{}
+
+func (DRPCApiKeysDescription) NumMethods() int { return 2 }
+
+func (DRPCApiKeysDescription) Method(n int) (string, drpc.Receiver, interface{}, bool) {
+	switch n {
+	case 0:
+		return "/apikeys.ApiKeys/Rotate",
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCApiKeysServer).
+					Rotate(
+						ctx,
+						in1.(*RotateRequest),
+					)
+			}, DRPCApiKeysServer.Rotate, true
+	case 1:
+		return "/apikeys.ApiKeys/Revoke",
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCApiKeysServer).
+					Revoke(
+						ctx,
+						in1.(*RevokeRequest),
+					)
+			}, DRPCApiKeysServer.Revoke, true
+	default:
+		return "", nil, nil, false
+	}
+}
+
+func DRPCRegisterApiKeys(mux drpc.Mux, impl DRPCApiKeysServer) error {
+	return mux.Register(impl, DRPCApiKeysDescription{})
+}
+
+type DRPCApiKeys_RotateStream interface {
+	drpc.Stream
+	SendAndClose(*RotateResponse) error
+}
+
+type drpcApiKeysRotateStream struct {
+	drpc.Stream
+}
+
+func (x *drpcApiKeysRotateStream) SendAndClose(m *RotateResponse) error {
+	if err := x.MsgSend(m); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCApiKeys_RevokeStream interface {
+	drpc.Stream
+	SendAndClose(*RevokeResponse) error
+}
+
+type drpcApiKeysRevokeStream struct {
+	drpc.Stream
+}
+
+func (x *drpcApiKeysRevokeStream) SendAndClose(m *RevokeResponse) error {
+	if err := x.MsgSend(m); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+// --- DRPC END ---
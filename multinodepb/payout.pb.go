@@ -0,0 +1,879 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: payout.proto
+
+package multinodepb
+
+import (
+	context "context"
+	fmt "fmt"
+	math "math"
+	time "time"
+
+	proto "github.com/gogo/protobuf/proto"
+
+	drpc "storj.io/drpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+type WalletRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WalletRequest) Reset()         { *m = WalletRequest{} }
+func (m *WalletRequest) String() string { return proto.CompactTextString(m) }
+func (*WalletRequest) ProtoMessage()    {}
+func (*WalletRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b9f811dbf895a600, []int{0}
+}
+func (m *WalletRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WalletRequest.Unmarshal(m, b)
+}
+func (m *WalletRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WalletRequest.Marshal(b, m, deterministic)
+}
+func (m *WalletRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WalletRequest.Merge(m, src)
+}
+func (m *WalletRequest) XXX_Size() int {
+	return xxx_messageInfo_WalletRequest.Size(m)
+}
+func (m *WalletRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WalletRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WalletRequest proto.InternalMessageInfo
+
+type WalletResponse struct {
+	Wallet               string   `protobuf:"bytes,1,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WalletResponse) Reset()         { *m = WalletResponse{} }
+func (m *WalletResponse) String() string { return proto.CompactTextString(m) }
+func (*WalletResponse) ProtoMessage()    {}
+func (*WalletResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b9f811dbf895a600, []int{1}
+}
+func (m *WalletResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WalletResponse.Unmarshal(m, b)
+}
+func (m *WalletResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WalletResponse.Marshal(b, m, deterministic)
+}
+func (m *WalletResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WalletResponse.Merge(m, src)
+}
+func (m *WalletResponse) XXX_Size() int {
+	return xxx_messageInfo_WalletResponse.Size(m)
+}
+func (m *WalletResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WalletResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WalletResponse proto.InternalMessageInfo
+
+func (m *WalletResponse) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type PaystubsRequest struct {
+	PeriodStart          string   `protobuf:"bytes,1,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	PeriodEnd            string   `protobuf:"bytes,2,opt,name=period_end,json=periodEnd,proto3" json:"period_end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PaystubsRequest) Reset()         { *m = PaystubsRequest{} }
+func (m *PaystubsRequest) String() string { return proto.CompactTextString(m) }
+func (*PaystubsRequest) ProtoMessage()    {}
+func (*PaystubsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b9f811dbf895a600, []int{2}
+}
+func (m *PaystubsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PaystubsRequest.Unmarshal(m, b)
+}
+func (m *PaystubsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PaystubsRequest.Marshal(b, m, deterministic)
+}
+func (m *PaystubsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PaystubsRequest.Merge(m, src)
+}
+func (m *PaystubsRequest) XXX_Size() int {
+	return xxx_messageInfo_PaystubsRequest.Size(m)
+}
+func (m *PaystubsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PaystubsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PaystubsRequest proto.InternalMessageInfo
+
+func (m *PaystubsRequest) GetPeriodStart() string {
+	if m != nil {
+		return m.PeriodStart
+	}
+	return ""
+}
+
+func (m *PaystubsRequest) GetPeriodEnd() string {
+	if m != nil {
+		return m.PeriodEnd
+	}
+	return ""
+}
+
+type PaystubsResponse struct {
+	Paystubs             []*Paystub `protobuf:"bytes,1,rep,name=paystubs,proto3" json:"paystubs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *PaystubsResponse) Reset()         { *m = PaystubsResponse{} }
+func (m *PaystubsResponse) String() string { return proto.CompactTextString(m) }
+func (*PaystubsResponse) ProtoMessage()    {}
+func (*PaystubsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b9f811dbf895a600, []int{3}
+}
+func (m *PaystubsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PaystubsResponse.Unmarshal(m, b)
+}
+func (m *PaystubsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PaystubsResponse.Marshal(b, m, deterministic)
+}
+func (m *PaystubsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PaystubsResponse.Merge(m, src)
+}
+func (m *PaystubsResponse) XXX_Size() int {
+	return xxx_messageInfo_PaystubsResponse.Size(m)
+}
+func (m *PaystubsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PaystubsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PaystubsResponse proto.InternalMessageInfo
+
+func (m *PaystubsResponse) GetPaystubs() []*Paystub {
+	if m != nil {
+		return m.Paystubs
+	}
+	return nil
+}
+
+type Paystub struct {
+	SatelliteId          NodeID    `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	Period               string    `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`
+	Created              time.Time `protobuf:"bytes,3,opt,name=created,proto3,stdtime" json:"created"`
+	Codes                string    `protobuf:"bytes,4,opt,name=codes,proto3" json:"codes,omitempty"`
+	UsageAtRest          float64   `protobuf:"fixed64,5,opt,name=usage_at_rest,json=usageAtRest,proto3" json:"usage_at_rest,omitempty"`
+	UsageGet             int64     `protobuf:"varint,6,opt,name=usage_get,json=usageGet,proto3" json:"usage_get,omitempty"`
+	UsagePut             int64     `protobuf:"varint,7,opt,name=usage_put,json=usagePut,proto3" json:"usage_put,omitempty"`
+	UsageGetRepair       int64     `protobuf:"varint,8,opt,name=usage_get_repair,json=usageGetRepair,proto3" json:"usage_get_repair,omitempty"`
+	UsagePutRepair       int64     `protobuf:"varint,9,opt,name=usage_put_repair,json=usagePutRepair,proto3" json:"usage_put_repair,omitempty"`
+	UsageGetAudit        int64     `protobuf:"varint,10,opt,name=usage_get_audit,json=usageGetAudit,proto3" json:"usage_get_audit,omitempty"`
+	CompAtRest           int64     `protobuf:"varint,11,opt,name=comp_at_rest,json=compAtRest,proto3" json:"comp_at_rest,omitempty"`
+	CompGet              int64     `protobuf:"varint,12,opt,name=comp_get,json=compGet,proto3" json:"comp_get,omitempty"`
+	CompPut              int64     `protobuf:"varint,13,opt,name=comp_put,json=compPut,proto3" json:"comp_put,omitempty"`
+	CompGetRepair        int64     `protobuf:"varint,14,opt,name=comp_get_repair,json=compGetRepair,proto3" json:"comp_get_repair,omitempty"`
+	CompPutRepair        int64     `protobuf:"varint,15,opt,name=comp_put_repair,json=compPutRepair,proto3" json:"comp_put_repair,omitempty"`
+	CompGetAudit         int64     `protobuf:"varint,16,opt,name=comp_get_audit,json=compGetAudit,proto3" json:"comp_get_audit,omitempty"`
+	SurgePercent         int64     `protobuf:"varint,17,opt,name=surge_percent,json=surgePercent,proto3" json:"surge_percent,omitempty"`
+	Held                 int64     `protobuf:"varint,18,opt,name=held,proto3" json:"held,omitempty"`
+	Owed                 int64     `protobuf:"varint,19,opt,name=owed,proto3" json:"owed,omitempty"`
+	Disposed             int64     `protobuf:"varint,20,opt,name=disposed,proto3" json:"disposed,omitempty"`
+	Paid                 int64     `protobuf:"varint,21,opt,name=paid,proto3" json:"paid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Paystub) Reset()         { *m = Paystub{} }
+func (m *Paystub) String() string { return proto.CompactTextString(m) }
+func (*Paystub) ProtoMessage()    {}
+func (*Paystub) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b9f811dbf895a600, []int{4}
+}
+func (m *Paystub) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Paystub.Unmarshal(m, b)
+}
+func (m *Paystub) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Paystub.Marshal(b, m, deterministic)
+}
+func (m *Paystub) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Paystub.Merge(m, src)
+}
+func (m *Paystub) XXX_Size() int {
+	return xxx_messageInfo_Paystub.Size(m)
+}
+func (m *Paystub) XXX_DiscardUnknown() {
+	xxx_messageInfo_Paystub.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Paystub proto.InternalMessageInfo
+
+func (m *Paystub) GetPeriod() string {
+	if m != nil {
+		return m.Period
+	}
+	return ""
+}
+
+func (m *Paystub) GetCreated() time.Time {
+	if m != nil {
+		return m.Created
+	}
+	return time.Time{}
+}
+
+func (m *Paystub) GetCodes() string {
+	if m != nil {
+		return m.Codes
+	}
+	return ""
+}
+
+func (m *Paystub) GetUsageAtRest() float64 {
+	if m != nil {
+		return m.UsageAtRest
+	}
+	return 0
+}
+
+func (m *Paystub) GetUsageGet() int64 {
+	if m != nil {
+		return m.UsageGet
+	}
+	return 0
+}
+
+func (m *Paystub) GetUsagePut() int64 {
+	if m != nil {
+		return m.UsagePut
+	}
+	return 0
+}
+
+func (m *Paystub) GetUsageGetRepair() int64 {
+	if m != nil {
+		return m.UsageGetRepair
+	}
+	return 0
+}
+
+func (m *Paystub) GetUsagePutRepair() int64 {
+	if m != nil {
+		return m.UsagePutRepair
+	}
+	return 0
+}
+
+func (m *Paystub) GetUsageGetAudit() int64 {
+	if m != nil {
+		return m.UsageGetAudit
+	}
+	return 0
+}
+
+func (m *Paystub) GetCompAtRest() int64 {
+	if m != nil {
+		return m.CompAtRest
+	}
+	return 0
+}
+
+func (m *Paystub) GetCompGet() int64 {
+	if m != nil {
+		return m.CompGet
+	}
+	return 0
+}
+
+func (m *Paystub) GetCompPut() int64 {
+	if m != nil {
+		return m.CompPut
+	}
+	return 0
+}
+
+func (m *Paystub) GetCompGetRepair() int64 {
+	if m != nil {
+		return m.CompGetRepair
+	}
+	return 0
+}
+
+func (m *Paystub) GetCompPutRepair() int64 {
+	if m != nil {
+		return m.CompPutRepair
+	}
+	return 0
+}
+
+func (m *Paystub) GetCompGetAudit() int64 {
+	if m != nil {
+		return m.CompGetAudit
+	}
+	return 0
+}
+
+func (m *Paystub) GetSurgePercent() int64 {
+	if m != nil {
+		return m.SurgePercent
+	}
+	return 0
+}
+
+func (m *Paystub) GetHeld() int64 {
+	if m != nil {
+		return m.Held
+	}
+	return 0
+}
+
+func (m *Paystub) GetOwed() int64 {
+	if m != nil {
+		return m.Owed
+	}
+	return 0
+}
+
+func (m *Paystub) GetDisposed() int64 {
+	if m != nil {
+		return m.Disposed
+	}
+	return 0
+}
+
+func (m *Paystub) GetPaid() int64 {
+	if m != nil {
+		return m.Paid
+	}
+	return 0
+}
+
+type HeldHistoryRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HeldHistoryRequest) Reset()         { *m = HeldHistoryRequest{} }
+func (m *HeldHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*HeldHistoryRequest) ProtoMessage()    {}
+func (*HeldHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b9f811dbf895a600, []int{5}
+}
+func (m *HeldHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HeldHistoryRequest.Unmarshal(m, b)
+}
+func (m *HeldHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HeldHistoryRequest.Marshal(b, m, deterministic)
+}
+func (m *HeldHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeldHistoryRequest.Merge(m, src)
+}
+func (m *HeldHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_HeldHistoryRequest.Size(m)
+}
+func (m *HeldHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeldHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeldHistoryRequest proto.InternalMessageInfo
+
+type HeldHistoryResponse struct {
+	History              []*SatelliteHeldHistory `protobuf:"bytes,1,rep,name=history,proto3" json:"history,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *HeldHistoryResponse) Reset()         { *m = HeldHistoryResponse{} }
+func (m *HeldHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*HeldHistoryResponse) ProtoMessage()    {}
+func (*HeldHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b9f811dbf895a600, []int{6}
+}
+func (m *HeldHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HeldHistoryResponse.Unmarshal(m, b)
+}
+func (m *HeldHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HeldHistoryResponse.Marshal(b, m, deterministic)
+}
+func (m *HeldHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeldHistoryResponse.Merge(m, src)
+}
+func (m *HeldHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_HeldHistoryResponse.Size(m)
+}
+func (m *HeldHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeldHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeldHistoryResponse proto.InternalMessageInfo
+
+func (m *HeldHistoryResponse) GetHistory() []*SatelliteHeldHistory {
+	if m != nil {
+		return m.History
+	}
+	return nil
+}
+
+type SatelliteHeldHistory struct {
+	SatelliteId          NodeID    `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	TotalHeld            int64     `protobuf:"varint,2,opt,name=total_held,json=totalHeld,proto3" json:"total_held,omitempty"`
+	TotalDisposed        int64     `protobuf:"varint,3,opt,name=total_disposed,json=totalDisposed,proto3" json:"total_disposed,omitempty"`
+	JoinedAt             time.Time `protobuf:"bytes,4,opt,name=joined_at,json=joinedAt,proto3,stdtime" json:"joined_at"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *SatelliteHeldHistory) Reset()         { *m = SatelliteHeldHistory{} }
+func (m *SatelliteHeldHistory) String() string { return proto.CompactTextString(m) }
+func (*SatelliteHeldHistory) ProtoMessage()    {}
+func (*SatelliteHeldHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b9f811dbf895a600, []int{7}
+}
+func (m *SatelliteHeldHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SatelliteHeldHistory.Unmarshal(m, b)
+}
+func (m *SatelliteHeldHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SatelliteHeldHistory.Marshal(b, m, deterministic)
+}
+func (m *SatelliteHeldHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SatelliteHeldHistory.Merge(m, src)
+}
+func (m *SatelliteHeldHistory) XXX_Size() int {
+	return xxx_messageInfo_SatelliteHeldHistory.Size(m)
+}
+func (m *SatelliteHeldHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_SatelliteHeldHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SatelliteHeldHistory proto.InternalMessageInfo
+
+func (m *SatelliteHeldHistory) GetTotalHeld() int64 {
+	if m != nil {
+		return m.TotalHeld
+	}
+	return 0
+}
+
+func (m *SatelliteHeldHistory) GetTotalDisposed() int64 {
+	if m != nil {
+		return m.TotalDisposed
+	}
+	return 0
+}
+
+func (m *SatelliteHeldHistory) GetJoinedAt() time.Time {
+	if m != nil {
+		return m.JoinedAt
+	}
+	return time.Time{}
+}
+
+type EstimatedPayoutRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EstimatedPayoutRequest) Reset()         { *m = EstimatedPayoutRequest{} }
+func (m *EstimatedPayoutRequest) String() string { return proto.CompactTextString(m) }
+func (*EstimatedPayoutRequest) ProtoMessage()    {}
+func (*EstimatedPayoutRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b9f811dbf895a600, []int{8}
+}
+func (m *EstimatedPayoutRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EstimatedPayoutRequest.Unmarshal(m, b)
+}
+func (m *EstimatedPayoutRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EstimatedPayoutRequest.Marshal(b, m, deterministic)
+}
+func (m *EstimatedPayoutRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EstimatedPayoutRequest.Merge(m, src)
+}
+func (m *EstimatedPayoutRequest) XXX_Size() int {
+	return xxx_messageInfo_EstimatedPayoutRequest.Size(m)
+}
+func (m *EstimatedPayoutRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EstimatedPayoutRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EstimatedPayoutRequest proto.InternalMessageInfo
+
+type EstimatedPayoutResponse struct {
+	Satellites           []*EstimatedSatellitePayout `protobuf:"bytes,1,rep,name=satellites,proto3" json:"satellites,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *EstimatedPayoutResponse) Reset()         { *m = EstimatedPayoutResponse{} }
+func (m *EstimatedPayoutResponse) String() string { return proto.CompactTextString(m) }
+func (*EstimatedPayoutResponse) ProtoMessage()    {}
+func (*EstimatedPayoutResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b9f811dbf895a600, []int{9}
+}
+func (m *EstimatedPayoutResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EstimatedPayoutResponse.Unmarshal(m, b)
+}
+func (m *EstimatedPayoutResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EstimatedPayoutResponse.Marshal(b, m, deterministic)
+}
+func (m *EstimatedPayoutResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EstimatedPayoutResponse.Merge(m, src)
+}
+func (m *EstimatedPayoutResponse) XXX_Size() int {
+	return xxx_messageInfo_EstimatedPayoutResponse.Size(m)
+}
+func (m *EstimatedPayoutResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EstimatedPayoutResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EstimatedPayoutResponse proto.InternalMessageInfo
+
+func (m *EstimatedPayoutResponse) GetSatellites() []*EstimatedSatellitePayout {
+	if m != nil {
+		return m.Satellites
+	}
+	return nil
+}
+
+type EstimatedSatellitePayout struct {
+	SatelliteId          NodeID   `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	CurrentMonthPayout   float64  `protobuf:"fixed64,2,opt,name=current_month_payout,json=currentMonthPayout,proto3" json:"current_month_payout,omitempty"`
+	CurrentMonthHeld     float64  `protobuf:"fixed64,3,opt,name=current_month_held,json=currentMonthHeld,proto3" json:"current_month_held,omitempty"`
+	PreviousMonthPayout  float64  `protobuf:"fixed64,4,opt,name=previous_month_payout,json=previousMonthPayout,proto3" json:"previous_month_payout,omitempty"`
+	PreviousMonthHeld    float64  `protobuf:"fixed64,5,opt,name=previous_month_held,json=previousMonthHeld,proto3" json:"previous_month_held,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EstimatedSatellitePayout) Reset()         { *m = EstimatedSatellitePayout{} }
+func (m *EstimatedSatellitePayout) String() string { return proto.CompactTextString(m) }
+func (*EstimatedSatellitePayout) ProtoMessage()    {}
+func (*EstimatedSatellitePayout) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b9f811dbf895a600, []int{10}
+}
+func (m *EstimatedSatellitePayout) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EstimatedSatellitePayout.Unmarshal(m, b)
+}
+func (m *EstimatedSatellitePayout) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EstimatedSatellitePayout.Marshal(b, m, deterministic)
+}
+func (m *EstimatedSatellitePayout) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EstimatedSatellitePayout.Merge(m, src)
+}
+func (m *EstimatedSatellitePayout) XXX_Size() int {
+	return xxx_messageInfo_EstimatedSatellitePayout.Size(m)
+}
+func (m *EstimatedSatellitePayout) XXX_DiscardUnknown() {
+	xxx_messageInfo_EstimatedSatellitePayout.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EstimatedSatellitePayout proto.InternalMessageInfo
+
+func (m *EstimatedSatellitePayout) GetCurrentMonthPayout() float64 {
+	if m != nil {
+		return m.CurrentMonthPayout
+	}
+	return 0
+}
+
+func (m *EstimatedSatellitePayout) GetCurrentMonthHeld() float64 {
+	if m != nil {
+		return m.CurrentMonthHeld
+	}
+	return 0
+}
+
+func (m *EstimatedSatellitePayout) GetPreviousMonthPayout() float64 {
+	if m != nil {
+		return m.PreviousMonthPayout
+	}
+	return 0
+}
+
+func (m *EstimatedSatellitePayout) GetPreviousMonthHeld() float64 {
+	if m != nil {
+		return m.PreviousMonthHeld
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*WalletRequest)(nil), "payout.WalletRequest")
+	proto.RegisterType((*WalletResponse)(nil), "payout.WalletResponse")
+	proto.RegisterType((*PaystubsRequest)(nil), "payout.PaystubsRequest")
+	proto.RegisterType((*PaystubsResponse)(nil), "payout.PaystubsResponse")
+	proto.RegisterType((*Paystub)(nil), "payout.Paystub")
+	proto.RegisterType((*HeldHistoryRequest)(nil), "payout.HeldHistoryRequest")
+	proto.RegisterType((*HeldHistoryResponse)(nil), "payout.HeldHistoryResponse")
+	proto.RegisterType((*SatelliteHeldHistory)(nil), "payout.SatelliteHeldHistory")
+	proto.RegisterType((*EstimatedPayoutRequest)(nil), "payout.EstimatedPayoutRequest")
+	proto.RegisterType((*EstimatedPayoutResponse)(nil), "payout.EstimatedPayoutResponse")
+	proto.RegisterType((*EstimatedSatellitePayout)(nil), "payout.EstimatedSatellitePayout")
+}
+
+func init() { proto.RegisterFile("payout.proto", fileDescriptor_b9f811dbf895a600) }
+
+var fileDescriptor_b9f811dbf895a600 = []byte{
+	// 859 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0x5d, 0x8f, 0xdb, 0x44,
+	0x14, 0xc5, 0x9b, 0xdd, 0xc4, 0xb9, 0xf9, 0xda, 0xce, 0x66, 0xb7, 0x83, 0xdb, 0x92, 0x60, 0xa0,
+	0x8a, 0x04, 0x4a, 0x20, 0x48, 0xf0, 0xc4, 0x47, 0x56, 0x2d, 0x6d, 0x1f, 0x8a, 0x22, 0x2f, 0x12,
+	0x12, 0x3c, 0x58, 0x4e, 0x66, 0xc8, 0xba, 0x72, 0x3c, 0xc6, 0x33, 0xa6, 0xda, 0x7f, 0x01, 0xef,
+	0xfc, 0x20, 0xde, 0x90, 0x78, 0xe4, 0xa1, 0xfc, 0x15, 0x34, 0x5f, 0xb6, 0x93, 0xb4, 0x0f, 0xdd,
+	0x37, 0xcf, 0xb9, 0xe7, 0xdc, 0x3b, 0xf7, 0xcc, 0x5c, 0x0f, 0x74, 0xb3, 0xe8, 0x86, 0x15, 0x62,
+	0x9a, 0xe5, 0x4c, 0x30, 0xd4, 0xd4, 0x2b, 0x0f, 0x36, 0x6c, 0xc3, 0x34, 0xe6, 0x8d, 0x36, 0x8c,
+	0x6d, 0x12, 0x3a, 0x53, 0xab, 0x55, 0xf1, 0xcb, 0x4c, 0xc4, 0x5b, 0xca, 0x45, 0xb4, 0xcd, 0x34,
+	0xc1, 0x1f, 0x40, 0xef, 0xc7, 0x28, 0x49, 0xa8, 0x08, 0xe8, 0xaf, 0x05, 0xe5, 0xc2, 0x9f, 0x40,
+	0xdf, 0x02, 0x3c, 0x63, 0x29, 0xa7, 0xe8, 0x02, 0x9a, 0x2f, 0x15, 0x82, 0x9d, 0xb1, 0x33, 0x69,
+	0x07, 0x66, 0xe5, 0x5f, 0xc1, 0x60, 0x19, 0xdd, 0x70, 0x51, 0xac, 0xb8, 0x11, 0xa3, 0xf7, 0xa1,
+	0x9b, 0xd1, 0x3c, 0x66, 0x24, 0xe4, 0x22, 0xca, 0xad, 0xa0, 0xa3, 0xb1, 0x2b, 0x09, 0xa1, 0x07,
+	0x00, 0x86, 0x42, 0x53, 0x82, 0x8f, 0x14, 0xa1, 0xad, 0x91, 0xc7, 0x29, 0xf1, 0xbf, 0x81, 0xd3,
+	0x2a, 0xa9, 0xd9, 0xc0, 0xc7, 0xe0, 0x66, 0x06, 0xc3, 0xce, 0xb8, 0x31, 0xe9, 0xcc, 0x07, 0x53,
+	0xd3, 0xb9, 0xe1, 0x06, 0x25, 0xc1, 0xff, 0xfb, 0x04, 0x5a, 0x06, 0x45, 0x9f, 0x41, 0x97, 0x47,
+	0x82, 0x26, 0x49, 0x2c, 0x68, 0x18, 0x13, 0xb5, 0x9d, 0xee, 0x65, 0xff, 0xaf, 0x57, 0xa3, 0x77,
+	0xfe, 0x7d, 0x35, 0x6a, 0x7e, 0xcf, 0x08, 0x7d, 0xf6, 0x28, 0xe8, 0x94, 0x9c, 0x67, 0x44, 0x36,
+	0xab, 0x37, 0x63, 0xb6, 0x66, 0x56, 0xe8, 0x6b, 0x68, 0xad, 0x73, 0x1a, 0x09, 0x4a, 0x70, 0x63,
+	0xec, 0x4c, 0x3a, 0x73, 0x6f, 0xaa, 0xad, 0x9d, 0x5a, 0x6b, 0xa7, 0x3f, 0x58, 0x6b, 0x2f, 0x5d,
+	0x59, 0xe1, 0xf7, 0xff, 0x46, 0x4e, 0x60, 0x45, 0x68, 0x08, 0x27, 0x6b, 0x46, 0x28, 0xc7, 0xc7,
+	0x2a, 0xad, 0x5e, 0x20, 0x1f, 0x7a, 0x05, 0x8f, 0x36, 0x34, 0x8c, 0x44, 0x98, 0x53, 0x2e, 0xf0,
+	0xc9, 0xd8, 0x99, 0x38, 0x41, 0x47, 0x81, 0x0b, 0x79, 0x04, 0x02, 0xdd, 0x83, 0xb6, 0xe6, 0x6c,
+	0xa8, 0xc0, 0xcd, 0xb1, 0x33, 0x69, 0x04, 0xae, 0x02, 0x9e, 0xd0, 0x5a, 0x30, 0x2b, 0x04, 0x6e,
+	0xd5, 0x82, 0xcb, 0x42, 0xa0, 0x09, 0x9c, 0x96, 0xca, 0x30, 0xa7, 0x59, 0x14, 0xe7, 0xd8, 0x55,
+	0x9c, 0xbe, 0x4d, 0x10, 0x28, 0xb4, 0x62, 0x66, 0x45, 0xc9, 0x6c, 0xd7, 0x98, 0xcb, 0xc2, 0x32,
+	0x1f, 0xc2, 0xa0, 0xca, 0x19, 0x15, 0x24, 0x16, 0x18, 0x14, 0xb1, 0x67, 0x53, 0x2e, 0x24, 0x88,
+	0xc6, 0xd0, 0x5d, 0xb3, 0x6d, 0x56, 0x36, 0xd6, 0x51, 0x24, 0x90, 0x98, 0xe9, 0xeb, 0x5d, 0x70,
+	0x15, 0x43, 0xb6, 0xd5, 0x55, 0xd1, 0x96, 0x5c, 0x3f, 0xa1, 0x55, 0x48, 0x36, 0xd5, 0xab, 0x42,
+	0xb2, 0xa7, 0x87, 0x30, 0xb0, 0x2a, 0xbb, 0xd1, 0xbe, 0xae, 0x6f, 0xc4, 0xd5, 0x3e, 0x6d, 0x0a,
+	0xcb, 0x1b, 0x54, 0xbc, 0xaa, 0x9f, 0x0f, 0xa1, 0x5f, 0xe6, 0xd3, 0xed, 0x9c, 0x2a, 0x5a, 0xd7,
+	0xa4, 0xd3, 0xdd, 0x7c, 0x00, 0x3d, 0x5e, 0xe4, 0xd2, 0x1f, 0x9a, 0xaf, 0x69, 0x2a, 0xf0, 0x1d,
+	0x4d, 0x52, 0xe0, 0x52, 0x63, 0x08, 0xc1, 0xf1, 0x35, 0x4d, 0x08, 0x46, 0x2a, 0xa6, 0xbe, 0x25,
+	0xc6, 0x5e, 0x52, 0x82, 0xcf, 0x34, 0x26, 0xbf, 0x91, 0x07, 0x2e, 0x89, 0x79, 0xc6, 0x38, 0x25,
+	0x78, 0xa8, 0x8f, 0xcc, 0xae, 0x25, 0x3f, 0x8b, 0x62, 0x82, 0xcf, 0x35, 0x5f, 0x7e, 0xfb, 0x43,
+	0x40, 0x4f, 0x69, 0x42, 0x9e, 0xc6, 0x5c, 0xb0, 0xfc, 0xc6, 0xce, 0xe9, 0x73, 0x38, 0xdb, 0x41,
+	0xcd, 0xac, 0x7c, 0x01, 0xad, 0x6b, 0x0d, 0x99, 0x51, 0xb9, 0x6f, 0x47, 0xe5, 0xca, 0xde, 0xf2,
+	0xba, 0xcc, 0x92, 0xfd, 0x7f, 0x1c, 0x18, 0xbe, 0x8e, 0x71, 0x9b, 0x19, 0x7a, 0x00, 0x20, 0x98,
+	0x88, 0x92, 0x50, 0xd9, 0x71, 0xa4, 0x5a, 0x69, 0x2b, 0x44, 0x26, 0x46, 0x1f, 0x41, 0x5f, 0x87,
+	0x4b, 0x17, 0x1a, 0xfa, 0x64, 0x14, 0xfa, 0xc8, 0x5a, 0xb1, 0x80, 0xf6, 0x0b, 0x16, 0xa7, 0x94,
+	0x84, 0x91, 0xc0, 0xc7, 0x6f, 0x31, 0x73, 0xae, 0x96, 0x2d, 0x84, 0x8f, 0xe1, 0xe2, 0x31, 0x17,
+	0xf1, 0x56, 0x4e, 0xe0, 0x52, 0xb9, 0x60, 0xdd, 0xfb, 0x19, 0xee, 0x1e, 0x44, 0x8c, 0x83, 0xdf,
+	0x02, 0x94, 0xcd, 0xd8, 0xff, 0xcd, 0xd8, 0x9a, 0x58, 0x8a, 0x4a, 0xaf, 0x8c, 0xba, 0xa6, 0xf1,
+	0xff, 0x38, 0x02, 0xfc, 0x26, 0xe2, 0x6d, 0xfc, 0xfc, 0x14, 0x86, 0xeb, 0x22, 0xcf, 0x69, 0x2a,
+	0xc2, 0x2d, 0x4b, 0xc5, 0x75, 0xa8, 0x37, 0xa3, 0x9c, 0x75, 0x02, 0x64, 0x62, 0xcf, 0x65, 0xc8,
+	0x14, 0xf9, 0x04, 0xd0, 0xae, 0x42, 0x9d, 0x44, 0x43, 0xf1, 0x4f, 0xeb, 0x7c, 0x75, 0x20, 0x73,
+	0x38, 0xcf, 0x72, 0xfa, 0x5b, 0xcc, 0x0a, 0xbe, 0x5b, 0xe0, 0x58, 0x09, 0xce, 0x6c, 0xb0, 0x5e,
+	0x61, 0x0a, 0x67, 0x7b, 0x1a, 0x55, 0x42, 0xff, 0xbf, 0xee, 0xec, 0x28, 0x64, 0x8d, 0xf9, 0x9f,
+	0x47, 0xd0, 0x34, 0xd2, 0x2f, 0xa1, 0xa9, 0x5f, 0x18, 0x74, 0x6e, 0x6d, 0xdd, 0x79, 0x82, 0xbc,
+	0x8b, 0x7d, 0xd8, 0x9c, 0xcc, 0x57, 0xe0, 0xda, 0xb7, 0x01, 0xdd, 0xdd, 0x7b, 0x01, 0xec, 0x13,
+	0xe4, 0xe1, 0xc3, 0x80, 0x91, 0x7f, 0x07, 0x9d, 0xfa, 0xc5, 0xf6, 0x2c, 0xf1, 0x70, 0xb8, 0xbc,
+	0x7b, 0xaf, 0x8d, 0x99, 0x3c, 0x01, 0x0c, 0xf6, 0xee, 0x0e, 0x7a, 0xef, 0xe0, 0x7e, 0xec, 0x5c,
+	0x37, 0x6f, 0xf4, 0xc6, 0xb8, 0xce, 0x79, 0x79, 0xff, 0x27, 0x4f, 0x16, 0x79, 0x31, 0x8d, 0xd9,
+	0x4c, 0x7d, 0xcc, 0xb6, 0x45, 0x22, 0xe2, 0x94, 0x11, 0x9a, 0xad, 0x56, 0x4d, 0x75, 0xdf, 0x3f,
+	0xff, 0x7f, 0x00, 0x49, 0xaf, 0xbf, 0xde, 0xf0, 0x07, 0x00, 0x00,
+}
+
+// --- DRPC BEGIN ---
+
+type DRPCPayoutClient interface {
+	DRPCConn() drpc.Conn
+
+	Wallet(ctx context.Context, in *WalletRequest) (*WalletResponse, error)
+	Paystubs(ctx context.Context, in *PaystubsRequest) (*PaystubsResponse, error)
+	HeldHistory(ctx context.Context, in *HeldHistoryRequest) (*HeldHistoryResponse, error)
+	EstimatedPayout(ctx context.Context, in *EstimatedPayoutRequest) (*EstimatedPayoutResponse, error)
+}
+
+type drpcPayoutClient struct {
+	cc drpc.Conn
+}
+
+func NewDRPCPayoutClient(cc drpc.Conn) DRPCPayoutClient {
+	return &drpcPayoutClient{cc}
+}
+
+func (c *drpcPayoutClient) DRPCConn() drpc.Conn { return c.cc }
+
+func (c *drpcPayoutClient) Wallet(ctx context.Context, in *WalletRequest) (*WalletResponse, error) {
+	out := new(WalletResponse)
+	err := c.cc.Invoke(ctx, "/payout.Payout/Wallet", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcPayoutClient) Paystubs(ctx context.Context, in *PaystubsRequest) (*PaystubsResponse, error) {
+	out := new(PaystubsResponse)
+	err := c.cc.Invoke(ctx, "/payout.Payout/Paystubs", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcPayoutClient) HeldHistory(ctx context.Context, in *HeldHistoryRequest) (*HeldHistoryResponse, error) {
+	out := new(HeldHistoryResponse)
+	err := c.cc.Invoke(ctx, "/payout.Payout/HeldHistory", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcPayoutClient) EstimatedPayout(ctx context.Context, in *EstimatedPayoutRequest) (*EstimatedPayoutResponse, error) {
+	out := new(EstimatedPayoutResponse)
+	err := c.cc.Invoke(ctx, "/payout.Payout/EstimatedPayout", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type DRPCPayoutServer interface {
+	Wallet(context.Context, *WalletRequest) (*WalletResponse, error)
+	Paystubs(context.Context, *PaystubsRequest) (*PaystubsResponse, error)
+	HeldHistory(context.Context, *HeldHistoryRequest) (*HeldHistoryResponse, error)
+	EstimatedPayout(context.Context, *EstimatedPayoutRequest) (*EstimatedPayoutResponse, error)
+}
+
+type DRPCPayoutDescription struct{}
+
+func (DRPCPayoutDescription) NumMethods() int { return 4 }
+
+func (DRPCPayoutDescription) Method(n int) (string, drpc.Receiver, interface{}, bool) {
+	switch n {
+	case 0:
+		return "/payout.Payout/Wallet",
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCPayoutServer).
+					Wallet(
+						ctx,
+						in1.(*WalletRequest),
+					)
+			}, DRPCPayoutServer.Wallet, true
+	case 1:
+		return "/payout.Payout/Paystubs",
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCPayoutServer).
+					Paystubs(
+						ctx,
+						in1.(*PaystubsRequest),
+					)
+			}, DRPCPayoutServer.Paystubs, true
+	case 2:
+		return "/payout.Payout/HeldHistory",
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCPayoutServer).
+					HeldHistory(
+						ctx,
+						in1.(*HeldHistoryRequest),
+					)
+			}, DRPCPayoutServer.HeldHistory, true
+	case 3:
+		return "/payout.Payout/EstimatedPayout",
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCPayoutServer).
+					EstimatedPayout(
+						ctx,
+						in1.(*EstimatedPayoutRequest),
+					)
+			}, DRPCPayoutServer.EstimatedPayout, true
+	default:
+		return "", nil, nil, false
+	}
+}
+
+func DRPCRegisterPayout(mux drpc.Mux, impl DRPCPayoutServer) error {
+	return mux.Register(impl, DRPCPayoutDescription{})
+}
+
+type DRPCPayout_WalletStream interface {
+	drpc.Stream
+	SendAndClose(*WalletResponse) error
+}
+
+type drpcPayoutWalletStream struct {
+	drpc.Stream
+}
+
+func (x *drpcPayoutWalletStream) SendAndClose(m *WalletResponse) error {
+	if err := x.MsgSend(m); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCPayout_PaystubsStream interface {
+	drpc.Stream
+	SendAndClose(*PaystubsResponse) error
+}
+
+type drpcPayoutPaystubsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcPayoutPaystubsStream) SendAndClose(m *PaystubsResponse) error {
+	if err := x.MsgSend(m); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCPayout_HeldHistoryStream interface {
+	drpc.Stream
+	SendAndClose(*HeldHistoryResponse) error
+}
+
+type drpcPayoutHeldHistoryStream struct {
+	drpc.Stream
+}
+
+func (x *drpcPayoutHeldHistoryStream) SendAndClose(m *HeldHistoryResponse) error {
+	if err := x.MsgSend(m); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCPayout_EstimatedPayoutStream interface {
+	drpc.Stream
+	SendAndClose(*EstimatedPayoutResponse) error
+}
+
+type drpcPayoutEstimatedPayoutStream struct {
+	drpc.Stream
+}
+
+func (x *drpcPayoutEstimatedPayoutStream) SendAndClose(m *EstimatedPayoutResponse) error {
+	if err := x.MsgSend(m); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+// --- DRPC END ---
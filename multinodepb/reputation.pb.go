@@ -159,6 +159,7 @@ type GetBySatelliteIDResponse struct {
 	OfflineSuspended     *time.Time       `protobuf:"bytes,5,opt,name=offline_suspended,json=offlineSuspended,proto3,stdtime" json:"offline_suspended,omitempty"`
 	OnlineScore          float64          `protobuf:"fixed64,6,opt,name=online_score,json=onlineScore,proto3" json:"online_score,omitempty"`
 	OfflineUnderReview   *time.Time       `protobuf:"bytes,7,opt,name=offline_under_review,json=offlineUnderReview,proto3,stdtime" json:"offline_under_review,omitempty"`
+	SatelliteId          NodeID           `protobuf:"bytes,8,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
 	XXX_sizecache        int32            `json:"-"`
@@ -316,44 +317,44 @@ func init() {
 func init() { proto.RegisterFile("reputation.proto", fileDescriptor_b35a2508345eddf0) }
 
 var fileDescriptor_b35a2508345eddf0 = []byte{
-	// 584 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xc1, 0x6e, 0xd3, 0x40,
-	0x14, 0xac, 0x31, 0x2d, 0xed, 0xb3, 0x69, 0xcb, 0x0a, 0x5a, 0xcb, 0x20, 0xb9, 0xa4, 0x48, 0x84,
-	0x8b, 0x23, 0x82, 0x54, 0x71, 0xe0, 0x12, 0xb7, 0x12, 0x54, 0x42, 0x48, 0x38, 0xc0, 0x01, 0x09,
-	0x59, 0x1b, 0x7b, 0x93, 0x6e, 0xbb, 0xd9, 0x75, 0xbd, 0x6b, 0x2a, 0xae, 0x7c, 0x01, 0xff, 0xc0,
-	0xcf, 0xf4, 0x1b, 0x38, 0x84, 0xcf, 0xe0, 0x8a, 0xbc, 0x76, 0x62, 0x93, 0xb4, 0x10, 0x6e, 0xde,
-	0xf1, 0xcc, 0xbc, 0xb1, 0xdf, 0x68, 0x61, 0x3b, 0x23, 0x69, 0xae, 0xb0, 0xa2, 0x82, 0xfb, 0x69,
-	0x26, 0x94, 0x40, 0x50, 0x23, 0x2e, 0x8c, 0xc4, 0x48, 0x94, 0xb8, 0xeb, 0x8d, 0x84, 0x18, 0x31,
-	0xd2, 0xd1, 0xa7, 0x41, 0x3e, 0xec, 0x28, 0x3a, 0x26, 0x52, 0xe1, 0x71, 0x5a, 0x12, 0x5a, 0x5f,
-	0x4d, 0xd8, 0x0a, 0x67, 0xda, 0xbe, 0xc2, 0x4a, 0x22, 0x0f, 0x2c, 0x25, 0x14, 0x66, 0x51, 0x2c,
-	0x72, 0xae, 0x1c, 0x63, 0xcf, 0x68, 0x9b, 0x21, 0x68, 0xe8, 0xb0, 0x40, 0xd0, 0x3e, 0xdc, 0x96,
-	0x79, 0x1c, 0x13, 0x29, 0x2b, 0xca, 0x0d, 0x4d, 0xb1, 0x2b, 0xb0, 0x24, 0x3d, 0x69, 0xc6, 0x8c,
-	0x30, 0x4b, 0x4f, 0xb0, 0x63, 0xee, 0x19, 0x6d, 0x23, 0xdc, 0xaa, 0xf1, 0x5e, 0x01, 0xa3, 0xc7,
-	0xd0, 0x80, 0xa2, 0x01, 0x51, 0xd8, 0xb9, 0xa9, 0x99, 0x9b, 0x35, 0x1c, 0x10, 0x85, 0xe7, 0x3c,
-	0x65, 0x2c, 0x32, 0xe2, 0xac, 0xce, 0x7b, 0xf6, 0x0b, 0x18, 0x3d, 0x07, 0x27, 0xe7, 0x67, 0x5c,
-	0x5c, 0xf0, 0x68, 0x21, 0xc6, 0x9a, 0x96, 0xec, 0x54, 0xef, 0xc3, 0xb9, 0x34, 0x07, 0xb0, 0x7b,
-	0x85, 0x52, 0xa7, 0xba, 0xa5, 0x85, 0xf7, 0x16, 0x84, 0x3a, 0xdc, 0xd5, 0x13, 0xcb, 0x90, 0xeb,
-	0xd7, 0x4c, 0xd4, 0x59, 0x5b, 0xaf, 0x61, 0xf7, 0x25, 0x51, 0xc1, 0x97, 0x3e, 0x56, 0x84, 0x31,
-	0xaa, 0xc8, 0xf1, 0x51, 0x48, 0xce, 0x73, 0x22, 0x15, 0x7a, 0x0a, 0xb6, 0x9c, 0xa2, 0x11, 0x4d,
-	0xf4, 0x32, 0xec, 0x60, 0xf3, 0x72, 0xe2, 0xad, 0xfc, 0x98, 0x78, 0x6b, 0x6f, 0x44, 0x52, 0x90,
-	0xad, 0x19, 0xe7, 0x38, 0x69, 0xfd, 0x32, 0xc1, 0x59, 0xb4, 0x93, 0xa9, 0xe0, 0x92, 0xa0, 0x17,
-	0x60, 0xe1, 0x3c, 0xa1, 0x2a, 0x8a, 0x4f, 0x48, 0x7c, 0xa6, 0xed, 0xac, 0xee, 0x7d, 0xbf, 0x51,
-	0xa8, 0xb9, 0x36, 0x84, 0xa0, 0xf9, 0x87, 0x05, 0x1d, 0xbd, 0x02, 0x3b, 0xa1, 0xf2, 0x3c, 0xc7,
-	0x8c, 0x0e, 0x29, 0x49, 0xf4, 0xde, 0xad, 0xae, 0xeb, 0x97, 0x2d, 0xf3, 0xa7, 0x2d, 0xf3, 0xdf,
-	0x4d, 0x5b, 0x16, 0xac, 0x5f, 0x4e, 0x3c, 0xe3, 0xdb, 0x4f, 0xcf, 0x08, 0xff, 0x50, 0xa2, 0x00,
-	0x36, 0x64, 0x2e, 0x53, 0xc2, 0x13, 0x92, 0xe8, 0x5a, 0x2c, 0x6b, 0x53, 0xcb, 0x50, 0x0f, 0x36,
-	0x4e, 0x05, 0xe5, 0x24, 0x89, 0xb0, 0xd2, 0x85, 0xf9, 0xb7, 0xc7, 0x8a, 0xf6, 0x58, 0x2f, 0x65,
-	0x3d, 0x85, 0xde, 0xc2, 0x1d, 0x31, 0x1c, 0x32, 0xca, 0x49, 0x54, 0xc7, 0x59, 0xfd, 0x8f, 0x38,
-	0xdb, 0x95, 0xbc, 0x3f, 0x4b, 0xf5, 0x10, 0x6c, 0xc1, 0x4b, 0x47, 0xbd, 0xfa, 0xb2, 0x6c, 0x56,
-	0x89, 0x95, 0xdd, 0xfc, 0x00, 0x77, 0xa7, 0x53, 0x73, 0x9e, 0x90, 0x2c, 0xca, 0xc8, 0x67, 0x4a,
-	0x2e, 0x74, 0xbd, 0x96, 0x1d, 0x8c, 0x2a, 0x87, 0xf7, 0x85, 0x41, 0xa8, 0xf5, 0x2d, 0x1b, 0xa0,
-	0xc7, 0x58, 0x55, 0x9d, 0x56, 0x1f, 0x2c, 0x7d, 0xaa, 0x36, 0x7f, 0x04, 0x8d, 0x4b, 0xc2, 0x31,
-	0xf6, 0xcc, 0xb6, 0xd5, 0x7d, 0xd4, 0x5c, 0xfc, 0x75, 0x9d, 0x09, 0x1b, 0xba, 0xee, 0x77, 0x03,
-	0xa0, 0x6e, 0x08, 0xfa, 0x04, 0xdb, 0xf3, 0x32, 0xb4, 0xff, 0x77, 0x53, 0x1d, 0xce, 0x5d, 0x6a,
-	0x32, 0x3a, 0x00, 0xb3, 0xc7, 0x18, 0xda, 0x69, 0x92, 0xeb, 0x2f, 0x74, 0x77, 0x17, 0xf0, 0x52,
-	0x17, 0x3c, 0xf8, 0xe8, 0x4a, 0x25, 0xb2, 0x53, 0x9f, 0x8a, 0x8e, 0x7e, 0xe8, 0x8c, 0x73, 0xa6,
-	0x28, 0x17, 0x09, 0x49, 0x07, 0x83, 0x35, 0xfd, 0x63, 0x9f, 0xfd, 0x0e, 0x00, 0x00, 0xff, 0xff,
-	0x13, 0x5c, 0xf1, 0xa4, 0x47, 0x05, 0x00, 0x00,
+	// 587 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xcd, 0x6e, 0xd3, 0x4c,
+	0x14, 0xad, 0x3f, 0xf7, 0x27, 0xbd, 0xf6, 0xd7, 0x96, 0x11, 0xb4, 0x96, 0x41, 0x72, 0x49, 0x91,
+	0x28, 0x1b, 0x47, 0x04, 0xa9, 0x62, 0xc1, 0x26, 0x6e, 0x25, 0xa8, 0x84, 0x90, 0x70, 0x80, 0x05,
+	0x12, 0xb2, 0x26, 0xf6, 0x24, 0x9d, 0x76, 0x3a, 0xe3, 0x7a, 0xc6, 0x54, 0x6c, 0x79, 0x02, 0xde,
+	0x81, 0x1d, 0x4f, 0xd2, 0x67, 0x60, 0x11, 0x5e, 0x05, 0x79, 0xec, 0xc4, 0x26, 0x69, 0xa1, 0xd9,
+	0xf9, 0x1e, 0x9f, 0x73, 0xee, 0xb1, 0xee, 0x91, 0x61, 0x2b, 0x23, 0x69, 0xae, 0xb0, 0xa2, 0x82,
+	0xfb, 0x69, 0x26, 0x94, 0x40, 0x50, 0x23, 0x2e, 0x8c, 0xc4, 0x48, 0x94, 0xb8, 0xeb, 0x8d, 0x84,
+	0x18, 0x31, 0xd2, 0xd1, 0xd3, 0x20, 0x1f, 0x76, 0x14, 0x3d, 0x27, 0x52, 0xe1, 0xf3, 0xb4, 0x24,
+	0xb4, 0xbf, 0x9a, 0xb0, 0x19, 0x4e, 0xb5, 0x7d, 0x85, 0x95, 0x44, 0x1e, 0x58, 0x4a, 0x28, 0xcc,
+	0xa2, 0x58, 0xe4, 0x5c, 0x39, 0xc6, 0xae, 0xb1, 0x6f, 0x86, 0xa0, 0xa1, 0xc3, 0x02, 0x41, 0x7b,
+	0xf0, 0xbf, 0xcc, 0xe3, 0x98, 0x48, 0x59, 0x51, 0xfe, 0xd3, 0x14, 0xbb, 0x02, 0x4b, 0xd2, 0x93,
+	0x66, 0xcc, 0x08, 0xb3, 0xf4, 0x04, 0x3b, 0xe6, 0xae, 0xb1, 0x6f, 0x84, 0x9b, 0x35, 0xde, 0x2b,
+	0x60, 0xf4, 0x18, 0x1a, 0x50, 0x34, 0x20, 0x0a, 0x3b, 0xcb, 0x9a, 0xb9, 0x51, 0xc3, 0x01, 0x51,
+	0x78, 0xc6, 0x53, 0xc6, 0x22, 0x23, 0xce, 0xca, 0xac, 0x67, 0xbf, 0x80, 0xd1, 0x73, 0x70, 0x72,
+	0x7e, 0xc6, 0xc5, 0x25, 0x8f, 0xe6, 0x62, 0xac, 0x6a, 0xc9, 0x76, 0xf5, 0x3e, 0x9c, 0x49, 0x73,
+	0x00, 0x3b, 0xd7, 0x28, 0x75, 0xaa, 0x35, 0x2d, 0xbc, 0x37, 0x27, 0xd4, 0xe1, 0xae, 0xdf, 0x58,
+	0x86, 0x6c, 0xdd, 0xb0, 0x51, 0x67, 0x6d, 0xbf, 0x86, 0x9d, 0x97, 0x44, 0x05, 0x5f, 0xfa, 0x58,
+	0x11, 0xc6, 0xa8, 0x22, 0xc7, 0x47, 0x21, 0xb9, 0xc8, 0x89, 0x54, 0xe8, 0x29, 0xd8, 0x72, 0x82,
+	0x46, 0x34, 0xd1, 0xc7, 0xb0, 0x83, 0x8d, 0xab, 0xb1, 0xb7, 0xf4, 0x73, 0xec, 0xad, 0xbe, 0x11,
+	0x49, 0x41, 0xb6, 0xa6, 0x9c, 0xe3, 0xa4, 0xfd, 0x63, 0x19, 0x9c, 0x79, 0x3b, 0x99, 0x0a, 0x2e,
+	0x09, 0x7a, 0x01, 0x16, 0xce, 0x13, 0xaa, 0xa2, 0xf8, 0x84, 0xc4, 0x67, 0xda, 0xce, 0xea, 0xde,
+	0xf7, 0x1b, 0x85, 0x9a, 0x69, 0x43, 0x08, 0x9a, 0x7f, 0x58, 0xd0, 0xd1, 0x2b, 0xb0, 0x13, 0x2a,
+	0x2f, 0x72, 0xcc, 0xe8, 0x90, 0x92, 0x44, 0xdf, 0xdd, 0xea, 0xba, 0x7e, 0xd9, 0x32, 0x7f, 0xd2,
+	0x32, 0xff, 0xdd, 0xa4, 0x65, 0x41, 0xeb, 0x6a, 0xec, 0x19, 0xdf, 0x7e, 0x79, 0x46, 0xf8, 0x87,
+	0x12, 0x05, 0xb0, 0x2e, 0x73, 0x99, 0x12, 0x9e, 0x90, 0xc4, 0x31, 0x17, 0xb0, 0xa9, 0x65, 0xa8,
+	0x07, 0xeb, 0xa7, 0x82, 0x72, 0x92, 0x44, 0x58, 0x39, 0xcb, 0xb7, 0xf2, 0x58, 0xd2, 0x1e, 0xad,
+	0x52, 0xd6, 0x53, 0xe8, 0x2d, 0xdc, 0x11, 0xc3, 0x21, 0xa3, 0x9c, 0x44, 0x75, 0x9c, 0x95, 0x05,
+	0xe2, 0x6c, 0x55, 0xf2, 0xfe, 0x34, 0xd5, 0x43, 0xb0, 0x05, 0x2f, 0x1d, 0xf5, 0xe9, 0xcb, 0xb2,
+	0x59, 0x25, 0x56, 0x76, 0xf3, 0x03, 0xdc, 0x9d, 0x6c, 0xcd, 0x79, 0x42, 0xb2, 0x28, 0x23, 0x9f,
+	0x29, 0xb9, 0x74, 0xd6, 0x16, 0x58, 0x8c, 0x2a, 0x87, 0xf7, 0x85, 0x41, 0xa8, 0xf5, 0x73, 0x65,
+	0x69, 0xfd, 0xbb, 0x2c, 0x36, 0x40, 0x8f, 0xb1, 0xaa, 0x6d, 0xed, 0x3e, 0x58, 0x7a, 0xaa, 0xca,
+	0x72, 0x04, 0x8d, 0xff, 0x8a, 0x63, 0xec, 0x9a, 0xfb, 0x56, 0xf7, 0x51, 0xb3, 0x2b, 0x37, 0xd5,
+	0x2c, 0x6c, 0xe8, 0xba, 0xdf, 0x0d, 0x80, 0xba, 0x54, 0xe8, 0x13, 0x6c, 0xcd, 0xca, 0xd0, 0xde,
+	0xdf, 0x4d, 0x75, 0x38, 0xf7, 0x56, 0x9b, 0xd1, 0x01, 0x98, 0x3d, 0xc6, 0xd0, 0x76, 0x93, 0x5c,
+	0x7f, 0xa1, 0xbb, 0x33, 0x87, 0x97, 0xba, 0xe0, 0xc1, 0x47, 0x57, 0x2a, 0x91, 0x9d, 0xfa, 0x54,
+	0x74, 0xf4, 0x43, 0xe7, 0x3c, 0x67, 0x8a, 0x72, 0x91, 0x90, 0x74, 0x30, 0x58, 0xd5, 0xb7, 0x78,
+	0xf6, 0x7b, 0x00, 0x56, 0x42, 0x46, 0xb2, 0x7a, 0x05, 0x00, 0x00,
 }
 
 // --- DRPC BEGIN ---
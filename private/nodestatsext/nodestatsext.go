@@ -0,0 +1,315 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package nodestatsext carries additional node stats, such as the vetting
+// progress and the audit history of a storage node, alongside the node stats
+// response of a satellite.
+//
+// The node stats protocol is defined in storj.io/common, so the additional
+// stats are appended to the response as fields which are unknown to the
+// generated message. Nodes which do not know about them ignore the fields.
+package nodestatsext
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/pb"
+)
+
+// Error is the default error class for nodestatsext.
+var Error = errs.Class("nodestatsext")
+
+// field numbers are far from the ones used by GetStatsResponse to leave room
+// for the fields added to the message upstream.
+const (
+	vettedAtField     = 1001
+	auditCountField   = 1002
+	auditHistoryField = 1003
+)
+
+// field numbers of the audit history, matching the audit history kept by
+// the satellite.
+const (
+	historyWindowsField = 1
+	historyScoreField   = 2
+
+	windowStartField       = 1
+	windowOnlineCountField = 2
+	windowTotalCountField  = 3
+)
+
+// Extensions are the stats attached to the node stats response.
+type Extensions struct {
+	// Vetting is nil when the satellite did not attach it.
+	Vetting *Vetting
+	// AuditHistory is nil when the satellite did not attach it.
+	AuditHistory *AuditHistory
+}
+
+// Vetting is the vetting progress of a node on a satellite.
+type Vetting struct {
+	// VettedAt is when the satellite vetted the node, nil while the node is
+	// still new.
+	VettedAt *time.Time
+	// AuditCount is the number of audits the satellite requires to vet a node.
+	AuditCount int64
+}
+
+// AuditHistory is the recent audit history of a node on a satellite, which
+// the satellite uses to calculate the online score.
+type AuditHistory struct {
+	Windows []AuditWindow `json:"windows"`
+	Score   float64       `json:"score"`
+}
+
+// AuditWindow counts the audits of a node during a single window.
+type AuditWindow struct {
+	WindowStart time.Time `json:"windowStart"`
+	TotalCount  int32     `json:"totalCount"`
+	OnlineCount int32     `json:"onlineCount"`
+}
+
+// Attach appends the extensions to the response.
+func Attach(response *pb.GetStatsResponse, extensions Extensions) error {
+	buffer := proto.NewBuffer(nil)
+
+	if vetting := extensions.Vetting; vetting != nil {
+		if vetting.VettedAt != nil {
+			if err := encodeTimestamp(buffer, vettedAtField, *vetting.VettedAt); err != nil {
+				return err
+			}
+		}
+		if err := encodeVarint(buffer, auditCountField, uint64(vetting.AuditCount)); err != nil {
+			return err
+		}
+	}
+
+	if extensions.AuditHistory != nil {
+		data, err := MarshalAuditHistory(*extensions.AuditHistory)
+		if err != nil {
+			return err
+		}
+		if err := encodeBytes(buffer, auditHistoryField, data); err != nil {
+			return err
+		}
+	}
+
+	response.XXX_unrecognized = append(response.XXX_unrecognized, buffer.Bytes()...)
+	return nil
+}
+
+// FromResponse returns the extensions attached to the response.
+func FromResponse(response *pb.GetStatsResponse) (_ Extensions, err error) {
+	var extensions Extensions
+	var vetting Vetting
+	var hasVetting bool
+
+	err = decode(response.XXX_unrecognized, func(field rawField) error {
+		switch field.key() {
+		case vettedAtField<<3 | proto.WireBytes:
+			vettedAt, err := decodeTimestamp(field.value)
+			if err != nil {
+				return err
+			}
+			vetting.VettedAt = &vettedAt
+		case auditCountField<<3 | proto.WireVarint:
+			vetting.AuditCount = int64(field.varint)
+			hasVetting = true
+		case auditHistoryField<<3 | proto.WireBytes:
+			history, err := UnmarshalAuditHistory(field.value)
+			if err != nil {
+				return err
+			}
+			extensions.AuditHistory = &history
+		}
+		return nil
+	})
+	if err != nil {
+		return Extensions{}, err
+	}
+
+	if hasVetting {
+		extensions.Vetting = &vetting
+	}
+	return extensions, nil
+}
+
+// MarshalAuditHistory encodes the audit history.
+func MarshalAuditHistory(history AuditHistory) (_ []byte, err error) {
+	buffer := proto.NewBuffer(nil)
+
+	for _, window := range history.Windows {
+		windowBuffer := proto.NewBuffer(nil)
+		if err := encodeTimestamp(windowBuffer, windowStartField, window.WindowStart); err != nil {
+			return nil, err
+		}
+		if err := encodeVarint(windowBuffer, windowOnlineCountField, uint64(window.OnlineCount)); err != nil {
+			return nil, err
+		}
+		if err := encodeVarint(windowBuffer, windowTotalCountField, uint64(window.TotalCount)); err != nil {
+			return nil, err
+		}
+		if err := encodeBytes(buffer, historyWindowsField, windowBuffer.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := buffer.EncodeVarint(historyScoreField<<3 | proto.WireFixed64); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if err := buffer.EncodeFixed64(math.Float64bits(history.Score)); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalAuditHistory decodes the audit history.
+func UnmarshalAuditHistory(data []byte) (_ AuditHistory, err error) {
+	var history AuditHistory
+
+	err = decode(data, func(field rawField) error {
+		switch field.key() {
+		case historyWindowsField<<3 | proto.WireBytes:
+			window, err := unmarshalAuditWindow(field.value)
+			if err != nil {
+				return err
+			}
+			history.Windows = append(history.Windows, window)
+		case historyScoreField<<3 | proto.WireFixed64:
+			history.Score = math.Float64frombits(field.varint)
+		}
+		return nil
+	})
+	if err != nil {
+		return AuditHistory{}, err
+	}
+
+	return history, nil
+}
+
+// unmarshalAuditWindow decodes a single audit window.
+func unmarshalAuditWindow(data []byte) (_ AuditWindow, err error) {
+	var window AuditWindow
+
+	err = decode(data, func(field rawField) (err error) {
+		switch field.key() {
+		case windowStartField<<3 | proto.WireBytes:
+			window.WindowStart, err = decodeTimestamp(field.value)
+		case windowOnlineCountField<<3 | proto.WireVarint:
+			window.OnlineCount = int32(field.varint)
+		case windowTotalCountField<<3 | proto.WireVarint:
+			window.TotalCount = int32(field.varint)
+		}
+		return err
+	})
+	if err != nil {
+		return AuditWindow{}, err
+	}
+
+	return window, nil
+}
+
+// rawField is a single decoded field. varint holds the value of varint and
+// fixed size fields, value holds the value of length delimited fields.
+type rawField struct {
+	number uint64
+	wire   uint64
+	varint uint64
+	value  []byte
+}
+
+// key returns the key of the field as encoded on the wire.
+func (field rawField) key() uint64 { return field.number<<3 | field.wire }
+
+// decode calls fn for every field of data.
+func decode(data []byte, fn func(rawField) error) error {
+	for len(data) > 0 {
+		key, n := proto.DecodeVarint(data)
+		if n == 0 {
+			return Error.New("invalid field key")
+		}
+		data = data[n:]
+
+		field := rawField{number: key >> 3, wire: key & 7}
+		switch field.wire {
+		case proto.WireVarint:
+			field.varint, n = proto.DecodeVarint(data)
+			if n == 0 {
+				return Error.New("invalid varint")
+			}
+		case proto.WireFixed64:
+			n = 8
+			if len(data) < n {
+				return Error.New("truncated field")
+			}
+			field.varint = binary.LittleEndian.Uint64(data)
+		case proto.WireFixed32:
+			n = 4
+			if len(data) < n {
+				return Error.New("truncated field")
+			}
+			field.varint = uint64(binary.LittleEndian.Uint32(data))
+		case proto.WireBytes:
+			length, k := proto.DecodeVarint(data)
+			if k == 0 || uint64(len(data)-k) < length {
+				return Error.New("invalid length")
+			}
+			field.value = data[k : k+int(length)]
+			n = k + int(length)
+		default:
+			return Error.New("unsupported wire type %d", field.wire)
+		}
+		data = data[n:]
+
+		if err := fn(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeVarint appends a varint field.
+func encodeVarint(buffer *proto.Buffer, number uint64, value uint64) error {
+	if err := buffer.EncodeVarint(number<<3 | proto.WireVarint); err != nil {
+		return Error.Wrap(err)
+	}
+	return Error.Wrap(buffer.EncodeVarint(value))
+}
+
+// encodeBytes appends a length delimited field.
+func encodeBytes(buffer *proto.Buffer, number uint64, value []byte) error {
+	if err := buffer.EncodeVarint(number<<3 | proto.WireBytes); err != nil {
+		return Error.Wrap(err)
+	}
+	return Error.Wrap(buffer.EncodeRawBytes(value))
+}
+
+// encodeTimestamp appends a field holding a well known timestamp.
+func encodeTimestamp(buffer *proto.Buffer, number uint64, value time.Time) error {
+	timestamp, err := types.TimestampProto(value)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	data, err := proto.Marshal(timestamp)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	return encodeBytes(buffer, number, data)
+}
+
+// decodeTimestamp decodes a well known timestamp.
+func decodeTimestamp(data []byte) (time.Time, error) {
+	var timestamp types.Timestamp
+	if err := proto.Unmarshal(data, &timestamp); err != nil {
+		return time.Time{}, Error.Wrap(err)
+	}
+	value, err := types.TimestampFromProto(&timestamp)
+	return value, Error.Wrap(err)
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nodestatsext_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/pb"
+	"storj.io/storj/private/nodestatsext"
+)
+
+func TestAttach(t *testing.T) {
+	vettedAt := time.Date(2020, 10, 1, 12, 30, 0, 5, time.UTC)
+	history := &nodestatsext.AuditHistory{
+		Windows: []nodestatsext.AuditWindow{
+			{WindowStart: time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC), TotalCount: 4, OnlineCount: 4},
+			{WindowStart: time.Date(2020, 10, 1, 13, 0, 0, 0, time.UTC), TotalCount: 3, OnlineCount: 0},
+		},
+		Score: 0.5,
+	}
+
+	for _, extensions := range []nodestatsext.Extensions{
+		{Vetting: &nodestatsext.Vetting{VettedAt: nil, AuditCount: 100}},
+		{Vetting: &nodestatsext.Vetting{VettedAt: &vettedAt, AuditCount: 50}, AuditHistory: history},
+		{AuditHistory: &nodestatsext.AuditHistory{Score: 1}},
+	} {
+		response := &pb.GetStatsResponse{
+			AuditCheck: &pb.ReputationStats{TotalCount: 70},
+			JoinedAt:   time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC),
+		}
+		require.NoError(t, nodestatsext.Attach(response, extensions))
+
+		data, err := proto.Marshal(response)
+		require.NoError(t, err)
+
+		var received pb.GetStatsResponse
+		require.NoError(t, proto.Unmarshal(data, &received))
+		require.Equal(t, int64(70), received.AuditCheck.TotalCount)
+
+		got, err := nodestatsext.FromResponse(&received)
+		require.NoError(t, err)
+
+		if extensions.Vetting == nil {
+			require.Nil(t, got.Vetting)
+		} else {
+			require.NotNil(t, got.Vetting)
+			require.Equal(t, extensions.Vetting.AuditCount, got.Vetting.AuditCount)
+			if extensions.Vetting.VettedAt == nil {
+				require.Nil(t, got.Vetting.VettedAt)
+			} else {
+				require.NotNil(t, got.Vetting.VettedAt)
+				require.True(t, extensions.Vetting.VettedAt.Equal(*got.Vetting.VettedAt))
+			}
+		}
+
+		if extensions.AuditHistory == nil {
+			require.Nil(t, got.AuditHistory)
+		} else {
+			require.NotNil(t, got.AuditHistory)
+			require.Equal(t, extensions.AuditHistory.Score, got.AuditHistory.Score)
+			require.Len(t, got.AuditHistory.Windows, len(extensions.AuditHistory.Windows))
+			for i, window := range extensions.AuditHistory.Windows {
+				require.True(t, window.WindowStart.Equal(got.AuditHistory.Windows[i].WindowStart))
+				require.Equal(t, window.TotalCount, got.AuditHistory.Windows[i].TotalCount)
+				require.Equal(t, window.OnlineCount, got.AuditHistory.Windows[i].OnlineCount)
+			}
+		}
+	}
+}
+
+func TestFromResponseMissing(t *testing.T) {
+	data, err := proto.Marshal(&pb.GetStatsResponse{OnlineScore: 1})
+	require.NoError(t, err)
+
+	var received pb.GetStatsResponse
+	require.NoError(t, proto.Unmarshal(data, &received))
+
+	extensions, err := nodestatsext.FromResponse(&received)
+	require.NoError(t, err)
+	require.Equal(t, nodestatsext.Extensions{}, extensions)
+}
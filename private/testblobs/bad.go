@@ -240,6 +240,14 @@ func (bad *BadBlobs) SpaceUsedForTrash(ctx context.Context) (int64, error) {
 	return bad.blobs.SpaceUsedForTrash(ctx)
 }
 
+// SpaceUsedForTrashInNamespace adds up how much is used by the trash in the given namespace.
+func (bad *BadBlobs) SpaceUsedForTrashInNamespace(ctx context.Context, namespace []byte) (int64, error) {
+	if err := bad.err.Err(); err != nil {
+		return 0, err
+	}
+	return bad.blobs.SpaceUsedForTrashInNamespace(ctx, namespace)
+}
+
 // CreateVerificationFile creates a file to be used for storage directory verification.
 func (bad *BadBlobs) CreateVerificationFile(id storj.NodeID) error {
 	if err := bad.err.Err(); err != nil {
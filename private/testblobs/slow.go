@@ -177,6 +177,12 @@ func (slow *SlowBlobs) SpaceUsedForTrash(ctx context.Context) (int64, error) {
 	return slow.blobs.SpaceUsedForTrash(ctx)
 }
 
+// SpaceUsedForTrashInNamespace adds up how much is used by the trash in the given namespace.
+func (slow *SlowBlobs) SpaceUsedForTrashInNamespace(ctx context.Context, namespace []byte) (int64, error) {
+	slow.sleep()
+	return slow.blobs.SpaceUsedForTrashInNamespace(ctx, namespace)
+}
+
 // CreateVerificationFile creates a file to be used for storage directory verification.
 func (slow *SlowBlobs) CreateVerificationFile(id storj.NodeID) error {
 	slow.sleep()
@@ -38,7 +38,9 @@ import (
 	"storj.io/storj/storagenode/preflight"
 	"storj.io/storj/storagenode/retain"
 	"storj.io/storj/storagenode/storagenodedb"
+	"storj.io/storj/storagenode/transferstats"
 	"storj.io/storj/storagenode/trust"
+	"storj.io/storj/storagenode/untrusted"
 )
 
 // StorageNode contains all the processes needed to run a full StorageNode setup.
@@ -135,7 +137,13 @@ func (planet *Planet) newStorageNode(ctx context.Context, prefix string, index,
 			KBucketRefreshInterval: defaultInterval,
 		},
 		Collector: collector.Config{
-			Interval: defaultInterval,
+			Interval:        defaultInterval,
+			BatchSize:       1000,
+			MaxBatches:      100,
+			Concurrency:     4,
+			BucketSize:      24 * time.Hour,
+			RetryBackoff:    time.Hour,
+			MaxRetryBackoff: 7 * 24 * time.Hour,
 		},
 		Nodestats: nodestats.Config{
 			MaxSleep:       0,
@@ -178,11 +186,20 @@ func (planet *Planet) newStorageNode(ctx context.Context, prefix string, index,
 		},
 		Pieces:    pieces.DefaultConfig,
 		Filestore: filestore.DefaultConfig,
+		Trash: pieces.TrashConfig{
+			Interval:  24 * time.Hour,
+			Retention: 7 * 24 * time.Hour,
+		},
 		Retain: retain.Config{
 			MaxTimeSkew: 10 * time.Second,
 			Status:      retain.Enabled,
 			Concurrency: 5,
 		},
+		Untrusted: untrusted.Config{
+			Interval:    defaultInterval,
+			Policy:      untrusted.PolicyKeep,
+			GracePeriod: 720 * time.Hour,
+		},
 		Version: planet.NewVersionConfig(),
 		Bandwidth: bandwidth.Config{
 			Interval: defaultInterval,
@@ -190,6 +207,10 @@ func (planet *Planet) newStorageNode(ctx context.Context, prefix string, index,
 		Contact: contact.Config{
 			Interval: defaultInterval,
 		},
+		TransferStats: transferstats.Config{
+			FlushInterval: defaultInterval,
+			Retention:     720 * time.Hour,
+		},
 		GracefulExit: gracefulexit.Config{
 			ChoreInterval:          defaultInterval,
 			NumWorkers:             3,
@@ -6,9 +6,13 @@ package checker
 import (
 	"bytes"
 	"context"
+	"crypto/ed25519"
+	"encoding/base64"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"path"
 	"reflect"
 	"strings"
 	"time"
@@ -30,6 +34,8 @@ var (
 type ClientConfig struct {
 	ServerAddress  string        `help:"server address to check its version against" default:"https://version.storj.io"`
 	RequestTimeout time.Duration `help:"Request timeout for version checks" default:"0h1m0s"`
+	Channel        string        `help:"release channel to follow, e.g. beta; the default channel of the server is used when empty" default:""`
+	PublicKey      PublicKey     `help:"base64 encoded ed25519 public key the release manifests must be signed with; when set, unsigned responses are rejected, when empty, responses are not verified" default:""`
 }
 
 // Client defines helper methods for using version control server response data.
@@ -55,8 +61,13 @@ func (client *Client) All(ctx context.Context) (ver version.AllowedVersions, err
 		Timeout: client.config.RequestTimeout,
 	}
 
+	address, err := client.address()
+	if err != nil {
+		return version.AllowedVersions{}, Error.Wrap(err)
+	}
+
 	// New Request that used the passed in context
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.config.ServerAddress, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
 	if err != nil {
 		return version.AllowedVersions{}, Error.Wrap(err)
 	}
@@ -76,6 +87,10 @@ func (client *Client) All(ctx context.Context) (ver version.AllowedVersions, err
 		return version.AllowedVersions{}, Error.New("non-success http status code: %d; body: %s\n", resp.StatusCode, body)
 	}
 
+	if client.config.PublicKey.Key != nil {
+		return verify(client.config.PublicKey.Key, client.channel(), body, time.Now())
+	}
+
 	err = json.NewDecoder(bytes.NewReader(body)).Decode(&ver)
 	return ver, Error.Wrap(err)
 }
@@ -124,6 +139,66 @@ func (client *Client) Process(ctx context.Context, processName string) (process
 	return process, nil
 }
 
+// channel returns the configured release channel.
+func (client *Client) channel() string {
+	if client.config.Channel == "" {
+		return DefaultChannel
+	}
+	return client.config.Channel
+}
+
+// address returns the address of the configured release channel.
+func (client *Client) address() (string, error) {
+	if client.config.Channel == "" {
+		return client.config.ServerAddress, nil
+	}
+
+	u, err := url.Parse(client.config.ServerAddress)
+	if err != nil {
+		return "", err
+	}
+	u.Path = path.Join("/", u.Path, client.config.Channel)
+	u.RawPath = ""
+	return u.String(), nil
+}
+
+// PublicKey is an ed25519 public key that implements pflag.Value.
+type PublicKey struct {
+	Key ed25519.PublicKey
+}
+
+// String returns the base64 encoding of the key.
+func (key *PublicKey) String() string {
+	if key.Key == nil {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(key.Key)
+}
+
+// Set implements pflag.Value by parsing a base64 encoded key.
+func (key *PublicKey) Set(value string) error {
+	if value == "" {
+		key.Key = nil
+		return nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return Error.New("invalid public key %q: %w", value, err)
+	}
+	if len(decoded) != ed25519.PublicKeySize {
+		return Error.New("invalid public key %q: expected %d bytes, got %d", value, ed25519.PublicKeySize, len(decoded))
+	}
+
+	key.Key = ed25519.PublicKey(decoded)
+	return nil
+}
+
+// Type returns the type of the pflag.Value.
+func (key PublicKey) Type() string {
+	return "version-public-key"
+}
+
 func kebabToPascal(str string) string {
 	return strings.ReplaceAll(strings.Title(str), "-", "")
 }
@@ -4,10 +4,15 @@
 package checker_test
 
 import (
+	"crypto/ed25519"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
@@ -86,7 +91,154 @@ func TestClient_Process(t *testing.T) {
 	}
 }
 
-func newTestPeer(t *testing.T, ctx *testcontext.Context) *versioncontrol.Peer {
+func TestClient_Signed(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	keyPath := ctx.File("signing.key")
+	publicKey, err := versioncontrol.GenerateSigningKey(keyPath)
+	require.NoError(t, err)
+	key, err := versioncontrol.LoadSigningKey(keyPath)
+	require.NoError(t, err)
+
+	otherKey, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	signedPeer := newTestPeer(t, ctx, func(config *versioncontrol.Config) {
+		config.Beta.Enabled = true
+		config.Beta.Binary = newTestVersions(t)
+
+		manifest, err := versioncontrol.NewManifest(config, versioncontrol.StableChannel, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		signed, err := versioncontrol.SignManifest(key, manifest)
+		require.NoError(t, err)
+
+		config.Signing.StableManifest = ctx.File("stable.manifest")
+		require.NoError(t, versioncontrol.SaveManifest(config.Signing.StableManifest, signed))
+	})
+	defer ctx.Check(signedPeer.Close)
+
+	unsignedPeer := newTestPeer(t, ctx)
+	defer ctx.Check(unsignedPeer.Close)
+
+	for _, test := range []struct {
+		name      string
+		peer      *versioncontrol.Peer
+		channel   string
+		publicKey ed25519.PublicKey
+		valid     bool
+	}{
+		{"signed", signedPeer, "", publicKey, true},
+		{"signed stable", signedPeer, versioncontrol.StableChannel, publicKey, true},
+		{"signed without key", signedPeer, "", nil, true},
+		{"signed by other key", signedPeer, "", otherKey, false},
+		{"unsigned channel", signedPeer, versioncontrol.BetaChannel, publicKey, false},
+		{"unsigned", unsignedPeer, "", publicKey, false},
+	} {
+		client := checker.New(checker.ClientConfig{
+			ServerAddress: "http://" + test.peer.Addr(),
+			Channel:       test.channel,
+			PublicKey:     checker.PublicKey{Key: test.publicKey},
+		})
+
+		_, err := client.All(ctx)
+		if test.valid {
+			require.NoError(t, err, test.name)
+		} else {
+			require.Error(t, err, test.name)
+		}
+	}
+}
+
+func TestClient_SignedManifest(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	publicKey, key, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	config := &versioncontrol.Config{
+		Versions: versioncontrol.OldVersionConfig{
+			Satellite:   "v0.0.1",
+			Storagenode: "v0.0.1",
+			Uplink:      "v0.0.1",
+			Gateway:     "v0.0.1",
+			Identity:    "v0.0.1",
+		},
+		Binary: newTestVersions(t),
+	}
+
+	var response []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(response)
+	}))
+	defer server.Close()
+
+	serve := func(channel string, validUntil time.Time, cursor version.RolloutBytes) {
+		manifest, err := versioncontrol.NewManifest(config, versioncontrol.StableChannel, validUntil)
+		require.NoError(t, err)
+		manifest.Channel = channel
+		signed, err := versioncontrol.SignManifest(key, manifest)
+		require.NoError(t, err)
+
+		served := checker.SignedVersions{AllowedVersions: manifest.Versions, Signed: &signed}
+		served.Processes.Storagenode.Rollout.Cursor = cursor
+		response, err = json.Marshal(served)
+		require.NoError(t, err)
+	}
+
+	client := checker.New(checker.ClientConfig{
+		ServerAddress: server.URL,
+		PublicKey:     checker.PublicKey{Key: publicKey},
+	})
+
+	// the cursor is not signed, so it can change without signing the manifest again.
+	cursor := version.PercentageToCursor(42)
+	serve(versioncontrol.StableChannel, time.Now().Add(time.Hour), cursor)
+	versions, err := client.All(ctx)
+	require.NoError(t, err)
+	require.Equal(t, cursor, versions.Processes.Storagenode.Rollout.Cursor)
+
+	serve(versioncontrol.StableChannel, time.Now().Add(-time.Hour), cursor)
+	_, err = client.All(ctx)
+	require.Error(t, err)
+
+	serve(versioncontrol.BetaChannel, time.Now().Add(time.Hour), cursor)
+	_, err = client.All(ctx)
+	require.Error(t, err)
+}
+
+func TestClient_Channel(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	peer := newTestPeer(t, ctx, func(config *versioncontrol.Config) {
+		config.Beta.Enabled = true
+		config.Beta.Binary = newTestVersions(t)
+		config.Beta.Binary.Storagenode.Suggested.Version = "v2.0.0"
+	})
+	defer ctx.Check(peer.Close)
+
+	for _, test := range []struct {
+		channel   string
+		suggested string
+	}{
+		{"", "v2.3.4"},
+		{versioncontrol.StableChannel, "v2.3.4"},
+		{versioncontrol.BetaChannel, "v2.0.0"},
+	} {
+		client := checker.New(checker.ClientConfig{
+			ServerAddress: "http://" + peer.Addr() + "/",
+			Channel:       test.channel,
+		})
+
+		process, err := client.Process(ctx, "storagenode")
+		require.NoError(t, err)
+		require.Equal(t, test.suggested, process.Suggested.Version, test.channel)
+	}
+}
+
+func newTestPeer(t *testing.T, ctx *testcontext.Context, reconfigure ...func(config *versioncontrol.Config)) *versioncontrol.Peer {
 	t.Helper()
 
 	testVersions := newTestVersions(t)
@@ -101,6 +253,9 @@ func newTestPeer(t *testing.T, ctx *testcontext.Context) *versioncontrol.Peer {
 		},
 		Binary: testVersions,
 	}
+	for _, fn := range reconfigure {
+		fn(serverConfig)
+	}
 	peer, err := versioncontrol.New(zaptest.NewLogger(t), serverConfig)
 	require.NoError(t, err)
 
@@ -0,0 +1,83 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package checker
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"storj.io/private/version"
+)
+
+// DefaultChannel is the release channel served when no channel is requested.
+const DefaultChannel = "stable"
+
+// Manifest is the release manifest of a channel. It is signed offline and
+// served as-is by the version control server.
+//
+// The rollout cursors are not part of the manifest, so that a rollout can be
+// advanced without signing the manifest again.
+type Manifest struct {
+	Channel    string                  `json:"channel"`
+	ValidUntil time.Time               `json:"validUntil"`
+	Versions   version.AllowedVersions `json:"versions"`
+}
+
+// SignedManifest is an encoded manifest and its ed25519 signature.
+type SignedManifest struct {
+	Manifest  []byte `json:"manifest"`
+	Signature []byte `json:"signature"`
+}
+
+// SignedVersions is the response of the version control server. Clients which
+// don't verify the signed manifest use the allowed versions as before.
+type SignedVersions struct {
+	version.AllowedVersions
+	Signed *SignedManifest `json:"signed,omitempty"`
+}
+
+// verify verifies the signed manifest of the response body and returns the
+// versions of the manifest with the rollout cursors of the response.
+func verify(publicKey ed25519.PublicKey, channel string, body []byte, now time.Time) (_ version.AllowedVersions, err error) {
+	var served SignedVersions
+	if err := json.Unmarshal(body, &served); err != nil {
+		return version.AllowedVersions{}, Error.Wrap(err)
+	}
+	if served.Signed == nil {
+		return version.AllowedVersions{}, Error.New("response is not signed")
+	}
+	if !ed25519.Verify(publicKey, served.Signed.Manifest, served.Signed.Signature) {
+		return version.AllowedVersions{}, Error.New("signature verification failed")
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(served.Signed.Manifest, &manifest); err != nil {
+		return version.AllowedVersions{}, Error.New("invalid manifest: %w", err)
+	}
+	if manifest.Channel != channel {
+		return version.AllowedVersions{}, Error.New("manifest of release channel %q served for %q", manifest.Channel, channel)
+	}
+	if !now.Before(manifest.ValidUntil) {
+		return version.AllowedVersions{}, Error.New("manifest expired at %s", manifest.ValidUntil.Format(time.RFC3339))
+	}
+
+	versions := manifest.Versions
+	copyCursors(&versions.Processes, served.Processes)
+	return versions, nil
+}
+
+// copyCursors copies the rollout cursors of every process from src to dst.
+func copyCursors(dst *version.Processes, src version.Processes) {
+	dstValue := reflect.ValueOf(dst).Elem()
+	srcValue := reflect.ValueOf(src)
+	for i := 0; i < dstValue.NumField(); i++ {
+		process, ok := dstValue.Field(i).Addr().Interface().(*version.Process)
+		if !ok {
+			continue
+		}
+		process.Rollout.Cursor = srcValue.Field(i).Interface().(version.Process).Rollout.Cursor
+	}
+}
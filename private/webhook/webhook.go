@@ -0,0 +1,89 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package webhook posts JSON payloads to HTTP endpoints.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/sync2"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is an error class for webhook errors.
+	Error = errs.Class("webhook error")
+)
+
+// Options defines the endpoint payloads are posted to and how failed attempts are retried.
+type Options struct {
+	URL           string
+	Timeout       time.Duration
+	Retries       int
+	RetryInterval time.Duration
+}
+
+// Client posts JSON payloads to a webhook.
+type Client struct {
+	options Options
+	client  *http.Client
+}
+
+// New creates a new webhook client.
+func New(options Options) *Client {
+	return &Client{
+		options: options,
+		client:  &http.Client{Timeout: options.Timeout},
+	}
+}
+
+// Post posts the value as JSON, retrying failed attempts with a doubling delay.
+func (hook *Client) Post(ctx context.Context, value interface{}) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	delay := hook.options.RetryInterval
+	for attempt := 0; ; attempt++ {
+		err = hook.post(ctx, payload)
+		if err == nil || attempt >= hook.options.Retries {
+			return err
+		}
+		if !sync2.Sleep(ctx, delay) {
+			return errs.Combine(err, ctx.Err())
+		}
+		delay *= 2
+	}
+}
+
+// post makes a single attempt at posting the payload.
+func (hook *Client) post(ctx context.Context, payload []byte) (err error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.options.URL, bytes.NewReader(payload))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := hook.client.Do(request)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(response.Body.Close())) }()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return Error.New("webhook responded with %s", response.Status)
+	}
+	return nil
+}
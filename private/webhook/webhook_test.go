@@ -0,0 +1,64 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package webhook_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/webhook"
+)
+
+func TestPost(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	type payload struct {
+		Message string `json:"message"`
+	}
+
+	var attempts, failures int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var received payload
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		assert.Equal(t, "hello", received.Message)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		atomic.AddInt32(&attempts, 1)
+		if atomic.AddInt32(&failures, -1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	hook := webhook.New(webhook.Options{
+		URL:           server.URL,
+		Timeout:       time.Second,
+		Retries:       1,
+		RetryInterval: time.Millisecond,
+	})
+
+	// the first attempt fails.
+	atomic.StoreInt32(&failures, 1)
+	require.NoError(t, hook.Post(ctx, payload{Message: "hello"}))
+	require.EqualValues(t, 2, atomic.LoadInt32(&attempts))
+
+	// out of retries.
+	atomic.StoreInt32(&attempts, 0)
+	atomic.StoreInt32(&failures, 2)
+	err := hook.Post(ctx, payload{Message: "hello"})
+	require.Error(t, err)
+	require.True(t, webhook.Error.Has(err))
+	require.EqualValues(t, 2, atomic.LoadInt32(&attempts))
+}
@@ -647,6 +647,7 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 			peer.Log.Named("nodestats:endpoint"),
 			peer.Overlay.DB,
 			peer.DB.StoragenodeAccounting(),
+			config.Overlay.Node,
 			config.Payments,
 		)
 		if err := pb.DRPCRegisterNodeStats(peer.Server.DRPC(), peer.NodeStats.Endpoint); err != nil {
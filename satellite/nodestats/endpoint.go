@@ -12,7 +12,9 @@ import (
 	"storj.io/common/identity"
 	"storj.io/common/pb"
 	"storj.io/common/rpc/rpcstatus"
+	"storj.io/storj/private/nodestatsext"
 	"storj.io/storj/satellite/accounting"
+	"storj.io/storj/satellite/internalpb"
 	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/satellite/payments/paymentsconfig"
 )
@@ -25,19 +27,21 @@ var (
 //
 // architecture: Endpoint
 type Endpoint struct {
-	log        *zap.Logger
-	overlay    overlay.DB
-	accounting accounting.StoragenodeAccounting
-	config     paymentsconfig.Config
+	log           *zap.Logger
+	overlay       overlay.DB
+	accounting    accounting.StoragenodeAccounting
+	nodeSelection overlay.NodeSelectionConfig
+	config        paymentsconfig.Config
 }
 
 // NewEndpoint creates new endpoint.
-func NewEndpoint(log *zap.Logger, overlay overlay.DB, accounting accounting.StoragenodeAccounting, config paymentsconfig.Config) *Endpoint {
+func NewEndpoint(log *zap.Logger, overlay overlay.DB, accounting accounting.StoragenodeAccounting, nodeSelection overlay.NodeSelectionConfig, config paymentsconfig.Config) *Endpoint {
 	return &Endpoint{
-		log:        log,
-		overlay:    overlay,
-		accounting: accounting,
-		config:     config,
+		log:           log,
+		overlay:       overlay,
+		accounting:    accounting,
+		nodeSelection: nodeSelection,
+		config:        config,
 	}
 }
 
@@ -66,7 +70,7 @@ func (e *Endpoint) GetStats(ctx context.Context, req *pb.GetStatsRequest) (_ *pb
 		node.Reputation.UnknownAuditReputationAlpha,
 		node.Reputation.UnknownAuditReputationBeta)
 
-	return &pb.GetStatsResponse{
+	response := &pb.GetStatsResponse{
 		UptimeCheck: &pb.ReputationStats{
 			TotalCount:   node.Reputation.UptimeCount,
 			SuccessCount: node.Reputation.UptimeSuccessCount,
@@ -87,7 +91,31 @@ func (e *Endpoint) GetStats(ctx context.Context, req *pb.GetStatsRequest) (_ *pb
 		OfflineSuspended:   node.OfflineSuspended,
 		OfflineUnderReview: node.OfflineUnderReview,
 		JoinedAt:           node.CreatedAt,
-	}, nil
+	}
+
+	extensions := nodestatsext.Extensions{
+		Vetting: &nodestatsext.Vetting{
+			VettedAt:   node.Reputation.VettedAt,
+			AuditCount: e.nodeSelection.AuditCount,
+		},
+	}
+
+	// the audit history is additional information, so the node still gets
+	// its reputation when the history can't be read.
+	auditHistory, err := e.overlay.GetAuditHistory(ctx, peer.ID)
+	if err != nil {
+		e.log.Error("overlay.GetAuditHistory failed", zap.Error(err))
+	} else {
+		extensions.AuditHistory = toAuditHistory(auditHistory)
+	}
+
+	err = nodestatsext.Attach(response, extensions)
+	if err != nil {
+		e.log.Error("nodestatsext.Attach failed", zap.Error(err))
+		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
+	}
+
+	return response, nil
 }
 
 // DailyStorageUsage returns slice of daily storage usage for given period of time sorted in ASC order by date.
@@ -145,6 +173,23 @@ func toProtoDailyStorageUsage(usages []accounting.StorageNodeUsage) []*pb.DailyS
 	return pbUsages
 }
 
+// toAuditHistory converts the audit history kept by the satellite to the one sent to the node.
+func toAuditHistory(history *internalpb.AuditHistory) *nodestatsext.AuditHistory {
+	windows := make([]nodestatsext.AuditWindow, 0, len(history.Windows))
+	for _, window := range history.Windows {
+		windows = append(windows, nodestatsext.AuditWindow{
+			WindowStart: window.WindowStart,
+			TotalCount:  window.TotalCount,
+			OnlineCount: window.OnlineCount,
+		})
+	}
+
+	return &nodestatsext.AuditHistory{
+		Windows: windows,
+		Score:   history.Score,
+	}
+}
+
 // calculateReputationScore is helper method to calculate reputation score value.
 func calculateReputationScore(alpha, beta float64) float64 {
 	return alpha / (alpha + beta)
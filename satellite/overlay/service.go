@@ -70,6 +70,8 @@ type DB interface {
 
 	// UpdateAuditHistory updates a node's audit history with an online or offline audit.
 	UpdateAuditHistory(ctx context.Context, nodeID storj.NodeID, auditTime time.Time, online bool, config AuditHistoryConfig) (auditHistory *internalpb.AuditHistory, err error)
+	// GetAuditHistory returns a node's audit history, which is empty when the node was not audited yet.
+	GetAuditHistory(ctx context.Context, nodeID storj.NodeID) (auditHistory *internalpb.AuditHistory, err error)
 
 	// AllPieceCounts returns a map of node IDs to piece counts from the db.
 	AllPieceCounts(ctx context.Context) (pieceCounts map[storj.NodeID]int, err error)
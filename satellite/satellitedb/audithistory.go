@@ -94,6 +94,30 @@ func (cache *overlaycache) UpdateAuditHistory(ctx context.Context, nodeID storj.
 	return history, err
 }
 
+// GetAuditHistory returns a node's audit history, which is empty when the node was not audited yet.
+func (cache *overlaycache) GetAuditHistory(ctx context.Context, nodeID storj.NodeID) (history *internalpb.AuditHistory, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	history = &internalpb.AuditHistory{}
+
+	dbAuditHistory, err := cache.db.Get_AuditHistory_By_NodeId(
+		ctx,
+		dbx.AuditHistory_NodeId(nodeID.Bytes()),
+	)
+	if errs.Is(err, sql.ErrNoRows) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	err = pb.Unmarshal(dbAuditHistory.History, history)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return history, nil
+}
+
 func (cache *overlaycache) updateAuditHistoryWithTx(ctx context.Context, tx *dbx.Tx, nodeID storj.NodeID, auditTime time.Time, online bool, config overlay.AuditHistoryConfig) (*internalpb.AuditHistory, error) {
 	// get and deserialize node audit history
 	historyBytes := []byte{}
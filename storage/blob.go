@@ -101,6 +101,8 @@ type Blobs interface {
 	CheckWritability() error
 	// SpaceUsedForTrash returns the total space used by the trash.
 	SpaceUsedForTrash(ctx context.Context) (int64, error)
+	// SpaceUsedForTrashInNamespace returns the space used by the trash in the given namespace.
+	SpaceUsedForTrashInNamespace(ctx context.Context, namespace []byte) (int64, error)
 	// SpaceUsedForBlobs adds up how much is used in all namespaces.
 	SpaceUsedForBlobs(ctx context.Context) (int64, error)
 	// SpaceUsedForBlobsInNamespace adds up how much is used in the given namespace.
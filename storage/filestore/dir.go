@@ -486,6 +486,24 @@ func (dir *Dir) EmptyTrash(ctx context.Context, namespace []byte, trashedBefore
 	return bytesEmptied, deletedKeys, nil
 }
 
+// SpaceUsedForTrashInNamespace walks the trash files for the given namespace
+// and adds up their sizes.
+func (dir *Dir) SpaceUsedForTrashInNamespace(ctx context.Context, namespace []byte) (total int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = dir.walkNamespaceInPath(ctx, namespace, dir.trashdir(), func(blobInfo storage.BlobInfo) error {
+		fileInfo, err := blobInfo.Stat(ctx)
+		if err != nil {
+			return err
+		}
+		total += fileInfo.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
 // iterateStorageFormatVersions executes f for all storage format versions,
 // starting with the oldest format version. It is more likely, in the general
 // case, that we will find the piece with the newest format version instead,
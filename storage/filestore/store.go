@@ -246,6 +246,12 @@ func (store *blobStore) SpaceUsedForTrash(ctx context.Context) (total int64, err
 	return total, err
 }
 
+// SpaceUsedForTrashInNamespace returns the space used by the trash in the given namespace.
+func (store *blobStore) SpaceUsedForTrashInNamespace(ctx context.Context, namespace []byte) (total int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.dir.SpaceUsedForTrashInNamespace(ctx, namespace)
+}
+
 // FreeSpace returns how much space left in underlying directory.
 func (store *blobStore) FreeSpace() (int64, error) {
 	info, err := store.dir.Info()
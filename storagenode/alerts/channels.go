@@ -0,0 +1,178 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"storj.io/storj/private/post"
+	"storj.io/storj/private/webhook"
+)
+
+// webhookChannel posts alerts as JSON to an HTTP endpoint.
+type webhookChannel struct {
+	config WebhookConfig
+	client *webhook.Client
+}
+
+func newWebhook(config WebhookConfig) *webhookChannel {
+	return &webhookChannel{
+		config: config,
+		client: webhook.New(webhook.Options{
+			URL:           config.URL,
+			Timeout:       config.Timeout,
+			Retries:       config.Retries,
+			RetryInterval: config.RetryInterval,
+		}),
+	}
+}
+
+// Name implements channel.
+func (*webhookChannel) Name() string { return "webhook" }
+
+// Types implements channel.
+func (hook *webhookChannel) Types() Types { return hook.config.Types }
+
+// Deliver posts the alert, retrying failed attempts.
+func (hook *webhookChannel) Deliver(ctx context.Context, alert Alert) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return Error.Wrap(hook.client.Post(ctx, alert))
+}
+
+// email sends alerts through an SMTP server.
+type email struct {
+	config EmailConfig
+	sender *post.SMTPSender
+	to     []post.Address
+}
+
+func newEmail(config EmailConfig) (*email, error) {
+	from, err := mail.ParseAddress(config.From)
+	if err != nil {
+		return nil, Error.New("invalid email sender %q: %v", config.From, err)
+	}
+
+	to, err := mail.ParseAddressList(config.To)
+	if err != nil {
+		return nil, Error.New("invalid email recipients %q: %v", config.To, err)
+	}
+
+	host, _, err := net.SplitHostPort(config.SMTPServerAddress)
+	if err != nil {
+		return nil, Error.New("invalid smtp server address %q: %v", config.SMTPServerAddress, err)
+	}
+
+	var auth smtp.Auth
+	switch config.AuthType {
+	case "plain":
+		auth = smtp.PlainAuth("", config.Login, config.Password, host)
+	case "login":
+		auth = post.LoginAuth{
+			Username: config.Login,
+			Password: config.Password,
+		}
+	default:
+		return nil, Error.New("unsupported smtp authentication type %q", config.AuthType)
+	}
+
+	recipients := make([]post.Address, 0, len(to))
+	for _, address := range to {
+		recipients = append(recipients, *address)
+	}
+
+	return &email{
+		config: config,
+		sender: &post.SMTPSender{
+			ServerAddress: config.SMTPServerAddress,
+			From:          *from,
+			Auth:          auth,
+		},
+		to: recipients,
+	}, nil
+}
+
+// Name implements channel.
+func (*email) Name() string { return "email" }
+
+// Types implements channel.
+func (mailer *email) Types() Types { return mailer.config.Types }
+
+// Deliver sends the alert as email.
+func (mailer *email) Deliver(ctx context.Context, alert Alert) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "%s\n\n", alert.Message)
+	fmt.Fprintf(&body, "Node ID: %s\n", alert.NodeID)
+	fmt.Fprintf(&body, "Sender ID: %s\n", alert.SenderID)
+	fmt.Fprintf(&body, "Type: %s\n", alert.Type)
+	fmt.Fprintf(&body, "Created: %s\n", alert.CreatedAt.UTC().Format(time.RFC3339))
+
+	return Error.Wrap(mailer.sender.SendEmail(ctx, &post.Message{
+		From:      mailer.sender.FromAddress(),
+		To:        mailer.to,
+		Subject:   "Storage node alert: " + alert.Title,
+		Date:      time.Now(),
+		PlainText: body.String(),
+	}))
+}
+
+// command runs a local command for alerts.
+type command struct {
+	config CommandConfig
+}
+
+func newCommand(config CommandConfig) *command {
+	return &command{config: config}
+}
+
+// Name implements channel.
+func (*command) Name() string { return "command" }
+
+// Types implements channel.
+func (cmd *command) Types() Types { return cmd.config.Types }
+
+// Deliver runs the command with the alert as JSON on stdin and
+// the main fields of the alert in environment variables.
+func (cmd *command) Deliver(ctx context.Context, alert Alert) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	payload, err := json.Marshal(alert)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if cmd.config.Timeout > 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, cmd.config.Timeout)
+		defer cancel()
+	}
+
+	process := exec.CommandContext(ctx, cmd.config.Path) // #nosec G204, the command is configured by the operator
+	process.Stdin = bytes.NewReader(payload)
+	process.Env = append(os.Environ(),
+		"STORJ_ALERT_NODE_ID="+alert.NodeID.String(),
+		"STORJ_ALERT_SENDER_ID="+alert.SenderID.String(),
+		"STORJ_ALERT_TYPE="+alert.Type,
+		"STORJ_ALERT_TITLE="+alert.Title,
+		"STORJ_ALERT_MESSAGE="+alert.Message,
+	)
+
+	output, err := process.CombinedOutput()
+	if err != nil {
+		return Error.New("command %q failed: %v: %s", cmd.config.Path, err, bytes.TrimSpace(output))
+	}
+	return nil
+}
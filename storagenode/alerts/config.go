@@ -0,0 +1,94 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package alerts
+
+import (
+	"strings"
+	"time"
+
+	"storj.io/storj/storagenode/notifications"
+)
+
+// Config defines the channels which alerts are delivered through.
+type Config struct {
+	Webhook WebhookConfig
+	Email   EmailConfig
+	Command CommandConfig
+
+	RateLimit int `help:"maximum number of alerts of the same type and satellite delivered through each channel per hour, 0 for unlimited" default:"4"`
+	QueueSize int `help:"maximum number of alerts waiting to be delivered" default:"100"`
+}
+
+// WebhookConfig defines the HTTP endpoint which alerts are posted to as JSON.
+type WebhookConfig struct {
+	URL           string        `help:"URL which alerts are posted to as JSON, disabled when empty" default:""`
+	Types         Types         `help:"comma separated notification types which are posted, all when empty" default:""`
+	Timeout       time.Duration `help:"timeout of posting an alert" default:"10s"`
+	Retries       int           `help:"number of times posting an alert is retried" default:"3"`
+	RetryInterval time.Duration `help:"how long to wait before posting an alert again, doubled with every retry" default:"10s"`
+}
+
+// EmailConfig defines the SMTP server which alerts are sent through.
+type EmailConfig struct {
+	SMTPServerAddress string `help:"smtp server address which alerts are sent through, disabled when empty" default:""`
+	From              string `help:"sender email address" default:""`
+	To                string `help:"comma separated recipient email addresses" default:""`
+	AuthType          string `help:"smtp authentication type, login or plain" default:"login"`
+	Login             string `help:"plain/login auth user login" default:""`
+	Password          string `help:"plain/login auth user password" default:""`
+	Types             Types  `help:"comma separated notification types which are sent, all when empty" default:""`
+}
+
+// CommandConfig defines the command which is run for alerts.
+type CommandConfig struct {
+	Path    string        `help:"command which is run for every alert with the alert as JSON on stdin, disabled when empty" default:""`
+	Types   Types         `help:"comma separated notification types which the command is run for, all when empty" default:""`
+	Timeout time.Duration `help:"how long the command may run" default:"30s"`
+}
+
+// Types is a set of notification types, an empty set contains all types.
+type Types []notifications.Type
+
+// Contains returns whether notifications of type t are in the set.
+func (types Types) Contains(t notifications.Type) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, typ := range types {
+		if typ == t {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the comma separated names of the types.
+func (types *Types) String() string {
+	names := make([]string, 0, len(*types))
+	for _, t := range *types {
+		names = append(names, t.String())
+	}
+	return strings.Join(names, ",")
+}
+
+// Set parses comma separated names of notification types.
+func (types *Types) Set(value string) error {
+	var parsed Types
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		t, err := notifications.ParseType(name)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		parsed = append(parsed, t)
+	}
+	*types = parsed
+	return nil
+}
+
+// Type implements pflag.Value.
+func (*Types) Type() string { return "alert-types" }
@@ -0,0 +1,194 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package alerts delivers storage node notifications through webhooks,
+// email and local commands.
+package alerts
+
+import (
+	"context"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/storj/storagenode/notifications"
+)
+
+var (
+	// Error is the default error class for alerts package.
+	Error = errs.Class("alerts")
+
+	mon = monkit.Package()
+)
+
+// ensures that Service implements notifications.Sender.
+var _ notifications.Sender = (*Service)(nil)
+
+// Alert is a notification as delivered outside of the node.
+type Alert struct {
+	ID        uuid.UUID    `json:"id"`
+	NodeID    storj.NodeID `json:"nodeID"`
+	SenderID  storj.NodeID `json:"senderID"`
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Message   string       `json:"message"`
+	CreatedAt time.Time    `json:"createdAt"`
+
+	notificationType notifications.Type
+}
+
+// channel delivers alerts.
+type channel interface {
+	// Name returns the name of the channel.
+	Name() string
+	// Types returns the notification types delivered through the channel.
+	Types() Types
+	// Deliver delivers the alert.
+	Deliver(ctx context.Context, alert Alert) error
+}
+
+// Service delivers notifications through the configured channels.
+//
+// architecture: Service
+type Service struct {
+	log    *zap.Logger
+	nodeID storj.NodeID
+
+	channels []channel
+	limiter  *rateLimiter
+	queue    chan Alert
+}
+
+// NewService creates a new alerts service.
+func NewService(log *zap.Logger, nodeID storj.NodeID, config Config) (*Service, error) {
+	service := &Service{
+		log:     log,
+		nodeID:  nodeID,
+		limiter: newRateLimiter(config.RateLimit, time.Hour),
+	}
+
+	if config.Webhook.URL != "" {
+		service.channels = append(service.channels, newWebhook(config.Webhook))
+	}
+	if config.Email.SMTPServerAddress != "" {
+		email, err := newEmail(config.Email)
+		if err != nil {
+			return nil, err
+		}
+		service.channels = append(service.channels, email)
+	}
+	if config.Command.Path != "" {
+		service.channels = append(service.channels, newCommand(config.Command))
+	}
+
+	queueSize := config.QueueSize
+	if queueSize <= 0 {
+		queueSize = 1
+	}
+	service.queue = make(chan Alert, queueSize)
+
+	return service, nil
+}
+
+// Send queues the notification for delivery without blocking.
+func (service *Service) Send(ctx context.Context, notification notifications.Notification) {
+	if len(service.channels) == 0 {
+		return
+	}
+
+	alert := Alert{
+		ID:        notification.ID,
+		NodeID:    service.nodeID,
+		SenderID:  notification.SenderID,
+		Type:      notification.Type.String(),
+		Title:     notification.Title,
+		Message:   notification.Message,
+		CreatedAt: notification.CreatedAt,
+
+		notificationType: notification.Type,
+	}
+
+	select {
+	case service.queue <- alert:
+	default:
+		service.log.Warn("alert queue is full, dropping alert", zap.String("type", alert.Type), zap.String("title", alert.Title))
+	}
+}
+
+// Run delivers queued alerts until the context is canceled.
+func (service *Service) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case alert := <-service.queue:
+			service.deliver(ctx, alert, time.Now())
+		}
+	}
+}
+
+// deliver delivers the alert through every channel which accepts its type.
+func (service *Service) deliver(ctx context.Context, alert Alert, now time.Time) {
+	for _, channel := range service.channels {
+		if !channel.Types().Contains(alert.notificationType) {
+			continue
+		}
+
+		log := service.log.With(zap.String("channel", channel.Name()), zap.String("type", alert.Type))
+		// alerts about different satellites are limited separately, so
+		// that alerts about one satellite don't suppress the others.
+		if !service.limiter.Allow(channel.Name()+"/"+alert.Type+"/"+alert.SenderID.String(), now) {
+			log.Debug("alert rate limit reached, dropping alert", zap.String("title", alert.Title))
+			continue
+		}
+
+		if err := channel.Deliver(ctx, alert); err != nil {
+			log.Error("failed to deliver alert", zap.String("title", alert.Title), zap.Error(err))
+		}
+	}
+}
+
+// rateLimiter limits the number of events per key within a sliding window.
+type rateLimiter struct {
+	limit  int
+	window time.Duration
+	events map[string][]time.Time
+}
+
+// newRateLimiter creates a rate limiter allowing limit events per window,
+// a limit of 0 disables limiting.
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		limit:  limit,
+		window: window,
+		events: map[string][]time.Time{},
+	}
+}
+
+// Allow records an event for key and returns whether it is within the limit.
+func (limiter *rateLimiter) Allow(key string, now time.Time) bool {
+	if limiter.limit <= 0 {
+		return true
+	}
+
+	events := limiter.events[key][:0]
+	for _, at := range limiter.events[key] {
+		if now.Sub(at) < limiter.window {
+			events = append(events, at)
+		}
+	}
+
+	if len(events) >= limiter.limit {
+		limiter.events[key] = events
+		return false
+	}
+
+	limiter.events[key] = append(events, now)
+	return true
+}
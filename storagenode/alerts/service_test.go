@@ -0,0 +1,154 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/notifications"
+)
+
+func TestTypes(t *testing.T) {
+	var types Types
+	require.NoError(t, types.Set("suspension, disqualification"))
+	require.Equal(t, Types{notifications.TypeSuspension, notifications.TypeDisqualification}, types)
+	require.Equal(t, "suspension,disqualification", types.String())
+	require.True(t, types.Contains(notifications.TypeSuspension))
+	require.False(t, types.Contains(notifications.TypeLowDiskSpace))
+
+	require.NoError(t, types.Set(""))
+	require.True(t, types.Contains(notifications.TypeLowDiskSpace))
+
+	require.Error(t, types.Set("suspended"))
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := newRateLimiter(2, time.Hour)
+	now := time.Now()
+
+	require.True(t, limiter.Allow("a", now))
+	require.True(t, limiter.Allow("a", now.Add(time.Minute)))
+	require.False(t, limiter.Allow("a", now.Add(2*time.Minute)))
+	require.True(t, limiter.Allow("b", now.Add(2*time.Minute)))
+	require.True(t, limiter.Allow("a", now.Add(time.Hour+time.Second)))
+
+	unlimited := newRateLimiter(0, time.Hour)
+	for i := 0; i < 10; i++ {
+		require.True(t, unlimited.Allow("a", now))
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	var mu sync.Mutex
+	var attempts int
+	var received []Alert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		var alert Alert
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&alert)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = append(received, alert)
+	}))
+	defer server.Close()
+
+	nodeID := testrand.NodeID()
+	service, err := NewService(zaptest.NewLogger(t), nodeID, Config{
+		Webhook: WebhookConfig{
+			URL:           server.URL,
+			Types:         Types{notifications.TypeSuspension},
+			Timeout:       time.Second,
+			Retries:       1,
+			RetryInterval: time.Millisecond,
+		},
+		RateLimit: 1,
+		QueueSize: 10,
+	})
+	require.NoError(t, err)
+
+	notification := notifications.Notification{
+		ID:        testrand.UUID(),
+		SenderID:  testrand.NodeID(),
+		Type:      notifications.TypeSuspension,
+		Title:     "Your Node was suspended!",
+		Message:   "suspended",
+		CreatedAt: time.Now().UTC(),
+	}
+
+	now := time.Now()
+	// the first attempt fails and is retried
+	service.deliver(ctx, newAlert(ctx, service, notification), now)
+	// filtered by type
+	service.deliver(ctx, newAlert(ctx, service, notifications.Notification{Type: notifications.TypeLowDiskSpace}), now)
+	// rate limited
+	service.deliver(ctx, newAlert(ctx, service, notification), now)
+	// limited separately for another satellite
+	other := notification
+	other.ID = testrand.UUID()
+	other.SenderID = testrand.NodeID()
+	service.deliver(ctx, newAlert(ctx, service, other), now)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, 3, attempts)
+	require.Len(t, received, 2)
+	require.Equal(t, nodeID, received[0].NodeID)
+	require.Equal(t, notification.ID, received[0].ID)
+	require.Equal(t, "suspension", received[0].Type)
+	require.Equal(t, notification.Title, received[0].Title)
+	require.Equal(t, other.ID, received[1].ID)
+	require.Equal(t, other.SenderID, received[1].SenderID)
+}
+
+func TestEmailConfig(t *testing.T) {
+	_, err := NewService(zaptest.NewLogger(t), testrand.NodeID(), Config{
+		Email: EmailConfig{
+			SMTPServerAddress: "smtp.example.test:587",
+			From:              "node@example.test",
+			To:                "operator@example.test, Other <other@example.test>",
+			AuthType:          "plain",
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = NewService(zaptest.NewLogger(t), testrand.NodeID(), Config{
+		Email: EmailConfig{
+			SMTPServerAddress: "smtp.example.test",
+			From:              "node@example.test",
+			To:                "operator@example.test",
+			AuthType:          "plain",
+		},
+	})
+	require.Error(t, err)
+}
+
+// newAlert converts the notification the same way Send does.
+func newAlert(ctx context.Context, service *Service, notification notifications.Notification) Alert {
+	service.Send(ctx, notification)
+	return <-service.queue
+}
@@ -39,7 +39,7 @@ func (service *Service) Issue(ctx context.Context) (apiKey APIKey, err error) {
 	}
 
 	apiKey.Secret = secret
-	apiKey.CreatedAt = time.Now()
+	apiKey.CreatedAt = time.Now().UTC()
 
 	err = service.store.Store(ctx, apiKey)
 	if err != nil {
@@ -7,7 +7,9 @@ package collector
 import (
 	"context"
 	"errors"
+	"math"
 	"os"
+	"sync/atomic"
 	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
@@ -22,7 +24,13 @@ var mon = monkit.Package()
 
 // Config defines parameters for storage node Collector.
 type Config struct {
-	Interval time.Duration `help:"how frequently expired pieces are collected" default:"1h0m0s"`
+	Interval        time.Duration `help:"how frequently expired pieces are collected" default:"1h0m0s"`
+	BatchSize       int           `help:"how many expired pieces are fetched from the database at once" default:"1000"`
+	MaxBatches      int           `help:"maximum number of batches processed during a single collection" default:"100"`
+	Concurrency     int           `help:"how many expired pieces are deleted concurrently" default:"4"`
+	BucketSize      time.Duration `help:"size of the expiration time range processed at once, oldest first" default:"24h0m0s"`
+	RetryBackoff    time.Duration `help:"how long to wait before retrying a failed deletion, doubled with every failure" default:"1h0m0s"`
+	MaxRetryBackoff time.Duration `help:"maximum time to wait before retrying a failed deletion" default:"168h0m0s"`
 }
 
 // Service implements collecting expired pieces on the storage node.
@@ -32,6 +40,7 @@ type Service struct {
 	log         *zap.Logger
 	pieces      *pieces.Store
 	usedSerials *usedserials.Table
+	config      Config
 
 	Loop *sync2.Cycle
 }
@@ -42,6 +51,7 @@ func NewService(log *zap.Logger, pieces *pieces.Store, usedSerials *usedserials.
 		log:         log,
 		pieces:      pieces,
 		usedSerials: usedSerials,
+		config:      config,
 		Loop:        sync2.NewCycle(config.Interval),
 	}
 }
@@ -69,49 +79,201 @@ func (service *Service) Close() (err error) {
 }
 
 // Collect collects pieces that have expired by now.
+//
+// Expired pieces are processed in buckets of expiration time, oldest first,
+// each bucket in batches of BatchSize pieces deleted concurrently. Pieces
+// which failed to be deleted are retried with an exponential backoff.
 func (service *Service) Collect(ctx context.Context, now time.Time) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	service.usedSerials.DeleteExpired(now)
 
-	const maxBatches = 100
-	const batchSize = 1000
+	summary, err := service.pieces.GetExpirationSummary(ctx, now)
+	if err != nil {
+		return err
+	}
+	mon.IntVal("expired_pieces_pending").Observe(summary.Count)
+	mon.IntVal("expired_pieces_failed").Observe(summary.Failed)
+	if !summary.Oldest.IsZero() {
+		mon.FloatVal("expired_pieces_oldest_age_hours").Observe(now.Sub(summary.Oldest).Hours())
+	}
 
-	var count int64
+	var deleted, failed int64
 	defer func() {
-		if count > 0 {
-			service.log.Info("collect", zap.Int64("count", count))
+		mon.IntVal("expired_pieces_deleted").Observe(deleted)
+		mon.IntVal("expired_pieces_delete_failed").Observe(failed)
+		if deleted > 0 || failed > 0 {
+			service.log.Info("collect", zap.Int64("count", deleted), zap.Int64("failed", failed))
 		}
 	}()
 
-	for k := 0; k < maxBatches; k++ {
-		infos, err := service.pieces.GetExpired(ctx, now, batchSize)
+	batchSize := service.config.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	maxBatches := service.config.MaxBatches
+	if maxBatches <= 0 {
+		maxBatches = 100
+	}
+
+	// pieces stored with storage format V0 are not indexed by expiration
+	// time, so they are paged separately, taking turns with the buckets, so
+	// that neither starves the other.
+	buckets := newBucketCursor(summary.Oldest, now, service.config.BucketSize)
+	v0Done := false
+
+	batches := 0
+	for batches < maxBatches && !(buckets.done() && v0Done) {
+		if !buckets.done() {
+			infos, err := buckets.next(ctx, service.pieces, int64(batchSize))
+			if err != nil {
+				return err
+			}
+			if len(infos) > 0 {
+				batches++
+				batchDeleted, batchFailed := service.deleteBatch(ctx, infos, now)
+				deleted += batchDeleted
+				failed += batchFailed
+			}
+		}
+
+		if !v0Done && batches < maxBatches {
+			infos, err := service.pieces.GetExpiredV0(ctx, now, now, int64(batchSize))
+			if err != nil {
+				return err
+			}
+			v0Done = len(infos) < batchSize
+			if len(infos) > 0 {
+				batches++
+				batchDeleted, batchFailed := service.deleteBatch(ctx, infos, now)
+				deleted += batchDeleted
+				failed += batchFailed
+			}
+		}
+	}
+
+	return nil
+}
+
+// bucketCursor iterates over the expiration time buckets of indexed pieces,
+// oldest first.
+type bucketCursor struct {
+	from, to   time.Time
+	now        time.Time
+	bucketSize time.Duration
+}
+
+// newBucketCursor creates a cursor over the buckets from oldest up to now.
+func newBucketCursor(oldest, now time.Time, bucketSize time.Duration) *bucketCursor {
+	cursor := &bucketCursor{to: now, now: now, bucketSize: bucketSize}
+	if bucketSize > 0 && !oldest.IsZero() {
+		cursor.to = oldest.Truncate(bucketSize).Add(bucketSize)
+	}
+	return cursor
+}
+
+// done returns whether all buckets have been processed.
+func (cursor *bucketCursor) done() bool { return !cursor.from.Before(cursor.now) }
+
+// next returns the next batch of expired pieces, skipping empty buckets.
+func (cursor *bucketCursor) next(ctx context.Context, store *pieces.Store, limit int64) (_ []pieces.ExpiredInfo, err error) {
+	for !cursor.done() {
+		to := cursor.to
+		if to.After(cursor.now) {
+			to = cursor.now
+		}
+
+		infos, err := store.GetExpiredBetween(ctx, cursor.from, to, cursor.now, limit)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		if len(infos) == 0 {
-			return nil
+
+		if int64(len(infos)) < limit {
+			cursor.advance()
 		}
+		if len(infos) > 0 {
+			return infos, nil
+		}
+	}
+	return nil, nil
+}
 
-		for _, expired := range infos {
-			err := service.pieces.Delete(ctx, expired.SatelliteID, expired.PieceID)
-			if err != nil {
-				if os.IsNotExist(errors.Unwrap(err)) {
-					service.log.Info("file does not exist", zap.Stringer("Satellite ID", expired.SatelliteID), zap.Stringer("Piece ID", expired.PieceID))
-					continue
-				}
-				errfailed := service.pieces.DeleteFailed(ctx, expired, now)
-				if errfailed != nil {
-					service.log.Error("unable to update piece info", zap.Stringer("Satellite ID", expired.SatelliteID), zap.Stringer("Piece ID", expired.PieceID), zap.Error(errfailed))
-				}
-				service.log.Error("unable to delete piece", zap.Stringer("Satellite ID", expired.SatelliteID), zap.Stringer("Piece ID", expired.PieceID), zap.Error(err))
-				continue
+// advance moves the cursor to the next bucket.
+func (cursor *bucketCursor) advance() {
+	if cursor.bucketSize <= 0 {
+		cursor.from = cursor.now
+		return
+	}
+	cursor.from, cursor.to = cursor.to, cursor.to.Add(cursor.bucketSize)
+}
+
+// deleteBatch deletes expired pieces concurrently and returns the number of
+// deleted pieces and pieces which failed to be deleted.
+func (service *Service) deleteBatch(ctx context.Context, infos []pieces.ExpiredInfo, now time.Time) (deleted, failed int64) {
+	concurrency := service.config.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	limiter := sync2.NewLimiter(concurrency)
+	for _, expired := range infos {
+		expired := expired
+		started := limiter.Go(ctx, func() {
+			if service.delete(ctx, expired, now) {
+				atomic.AddInt64(&deleted, 1)
+			} else {
+				atomic.AddInt64(&failed, 1)
 			}
-			service.log.Info("delete expired", zap.Stringer("Satellite ID", expired.SatelliteID), zap.Stringer("Piece ID", expired.PieceID))
+		})
+		if !started {
+			break
+		}
+	}
+	limiter.Wait()
+
+	return deleted, failed
+}
 
-			count++
+// delete deletes a single expired piece and returns whether it succeeded.
+func (service *Service) delete(ctx context.Context, expired pieces.ExpiredInfo, now time.Time) bool {
+	err := service.pieces.Delete(ctx, expired.SatelliteID, expired.PieceID)
+	if err == nil {
+		service.log.Info("delete expired", zap.Stringer("Satellite ID", expired.SatelliteID), zap.Stringer("Piece ID", expired.PieceID))
+		return true
+	}
+
+	if os.IsNotExist(errors.Unwrap(err)) {
+		service.log.Info("file does not exist", zap.Stringer("Satellite ID", expired.SatelliteID), zap.Stringer("Piece ID", expired.PieceID))
+		// the piece is already gone, so make sure the expiration record is gone too.
+		if errdelete := service.pieces.DeleteExpiration(ctx, expired); errdelete != nil {
+			service.log.Error("unable to delete expiration record", zap.Stringer("Satellite ID", expired.SatelliteID), zap.Stringer("Piece ID", expired.PieceID), zap.Error(errdelete))
 		}
+		return true
 	}
 
-	return nil
+	retryAt := now.Add(service.retryBackoff(expired.DeletionFailures))
+	errfailed := service.pieces.DeleteFailed(ctx, expired, now, retryAt)
+	if errfailed != nil {
+		service.log.Error("unable to update piece info", zap.Stringer("Satellite ID", expired.SatelliteID), zap.Stringer("Piece ID", expired.PieceID), zap.Error(errfailed))
+	}
+	service.log.Error("unable to delete piece", zap.Stringer("Satellite ID", expired.SatelliteID), zap.Stringer("Piece ID", expired.PieceID), zap.Time("Retry At", retryAt), zap.Error(err))
+	return false
+}
+
+// retryBackoff returns how long to wait before retrying a deletion which
+// has already failed the given number of times. A zero MaxRetryBackoff does
+// not cap the backoff.
+func (service *Service) retryBackoff(failures int) time.Duration {
+	backoff := service.config.RetryBackoff
+	maxBackoff := service.config.MaxRetryBackoff
+	for i := 0; i < failures && backoff < math.MaxInt64/2; i++ {
+		if maxBackoff > 0 && backoff >= maxBackoff {
+			break
+		}
+		backoff *= 2
+	}
+	if maxBackoff > 0 && backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
 }
@@ -8,11 +8,19 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
 
 	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/storj"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
 	"storj.io/storj/private/testplanet"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/collector"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/piecestore/usedserials"
+	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
 )
 
 func TestCollector(t *testing.T) {
@@ -108,3 +116,106 @@ func TestCollector(t *testing.T) {
 		require.Equal(t, 0, serialsPresent)
 	})
 }
+
+func TestCollectorBuckets(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		store := pieces.NewStore(log, db.Pieces(), db.V0PieceInfo(), db.PieceExpirationDB(), db.PieceSpaceUsedDB(), pieces.DefaultConfig)
+
+		satelliteID := testrand.NodeID()
+		now := time.Now()
+
+		// pieces expired one per day during the last 10 days, oldest first
+		var pieceIDs []storj.PieceID
+		for i := 10; i > 0; i-- {
+			pieceID := testrand.PieceID()
+			pieceIDs = append(pieceIDs, pieceID)
+
+			w, err := store.Writer(ctx, satelliteID, pieceID)
+			require.NoError(t, err)
+			_, err = w.Write(testrand.Bytes(memory.KiB))
+			require.NoError(t, err)
+			require.NoError(t, w.Commit(ctx, &pb.PieceHeader{}))
+
+			require.NoError(t, store.SetExpiration(ctx, satelliteID, pieceID, now.Add(-time.Duration(i)*24*time.Hour)))
+		}
+
+		service := collector.NewService(log, store, usedserials.NewTable(memory.MiB), collector.Config{
+			BatchSize:   10,
+			MaxBatches:  3,
+			Concurrency: 2,
+			BucketSize:  24 * time.Hour,
+		})
+
+		// every piece is in its own bucket, so only the three oldest pieces
+		// are collected before the batch limit is reached
+		require.NoError(t, service.Collect(ctx, now))
+
+		summary, err := store.GetExpirationSummary(ctx, now)
+		require.NoError(t, err)
+		require.EqualValues(t, 7, summary.Count)
+
+		for i, pieceID := range pieceIDs {
+			r, err := store.Reader(ctx, satelliteID, pieceID)
+			if i < 3 {
+				require.Error(t, err, "piece %d should have been collected", i)
+				continue
+			}
+			require.NoError(t, err, "piece %d should not have been collected yet", i)
+			require.NoError(t, r.Close())
+		}
+
+		// the remaining pieces are collected on the next runs
+		for i := 0; i < 3; i++ {
+			require.NoError(t, service.Collect(ctx, now))
+		}
+
+		summary, err = store.GetExpirationSummary(ctx, now)
+		require.NoError(t, err)
+		require.Zero(t, summary.Count)
+	})
+}
+
+func TestCollectorV0(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		v0PieceInfo, ok := db.V0PieceInfo().(pieces.V0PieceInfoDBForTest)
+		require.True(t, ok, "V0PieceInfoDB can not satisfy V0PieceInfoDBForTest")
+		store := pieces.NewStore(log, db.Pieces(), v0PieceInfo, db.PieceExpirationDB(), db.PieceSpaceUsedDB(), pieces.DefaultConfig)
+
+		satelliteID := testrand.NodeID()
+		now := time.Now()
+
+		// more expired pieces than a single collection can process
+		for i := 10; i > 0; i-- {
+			require.NoError(t, store.SetExpiration(ctx, satelliteID, testrand.PieceID(), now.Add(-time.Duration(i)*24*time.Hour)))
+		}
+		for i := 0; i < 2; i++ {
+			require.NoError(t, v0PieceInfo.Add(ctx, &pieces.Info{
+				SatelliteID:     satelliteID,
+				PieceID:         testrand.PieceID(),
+				OrderLimit:      &pb.OrderLimit{},
+				UplinkPieceHash: &pb.PieceHash{},
+				PieceExpiration: now.Add(-time.Hour),
+			}))
+		}
+
+		service := collector.NewService(log, store, usedserials.NewTable(memory.MiB), collector.Config{
+			BatchSize:   1,
+			MaxBatches:  4,
+			Concurrency: 1,
+			BucketSize:  24 * time.Hour,
+		})
+
+		// the v0 pieces take turns with the buckets instead of waiting for them
+		require.NoError(t, service.Collect(ctx, now))
+
+		expired, err := store.GetExpiredV0(ctx, now, now, 10)
+		require.NoError(t, err)
+		require.Empty(t, expired)
+
+		summary, err := store.GetExpirationSummary(ctx, now)
+		require.NoError(t, err)
+		require.EqualValues(t, 8, summary.Count)
+	})
+}
@@ -0,0 +1,176 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/storagenode/gracefulexit"
+	"storj.io/storj/storagenode/satellites"
+)
+
+// ErrGracefulExitAPI - console graceful exit api error type.
+var ErrGracefulExitAPI = errs.Class("graceful exit console web error")
+
+// defaultTransferFailuresLimit is the number of failed transfers returned per satellite by default.
+const defaultTransferFailuresLimit = 10
+
+// GracefulExit is an api controller that exposes the progress of graceful exits.
+type GracefulExit struct {
+	service gracefulexit.Service
+
+	log *zap.Logger
+}
+
+// NewGracefulExit is a constructor for graceful exit controller.
+func NewGracefulExit(log *zap.Logger, service gracefulexit.Service) *GracefulExit {
+	return &GracefulExit{
+		log:     log,
+		service: service,
+	}
+}
+
+// GracefulExitProgress is the progress of a graceful exit from a satellite.
+type GracefulExitProgress struct {
+	SatelliteID       storj.NodeID `json:"satelliteID"`
+	InitiatedAt       *time.Time   `json:"initiatedAt"`
+	FinishedAt        *time.Time   `json:"finishedAt"`
+	Status            int32        `json:"status"`
+	StartingDiskUsage int64        `json:"startingDiskUsage"`
+	BytesDeleted      int64        `json:"bytesDeleted"`
+	PiecesTransferred int64        `json:"piecesTransferred"`
+	PiecesFailed      int64        `json:"piecesFailed"`
+	BytesTransferred  int64        `json:"bytesTransferred"`
+	// Throughput is the number of bytes transferred per second since the graceful exit was initiated.
+	Throughput float64               `json:"throughput"`
+	Stats      []GracefulExitStats   `json:"stats"`
+	Failures   []GracefulExitFailure `json:"failures"`
+}
+
+// GracefulExitStats aggregates the piece transfers with the same outcome.
+type GracefulExitStats struct {
+	Reason    string        `json:"reason"`
+	Pieces    int64         `json:"pieces"`
+	Bytes     int64         `json:"bytes"`
+	Duration  time.Duration `json:"duration"`
+	LastError string        `json:"lastError"`
+	LastAt    time.Time     `json:"lastAt"`
+}
+
+// GracefulExitFailure is a failed piece transfer.
+type GracefulExitFailure struct {
+	PieceID  storj.PieceID `json:"pieceID"`
+	Reason   string        `json:"reason"`
+	Bytes    int64         `json:"bytes"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error"`
+	FailedAt time.Time     `json:"failedAt"`
+}
+
+// Progress returns the progress of graceful exits with transfer statistics and the latest failed transfers,
+// the number of which is specified by query parameter limit.
+func (controller *GracefulExit) Progress(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	limit := defaultTransferFailuresLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 0 {
+			controller.serveJSONError(w, http.StatusBadRequest, ErrGracefulExitAPI.New("invalid limit %q", value))
+			return
+		}
+	}
+
+	progress, err := controller.service.ListTransferProgress(ctx, limit)
+	if err != nil {
+		controller.serveJSONError(w, http.StatusInternalServerError, ErrGracefulExitAPI.Wrap(err))
+		return
+	}
+
+	now := time.Now()
+	response := make([]GracefulExitProgress, 0, len(progress))
+	for _, sat := range progress {
+		response = append(response, newGracefulExitProgress(sat, now))
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		controller.log.Error("failed to encode json response", zap.Error(ErrGracefulExitAPI.Wrap(err)))
+		return
+	}
+}
+
+// newGracefulExitProgress converts the transfer progress of a satellite into its api representation.
+func newGracefulExitProgress(progress gracefulexit.TransferProgress, now time.Time) GracefulExitProgress {
+	result := GracefulExitProgress{
+		SatelliteID:       progress.SatelliteID,
+		InitiatedAt:       progress.InitiatedAt,
+		FinishedAt:        progress.FinishedAt,
+		Status:            progress.Status,
+		StartingDiskUsage: progress.StartingDiskUsage,
+		BytesDeleted:      progress.BytesDeleted,
+		Stats:             []GracefulExitStats{},
+		Failures:          []GracefulExitFailure{},
+	}
+
+	for _, stat := range progress.Stats {
+		if stat.Reason == satellites.TransferSucceeded {
+			result.PiecesTransferred += stat.Pieces
+			result.BytesTransferred += stat.Bytes
+		} else {
+			result.PiecesFailed += stat.Pieces
+		}
+		result.Stats = append(result.Stats, GracefulExitStats(stat))
+	}
+
+	for _, failure := range progress.Failures {
+		result.Failures = append(result.Failures, GracefulExitFailure{
+			PieceID:  failure.PieceID,
+			Reason:   failure.Reason,
+			Bytes:    failure.Bytes,
+			Duration: failure.Duration,
+			Error:    failure.Error,
+			FailedAt: failure.FinishedAt,
+		})
+	}
+
+	if progress.InitiatedAt != nil {
+		end := now
+		if progress.FinishedAt != nil {
+			end = *progress.FinishedAt
+		}
+		if elapsed := end.Sub(*progress.InitiatedAt); elapsed > 0 {
+			result.Throughput = float64(result.BytesTransferred) / elapsed.Seconds()
+		}
+	}
+
+	return result
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (controller *GracefulExit) serveJSONError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		controller.log.Error("failed to write json error response", zap.Error(ErrGracefulExitAPI.Wrap(err)))
+		return
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/pb"
+	"storj.io/storj/storagenode/console"
+	"storj.io/storj/storagenode/transferstats"
+)
+
+// ErrMetricsAPI - console metrics api error type.
+var ErrMetricsAPI = errs.Class("metrics console web error")
+
+// Metrics is an api controller that exposes operator level metrics in Prometheus text format.
+type Metrics struct {
+	service *console.Service
+
+	log *zap.Logger
+}
+
+// NewMetrics is a constructor for metrics controller.
+func NewMetrics(log *zap.Logger, service *console.Service) *Metrics {
+	return &Metrics{
+		log:     log,
+		service: service,
+	}
+}
+
+// Metrics writes the metrics of the node in Prometheus text format.
+func (controller *Metrics) Metrics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	metrics, err := controller.service.GetMetrics(ctx)
+	if err != nil {
+		controller.log.Error("failed to collect metrics", zap.Error(ErrMetricsAPI.Wrap(err)))
+		http.Error(w, ErrMetricsAPI.Wrap(err).Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var buffer bytes.Buffer
+	WritePrometheusMetrics(&buffer, metrics)
+
+	w.Header().Set(contentType, "text/plain; version=0.0.4; charset=utf-8")
+	if _, err := w.Write(buffer.Bytes()); err != nil {
+		controller.log.Error("failed to write metrics response", zap.Error(ErrMetricsAPI.Wrap(err)))
+		return
+	}
+}
+
+// WritePrometheusMetrics writes metrics in Prometheus text format.
+func WritePrometheusMetrics(w io.Writer, metrics *console.Metrics) {
+	node := prometheusLabels{"node_id", metrics.NodeID.String()}
+
+	writeMetricHeader(w, "storagenode_disk_space_bytes", "gauge", "Disk space of the node by kind: allocated, used, trash or free.")
+	writeMetric(w, "storagenode_disk_space_bytes", node.with("kind", "allocated"), float64(metrics.Allocated))
+	writeMetric(w, "storagenode_disk_space_bytes", node.with("kind", "used"), float64(metrics.Used))
+	writeMetric(w, "storagenode_disk_space_bytes", node.with("kind", "trash"), float64(metrics.Trash))
+	writeMetric(w, "storagenode_disk_space_bytes", node.with("kind", "free"), float64(metrics.Free))
+
+	satelliteLabels := make([]prometheusLabels, len(metrics.Satellites))
+	for i, satellite := range metrics.Satellites {
+		satelliteLabels[i] = node.with("satellite_id", satellite.ID.String(), "satellite", satellite.URL)
+	}
+
+	gauges := []struct {
+		name  string
+		help  string
+		value func(satellite console.SatelliteMetrics) float64
+	}{
+		{"storagenode_satellite_used_bytes", "Space used by pieces of the satellite.", func(satellite console.SatelliteMetrics) float64 { return float64(satellite.Used) }},
+		{"storagenode_satellite_trash_bytes", "Space used by trashed pieces of the satellite.", func(satellite console.SatelliteMetrics) float64 { return float64(satellite.Trash) }},
+		{"storagenode_audit_score", "Audit score of the node on the satellite.", func(satellite console.SatelliteMetrics) float64 { return satellite.Audit.Score }},
+		{"storagenode_suspension_score", "Suspension score of the node on the satellite.", func(satellite console.SatelliteMetrics) float64 { return satellite.Audit.SuspensionScore }},
+		{"storagenode_uptime_score", "Uptime score of the node on the satellite.", func(satellite console.SatelliteMetrics) float64 { return satellite.UptimeScore }},
+		{"storagenode_online_score", "Online score of the node on the satellite.", func(satellite console.SatelliteMetrics) float64 { return satellite.OnlineScore }},
+		{"storagenode_audits_total", "Number of audits of the node on the satellite, nodes are vetted after enough successful audits.", func(satellite console.SatelliteMetrics) float64 { return float64(satellite.Audit.TotalCount) }},
+		{"storagenode_audits_success", "Number of successful audits of the node on the satellite.", func(satellite console.SatelliteMetrics) float64 { return float64(satellite.Audit.SuccessCount) }},
+		{"storagenode_disqualified", "Whether the node is disqualified on the satellite.", func(satellite console.SatelliteMetrics) float64 { return boolToFloat(satellite.Disqualified) }},
+		{"storagenode_suspended", "Whether the node is suspended on the satellite.", func(satellite console.SatelliteMetrics) float64 { return boolToFloat(satellite.Suspended) }},
+		{"storagenode_offline_suspended", "Whether the node is suspended for being offline on the satellite.", func(satellite console.SatelliteMetrics) float64 { return boolToFloat(satellite.OfflineSuspended) }},
+		{"storagenode_estimated_payout_cents", "Estimated payout of the current month in cents.", func(satellite console.SatelliteMetrics) float64 { return satellite.EstimatedPayout }},
+	}
+	for _, gauge := range gauges {
+		writeMetricHeader(w, gauge.name, "gauge", gauge.help)
+		for i, satellite := range metrics.Satellites {
+			writeMetric(w, gauge.name, satelliteLabels[i], gauge.value(satellite))
+		}
+	}
+
+	// satellites which don't report vetting are left out instead of
+	// reporting the node as not vetted.
+	writeMetricHeader(w, "storagenode_vetted", "gauge", "Whether the node is vetted on the satellite.")
+	for i, satellite := range metrics.Satellites {
+		if satellite.VettingAuditCount > 0 {
+			writeMetric(w, "storagenode_vetted", satelliteLabels[i], boolToFloat(satellite.Vetted))
+		}
+	}
+	writeMetricHeader(w, "storagenode_vetting_audits_required", "gauge", "Number of audits the satellite requires to vet the node.")
+	for i, satellite := range metrics.Satellites {
+		if satellite.VettingAuditCount > 0 {
+			writeMetric(w, "storagenode_vetting_audits_required", satelliteLabels[i], float64(satellite.VettingAuditCount))
+		}
+	}
+
+	writeMetricHeader(w, "storagenode_bandwidth_bytes", "gauge", "Bandwidth used in the current month by action.")
+	for i, satellite := range metrics.Satellites {
+		usage := satellite.Bandwidth
+		for _, action := range []struct {
+			name   string
+			amount int64
+		}{
+			{"put", usage.Put},
+			{"get", usage.Get},
+			{"get_audit", usage.GetAudit},
+			{"get_repair", usage.GetRepair},
+			{"put_repair", usage.PutRepair},
+			{"delete", usage.Delete},
+		} {
+			writeMetric(w, "storagenode_bandwidth_bytes", satelliteLabels[i].with("action", action.name), float64(action.amount))
+		}
+	}
+
+	writeMetricHeader(w, "storagenode_transfers", "gauge", "Transfers in the last 24 hours by action and status.")
+	for i, satellite := range metrics.Satellites {
+		for _, action := range sortedActions(satellite.Transfers) {
+			counts := satellite.Transfers[action]
+			labels := satelliteLabels[i].with("action", strings.ToLower(action.String()))
+			writeMetric(w, "storagenode_transfers", labels.with("status", "success"), float64(counts.Success))
+			writeMetric(w, "storagenode_transfers", labels.with("status", "cancel"), float64(counts.Cancel))
+			writeMetric(w, "storagenode_transfers", labels.with("status", "failure"), float64(counts.Failure))
+		}
+	}
+
+	writeMetricHeader(w, "storagenode_transfer_success_rate", "gauge", "Share of successful transfers in the last 24 hours by action.")
+	for i, satellite := range metrics.Satellites {
+		for _, action := range sortedActions(satellite.Transfers) {
+			labels := satelliteLabels[i].with("action", strings.ToLower(action.String()))
+			writeMetric(w, "storagenode_transfer_success_rate", labels, satellite.Transfers[action].SuccessRate())
+		}
+	}
+}
+
+// sortedActions returns the actions of the transfers in a stable order.
+func sortedActions(transfers map[pb.PieceAction]transferstats.Counts) []pb.PieceAction {
+	actions := make([]pb.PieceAction, 0, len(transfers))
+	for action := range transfers {
+		actions = append(actions, action)
+	}
+	sort.Slice(actions, func(i, k int) bool { return actions[i] < actions[k] })
+	return actions
+}
+
+// labelValueEscaper escapes label values as required by the text format.
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// prometheusLabels are label names and values, alternating.
+type prometheusLabels []string
+
+// with returns a copy of labels with additional names and values.
+func (labels prometheusLabels) with(namesAndValues ...string) prometheusLabels {
+	result := make(prometheusLabels, 0, len(labels)+len(namesAndValues))
+	result = append(result, labels...)
+	return append(result, namesAndValues...)
+}
+
+// String formats the labels as {name="value",...}.
+func (labels prometheusLabels) String() string {
+	pairs := make([]string, 0, len(labels)/2)
+	for i := 0; i+1 < len(labels); i += 2 {
+		pairs = append(pairs, labels[i]+`="`+labelValueEscaper.Replace(labels[i+1])+`"`)
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func writeMetricHeader(w io.Writer, name, typ, help string) {
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	_, _ = fmt.Fprintf(w, "# TYPE %s %s\n", name, typ)
+}
+
+func writeMetric(w io.Writer, name string, labels prometheusLabels, value float64) {
+	_, _ = fmt.Fprintf(w, "%s%s %s\n", name, labels, strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+func boolToFloat(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/storj/storagenode/bandwidth"
+	"storj.io/storj/storagenode/console"
+	"storj.io/storj/storagenode/console/consoleapi"
+	"storj.io/storj/storagenode/transferstats"
+)
+
+func TestWritePrometheusMetrics(t *testing.T) {
+	nodeID := storj.NodeID{1}
+	satelliteID := storj.NodeID{2}
+	oldSatelliteID := storj.NodeID{3}
+
+	var buffer bytes.Buffer
+	consoleapi.WritePrometheusMetrics(&buffer, &console.Metrics{
+		NodeID:    nodeID,
+		Allocated: 1000,
+		Used:      300,
+		Trash:     100,
+		Free:      600,
+		Satellites: []console.SatelliteMetrics{{
+			ID:  satelliteID,
+			URL: `satellite.test:7777"`,
+			Audit: console.ReputationMetric{
+				TotalCount:   50,
+				SuccessCount: 49,
+				Score:        0.95,
+			},
+			Suspended:         true,
+			Vetted:            true,
+			VettingAuditCount: 100,
+			Bandwidth:         bandwidth.Usage{Get: 2048},
+			Transfers: map[pb.PieceAction]transferstats.Counts{
+				pb.PieceAction_GET_AUDIT: {Success: 3, Cancel: 0, Failure: 1},
+			},
+			EstimatedPayout: 12.5,
+		}, {
+			// a satellite which doesn't report vetting.
+			ID:  oldSatelliteID,
+			URL: "old.test:7777",
+		}},
+	})
+
+	node := `node_id="` + nodeID.String() + `"`
+	satellite := node + `,satellite_id="` + satelliteID.String() + `",satellite="satellite.test:7777\""`
+	oldSatellite := node + `,satellite_id="` + oldSatelliteID.String() + `",satellite="old.test:7777"`
+
+	output := buffer.String()
+	require.Contains(t, output, "# TYPE storagenode_disk_space_bytes gauge\n")
+	require.Contains(t, output, "storagenode_disk_space_bytes{"+node+`,kind="free"} 600`+"\n")
+	require.Contains(t, output, "storagenode_audit_score{"+satellite+"} 0.95\n")
+	require.Contains(t, output, "storagenode_audits_total{"+satellite+"} 50\n")
+	require.Contains(t, output, "storagenode_suspended{"+satellite+"} 1\n")
+	require.Contains(t, output, "storagenode_disqualified{"+satellite+"} 0\n")
+	require.Contains(t, output, "storagenode_estimated_payout_cents{"+satellite+"} 12.5\n")
+	require.Contains(t, output, "storagenode_bandwidth_bytes{"+satellite+`,action="get"} 2048`+"\n")
+	require.Contains(t, output, "storagenode_vetted{"+satellite+"} 1\n")
+	require.Contains(t, output, "storagenode_vetting_audits_required{"+satellite+"} 100\n")
+	require.Contains(t, output, "storagenode_audits_total{"+oldSatellite+"} 0\n")
+	require.NotContains(t, output, "storagenode_vetted{"+oldSatellite+"}")
+	require.NotContains(t, output, "storagenode_vetting_audits_required{"+oldSatellite+"}")
+	require.Contains(t, output, "storagenode_transfers{"+satellite+`,action="get_audit",status="failure"} 1`+"\n")
+	require.Contains(t, output, "storagenode_transfer_success_rate{"+satellite+`,action="get_audit"} 0.75`+"\n")
+}
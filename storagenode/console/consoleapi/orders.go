@@ -0,0 +1,83 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/storagenode/orders"
+)
+
+// ErrOrdersAPI - console orders api error type.
+var ErrOrdersAPI = errs.Class("orders console web error")
+
+// Orders is an api controller that exposes sending of orders.
+type Orders struct {
+	service *orders.Service
+
+	log *zap.Logger
+}
+
+// NewOrders is a constructor for orders controller.
+func NewOrders(log *zap.Logger, service *orders.Service) *Orders {
+	return &Orders{
+		log:     log,
+		service: service,
+	}
+}
+
+// Send immediately sends the unsent orders of the satellite specified by the id path parameter.
+func (controller *Orders) Send(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	satelliteID, err := storj.NodeIDFromString(mux.Vars(r)["id"])
+	if err != nil {
+		controller.serveJSONError(w, http.StatusBadRequest, ErrOrdersAPI.Wrap(err))
+		return
+	}
+
+	windows, err := controller.service.SendSatellite(ctx, satelliteID, time.Now())
+	if err != nil {
+		controller.serveJSONError(w, http.StatusInternalServerError, ErrOrdersAPI.Wrap(err))
+		return
+	}
+
+	var response struct {
+		Windows int `json:"windows"`
+	}
+	response.Windows = windows
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		controller.log.Error("failed to encode json response", zap.Error(ErrOrdersAPI.Wrap(err)))
+		return
+	}
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (controller *Orders) serveJSONError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		controller.log.Error("failed to write json error response", zap.Error(ErrOrdersAPI.Wrap(err)))
+		return
+	}
+}
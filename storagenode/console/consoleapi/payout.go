@@ -244,6 +244,56 @@ func (payouts *Payout) HeldAmountPeriods(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// Reconciliation compares the usage measured by the node with the paystubs for selected range of months
+// for all satellites or specified satellite by query parameter id.
+func (payouts *Payout) Reconciliation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	segmentParams := mux.Vars(r)
+	queryParams := r.URL.Query()
+
+	start, ok := segmentParams["start"]
+	if !ok {
+		payouts.serveJSONError(w, http.StatusBadRequest, ErrPayoutAPI.New("period start is missing"))
+		return
+	}
+
+	end, ok := segmentParams["end"]
+	if !ok {
+		payouts.serveJSONError(w, http.StatusBadRequest, ErrPayoutAPI.New("period end is missing"))
+		return
+	}
+
+	var satelliteID storj.NodeID
+	if id := queryParams.Get("id"); id != "" {
+		satelliteID, err = storj.NodeIDFromString(id)
+		if err != nil {
+			payouts.serveJSONError(w, http.StatusBadRequest, ErrPayoutAPI.Wrap(err))
+			return
+		}
+	}
+
+	reconciliations, err := payouts.service.Reconcile(ctx, satelliteID, start, end)
+	if err != nil {
+		if payout.ErrBadPeriod.Has(err) {
+			payouts.serveJSONError(w, http.StatusBadRequest, ErrPayoutAPI.Wrap(err))
+			return
+		}
+
+		payouts.serveJSONError(w, http.StatusInternalServerError, ErrPayoutAPI.Wrap(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(reconciliations); err != nil {
+		payouts.log.Error("failed to encode json response", zap.Error(ErrPayoutAPI.Wrap(err)))
+		return
+	}
+}
+
 // serveJSONError writes JSON error to response output stream.
 func (payouts *Payout) serveJSONError(w http.ResponseWriter, status int, err error) {
 	w.WriteHeader(status)
@@ -0,0 +1,94 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/storagenode/retain"
+)
+
+// ErrRetainAPI - console retain api error type.
+var ErrRetainAPI = errs.Class("retain console web error")
+
+// defaultRetainReportsLimit is the amount of reports returned when no limit is specified.
+const defaultRetainReportsLimit = 20
+
+// Retain is an api controller that exposes retain requests history.
+type Retain struct {
+	service *retain.Service
+
+	log *zap.Logger
+}
+
+// NewRetain is a constructor for retain controller.
+func NewRetain(log *zap.Logger, service *retain.Service) *Retain {
+	return &Retain{
+		log:     log,
+		service: service,
+	}
+}
+
+// Reports returns latest reports of retain requests from all satellites or specified satellite by query parameter id.
+func (controller *Retain) Reports(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	queryParams := r.URL.Query()
+
+	limit := defaultRetainReportsLimit
+	if value := queryParams.Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			controller.serveJSONError(w, http.StatusBadRequest, ErrRetainAPI.New("invalid limit %q", value))
+			return
+		}
+	}
+
+	var satelliteID storj.NodeID
+	if id := queryParams.Get("id"); id != "" {
+		satelliteID, err = storj.NodeIDFromString(id)
+		if err != nil {
+			controller.serveJSONError(w, http.StatusBadRequest, ErrRetainAPI.Wrap(err))
+			return
+		}
+	}
+
+	reports, err := controller.service.Reports(ctx, satelliteID, limit)
+	if err != nil {
+		controller.serveJSONError(w, http.StatusInternalServerError, ErrRetainAPI.Wrap(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(reports); err != nil {
+		controller.log.Error("failed to encode json response", zap.Error(ErrRetainAPI.Wrap(err)))
+		return
+	}
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (controller *Retain) serveJSONError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		controller.log.Error("failed to write json error response", zap.Error(ErrRetainAPI.Wrap(err)))
+		return
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/storagenode/transferstats"
+)
+
+// ErrTransferStatsAPI - console transfer stats api error type.
+var ErrTransferStatsAPI = errs.Class("transfer stats console web error")
+
+// defaultTransferStatsPeriod is the period covered when no start is specified.
+const defaultTransferStatsPeriod = 24 * time.Hour
+
+// TransferStats is an api controller that exposes success rates of transfers.
+type TransferStats struct {
+	service *transferstats.Service
+
+	log *zap.Logger
+}
+
+// NewTransferStats is a constructor for transfer stats controller.
+func NewTransferStats(log *zap.Logger, service *transferstats.Service) *TransferStats {
+	return &TransferStats{
+		log:     log,
+		service: service,
+	}
+}
+
+// TransferStatsItem contains the transfer outcomes of a satellite and action.
+type TransferStatsItem struct {
+	SatelliteID storj.NodeID `json:"satelliteId"`
+	Action      string       `json:"action"`
+	Success     int64        `json:"success"`
+	Cancel      int64        `json:"cancel"`
+	Failure     int64        `json:"failure"`
+	SuccessRate float64      `json:"successRate"`
+}
+
+// Stats returns transfer outcomes per satellite and action between the from and to
+// query parameters, formatted as RFC3339. By default the last 24 hours are returned.
+// Results can be limited to a single satellite with the id query parameter.
+func (controller *TransferStats) Stats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	queryParams := r.URL.Query()
+
+	to := time.Now()
+	if value := queryParams.Get("to"); value != "" {
+		to, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			controller.serveJSONError(w, http.StatusBadRequest, ErrTransferStatsAPI.New("invalid to %q", value))
+			return
+		}
+	}
+
+	from := to.Add(-defaultTransferStatsPeriod)
+	if value := queryParams.Get("from"); value != "" {
+		from, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			controller.serveJSONError(w, http.StatusBadRequest, ErrTransferStatsAPI.New("invalid from %q", value))
+			return
+		}
+	}
+
+	if !from.Before(to) {
+		controller.serveJSONError(w, http.StatusBadRequest, ErrTransferStatsAPI.New("from must be before to"))
+		return
+	}
+
+	var satelliteID storj.NodeID
+	if id := queryParams.Get("id"); id != "" {
+		satelliteID, err = storj.NodeIDFromString(id)
+		if err != nil {
+			controller.serveJSONError(w, http.StatusBadRequest, ErrTransferStatsAPI.Wrap(err))
+			return
+		}
+	}
+
+	stats, err := controller.service.Summary(ctx, satelliteID, from, to)
+	if err != nil {
+		controller.serveJSONError(w, http.StatusInternalServerError, ErrTransferStatsAPI.Wrap(err))
+		return
+	}
+
+	items := make([]TransferStatsItem, 0, len(stats))
+	for _, stat := range stats {
+		items = append(items, TransferStatsItem{
+			SatelliteID: stat.SatelliteID,
+			Action:      stat.Action.String(),
+			Success:     stat.Success,
+			Cancel:      stat.Cancel,
+			Failure:     stat.Failure,
+			SuccessRate: stat.SuccessRate(),
+		})
+	}
+
+	if err := json.NewEncoder(w).Encode(items); err != nil {
+		controller.log.Error("failed to encode json response", zap.Error(ErrTransferStatsAPI.Wrap(err)))
+		return
+	}
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (controller *TransferStats) serveJSONError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		controller.log.Error("failed to write json error response", zap.Error(ErrTransferStatsAPI.Wrap(err)))
+		return
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/storagenode/untrusted"
+)
+
+// ErrUntrustedAPI - console untrusted satellites api error type.
+var ErrUntrustedAPI = errs.Class("untrusted satellites console web error")
+
+// Untrusted is an api controller that exposes what happens to the data of satellites which are no longer trusted.
+type Untrusted struct {
+	chore *untrusted.Chore
+
+	log *zap.Logger
+}
+
+// NewUntrusted is a constructor for untrusted satellites controller.
+func NewUntrusted(log *zap.Logger, chore *untrusted.Chore) *Untrusted {
+	return &Untrusted{
+		log:   log,
+		chore: chore,
+	}
+}
+
+// Preview returns the satellites which are no longer trusted, with when and whether their data is deleted.
+func (controller *Untrusted) Preview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	plans, err := controller.chore.Preview(ctx)
+	if err != nil {
+		controller.serveJSONError(w, http.StatusInternalServerError, ErrUntrustedAPI.Wrap(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(plans); err != nil {
+		controller.log.Error("failed to encode json response", zap.Error(ErrUntrustedAPI.Wrap(err)))
+		return
+	}
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (controller *Untrusted) serveJSONError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		controller.log.Error("failed to write json error response", zap.Error(ErrUntrustedAPI.Wrap(err)))
+		return
+	}
+}
@@ -0,0 +1,354 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/private/web"
+	"storj.io/storj/storagenode/apikeys"
+)
+
+// ErrAuth is console authentication error type.
+var ErrAuth = errs.Class("console authentication error")
+
+// sessionCookieName is the name of the cookie holding the session token of the web UI.
+const sessionCookieName = "_session"
+
+// AuthConfig contains configuration for authentication of the console api.
+//
+// Authentication is enabled when a password or a token is configured. Signing
+// in with the password and using the token grant the admin scope, api keys
+// issued with the issue-apikey command grant the read-only scope.
+type AuthConfig struct {
+	Password        string        `help:"password for signing in to the dashboard, when set the console api requires authentication" default:""`
+	Token           string        `help:"bearer token granting admin access to the console api, when set the console api requires authentication" default:""`
+	SessionDuration time.Duration `help:"how long a dashboard session is valid after signing in" default:"24h0m0s"`
+
+	LoginRateLimit web.IPRateLimiterConfig
+}
+
+// Enabled returns whether authentication is required.
+func (config AuthConfig) Enabled() bool {
+	return config.Password != "" || config.Token != ""
+}
+
+// Scope is the level of access to the console api.
+type Scope int
+
+const (
+	// ScopeNone grants no access.
+	ScopeNone Scope = iota
+	// ScopeReadOnly grants access to endpoints which do not change the node.
+	ScopeReadOnly
+	// ScopeAdmin grants access to all endpoints.
+	ScopeAdmin
+)
+
+// String returns the name of the scope.
+func (scope Scope) String() string {
+	switch scope {
+	case ScopeReadOnly:
+		return "read-only"
+	case ScopeAdmin:
+		return "admin"
+	default:
+		return "none"
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (scope Scope) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + scope.String() + `"`), nil
+}
+
+// session is a signed in dashboard session.
+type session struct {
+	scope     Scope
+	expiresAt time.Time
+}
+
+// Auth authenticates requests to the console api with session cookies and bearer tokens.
+type Auth struct {
+	log     *zap.Logger
+	apiKeys apikeys.DB
+	config  AuthConfig
+
+	// loginLimiter throttles sign in attempts per IP.
+	loginLimiter *web.IPRateLimiter
+
+	mu       sync.Mutex
+	sessions map[apikeys.Secret]session
+}
+
+// NewAuth creates a new console authenticator.
+func NewAuth(log *zap.Logger, apiKeys apikeys.DB, config AuthConfig) *Auth {
+	return &Auth{
+		log:      log,
+		apiKeys:  apiKeys,
+		config:   config,
+		sessions: map[apikeys.Secret]session{},
+
+		loginLimiter: web.NewIPRateLimiter(config.LoginRateLimit),
+	}
+}
+
+// Run cleans up the sign in rate limits until the context is canceled.
+func (auth *Auth) Run(ctx context.Context) {
+	// a zero duration doesn't limit and has nothing to clean up.
+	if auth.config.LoginRateLimit.Duration <= 0 {
+		return
+	}
+	auth.loginLimiter.Run(ctx)
+}
+
+// LoginHandler returns the Login handler throttled per IP, so that the
+// password can't be guessed quickly.
+func (auth *Auth) LoginHandler() http.Handler {
+	return auth.loginLimiter.Limit(http.HandlerFunc(auth.Login))
+}
+
+// Middleware rejects requests to the api which do not have the scope required
+// by the request method. Requests which only read data require the read-only
+// scope, all other requests require the admin scope. Static assets and the auth
+// endpoints are always accessible.
+func (auth *Auth) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !auth.config.Enabled() || !requiresAuth(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		required := ScopeAdmin
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			required = ScopeReadOnly
+		}
+
+		scope := auth.scope(r.Context(), r, time.Now())
+		switch {
+		case scope == ScopeNone:
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			auth.serveJSONError(w, http.StatusUnauthorized, ErrAuth.New("authentication required"))
+		case scope < required:
+			auth.serveJSONError(w, http.StatusForbidden, ErrAuth.New("%s access required", required))
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// requiresAuth returns whether the path is protected.
+func requiresAuth(path string) bool {
+	if strings.HasPrefix(path, "/api/auth/") || path == "/api/auth" {
+		return false
+	}
+	return strings.HasPrefix(path, "/api/") || path == "/metrics"
+}
+
+// scope returns the scope granted to the request by its bearer token or session cookie.
+func (auth *Auth) scope(ctx context.Context, r *http.Request, now time.Time) Scope {
+	if header := r.Header.Get("Authorization"); header != "" {
+		token := strings.TrimPrefix(header, "Bearer ")
+		if token == header {
+			return ScopeNone
+		}
+		return auth.tokenScope(ctx, token)
+	}
+
+	cookie, err := r.Cookie(sessionCookieName)
+	if err != nil {
+		return ScopeNone
+	}
+	return auth.sessionScope(cookie.Value, now)
+}
+
+// tokenScope returns the scope of a bearer token.
+func (auth *Auth) tokenScope(ctx context.Context, token string) Scope {
+	if auth.config.Token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(auth.config.Token)) == 1 {
+		return ScopeAdmin
+	}
+
+	secret, err := apikeys.TokenSecretFromBase64(token)
+	if err != nil || secret.IsZero() || auth.apiKeys == nil {
+		return ScopeNone
+	}
+
+	err = auth.apiKeys.Check(ctx, secret)
+	if err != nil {
+		if !apikeys.ErrNoSecret.Has(err) {
+			auth.log.Error("failed to check api key", zap.Error(ErrAuth.Wrap(err)))
+		}
+		return ScopeNone
+	}
+	return ScopeReadOnly
+}
+
+// sessionScope returns the scope of a session token.
+func (auth *Auth) sessionScope(token string, now time.Time) Scope {
+	secret, err := apikeys.TokenSecretFromBase64(token)
+	if err != nil || secret.IsZero() {
+		return ScopeNone
+	}
+
+	auth.mu.Lock()
+	defer auth.mu.Unlock()
+
+	session, ok := auth.sessions[secret]
+	if !ok {
+		return ScopeNone
+	}
+	if !now.Before(session.expiresAt) {
+		delete(auth.sessions, secret)
+		return ScopeNone
+	}
+	return session.scope
+}
+
+// newSession creates a session with the given scope.
+func (auth *Auth) newSession(scope Scope, now time.Time) (apikeys.Secret, time.Time, error) {
+	secret, err := apikeys.NewSecret()
+	if err != nil {
+		return apikeys.Secret{}, time.Time{}, ErrAuth.Wrap(err)
+	}
+	expiresAt := now.Add(auth.config.SessionDuration)
+
+	auth.mu.Lock()
+	defer auth.mu.Unlock()
+
+	for token, session := range auth.sessions {
+		if !now.Before(session.expiresAt) {
+			delete(auth.sessions, token)
+		}
+	}
+	auth.sessions[secret] = session{scope: scope, expiresAt: expiresAt}
+
+	return secret, expiresAt, nil
+}
+
+// Status returns whether authentication is enabled and the scope of the request.
+func (auth *Auth) Status(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var response struct {
+		Enabled bool  `json:"enabled"`
+		Scope   Scope `json:"scope"`
+	}
+	response.Enabled = auth.config.Enabled()
+	response.Scope = ScopeAdmin
+	if response.Enabled {
+		response.Scope = auth.scope(ctx, r, time.Now())
+	}
+
+	if err = json.NewEncoder(w).Encode(response); err != nil {
+		auth.log.Error("failed to encode json response", zap.Error(ErrAuth.Wrap(err)))
+		return
+	}
+}
+
+// Login signs in to the dashboard with the configured password and sets the session cookie.
+func (auth *Auth) Login(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var request struct {
+		Password string `json:"password"`
+	}
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		auth.serveJSONError(w, http.StatusBadRequest, ErrAuth.Wrap(err))
+		return
+	}
+
+	if auth.config.Password == "" || subtle.ConstantTimeCompare([]byte(request.Password), []byte(auth.config.Password)) != 1 {
+		auth.log.Warn("failed console sign in attempt", zap.String("Remote Address", r.RemoteAddr))
+		auth.serveJSONError(w, http.StatusUnauthorized, ErrAuth.New("invalid password"))
+		return
+	}
+
+	token, expiresAt, err := auth.newSession(ScopeAdmin, time.Now())
+	if err != nil {
+		auth.serveJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    token.String(),
+		Path:     "/",
+		Expires:  expiresAt,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	var response struct {
+		Scope     Scope     `json:"scope"`
+		ExpiresAt time.Time `json:"expiresAt"`
+	}
+	response.Scope = ScopeAdmin
+	response.ExpiresAt = expiresAt
+
+	if err = json.NewEncoder(w).Encode(response); err != nil {
+		auth.log.Error("failed to encode json response", zap.Error(ErrAuth.Wrap(err)))
+		return
+	}
+}
+
+// Logout ends the dashboard session and removes the session cookie.
+func (auth *Auth) Logout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	if cookie, err := r.Cookie(sessionCookieName); err == nil {
+		if secret, err := apikeys.TokenSecretFromBase64(cookie.Value); err == nil {
+			auth.mu.Lock()
+			delete(auth.sessions, secret)
+			auth.mu.Unlock()
+		}
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    "",
+		Path:     "/",
+		Expires:  time.Unix(0, 0),
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (auth *Auth) serveJSONError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		auth.log.Error("failed to write json error response", zap.Error(ErrAuth.Wrap(err)))
+		return
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleserver_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/web"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/apikeys"
+	"storj.io/storj/storagenode/console/consoleserver"
+	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
+)
+
+func TestAuth(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		apiKey, err := apikeys.NewService(db.Secret()).Issue(ctx)
+		require.NoError(t, err)
+
+		auth := consoleserver.NewAuth(zaptest.NewLogger(t), db.Secret(), consoleserver.AuthConfig{
+			Password:        "password",
+			Token:           "admin-token",
+			SessionDuration: time.Hour,
+		})
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/auth/login", auth.Login)
+		mux.HandleFunc("/api/auth/logout", auth.Logout)
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		handler := auth.Middleware(mux)
+
+		serve := func(method, path, body string, prepare func(r *http.Request)) *httptest.ResponseRecorder {
+			request := httptest.NewRequest(method, path, strings.NewReader(body))
+			if prepare != nil {
+				prepare(request)
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			return recorder
+		}
+		bearer := func(token string) func(r *http.Request) {
+			return func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+token) }
+		}
+
+		// static assets are public, the api is not
+		require.Equal(t, http.StatusOK, serve(http.MethodGet, "/static/app.js", "", nil).Code)
+		require.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, "/api/sno", "", nil).Code)
+		require.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, "/api/sno", "", bearer("invalid")).Code)
+
+		// api keys are read-only
+		require.Equal(t, http.StatusOK, serve(http.MethodGet, "/api/sno", "", bearer(apiKey.Secret.String())).Code)
+		require.Equal(t, http.StatusForbidden, serve(http.MethodPost, "/api/notifications/readall", "", bearer(apiKey.Secret.String())).Code)
+
+		// the configured token is admin
+		require.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/notifications/readall", "", bearer("admin-token")).Code)
+
+		// signing in with the password creates an admin session
+		require.Equal(t, http.StatusUnauthorized, serve(http.MethodPost, "/api/auth/login", `{"password":"wrong"}`, nil).Code)
+		login := serve(http.MethodPost, "/api/auth/login", `{"password":"password"}`, nil)
+		require.Equal(t, http.StatusOK, login.Code)
+		cookies := login.Result().Cookies()
+		require.Len(t, cookies, 1)
+		withCookie := func(r *http.Request) { r.AddCookie(cookies[0]) }
+
+		require.Equal(t, http.StatusOK, serve(http.MethodGet, "/api/sno", "", withCookie).Code)
+		require.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/notifications/readall", "", withCookie).Code)
+
+		// signing out ends the session
+		require.Equal(t, http.StatusNoContent, serve(http.MethodPost, "/api/auth/logout", "", withCookie).Code)
+		require.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, "/api/sno", "", withCookie).Code)
+	})
+}
+
+func TestAuthDisabled(t *testing.T) {
+	auth := consoleserver.NewAuth(zaptest.NewLogger(t), nil, consoleserver.AuthConfig{})
+	handler := auth.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/notifications/readall", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestAuthLoginRateLimit(t *testing.T) {
+	auth := consoleserver.NewAuth(zaptest.NewLogger(t), nil, consoleserver.AuthConfig{
+		Password:        "password",
+		SessionDuration: time.Hour,
+		LoginRateLimit: web.IPRateLimiterConfig{
+			Duration:  time.Hour,
+			Burst:     2,
+			NumLimits: 10,
+		},
+	})
+	handler := auth.LoginHandler()
+
+	login := func(remoteAddr, password string) int {
+		request := httptest.NewRequest(http.MethodPost, "/api/auth/login", strings.NewReader(`{"password":"`+password+`"}`))
+		request.RemoteAddr = remoteAddr
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	require.Equal(t, http.StatusUnauthorized, login("10.0.0.1:1000", "guess"))
+	require.Equal(t, http.StatusUnauthorized, login("10.0.0.1:1001", "guess"))
+	// even the right password is rejected once the limit is reached
+	require.Equal(t, http.StatusTooManyRequests, login("10.0.0.1:1002", "password"))
+	// other addresses are limited separately
+	require.Equal(t, http.StatusOK, login("10.0.0.2:1000", "password"))
+}
@@ -17,10 +17,16 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"storj.io/common/errs2"
+	"storj.io/storj/storagenode/apikeys"
 	"storj.io/storj/storagenode/console"
 	"storj.io/storj/storagenode/console/consoleapi"
+	"storj.io/storj/storagenode/gracefulexit"
 	"storj.io/storj/storagenode/notifications"
+	"storj.io/storj/storagenode/orders"
 	"storj.io/storj/storagenode/payout"
+	"storj.io/storj/storagenode/retain"
+	"storj.io/storj/storagenode/transferstats"
+	"storj.io/storj/storagenode/untrusted"
 )
 
 var (
@@ -33,6 +39,9 @@ var (
 type Config struct {
 	Address   string `help:"server address of the api gateway and frontend app" default:"127.0.0.1:14002"`
 	StaticDir string `help:"path to static resources" default:""`
+	Metrics   bool   `help:"publish operator metrics in Prometheus text format at /metrics" default:"false"`
+
+	Auth AuthConfig
 }
 
 // Server represents storagenode console web server.
@@ -44,24 +53,42 @@ type Server struct {
 	service       *console.Service
 	notifications *notifications.Service
 	payout        *payout.Service
+	retain        *retain.Service
+	orders        *orders.Service
+	untrusted     *untrusted.Chore
+	gracefulExit  gracefulexit.Service
+	transferStats *transferstats.Service
+	auth          *Auth
 	listener      net.Listener
 
 	server http.Server
 }
 
 // NewServer creates new instance of storagenode console web server.
-func NewServer(logger *zap.Logger, assets http.FileSystem, notifications *notifications.Service, service *console.Service, payout *payout.Service, listener net.Listener) *Server {
+func NewServer(logger *zap.Logger, assets http.FileSystem, notifications *notifications.Service, service *console.Service, payout *payout.Service, retain *retain.Service, orders *orders.Service, untrusted *untrusted.Chore, gracefulExit gracefulexit.Service, transferStats *transferstats.Service, apiKeys apikeys.DB, listener net.Listener, config Config) *Server {
 	server := Server{
 		log:           logger,
 		service:       service,
 		listener:      listener,
 		notifications: notifications,
 		payout:        payout,
+		retain:        retain,
+		orders:        orders,
+		untrusted:     untrusted,
+		gracefulExit:  gracefulExit,
+		transferStats: transferStats,
+		auth:          NewAuth(logger.Named("auth"), apiKeys, config.Auth),
 	}
 
 	router := mux.NewRouter()
 
 	// handle api endpoints
+	authRouter := router.PathPrefix("/api/auth").Subrouter()
+	authRouter.StrictSlash(true)
+	authRouter.HandleFunc("/", server.auth.Status).Methods(http.MethodGet)
+	authRouter.Handle("/login", server.auth.LoginHandler()).Methods(http.MethodPost)
+	authRouter.HandleFunc("/logout", server.auth.Logout).Methods(http.MethodPost)
+
 	storageNodeController := consoleapi.NewStorageNode(server.log, server.service)
 	storageNodeRouter := router.PathPrefix("/api/sno").Subrouter()
 	storageNodeRouter.StrictSlash(true)
@@ -85,6 +112,37 @@ func NewServer(logger *zap.Logger, assets http.FileSystem, notifications *notifi
 	payoutRouter.HandleFunc("/held-history", payoutController.HeldHistory).Methods(http.MethodGet)
 	payoutRouter.HandleFunc("/periods", payoutController.HeldAmountPeriods).Methods(http.MethodGet)
 	payoutRouter.HandleFunc("/payout-history/{period}", payoutController.PayoutHistory).Methods(http.MethodGet)
+	payoutRouter.HandleFunc("/reconciliation/{start}/{end}", payoutController.Reconciliation).Methods(http.MethodGet)
+
+	retainController := consoleapi.NewRetain(server.log, server.retain)
+	retainRouter := router.PathPrefix("/api/retain").Subrouter()
+	retainRouter.StrictSlash(true)
+	retainRouter.HandleFunc("/reports", retainController.Reports).Methods(http.MethodGet)
+
+	ordersController := consoleapi.NewOrders(server.log, server.orders)
+	ordersRouter := router.PathPrefix("/api/orders").Subrouter()
+	ordersRouter.StrictSlash(true)
+	ordersRouter.HandleFunc("/send/{id}", ordersController.Send).Methods(http.MethodPost)
+
+	untrustedController := consoleapi.NewUntrusted(server.log, server.untrusted)
+	untrustedRouter := router.PathPrefix("/api/untrusted").Subrouter()
+	untrustedRouter.StrictSlash(true)
+	untrustedRouter.HandleFunc("/", untrustedController.Preview).Methods(http.MethodGet)
+
+	gracefulExitController := consoleapi.NewGracefulExit(server.log, server.gracefulExit)
+	gracefulExitRouter := router.PathPrefix("/api/gracefulexit").Subrouter()
+	gracefulExitRouter.StrictSlash(true)
+	gracefulExitRouter.HandleFunc("/progress", gracefulExitController.Progress).Methods(http.MethodGet)
+
+	transferStatsController := consoleapi.NewTransferStats(server.log, server.transferStats)
+	transferStatsRouter := router.PathPrefix("/api/transfers").Subrouter()
+	transferStatsRouter.StrictSlash(true)
+	transferStatsRouter.HandleFunc("/stats", transferStatsController.Stats).Methods(http.MethodGet)
+
+	if config.Metrics {
+		metricsController := consoleapi.NewMetrics(server.log, server.service)
+		router.HandleFunc("/metrics", metricsController.Metrics).Methods(http.MethodGet)
+	}
 
 	if assets != nil {
 		fs := http.FileServer(assets)
@@ -97,7 +155,7 @@ func NewServer(logger *zap.Logger, assets http.FileSystem, notifications *notifi
 	}
 
 	server.server = http.Server{
-		Handler: router,
+		Handler: server.auth.Middleware(router),
 	}
 
 	return &server
@@ -109,6 +167,10 @@ func (server *Server) Run(ctx context.Context) (err error) {
 
 	ctx, cancel := context.WithCancel(ctx)
 	var group errgroup.Group
+	group.Go(func() error {
+		server.auth.Run(ctx)
+		return nil
+	})
 	group.Go(func() error {
 		<-ctx.Done()
 		return server.server.Shutdown(context.Background())
@@ -0,0 +1,167 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/storj/private/date"
+	"storj.io/storj/storagenode/bandwidth"
+	"storj.io/storj/storagenode/transferstats"
+)
+
+// metricsTransfersPeriod is the period covered by the transfer outcomes.
+const metricsTransfersPeriod = 24 * time.Hour
+
+// Metrics contains the operator level metrics of the node.
+type Metrics struct {
+	NodeID storj.NodeID
+
+	Allocated int64
+	Used      int64
+	Trash     int64
+	Free      int64
+
+	Satellites []SatelliteMetrics
+}
+
+// SatelliteMetrics contains the operator level metrics of the node for a satellite.
+type SatelliteMetrics struct {
+	ID  storj.NodeID
+	URL string
+
+	Used  int64
+	Trash int64
+
+	Audit            ReputationMetric
+	UptimeScore      float64
+	OnlineScore      float64
+	Disqualified     bool
+	Suspended        bool
+	OfflineSuspended bool
+
+	// Vetted is whether the satellite vetted the node, only known when the
+	// satellite reports VettingAuditCount.
+	Vetted bool
+	// VettingAuditCount is the number of audits the satellite requires to
+	// vet a node, zero when the satellite does not report it.
+	VettingAuditCount int64
+
+	// Bandwidth is the bandwidth used in the current month.
+	Bandwidth bandwidth.Usage
+	// Transfers are the outcomes of transfers in the last 24 hours by action.
+	Transfers map[pb.PieceAction]transferstats.Counts
+	// EstimatedPayout is the estimated payout of the current month in cents.
+	EstimatedPayout float64
+}
+
+// ReputationMetric contains the audit counters and scores of the node.
+type ReputationMetric struct {
+	TotalCount      int64
+	SuccessCount    int64
+	Score           float64
+	SuspensionScore float64
+}
+
+// GetMetrics returns the operator level metrics of the node.
+func (s *Service) GetMetrics(ctx context.Context) (_ *Metrics, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	metrics := &Metrics{
+		NodeID:    s.contact.Local().ID,
+		Allocated: s.allocatedDiskSpace.Int64(),
+	}
+
+	metrics.Used, _, err = s.pieceStore.SpaceUsedForPieces(ctx)
+	if err != nil {
+		return nil, SNOServiceErr.Wrap(err)
+	}
+
+	metrics.Trash, err = s.pieceStore.SpaceUsedForTrash(ctx)
+	if err != nil {
+		return nil, SNOServiceErr.Wrap(err)
+	}
+
+	metrics.Free = metrics.Allocated - metrics.Used - metrics.Trash
+	if metrics.Free < 0 {
+		metrics.Free = 0
+	}
+
+	stats, err := s.reputationDB.All(ctx)
+	if err != nil {
+		return nil, SNOServiceErr.Wrap(err)
+	}
+
+	now := time.Now().UTC()
+	transfers, err := s.transferStats.Summary(ctx, storj.NodeID{}, now.Add(-metricsTransfersPeriod), now)
+	if err != nil {
+		return nil, SNOServiceErr.Wrap(err)
+	}
+
+	from, to := date.MonthBoundary(now)
+	for _, rep := range stats {
+		satellite := SatelliteMetrics{
+			ID: rep.SatelliteID,
+			Audit: ReputationMetric{
+				TotalCount:      rep.Audit.TotalCount,
+				SuccessCount:    rep.Audit.SuccessCount,
+				Score:           rep.Audit.Score,
+				SuspensionScore: rep.Audit.UnknownScore,
+			},
+			UptimeScore:      rep.Uptime.Score,
+			OnlineScore:      rep.OnlineScore,
+			Disqualified:     rep.DisqualifiedAt != nil,
+			Suspended:        rep.SuspendedAt != nil,
+			OfflineSuspended: rep.OfflineSuspendedAt != nil,
+
+			Vetted:            rep.VettedAt != nil,
+			VettingAuditCount: rep.VettingAuditCount,
+
+			Transfers: make(map[pb.PieceAction]transferstats.Counts),
+		}
+
+		for _, transfer := range transfers {
+			if transfer.SatelliteID == rep.SatelliteID {
+				satellite.Transfers[transfer.Action] = transfer.Counts
+			}
+		}
+
+		if url, err := s.trust.GetNodeURL(ctx, rep.SatelliteID); err == nil {
+			satellite.URL = url.Address
+		}
+
+		_, satellite.Used, err = s.usageCache.SpaceUsedBySatellite(ctx, rep.SatelliteID)
+		if err != nil {
+			return nil, SNOServiceErr.Wrap(err)
+		}
+
+		satellite.Trash, err = s.usageCache.SpaceUsedForTrashInNamespace(ctx, rep.SatelliteID.Bytes())
+		if err != nil {
+			return nil, SNOServiceErr.Wrap(err)
+		}
+
+		usage, err := s.bandwidthDB.SatelliteSummary(ctx, rep.SatelliteID, from, to)
+		if err != nil {
+			return nil, SNOServiceErr.Wrap(err)
+		}
+		satellite.Bandwidth = *usage
+
+		payout, err := s.estimation.GetSatelliteEstimatedPayout(ctx, rep.SatelliteID)
+		if err != nil {
+			// estimations need pricing which may not be known yet
+			s.log.Debug("unable to estimate payout", zap.Stringer("Satellite ID", rep.SatelliteID), zap.Error(err))
+		} else {
+			satellite.EstimatedPayout = payout.CurrentMonth.Payout
+		}
+
+		metrics.Satellites = append(metrics.Satellites, satellite)
+	}
+
+	return metrics, nil
+}
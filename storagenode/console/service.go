@@ -16,6 +16,7 @@ import (
 	"storj.io/common/storj"
 	"storj.io/private/version"
 	"storj.io/storj/private/date"
+	"storj.io/storj/private/nodestatsext"
 	"storj.io/storj/private/version/checker"
 	"storj.io/storj/storagenode/bandwidth"
 	"storj.io/storj/storagenode/contact"
@@ -25,6 +26,7 @@ import (
 	"storj.io/storj/storagenode/reputation"
 	"storj.io/storj/storagenode/satellites"
 	"storj.io/storj/storagenode/storageusage"
+	"storj.io/storj/storagenode/transferstats"
 	"storj.io/storj/storagenode/trust"
 )
 
@@ -50,9 +52,10 @@ type Service struct {
 	pieceStore     *pieces.Store
 	contact        *contact.Service
 
-	estimation *estimatedpayout.Service
-	version    *checker.Service
-	pingStats  *contact.PingStats
+	estimation    *estimatedpayout.Service
+	version       *checker.Service
+	pingStats     *contact.PingStats
+	transferStats *transferstats.Service
 
 	allocatedDiskSpace memory.Size
 
@@ -65,7 +68,8 @@ type Service struct {
 func NewService(log *zap.Logger, bandwidth bandwidth.DB, pieceStore *pieces.Store, version *checker.Service,
 	allocatedDiskSpace memory.Size, walletAddress string, versionInfo version.Info, trust *trust.Pool,
 	reputationDB reputation.DB, storageUsageDB storageusage.DB, pricingDB pricing.DB, satelliteDB satellites.DB,
-	pingStats *contact.PingStats, contact *contact.Service, estimation *estimatedpayout.Service, usageCache *pieces.BlobsUsageCache) (*Service, error) {
+	pingStats *contact.PingStats, contact *contact.Service, estimation *estimatedpayout.Service, usageCache *pieces.BlobsUsageCache,
+	transferStats *transferstats.Service) (*Service, error) {
 	if log == nil {
 		return nil, errs.New("log can't be nil")
 	}
@@ -98,6 +102,10 @@ func NewService(log *zap.Logger, bandwidth bandwidth.DB, pieceStore *pieces.Stor
 		return nil, errs.New("estimation service can't be nil")
 	}
 
+	if transferStats == nil {
+		return nil, errs.New("transfer stats service can't be nil")
+	}
+
 	return &Service{
 		log:                log,
 		trust:              trust,
@@ -113,6 +121,7 @@ func NewService(log *zap.Logger, bandwidth bandwidth.DB, pieceStore *pieces.Stor
 		allocatedDiskSpace: allocatedDiskSpace,
 		contact:            contact,
 		estimation:         estimation,
+		transferStats:      transferStats,
 		walletAddress:      walletAddress,
 		startedAt:          time.Now(),
 		versionInfo:        versionInfo,
@@ -121,11 +130,13 @@ func NewService(log *zap.Logger, bandwidth bandwidth.DB, pieceStore *pieces.Stor
 
 // SatelliteInfo encapsulates satellite ID and disqualification.
 type SatelliteInfo struct {
-	ID                 storj.NodeID `json:"id"`
-	URL                string       `json:"url"`
-	Disqualified       *time.Time   `json:"disqualified"`
-	Suspended          *time.Time   `json:"suspended"`
-	CurrentStorageUsed int64        `json:"currentStorageUsed"`
+	ID                 storj.NodeID        `json:"id"`
+	URL                string              `json:"url"`
+	Disqualified       *time.Time          `json:"disqualified"`
+	Suspended          *time.Time          `json:"suspended"`
+	Vetting            *reputation.Vetting `json:"vetting"`
+	CurrentStorageUsed int64               `json:"currentStorageUsed"`
+	CurrentTrash       int64               `json:"currentTrash"`
 }
 
 // Dashboard encapsulates dashboard stale data.
@@ -178,14 +189,22 @@ func (s *Service) GetDashboardData(ctx context.Context) (_ *Dashboard, err error
 				zap.Error(SNOServiceErr.Wrap(err)))
 			continue
 		}
+		currentTrash, err := s.usageCache.SpaceUsedForTrashInNamespace(ctx, rep.SatelliteID.Bytes())
+		if err != nil {
+			s.log.Warn("unable to get Satellite Current Trash", zap.String("Satellite ID", rep.SatelliteID.String()),
+				zap.Error(SNOServiceErr.Wrap(err)))
+			continue
+		}
 
 		data.Satellites = append(data.Satellites,
 			SatelliteInfo{
 				ID:                 rep.SatelliteID,
 				Disqualified:       rep.DisqualifiedAt,
 				Suspended:          rep.SuspendedAt,
+				Vetting:            rep.Vetting(),
 				URL:                url.Address,
 				CurrentStorageUsed: currentStorageUsed,
+				CurrentTrash:       currentTrash,
 			},
 		)
 	}
@@ -233,19 +252,22 @@ type PriceModel struct {
 
 // Satellite encapsulates satellite related data.
 type Satellite struct {
-	ID                 storj.NodeID            `json:"id"`
-	StorageDaily       []storageusage.Stamp    `json:"storageDaily"`
-	BandwidthDaily     []bandwidth.UsageRollup `json:"bandwidthDaily"`
-	StorageSummary     float64                 `json:"storageSummary"`
-	BandwidthSummary   int64                   `json:"bandwidthSummary"`
-	EgressSummary      int64                   `json:"egressSummary"`
-	IngressSummary     int64                   `json:"ingressSummary"`
-	CurrentStorageUsed int64                   `json:"currentStorageUsed"`
-	Audit              reputation.Metric       `json:"audit"`
-	Uptime             reputation.Metric       `json:"uptime"`
-	OnlineScore        float64                 `json:"onlineScore"`
-	PriceModel         PriceModel              `json:"priceModel"`
-	NodeJoinedAt       time.Time               `json:"nodeJoinedAt"`
+	ID                 storj.NodeID               `json:"id"`
+	StorageDaily       []storageusage.Stamp       `json:"storageDaily"`
+	BandwidthDaily     []bandwidth.UsageRollup    `json:"bandwidthDaily"`
+	StorageSummary     float64                    `json:"storageSummary"`
+	BandwidthSummary   int64                      `json:"bandwidthSummary"`
+	EgressSummary      int64                      `json:"egressSummary"`
+	IngressSummary     int64                      `json:"ingressSummary"`
+	CurrentStorageUsed int64                      `json:"currentStorageUsed"`
+	CurrentTrash       int64                      `json:"currentTrash"`
+	Audit              reputation.Metric          `json:"audit"`
+	Uptime             reputation.Metric          `json:"uptime"`
+	OnlineScore        float64                    `json:"onlineScore"`
+	Vetting            *reputation.Vetting        `json:"vetting"`
+	AuditHistory       *nodestatsext.AuditHistory `json:"auditHistory"`
+	PriceModel         PriceModel                 `json:"priceModel"`
+	NodeJoinedAt       time.Time                  `json:"nodeJoinedAt"`
 }
 
 // GetSatelliteData returns satellite related data.
@@ -288,6 +310,11 @@ func (s *Service) GetSatelliteData(ctx context.Context, satelliteID storj.NodeID
 		return nil, SNOServiceErr.Wrap(err)
 	}
 
+	currentTrash, err := s.usageCache.SpaceUsedForTrashInNamespace(ctx, satelliteID.Bytes())
+	if err != nil {
+		return nil, SNOServiceErr.Wrap(err)
+	}
+
 	rep, err := s.reputationDB.Get(ctx, satelliteID)
 	if err != nil {
 		return nil, SNOServiceErr.Wrap(err)
@@ -312,11 +339,14 @@ func (s *Service) GetSatelliteData(ctx context.Context, satelliteID storj.NodeID
 		StorageSummary:     storageSummary,
 		BandwidthSummary:   bandwidthSummary.Total(),
 		CurrentStorageUsed: currentStorageUsed,
+		CurrentTrash:       currentTrash,
 		EgressSummary:      egressSummary.Total(),
 		IngressSummary:     ingressSummary.Total(),
 		Audit:              rep.Audit,
 		Uptime:             rep.Uptime,
 		OnlineScore:        rep.OnlineScore,
+		Vetting:            rep.Vetting(),
+		AuditHistory:       rep.AuditHistory,
 		PriceModel:         satellitePricing,
 		NodeJoinedAt:       rep.JoinedAt,
 	}, nil
@@ -71,3 +71,80 @@ func TestDB(t *testing.T) {
 		}
 	})
 }
+
+func TestTransferStats(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		satelliteDB := db.Satellites()
+		satelliteID := testrand.NodeID()
+		now := time.Now().UTC()
+
+		require.NoError(t, satelliteDB.InitiateGracefulExit(ctx, satelliteID, now, 5000))
+
+		stats, err := satelliteDB.GetTransferStats(ctx, satelliteID)
+		require.NoError(t, err)
+		require.Empty(t, stats)
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, satelliteDB.RecordTransfer(ctx, satelliteID, satellites.Transfer{
+				PieceID:    testrand.PieceID(),
+				Reason:     satellites.TransferSucceeded,
+				Bytes:      100,
+				Duration:   time.Second,
+				FinishedAt: now.Add(time.Duration(i) * time.Second),
+			}))
+		}
+
+		failed := testrand.PieceID()
+		require.NoError(t, satelliteDB.RecordTransfer(ctx, satelliteID, satellites.Transfer{
+			PieceID:    failed,
+			Reason:     "hash_mismatch",
+			Bytes:      50,
+			Duration:   time.Second,
+			Error:      "piece hash from new storagenode does not match",
+			FinishedAt: now,
+		}))
+
+		stats, err = satelliteDB.GetTransferStats(ctx, satelliteID)
+		require.NoError(t, err)
+		require.Len(t, stats, 2)
+		require.Equal(t, "hash_mismatch", stats[0].Reason)
+		require.EqualValues(t, 1, stats[0].Pieces)
+		require.EqualValues(t, 50, stats[0].Bytes)
+		require.Equal(t, "piece hash from new storagenode does not match", stats[0].LastError)
+		require.Equal(t, satellites.TransferSucceeded, stats[1].Reason)
+		require.EqualValues(t, 3, stats[1].Pieces)
+		require.EqualValues(t, 300, stats[1].Bytes)
+		require.Equal(t, 3*time.Second, stats[1].Duration)
+		require.True(t, stats[1].LastAt.Equal(now.Add(2*time.Second)))
+
+		failures, err := satelliteDB.ListTransferFailures(ctx, satelliteID, 10)
+		require.NoError(t, err)
+		require.Len(t, failures, 1)
+		require.Equal(t, failed, failures[0].PieceID)
+		require.Equal(t, "hash_mismatch", failures[0].Reason)
+		require.EqualValues(t, 50, failures[0].Bytes)
+
+		// only the latest failures are kept
+		for i := 0; i < 150; i++ {
+			require.NoError(t, satelliteDB.RecordTransfer(ctx, satelliteID, satellites.Transfer{
+				PieceID:    testrand.PieceID(),
+				Reason:     "timeout",
+				FinishedAt: now.Add(time.Duration(i) * time.Second),
+			}))
+		}
+		failures, err = satelliteDB.ListTransferFailures(ctx, satelliteID, 1000)
+		require.NoError(t, err)
+		require.Len(t, failures, 100)
+		require.True(t, failures[0].FinishedAt.Equal(now.Add(149*time.Second)))
+
+		// statistics are reset when graceful exit is initiated again
+		require.NoError(t, satelliteDB.CancelGracefulExit(ctx, satelliteID))
+		require.NoError(t, satelliteDB.InitiateGracefulExit(ctx, satelliteID, now, 5000))
+		stats, err = satelliteDB.GetTransferStats(ctx, satelliteID)
+		require.NoError(t, err)
+		require.Empty(t, stats)
+		failures, err = satelliteDB.ListTransferFailures(ctx, satelliteID, 10)
+		require.NoError(t, err)
+		require.Empty(t, failures)
+	})
+}
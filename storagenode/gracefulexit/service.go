@@ -15,6 +15,7 @@ import (
 	"storj.io/common/rpc"
 	"storj.io/common/storj"
 	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/piecetransfer"
 	"storj.io/storj/storagenode/satellites"
 	"storj.io/storj/storagenode/trust"
 )
@@ -49,6 +50,15 @@ type Service interface {
 	// This is intended to be called when a graceful exit operation was initiated but
 	// the satellite rejected it.
 	ExitNotPossible(ctx context.Context, satelliteID storj.NodeID) error
+
+	// RecordTransfer adds the result of a piece transfer to the statistics
+	// of the corresponding graceful exit operation.
+	RecordTransfer(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID, result piecetransfer.Result) error
+
+	// ListTransferProgress returns a slice with one record for every graceful
+	// exit operation, including the piece transfer statistics and up to
+	// failuresLimit of the latest failed transfers.
+	ListTransferProgress(ctx context.Context, failuresLimit int) ([]TransferProgress, error)
 }
 
 // ensures that service implements Service.
@@ -83,6 +93,14 @@ type ExitingSatellite struct {
 	NodeURL storj.NodeURL
 }
 
+// TransferProgress encapsulates the graceful exit progress of a satellite
+// with the statistics of its piece transfers.
+type TransferProgress struct {
+	satellites.ExitProgress
+	Stats    []satellites.TransferStats
+	Failures []satellites.Transfer
+}
+
 func (c *service) ListPendingExits(ctx context.Context) (_ []ExitingSatellite, err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -186,3 +204,51 @@ func (c *service) ExitNotPossible(ctx context.Context, satelliteID storj.NodeID)
 
 	return c.satelliteDB.CancelGracefulExit(ctx, satelliteID)
 }
+
+// RecordTransfer adds the result of a piece transfer to the statistics
+// of the corresponding graceful exit operation.
+func (c *service) RecordTransfer(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID, result piecetransfer.Result) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	transfer := satellites.Transfer{
+		PieceID:    pieceID,
+		Reason:     result.Reason,
+		Bytes:      result.Bytes,
+		Duration:   result.Duration,
+		FinishedAt: c.nowFunc(),
+	}
+	if result.Err != nil {
+		transfer.Error = result.Err.Error()
+	}
+	return Error.Wrap(c.satelliteDB.RecordTransfer(ctx, satelliteID, transfer))
+}
+
+// ListTransferProgress returns a slice with one record for every graceful
+// exit operation, including the piece transfer statistics and up to
+// failuresLimit of the latest failed transfers.
+func (c *service) ListTransferProgress(ctx context.Context, failuresLimit int) (_ []TransferProgress, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	exitProgress, err := c.satelliteDB.ListGracefulExits(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	progress := make([]TransferProgress, 0, len(exitProgress))
+	for _, sat := range exitProgress {
+		stats, err := c.satelliteDB.GetTransferStats(ctx, sat.SatelliteID)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		failures, err := c.satelliteDB.ListTransferFailures(ctx, sat.SatelliteID, failuresLimit)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		progress = append(progress, TransferProgress{
+			ExitProgress: sat,
+			Stats:        stats,
+			Failures:     failures,
+		})
+	}
+	return progress, nil
+}
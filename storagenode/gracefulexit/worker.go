@@ -94,7 +94,13 @@ func (worker *Worker) Run(ctx context.Context, done func()) (err error) {
 		case *pb.SatelliteMessage_TransferPiece:
 			transferPieceMsg := msg.TransferPiece
 			worker.limiter.Go(ctx, func() {
-				resp := worker.transferService.TransferPiece(ctx, worker.satelliteURL.ID, transferPieceMsg)
+				resp, result := worker.transferService.TransferPiece(ctx, worker.satelliteURL.ID, transferPieceMsg)
+				if err := worker.service.RecordTransfer(ctx, worker.satelliteURL.ID, transferPieceMsg.OriginalPieceId, result); err != nil {
+					worker.log.Error("failed to record piece transfer.",
+						zap.Stringer("Satellite ID", worker.satelliteURL.ID),
+						zap.Stringer("Piece ID", transferPieceMsg.OriginalPieceId),
+						zap.Error(errs.Wrap(err)))
+				}
 				if err := c.Send(resp); err != nil {
 					worker.log.Error("failed to send notification about piece transfer.",
 						zap.Stringer("Satellite ID", worker.satelliteURL.ID),
@@ -0,0 +1,172 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package monitor
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+	"unicode"
+
+	"storj.io/common/memory"
+	"storj.io/storj/storagenode/pieces"
+)
+
+// SpaceProvider reports how much space is left on the filesystem storing the pieces.
+type SpaceProvider interface {
+	// FreeSpace returns the number of bytes which can still be written.
+	FreeSpace(ctx context.Context) (int64, error)
+}
+
+// SpaceProviderConfig selects the disk space provider and implements pflag.Value.
+//
+// Supported values are:
+//
+//	statfs       free space reported by the filesystem
+//	quota        like statfs, but additionally limited by Config.DiskQuota
+//	file:<path>  free space read from a file, used for testing
+type SpaceProviderConfig struct {
+	Kind string
+	Path string
+}
+
+const (
+	spaceProviderStatfs = "statfs"
+	spaceProviderQuota  = "quota"
+	spaceProviderFile   = "file"
+)
+
+// String returns the string representation of the config.
+func (config SpaceProviderConfig) String() string {
+	if config.Kind == spaceProviderFile {
+		return spaceProviderFile + ":" + config.Path
+	}
+	return config.Kind
+}
+
+// Set implements pflag.Value by parsing the provider name.
+func (config *SpaceProviderConfig) Set(value string) error {
+	switch {
+	case value == "" || value == spaceProviderStatfs:
+		*config = SpaceProviderConfig{Kind: spaceProviderStatfs}
+	case value == spaceProviderQuota:
+		*config = SpaceProviderConfig{Kind: spaceProviderQuota}
+	case strings.HasPrefix(value, spaceProviderFile+":"):
+		path := strings.TrimPrefix(value, spaceProviderFile+":")
+		if path == "" {
+			return Error.New("invalid disk space provider %q: missing path", value)
+		}
+		*config = SpaceProviderConfig{Kind: spaceProviderFile, Path: path}
+	default:
+		return Error.New("invalid disk space provider %q: expected statfs, quota or file:<path>", value)
+	}
+	return nil
+}
+
+// Type returns the type of the pflag.Value.
+func (config SpaceProviderConfig) Type() string {
+	return "disk-space-provider"
+}
+
+// NewSpaceProvider creates the disk space provider selected by the config.
+func NewSpaceProvider(config Config, store *pieces.Store) SpaceProvider {
+	statfs := &StatfsSpaceProvider{store: store}
+	switch config.DiskSpaceProvider.Kind {
+	case spaceProviderQuota:
+		return &QuotaSpaceProvider{
+			statfs: statfs,
+			store:  store,
+			quota:  config.DiskQuota.Int64(),
+		}
+	case spaceProviderFile:
+		return &FileSpaceProvider{path: config.DiskSpaceProvider.Path}
+	default:
+		return statfs
+	}
+}
+
+// StatfsSpaceProvider reports the free space of the filesystem, as seen by
+// the storage directory.
+type StatfsSpaceProvider struct {
+	store *pieces.Store
+}
+
+// FreeSpace returns the free space reported by the filesystem.
+func (provider *StatfsSpaceProvider) FreeSpace(ctx context.Context) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	status, err := provider.store.StorageStatus(ctx)
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+	return status.DiskFree, nil
+}
+
+// QuotaSpaceProvider reports the free space of the filesystem, limited by a
+// quota on the space used by the pieces.
+//
+// It's meant for directory or user quotas and datasets with reservations,
+// which the free space reported by the filesystem doesn't take into account.
+type QuotaSpaceProvider struct {
+	statfs *StatfsSpaceProvider
+	store  *pieces.Store
+	quota  int64
+}
+
+// FreeSpace returns the smaller of the filesystem free space and the remaining quota.
+func (provider *QuotaSpaceProvider) FreeSpace(ctx context.Context) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	free, err := provider.statfs.FreeSpace(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if provider.quota <= 0 {
+		return free, nil
+	}
+
+	used, err := provider.store.SpaceUsedForPiecesAndTrash(ctx)
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	remaining := provider.quota - used
+	if remaining < 0 {
+		remaining = 0
+	}
+	if remaining < free {
+		return remaining, nil
+	}
+	return free, nil
+}
+
+// FileSpaceProvider reads the free space from a file, which contains a size
+// such as "100GB". The file is read on every call, so it can be modified to
+// simulate a filesystem filling up.
+type FileSpaceProvider struct {
+	path string
+}
+
+// FreeSpace returns the size stored in the file.
+func (provider *FileSpaceProvider) FreeSpace(ctx context.Context) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	data, err := ioutil.ReadFile(provider.path)
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	value := strings.TrimSpace(string(data))
+	if strings.IndexFunc(value, unicode.IsDigit) < 0 {
+		return 0, Error.New("invalid free space in %q: %q is not a size", provider.path, value)
+	}
+
+	size, err := memory.ParseString(value)
+	if err != nil {
+		return 0, Error.New("invalid free space in %q: %v", provider.path, err)
+	}
+	if size < 0 {
+		return 0, Error.New("invalid free space in %q: negative size", provider.path)
+	}
+	return size, nil
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package monitor_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storagenode/monitor"
+	"storj.io/storj/storagenode/pieces"
+)
+
+func TestSpaceProviderConfig(t *testing.T) {
+	for _, value := range []string{"statfs", "quota", "file:/tmp/free-space"} {
+		var config monitor.SpaceProviderConfig
+		require.NoError(t, config.Set(value))
+		require.Equal(t, value, config.String())
+	}
+
+	for _, value := range []string{"zfs", "file:", "file"} {
+		var config monitor.SpaceProviderConfig
+		require.Error(t, config.Set(value), value)
+	}
+}
+
+func TestSpaceProviders(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+
+	blobs, err := filestore.NewAt(log, ctx.Dir("store"), filestore.DefaultConfig)
+	require.NoError(t, err)
+	defer ctx.Check(blobs.Close)
+
+	store := pieces.NewStore(log, blobs, nil, nil, nil, pieces.DefaultConfig)
+
+	statfs, err := monitor.NewSpaceProvider(monitor.Config{}, store).FreeSpace(ctx)
+	require.NoError(t, err)
+	require.Greater(t, statfs, int64(0))
+
+	t.Run("quota", func(t *testing.T) {
+		var config monitor.Config
+		require.NoError(t, config.DiskSpaceProvider.Set("quota"))
+
+		free, err := monitor.NewSpaceProvider(config, store).FreeSpace(ctx)
+		require.NoError(t, err)
+		require.Greater(t, free, int64(0))
+
+		w, err := store.Writer(ctx, testrand.NodeID(), testrand.PieceID())
+		require.NoError(t, err)
+		_, err = w.Write(testrand.BytesInt(int(memory.KiB)))
+		require.NoError(t, err)
+		require.NoError(t, w.Commit(ctx, &pb.PieceHeader{}))
+
+		config.DiskQuota = 10 * memory.KiB
+		free, err = monitor.NewSpaceProvider(config, store).FreeSpace(ctx)
+		require.NoError(t, err)
+		require.Less(t, free, config.DiskQuota.Int64())
+		require.Greater(t, free, int64(8*memory.KiB))
+
+		config.DiskQuota = memory.KiB
+		free, err = monitor.NewSpaceProvider(config, store).FreeSpace(ctx)
+		require.NoError(t, err)
+		require.Zero(t, free)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(ctx.Dir("provider"), "free-space")
+
+		var config monitor.Config
+		require.NoError(t, config.DiskSpaceProvider.Set("file:"+path))
+		provider := monitor.NewSpaceProvider(config, store)
+
+		_, err := provider.FreeSpace(ctx)
+		require.Error(t, err)
+
+		require.NoError(t, ioutil.WriteFile(path, []byte("2GB\n"), 0644))
+		free, err := provider.FreeSpace(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2*memory.GB.Int64(), free)
+
+		require.NoError(t, ioutil.WriteFile(path, []byte("500MB"), 0644))
+		free, err = provider.FreeSpace(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 500*memory.MB.Int64(), free)
+
+		for _, invalid := range []string{"", "plenty", "-1GB"} {
+			require.NoError(t, ioutil.WriteFile(path, []byte(invalid), 0644))
+			_, err = provider.FreeSpace(ctx)
+			require.Error(t, err, invalid)
+		}
+	})
+}
@@ -5,6 +5,7 @@ package monitor
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
@@ -14,9 +15,11 @@ import (
 
 	"storj.io/common/memory"
 	"storj.io/common/pb"
+	"storj.io/common/storj"
 	"storj.io/common/sync2"
 	"storj.io/storj/storagenode/bandwidth"
 	"storj.io/storj/storagenode/contact"
+	"storj.io/storj/storagenode/notifications"
 	"storj.io/storj/storagenode/pieces"
 )
 
@@ -29,12 +32,15 @@ var (
 
 // Config defines parameters for storage node disk and bandwidth usage monitoring.
 type Config struct {
-	Interval                  time.Duration `help:"how frequently Kademlia bucket should be refreshed with node stats" default:"1h0m0s"`
-	VerifyDirReadableInterval time.Duration `help:"how frequently to verify the location and readability of the storage directory" releaseDefault:"1m" devDefault:"30s"`
-	VerifyDirWritableInterval time.Duration `help:"how frequently to verify writability of storage directory" releaseDefault:"5m" devDefault:"30s"`
-	MinimumDiskSpace          memory.Size   `help:"how much disk space a node at minimum has to advertise" default:"500GB"`
-	MinimumBandwidth          memory.Size   `help:"how much bandwidth a node at minimum has to advertise (deprecated)" default:"0TB"`
-	NotifyLowDiskCooldown     time.Duration `help:"minimum length of time between capacity reports" default:"10m" hidden:"true"`
+	Interval                  time.Duration       `help:"how frequently Kademlia bucket should be refreshed with node stats" default:"1h0m0s"`
+	VerifyDirReadableInterval time.Duration       `help:"how frequently to verify the location and readability of the storage directory" releaseDefault:"1m" devDefault:"30s"`
+	VerifyDirWritableInterval time.Duration       `help:"how frequently to verify writability of storage directory" releaseDefault:"5m" devDefault:"30s"`
+	MinimumDiskSpace          memory.Size         `help:"how much disk space a node at minimum has to advertise" default:"500GB"`
+	MinimumBandwidth          memory.Size         `help:"how much bandwidth a node at minimum has to advertise (deprecated)" default:"0TB"`
+	NotifyLowDiskCooldown     time.Duration       `help:"minimum length of time between capacity reports" default:"10m" hidden:"true"`
+	DiskSpaceProvider         SpaceProviderConfig `help:"how the free space of the filesystem is determined: statfs, quota or file:<path>" default:"statfs"`
+	DiskQuota                 memory.Size         `help:"quota of the space used by pieces and trash, used by the quota disk space provider; 0 means no quota" default:"0B"`
+	MinimumFreeDisk           memory.Size         `help:"how much space to keep free on the filesystem, the advertised capacity is reduced when the filesystem is running out of space regardless of the allocation" default:"1GB"`
 }
 
 // Service which monitors disk usage.
@@ -45,21 +51,35 @@ type Service struct {
 	store                 *pieces.Store
 	contact               *contact.Service
 	usageDB               bandwidth.DB
+	spaceProvider         SpaceProvider
+	notifications         *notifications.Service
 	allocatedDiskSpace    int64
 	cooldown              *sync2.Cooldown
 	Loop                  *sync2.Cycle
 	VerifyDirReadableLoop *sync2.Cycle
 	VerifyDirWritableLoop *sync2.Cycle
 	Config                Config
+
+	// lowDisk is updated by both the Loop and the cooldown.
+	lowDisk struct {
+		sync.Mutex
+		checked bool
+		low     bool
+	}
 }
 
 // NewService creates a new storage node monitoring service.
-func NewService(log *zap.Logger, store *pieces.Store, contact *contact.Service, usageDB bandwidth.DB, allocatedDiskSpace int64, interval time.Duration, reportCapacity func(context.Context), config Config) *Service {
+//
+// spaceProvider reports the free space of the filesystem; the advertised
+// capacity never exceeds it, minus config.MinimumFreeDisk.
+func NewService(log *zap.Logger, store *pieces.Store, contact *contact.Service, usageDB bandwidth.DB, spaceProvider SpaceProvider, notifications *notifications.Service, allocatedDiskSpace int64, interval time.Duration, reportCapacity func(context.Context), config Config) *Service {
 	return &Service{
 		log:                   log,
 		store:                 store,
 		contact:               contact,
 		usageDB:               usageDB,
+		spaceProvider:         spaceProvider,
+		notifications:         notifications,
 		allocatedDiskSpace:    allocatedDiskSpace,
 		cooldown:              sync2.NewCooldown(config.NotifyLowDiskCooldown),
 		Loop:                  sync2.NewCycle(interval),
@@ -74,12 +94,10 @@ func (service *Service) Run(ctx context.Context) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	// get the disk space details
-	// The returned path ends in a slash only if it represents a root directory, such as "/" on Unix or `C:\` on Windows.
-	storageStatus, err := service.store.StorageStatus(ctx)
+	freeDiskSpace, err := service.diskFree(ctx)
 	if err != nil {
-		return Error.Wrap(err)
+		return err
 	}
-	freeDiskSpace := storageStatus.DiskFree
 
 	totalUsed, err := service.usedSpace(ctx)
 	if err != nil {
@@ -173,7 +191,7 @@ func (service *Service) Close() (err error) {
 func (service *Service) updateNodeInformation(ctx context.Context) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	freeSpace, err := service.AvailableSpace(ctx)
+	freeSpace, filesystemLimited, err := service.availableSpace(ctx)
 	if err != nil {
 		return err
 	}
@@ -181,9 +199,68 @@ func (service *Service) updateNodeInformation(ctx context.Context) (err error) {
 		FreeDisk: freeSpace,
 	})
 
+	service.checkLowDiskSpace(ctx, freeSpace, filesystemLimited)
 	return nil
 }
 
+// checkLowDiskSpace raises a notification and reports the reduced capacity
+// to the satellites when the filesystem starts limiting the capacity below
+// the allocated disk space.
+//
+// The first check after a restart doesn't raise a notification, the node
+// was most likely already notified before it was restarted.
+func (service *Service) checkLowDiskSpace(ctx context.Context, freeSpace int64, filesystemLimited bool) {
+	service.lowDisk.Lock()
+	wasChecked, wasLow := service.lowDisk.checked, service.lowDisk.low
+	service.lowDisk.checked, service.lowDisk.low = true, filesystemLimited
+	service.lowDisk.Unlock()
+
+	if !filesystemLimited || wasLow {
+		return
+	}
+
+	service.log.Warn("Filesystem is running out of space, reducing the advertised capacity",
+		zap.Int64("available bytes", freeSpace))
+	service.NotifyLowDisk()
+
+	if service.notifications == nil || !wasChecked {
+		return
+	}
+	_, err := service.notifications.Receive(ctx, NewLowDiskSpaceNotification(service.contact.Local().ID, freeSpace))
+	if err != nil {
+		service.log.Error("failed to create low disk space notification", zap.Error(err))
+	}
+}
+
+// NewLowDiskSpaceNotification returns the notification about the filesystem running out of space.
+func NewLowDiskSpaceNotification(senderID storj.NodeID, freeSpace int64) notifications.NewNotification {
+	return notifications.NewNotification{
+		SenderID: senderID,
+		Type:     notifications.TypeLowDiskSpace,
+		Title:    "Your Node is running out of disk space",
+		Message: "The filesystem storing your Node's data has only " + memory.Size(freeSpace).Base10String() +
+			" left, which is less than the allocated disk space. The capacity advertised to the satellites has been reduced.",
+	}
+}
+
+// diskFree returns the free space of the filesystem reported by the space
+// provider, minus the space which has to be kept free.
+func (service *Service) diskFree(ctx context.Context) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	free, err := service.spaceProvider.FreeSpace(ctx)
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+	mon.IntVal("filesystem_free_space").Observe(free)
+
+	free -= service.Config.MinimumFreeDisk.Int64()
+	if free < 0 {
+		free = 0
+	}
+	return free, nil
+}
+
 func (service *Service) usedSpace(ctx context.Context) (_ int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 	usedSpace, err := service.store.SpaceUsedForPiecesAndTrash(ctx)
@@ -195,25 +272,34 @@ func (service *Service) usedSpace(ctx context.Context) (_ int64, err error) {
 
 // AvailableSpace returns available disk space for upload.
 func (service *Service) AvailableSpace(ctx context.Context) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	available, _, err := service.availableSpace(ctx)
+	return available, err
+}
+
+// availableSpace returns available disk space for upload and whether it's
+// limited by the free space of the filesystem rather than the allocation.
+func (service *Service) availableSpace(ctx context.Context) (_ int64, filesystemLimited bool, err error) {
 	defer mon.Task()(&ctx)(&err)
 	usedSpace, err := service.usedSpace(ctx)
 	if err != nil {
-		return 0, Error.Wrap(err)
+		return 0, false, Error.Wrap(err)
 	}
 
 	freeSpaceForStorj := service.allocatedDiskSpace - usedSpace
 
-	diskStatus, err := service.store.StorageStatus(ctx)
+	diskFree, err := service.diskFree(ctx)
 	if err != nil {
-		return 0, Error.Wrap(err)
+		return 0, false, err
 	}
-	if diskStatus.DiskFree < freeSpaceForStorj {
-		freeSpaceForStorj = diskStatus.DiskFree
+	if diskFree < freeSpaceForStorj {
+		freeSpaceForStorj = diskFree
+		filesystemLimited = true
 	}
 
 	mon.IntVal("allocated_space").Observe(service.allocatedDiskSpace)
 	mon.IntVal("used_space").Observe(usedSpace)
 	mon.IntVal("available_space").Observe(freeSpaceForStorj)
 
-	return freeSpaceForStorj, nil
+	return freeSpaceForStorj, filesystemLimited, nil
 }
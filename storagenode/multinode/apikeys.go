@@ -0,0 +1,86 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package multinode
+
+import (
+	"bytes"
+	"context"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/storj/multinodepb"
+	"storj.io/storj/storagenode/apikeys"
+)
+
+var _ multinodepb.DRPCApiKeysServer = (*APIKeysEndpoint)(nil)
+
+// APIKeysEndpoint implements the api key rotation api of the multinode dashboard.
+//
+// architecture: Endpoint
+type APIKeysEndpoint struct {
+	log     *zap.Logger
+	apiKeys *apikeys.Service
+}
+
+// NewAPIKeysEndpoint creates a new multinode api keys endpoint.
+func NewAPIKeysEndpoint(log *zap.Logger, apiKeys *apikeys.Service) *APIKeysEndpoint {
+	return &APIKeysEndpoint{
+		log:     log,
+		apiKeys: apiKeys,
+	}
+}
+
+// Rotate issues a new api key. The api key the request is authenticated with
+// stays valid until it is revoked, so that the multinode dashboard can store
+// the new api key before giving up the old one.
+func (endpoint *APIKeysEndpoint) Rotate(ctx context.Context, req *multinodepb.RotateRequest) (_ *multinodepb.RotateResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, endpoint.apiKeys); err != nil {
+		return nil, err
+	}
+
+	apiKey, err := endpoint.apiKeys.Issue(ctx)
+	if err != nil {
+		endpoint.log.Error("failed to issue api key", zap.Error(err))
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, Error.Wrap(err))
+	}
+
+	endpoint.log.Info("api key issued by multinode dashboard")
+
+	return &multinodepb.RotateResponse{
+		ApiKey: apiKey.Secret[:],
+	}, nil
+}
+
+// Revoke revokes an api key other than the one the request is authenticated with.
+func (endpoint *APIKeysEndpoint) Revoke(ctx context.Context, req *multinodepb.RevokeRequest) (_ *multinodepb.RevokeResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, endpoint.apiKeys); err != nil {
+		return nil, err
+	}
+
+	if len(req.ApiKey) != len(apikeys.Secret{}) {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, "invalid api key")
+	}
+	// revoking the api key of the request would lock the dashboard out when
+	// it hasn't stored another one.
+	current, _ := multinodepb.APIKeyFromContext(ctx)
+	if bytes.Equal(current, req.ApiKey) {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, "can't revoke the api key of the request")
+	}
+
+	var secret apikeys.Secret
+	copy(secret[:], req.ApiKey)
+	if err = endpoint.apiKeys.Remove(ctx, secret); err != nil {
+		endpoint.log.Error("failed to revoke api key", zap.Error(err))
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, Error.Wrap(err))
+	}
+
+	endpoint.log.Info("api key revoked by multinode dashboard")
+
+	return &multinodepb.RevokeResponse{}, nil
+}
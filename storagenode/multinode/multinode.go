@@ -0,0 +1,42 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package multinode implements the storage node side of the multinode dashboard apis.
+package multinode
+
+import (
+	"context"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/storj/multinodepb"
+	"storj.io/storj/storagenode/apikeys"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the default error class for multinode endpoints.
+	Error = errs.Class("multinode")
+)
+
+// authenticate checks that the request carries an api key issued by the node.
+func authenticate(ctx context.Context, apiKeys *apikeys.Service) error {
+	apiKey, ok := multinodepb.APIKeyFromContext(ctx)
+	if !ok || len(apiKey) != len(apikeys.Secret{}) {
+		return rpcstatus.Error(rpcstatus.Unauthenticated, "api key is required")
+	}
+
+	var secret apikeys.Secret
+	copy(secret[:], apiKey)
+
+	if err := apiKeys.Check(ctx, secret); err != nil {
+		if apikeys.ErrNoSecret.Has(err) {
+			return rpcstatus.Error(rpcstatus.PermissionDenied, "invalid api key")
+		}
+		return rpcstatus.Wrap(rpcstatus.Internal, Error.Wrap(err))
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package multinode_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/private/version"
+	"storj.io/storj/multinodepb"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/apikeys"
+	"storj.io/storj/storagenode/multinode"
+	"storj.io/storj/storagenode/payout"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/reputation"
+	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
+	"storj.io/storj/storagenode/storageusage"
+)
+
+func TestEndpoints(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		apiKeys := apikeys.NewService(db.Secret())
+
+		apiKey, err := apiKeys.Issue(ctx)
+		require.NoError(t, err)
+		authorized := multinodepb.WithAPIKey(context.Background(), apiKey.Secret[:])
+
+		satelliteID := testrand.NodeID()
+		joinedAt := time.Now().UTC().Add(-24 * time.Hour).Truncate(time.Second)
+		require.NoError(t, db.Reputation().Store(ctx, reputation.Stats{
+			SatelliteID: satelliteID,
+			Audit: reputation.Metric{
+				TotalCount:   10,
+				SuccessCount: 9,
+				Score:        0.9,
+			},
+			OnlineScore: 1,
+			UpdatedAt:   time.Now().UTC(),
+			JoinedAt:    joinedAt,
+		}))
+
+		day := time.Now().UTC().Truncate(24 * time.Hour)
+		require.NoError(t, db.StorageUsage().Store(ctx, []storageusage.Stamp{
+			{SatelliteID: satelliteID, AtRestTotal: 100, IntervalStart: day.Add(-24 * time.Hour)},
+			{SatelliteID: satelliteID, AtRestTotal: 200, IntervalStart: day},
+		}))
+
+		t.Run("authentication", func(t *testing.T) {
+			status := multinode.NewStatusEndpoint(log, apiKeys, version.Info{})
+
+			_, err := status.Get(ctx, &multinodepb.GetRequest{})
+			require.Equal(t, rpcstatus.Unauthenticated, rpcstatus.Code(err))
+
+			invalid := multinodepb.WithAPIKey(context.Background(), testrand.BytesInt(32))
+			_, err = status.Get(invalid, &multinodepb.GetRequest{})
+			require.Equal(t, rpcstatus.PermissionDenied, rpcstatus.Code(err))
+		})
+
+		t.Run("status", func(t *testing.T) {
+			semver, err := version.NewSemVer("v1.2.3")
+			require.NoError(t, err)
+			status := multinode.NewStatusEndpoint(log, apiKeys, version.Info{Version: semver})
+
+			response, err := status.Get(authorized, &multinodepb.GetRequest{})
+			require.NoError(t, err)
+			require.Equal(t, "v1.2.3", response.Version)
+			require.False(t, response.StartedAt.IsZero())
+		})
+
+		t.Run("reputation", func(t *testing.T) {
+			endpoint := multinode.NewReputationEndpoint(log, apiKeys, db.Reputation())
+
+			response, err := endpoint.GetBySatelliteID(authorized, &multinodepb.GetBySatelliteIDRequest{SatelliteId: satelliteID})
+			require.NoError(t, err)
+			require.Equal(t, int64(10), response.AuditCheck.TotalCount)
+			require.Equal(t, 0.9, response.AuditCheck.ReputationScore)
+			require.True(t, joinedAt.Equal(response.JoinedAt))
+			require.Nil(t, response.Disqualified)
+			require.Equal(t, satelliteID, response.SatelliteId)
+
+			all, err := endpoint.All(authorized, &multinodepb.AllRequest{})
+			require.NoError(t, err)
+			require.Len(t, all.Reputation, 1)
+		})
+
+		t.Run("storage", func(t *testing.T) {
+			nodeID := testrand.NodeID()
+			usageCache := pieces.NewBlobsUsageCacheTest(log, db.Pieces(), 300, 300, 50, map[storj.NodeID]pieces.SatelliteUsage{})
+			endpoint := multinode.NewStorageEndpoint(log, nodeID, apiKeys, usageCache, db.StorageUsage(), 300*memory.B)
+
+			diskSpace, err := endpoint.GetDiskSpace(authorized, &multinodepb.GetDiskSpaceRequest{})
+			require.NoError(t, err)
+			require.Equal(t, &multinodepb.DiskSpace{Used: 300, Available: 300, Trash: 50, Overused: 50}, diskSpace.DiskSpace)
+
+			daily, err := endpoint.DailyStorageUsage(authorized, &multinodepb.DailyStorageUsageRequest{
+				From:        day.Add(-48 * time.Hour),
+				To:          day.Add(24 * time.Hour),
+				SatelliteId: satelliteID,
+			})
+			require.NoError(t, err)
+			require.Equal(t, nodeID.Bytes(), daily.NodeId)
+			require.Len(t, daily.DailyStorageUsage, 2)
+
+			summary, err := endpoint.SatelliteSummary(authorized, &multinodepb.SatelliteSummaryRequest{
+				From: day.Add(-48 * time.Hour),
+				To:   day.Add(24 * time.Hour),
+			})
+			require.NoError(t, err)
+			require.Equal(t, float64(300), summary.StorageUsage)
+		})
+
+		t.Run("payout", func(t *testing.T) {
+			require.NoError(t, db.Payout().StorePayStub(ctx, payout.PayStub{
+				SatelliteID: satelliteID,
+				Period:      "2020-11",
+				Created:     time.Now().UTC(),
+				CompAtRest:  1000,
+				Held:        250,
+				Paid:        750,
+			}))
+
+			service, err := payout.NewService(log, db.Payout(), db.Reputation(), db.Satellites(), db.Bandwidth(), db.StorageUsage(), nil)
+			require.NoError(t, err)
+			endpoint := multinode.NewPayoutEndpoint(log, apiKeys, "0x0123456789012345678901234567890123456789", service, nil, nil)
+
+			wallet, err := endpoint.Wallet(authorized, &multinodepb.WalletRequest{})
+			require.NoError(t, err)
+			require.Equal(t, "0x0123456789012345678901234567890123456789", wallet.Wallet)
+
+			paystubs, err := endpoint.Paystubs(authorized, &multinodepb.PaystubsRequest{PeriodStart: "2020-10", PeriodEnd: "2020-12"})
+			require.NoError(t, err)
+			require.Len(t, paystubs.Paystubs, 1)
+			require.Equal(t, satelliteID, paystubs.Paystubs[0].SatelliteId)
+			require.Equal(t, int64(1000), paystubs.Paystubs[0].CompAtRest)
+			require.Equal(t, int64(250), paystubs.Paystubs[0].Held)
+
+			_, err = endpoint.Paystubs(authorized, &multinodepb.PaystubsRequest{PeriodStart: "2020-10"})
+			require.Equal(t, rpcstatus.InvalidArgument, rpcstatus.Code(err))
+		})
+
+		t.Run("apikeys", func(t *testing.T) {
+			apiKey, err := apiKeys.Issue(ctx)
+			require.NoError(t, err)
+			old := multinodepb.WithAPIKey(context.Background(), apiKey.Secret[:])
+
+			endpoint := multinode.NewAPIKeysEndpoint(log, apiKeys)
+			response, err := endpoint.Rotate(old, &multinodepb.RotateRequest{})
+			require.NoError(t, err)
+			require.Len(t, response.ApiKey, len(apikeys.Secret{}))
+			rotated := multinodepb.WithAPIKey(context.Background(), response.ApiKey)
+
+			// both api keys are valid until the old one is revoked.
+			status := multinode.NewStatusEndpoint(log, apiKeys, version.Info{})
+			_, err = status.Get(old, &multinodepb.GetRequest{})
+			require.NoError(t, err)
+			_, err = status.Get(rotated, &multinodepb.GetRequest{})
+			require.NoError(t, err)
+
+			_, err = endpoint.Revoke(rotated, &multinodepb.RevokeRequest{ApiKey: response.ApiKey})
+			require.Equal(t, rpcstatus.InvalidArgument, rpcstatus.Code(err))
+
+			_, err = endpoint.Revoke(rotated, &multinodepb.RevokeRequest{ApiKey: apiKey.Secret[:]})
+			require.NoError(t, err)
+
+			_, err = status.Get(old, &multinodepb.GetRequest{})
+			require.Equal(t, rpcstatus.PermissionDenied, rpcstatus.Code(err))
+			_, err = status.Get(rotated, &multinodepb.GetRequest{})
+			require.NoError(t, err)
+		})
+	})
+}
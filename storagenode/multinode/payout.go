@@ -0,0 +1,166 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package multinode
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/storj/multinodepb"
+	"storj.io/storj/storagenode/apikeys"
+	"storj.io/storj/storagenode/payout"
+	"storj.io/storj/storagenode/payout/estimatedpayout"
+	"storj.io/storj/storagenode/trust"
+)
+
+var _ multinodepb.DRPCPayoutServer = (*PayoutEndpoint)(nil)
+
+// PayoutEndpoint implements the payout api of the multinode dashboard.
+//
+// architecture: Endpoint
+type PayoutEndpoint struct {
+	log             *zap.Logger
+	apiKeys         *apikeys.Service
+	wallet          string
+	payout          *payout.Service
+	estimatedPayout *estimatedpayout.Service
+	trust           *trust.Pool
+}
+
+// NewPayoutEndpoint creates a new multinode payout endpoint.
+func NewPayoutEndpoint(log *zap.Logger, apiKeys *apikeys.Service, wallet string, payout *payout.Service, estimatedPayout *estimatedpayout.Service, trust *trust.Pool) *PayoutEndpoint {
+	return &PayoutEndpoint{
+		log:             log,
+		apiKeys:         apiKeys,
+		wallet:          wallet,
+		payout:          payout,
+		estimatedPayout: estimatedPayout,
+		trust:           trust,
+	}
+}
+
+// Wallet returns the operator wallet the node is paid to.
+func (endpoint *PayoutEndpoint) Wallet(ctx context.Context, req *multinodepb.WalletRequest) (_ *multinodepb.WalletResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, endpoint.apiKeys); err != nil {
+		return nil, err
+	}
+
+	return &multinodepb.WalletResponse{Wallet: endpoint.wallet}, nil
+}
+
+// Paystubs returns the paystubs of all satellites for the periods between
+// period start and period end inclusive, both in the yyyy-mm format.
+func (endpoint *PayoutEndpoint) Paystubs(ctx context.Context, req *multinodepb.PaystubsRequest) (_ *multinodepb.PaystubsResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, endpoint.apiKeys); err != nil {
+		return nil, err
+	}
+
+	if req.PeriodStart == "" || req.PeriodEnd == "" {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, "period start and period end are required")
+	}
+
+	paystubs, err := endpoint.payout.AllPayStubsPeriod(ctx, req.PeriodStart, req.PeriodEnd)
+	if err != nil {
+		if payout.ErrBadPeriod.Has(err) {
+			return nil, rpcstatus.Wrap(rpcstatus.InvalidArgument, Error.Wrap(err))
+		}
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, Error.Wrap(err))
+	}
+
+	response := &multinodepb.PaystubsResponse{
+		Paystubs: make([]*multinodepb.Paystub, 0, len(paystubs)),
+	}
+	for _, paystub := range paystubs {
+		response.Paystubs = append(response.Paystubs, &multinodepb.Paystub{
+			SatelliteId:    paystub.SatelliteID,
+			Period:         paystub.Period,
+			Created:        paystub.Created,
+			Codes:          paystub.Codes,
+			UsageAtRest:    paystub.UsageAtRest,
+			UsageGet:       paystub.UsageGet,
+			UsagePut:       paystub.UsagePut,
+			UsageGetRepair: paystub.UsageGetRepair,
+			UsagePutRepair: paystub.UsagePutRepair,
+			UsageGetAudit:  paystub.UsageGetAudit,
+			CompAtRest:     paystub.CompAtRest,
+			CompGet:        paystub.CompGet,
+			CompPut:        paystub.CompPut,
+			CompGetRepair:  paystub.CompGetRepair,
+			CompPutRepair:  paystub.CompPutRepair,
+			CompGetAudit:   paystub.CompGetAudit,
+			SurgePercent:   paystub.SurgePercent,
+			Held:           paystub.Held,
+			Owed:           paystub.Owed,
+			Disposed:       paystub.Disposed,
+			Paid:           paystub.Paid,
+		})
+	}
+	return response, nil
+}
+
+// HeldHistory returns the amount held and disposed by each satellite since the node joined.
+func (endpoint *PayoutEndpoint) HeldHistory(ctx context.Context, req *multinodepb.HeldHistoryRequest) (_ *multinodepb.HeldHistoryResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, endpoint.apiKeys); err != nil {
+		return nil, err
+	}
+
+	history, err := endpoint.payout.AllHeldbackHistory(ctx)
+	if err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, Error.Wrap(err))
+	}
+
+	response := &multinodepb.HeldHistoryResponse{
+		History: make([]*multinodepb.SatelliteHeldHistory, 0, len(history)),
+	}
+	for _, held := range history {
+		response.History = append(response.History, &multinodepb.SatelliteHeldHistory{
+			SatelliteId:   held.SatelliteID,
+			TotalHeld:     held.TotalHeld,
+			TotalDisposed: held.TotalDisposed,
+			JoinedAt:      held.JoinedAt,
+		})
+	}
+	return response, nil
+}
+
+// EstimatedPayout returns the estimated payout of the current and previous month per trusted satellite.
+// Satellites the payout can't be estimated for are left out.
+func (endpoint *PayoutEndpoint) EstimatedPayout(ctx context.Context, req *multinodepb.EstimatedPayoutRequest) (_ *multinodepb.EstimatedPayoutResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, endpoint.apiKeys); err != nil {
+		return nil, err
+	}
+
+	satellites := endpoint.trust.GetSatellites(ctx)
+
+	response := &multinodepb.EstimatedPayoutResponse{
+		Satellites: make([]*multinodepb.EstimatedSatellitePayout, 0, len(satellites)),
+	}
+	for _, satelliteID := range satellites {
+		estimated, err := endpoint.estimatedPayout.GetSatelliteEstimatedPayout(ctx, satelliteID)
+		if err != nil {
+			// a satellite without pricing or reputation yet shouldn't hide the estimates of the others.
+			endpoint.log.Warn("failed to estimate payout", zap.Stringer("Satellite ID", satelliteID), zap.Error(err))
+			continue
+		}
+
+		response.Satellites = append(response.Satellites, &multinodepb.EstimatedSatellitePayout{
+			SatelliteId:         satelliteID,
+			CurrentMonthPayout:  estimated.CurrentMonth.Payout,
+			CurrentMonthHeld:    estimated.CurrentMonth.Held,
+			PreviousMonthPayout: estimated.PreviousMonth.Payout,
+			PreviousMonthHeld:   estimated.PreviousMonth.Held,
+		})
+	}
+	return response, nil
+}
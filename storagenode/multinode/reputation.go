@@ -0,0 +1,96 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package multinode
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/storj/multinodepb"
+	"storj.io/storj/storagenode/apikeys"
+	"storj.io/storj/storagenode/reputation"
+)
+
+var _ multinodepb.DRPCReputationServer = (*ReputationEndpoint)(nil)
+
+// ReputationEndpoint implements the reputation api of the multinode dashboard.
+//
+// architecture: Endpoint
+type ReputationEndpoint struct {
+	log        *zap.Logger
+	apiKeys    *apikeys.Service
+	reputation reputation.DB
+}
+
+// NewReputationEndpoint creates a new multinode reputation endpoint.
+func NewReputationEndpoint(log *zap.Logger, apiKeys *apikeys.Service, reputation reputation.DB) *ReputationEndpoint {
+	return &ReputationEndpoint{
+		log:        log,
+		apiKeys:    apiKeys,
+		reputation: reputation,
+	}
+}
+
+// GetBySatelliteID returns the reputation of the node on a satellite.
+func (endpoint *ReputationEndpoint) GetBySatelliteID(ctx context.Context, req *multinodepb.GetBySatelliteIDRequest) (_ *multinodepb.GetBySatelliteIDResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, endpoint.apiKeys); err != nil {
+		return nil, err
+	}
+
+	stats, err := endpoint.reputation.Get(ctx, req.SatelliteId)
+	if err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, Error.Wrap(err))
+	}
+
+	return reputationToPB(*stats), nil
+}
+
+// All returns the reputation of the node on all satellites.
+func (endpoint *ReputationEndpoint) All(ctx context.Context, req *multinodepb.AllRequest) (_ *multinodepb.AllResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, endpoint.apiKeys); err != nil {
+		return nil, err
+	}
+
+	stats, err := endpoint.reputation.All(ctx)
+	if err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, Error.Wrap(err))
+	}
+
+	response := &multinodepb.AllResponse{
+		Reputation: make([]*multinodepb.GetBySatelliteIDResponse, 0, len(stats)),
+	}
+	for _, stat := range stats {
+		response.Reputation = append(response.Reputation, reputationToPB(stat))
+	}
+	return response, nil
+}
+
+// reputationToPB converts reputation stats to their protobuf representation.
+func reputationToPB(stats reputation.Stats) *multinodepb.GetBySatelliteIDResponse {
+	return &multinodepb.GetBySatelliteIDResponse{
+		AuditCheck: &multinodepb.ReputationStats{
+			TotalCount:             stats.Audit.TotalCount,
+			SuccessCount:           stats.Audit.SuccessCount,
+			ReputationAlpha:        stats.Audit.Alpha,
+			ReputationBeta:         stats.Audit.Beta,
+			ReputationScore:        stats.Audit.Score,
+			UnknownReputationAlpha: stats.Audit.UnknownAlpha,
+			UnknownReputationBeta:  stats.Audit.UnknownBeta,
+			UnknownReputationScore: stats.Audit.UnknownScore,
+		},
+		Disqualified:       stats.DisqualifiedAt,
+		Suspended:          stats.SuspendedAt,
+		JoinedAt:           stats.JoinedAt,
+		OfflineSuspended:   stats.OfflineSuspendedAt,
+		OnlineScore:        stats.OnlineScore,
+		OfflineUnderReview: stats.OfflineUnderReviewAt,
+		SatelliteId:        stats.SatelliteID,
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package multinode
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/private/version"
+	"storj.io/storj/multinodepb"
+	"storj.io/storj/storagenode/apikeys"
+)
+
+var _ multinodepb.DRPCStatusServer = (*StatusEndpoint)(nil)
+
+// StatusEndpoint implements the status api of the multinode dashboard.
+//
+// architecture: Endpoint
+type StatusEndpoint struct {
+	log       *zap.Logger
+	apiKeys   *apikeys.Service
+	version   version.Info
+	startedAt time.Time
+}
+
+// NewStatusEndpoint creates a new multinode status endpoint.
+func NewStatusEndpoint(log *zap.Logger, apiKeys *apikeys.Service, version version.Info) *StatusEndpoint {
+	return &StatusEndpoint{
+		log:       log,
+		apiKeys:   apiKeys,
+		version:   version,
+		startedAt: time.Now(),
+	}
+}
+
+// Get returns when the node was started and its version.
+func (endpoint *StatusEndpoint) Get(ctx context.Context, req *multinodepb.GetRequest) (_ *multinodepb.GetResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, endpoint.apiKeys); err != nil {
+		return nil, err
+	}
+
+	return &multinodepb.GetResponse{
+		StartedAt: endpoint.startedAt,
+		Version:   endpoint.version.Version.String(),
+	}, nil
+}
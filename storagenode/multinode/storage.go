@@ -0,0 +1,130 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package multinode
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/storj"
+	"storj.io/storj/multinodepb"
+	"storj.io/storj/storagenode/apikeys"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/storageusage"
+)
+
+var _ multinodepb.DRPCNodeDiskSpaceServer = (*StorageEndpoint)(nil)
+
+// StorageEndpoint implements the disk space api of the multinode dashboard.
+//
+// architecture: Endpoint
+type StorageEndpoint struct {
+	log          *zap.Logger
+	nodeID       storj.NodeID
+	apiKeys      *apikeys.Service
+	usageCache   *pieces.BlobsUsageCache
+	storageUsage storageusage.DB
+
+	allocatedDiskSpace memory.Size
+}
+
+// NewStorageEndpoint creates a new multinode disk space endpoint.
+func NewStorageEndpoint(log *zap.Logger, nodeID storj.NodeID, apiKeys *apikeys.Service, usageCache *pieces.BlobsUsageCache, storageUsage storageusage.DB, allocatedDiskSpace memory.Size) *StorageEndpoint {
+	return &StorageEndpoint{
+		log:                log,
+		nodeID:             nodeID,
+		apiKeys:            apiKeys,
+		usageCache:         usageCache,
+		storageUsage:       storageUsage,
+		allocatedDiskSpace: allocatedDiskSpace,
+	}
+}
+
+// GetDiskSpace returns the allocated, used and trashed disk space of the node.
+func (storage *StorageEndpoint) GetDiskSpace(ctx context.Context, req *multinodepb.GetDiskSpaceRequest) (_ *multinodepb.GetDiskSpaceResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, storage.apiKeys); err != nil {
+		return nil, err
+	}
+
+	used, _, err := storage.usageCache.SpaceUsedForPieces(ctx)
+	if err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, Error.Wrap(err))
+	}
+
+	trash, err := storage.usageCache.SpaceUsedForTrash(ctx)
+	if err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, Error.Wrap(err))
+	}
+
+	diskSpace := &multinodepb.DiskSpace{
+		Used:      used,
+		Available: storage.allocatedDiskSpace.Int64(),
+		Trash:     trash,
+	}
+	if free := diskSpace.Available - used - trash; free < 0 {
+		diskSpace.Overused = -free
+	}
+
+	return &multinodepb.GetDiskSpaceResponse{DiskSpace: diskSpace}, nil
+}
+
+// DailyStorageUsage returns daily storage usage of a satellite, or of all
+// satellites when no satellite is specified, in the requested period.
+func (storage *StorageEndpoint) DailyStorageUsage(ctx context.Context, req *multinodepb.DailyStorageUsageRequest) (_ *multinodepb.DailyStorageUsageResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, storage.apiKeys); err != nil {
+		return nil, err
+	}
+
+	var stamps []storageusage.Stamp
+	if req.SatelliteId.IsZero() {
+		stamps, err = storage.storageUsage.GetDailyTotal(ctx, req.From, req.To)
+	} else {
+		stamps, err = storage.storageUsage.GetDaily(ctx, req.SatelliteId, req.From, req.To)
+	}
+	if err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, Error.Wrap(err))
+	}
+
+	usage := make([]*multinodepb.DailyStorageUsageResponse_StorageUsage, 0, len(stamps))
+	for _, stamp := range stamps {
+		usage = append(usage, &multinodepb.DailyStorageUsageResponse_StorageUsage{
+			AtRestTotal: stamp.AtRestTotal,
+			Timestamp:   stamp.IntervalStart,
+		})
+	}
+
+	return &multinodepb.DailyStorageUsageResponse{
+		NodeId:            storage.nodeID.Bytes(),
+		DailyStorageUsage: usage,
+	}, nil
+}
+
+// SatelliteSummary returns the storage usage of a satellite, or of all
+// satellites when no satellite is specified, in the requested period.
+func (storage *StorageEndpoint) SatelliteSummary(ctx context.Context, req *multinodepb.SatelliteSummaryRequest) (_ *multinodepb.SatelliteSummaryResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, storage.apiKeys); err != nil {
+		return nil, err
+	}
+
+	var summary float64
+	if req.SatelliteId.IsZero() {
+		summary, err = storage.storageUsage.Summary(ctx, req.From, req.To)
+	} else {
+		summary, err = storage.storageUsage.SatelliteSummary(ctx, req.SatelliteId, req.From, req.To)
+	}
+	if err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, Error.Wrap(err))
+	}
+
+	return &multinodepb.SatelliteSummaryResponse{StorageUsage: summary}, nil
+}
@@ -14,6 +14,7 @@ import (
 	"storj.io/common/pb"
 	"storj.io/common/rpc"
 	"storj.io/common/storj"
+	"storj.io/storj/private/nodestatsext"
 	"storj.io/storj/storagenode/pricing"
 	"storj.io/storj/storagenode/reputation"
 	"storj.io/storj/storagenode/storageusage"
@@ -77,7 +78,13 @@ func (s *Service) GetReputationStats(ctx context.Context, satelliteID storj.Node
 	uptime := resp.GetUptimeCheck()
 	audit := resp.GetAuditCheck()
 
-	return &reputation.Stats{
+	// satellites which do not report the extensions leave them empty.
+	extensions, err := nodestatsext.FromResponse(resp)
+	if err != nil {
+		return nil, NodeStatsServiceErr.Wrap(err)
+	}
+
+	stats := &reputation.Stats{
 		SatelliteID: satelliteID,
 		Uptime: reputation.Metric{
 			TotalCount:   uptime.GetTotalCount(),
@@ -98,9 +105,16 @@ func (s *Service) GetReputationStats(ctx context.Context, satelliteID storj.Node
 		SuspendedAt:          resp.GetSuspended(),
 		OfflineSuspendedAt:   resp.GetOfflineSuspended(),
 		OfflineUnderReviewAt: resp.GetOfflineUnderReview(),
+		AuditHistory:         extensions.AuditHistory,
 		UpdatedAt:            time.Now(),
 		JoinedAt:             resp.JoinedAt,
-	}, nil
+	}
+	if extensions.Vetting != nil {
+		stats.VettedAt = extensions.Vetting.VettedAt
+		stats.VettingAuditCount = extensions.Vetting.AuditCount
+	}
+
+	return stats, nil
 }
 
 // GetDailyStorageUsage returns daily storage usage over a period of time for a particular satellite.
@@ -5,8 +5,11 @@ package notifications
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/zeebo/errs"
+
 	"storj.io/common/storj"
 	"storj.io/common/uuid"
 )
@@ -37,8 +40,41 @@ const (
 	TypeDisqualification Type = 3
 	// TypeSuspension is a notification type which describes node's suspension status.
 	TypeSuspension Type = 4
+	// TypeLowDiskSpace is a notification type which describes that node's filesystem is running out of space.
+	TypeLowDiskSpace Type = 5
+	// TypeUntrustedSatellite is a notification type which describes that a satellite storing data on the node is no longer trusted.
+	TypeUntrustedSatellite Type = 6
 )
 
+// typeNames are the names of notification types used outside of the node.
+var typeNames = map[Type]string{
+	TypeCustom:             "custom",
+	TypeAuditCheckFailure:  "audit-check-failure",
+	TypeUptimeCheckFailure: "uptime-check-failure",
+	TypeDisqualification:   "disqualification",
+	TypeSuspension:         "suspension",
+	TypeLowDiskSpace:       "low-disk-space",
+	TypeUntrustedSatellite: "untrusted-satellite",
+}
+
+// String returns the name of the notification type.
+func (t Type) String() string {
+	if name, ok := typeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("type-%d", int(t))
+}
+
+// ParseType parses the name of a notification type.
+func ParseType(name string) (Type, error) {
+	for t, typeName := range typeNames {
+		if typeName == name {
+			return t, nil
+		}
+	}
+	return 0, errs.New("unknown notification type %q", name)
+}
+
 // NewNotification holds notification entity info which is being received from satellite or local client.
 type NewNotification struct {
 	SenderID storj.NodeID
@@ -30,18 +30,27 @@ const (
 	TimesNotifiedLast TimesNotified = 3
 )
 
+// Sender delivers notifications outside of the node.
+type Sender interface {
+	// Send delivers the notification, it must not block.
+	Send(ctx context.Context, notification Notification)
+}
+
 // Service is the notification service between storage nodes and satellites.
 // architecture: Service
 type Service struct {
-	log *zap.Logger
-	db  DB
+	log    *zap.Logger
+	db     DB
+	sender Sender
 }
 
-// NewService creates a new notification service.
-func NewService(log *zap.Logger, db DB) *Service {
+// NewService creates a new notification service. Received notifications
+// are additionally delivered by sender, when it is not nil.
+func NewService(log *zap.Logger, db DB, sender Sender) *Service {
 	return &Service{
-		log: log,
-		db:  db,
+		log:    log,
+		db:     db,
+		sender: sender,
 	}
 }
 
@@ -52,6 +61,10 @@ func (service *Service) Receive(ctx context.Context, newNotification NewNotifica
 		return Notification{}, err
 	}
 
+	if service.sender != nil {
+		service.sender.Send(ctx, notification)
+	}
+
 	return notification, nil
 }
 
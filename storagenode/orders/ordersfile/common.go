@@ -27,6 +27,9 @@ const (
 	V0 = Version("v0")
 	// V1 is the second orders file version. It includes a checksum for each entry so that file corruption is handled better.
 	V1 = Version("v1")
+	// V2 is the third orders file version. It includes a checksummed file header with the satellite ID and the window,
+	// and frames each entry with its length and checksum, so that a partially written entry doesn't affect the others.
+	V2 = Version("v2")
 
 	unsentFilePrefix  = "unsent-orders-"
 	archiveFilePrefix = "archived-orders-"
@@ -42,6 +45,9 @@ var (
 	Error = errs.Class("ordersfile")
 	// ErrEntryCorrupt is returned when a corrupt entry is found.
 	ErrEntryCorrupt = errs.Class("ordersfile corrupt entry")
+	// ErrUnknownVersion is returned for files written with a version which is
+	// not known to this release, e.g. by a newer release before a rollback.
+	ErrUnknownVersion = errs.Class("ordersfile unknown version")
 )
 
 // Info contains full information about an order.
@@ -64,20 +70,21 @@ type Readable interface {
 
 // OpenWritableUnsent creates or opens for appending the unsent orders file for a given satellite ID and creation hour.
 func OpenWritableUnsent(unsentDir string, satelliteID storj.NodeID, creationTime time.Time) (Writable, error) {
-	// if V0 file already exists, use that. Otherwise use V1 file.
-	versionToUse := V0
-	fileName := UnsentFileName(satelliteID, creationTime, V0)
-	filePath := filepath.Join(unsentDir, fileName)
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		fileName = UnsentFileName(satelliteID, creationTime, V1)
-		filePath = filepath.Join(unsentDir, fileName)
-		versionToUse = V1
+	// if a V0 or V1 file already exists, use that. Otherwise use V2 file.
+	for _, version := range []Version{V0, V1} {
+		filePath := filepath.Join(unsentDir, UnsentFileName(satelliteID, creationTime, version))
+		if _, err := os.Stat(filePath); os.IsNotExist(err) {
+			continue
+		}
+
+		if version == V0 {
+			return OpenWritableV0(filePath)
+		}
+		return OpenWritableV1(filePath, satelliteID, creationTime)
 	}
 
-	if versionToUse == V0 {
-		return OpenWritableV0(filePath)
-	}
-	return OpenWritableV1(filePath, satelliteID, creationTime)
+	filePath := filepath.Join(unsentDir, UnsentFileName(satelliteID, creationTime, V2))
+	return OpenWritableV2(filePath, satelliteID, creationTime)
 }
 
 // UnsentInfo contains information relevant to an unsent orders file, as well as information necessary to open it for reading.
@@ -129,10 +136,14 @@ func GetArchivedInfo(info os.FileInfo) (*ArchivedInfo, error) {
 // OpenReadable opens for reading the unsent or archived orders file at a given path.
 // It assumes the path has already been validated with GetUnsentInfo or GetArchivedInfo.
 func OpenReadable(path string, version Version) (Readable, error) {
-	if version == V0 {
+	switch version {
+	case V0:
 		return OpenReadableV0(path)
+	case V1:
+		return OpenReadableV1(path)
+	default:
+		return OpenReadableV2(path)
 	}
-	return OpenReadableV1(path)
 }
 
 // MoveUnsent moves an unsent orders file to the archived orders file directory.
@@ -146,7 +157,10 @@ func MoveUnsent(unsentDir, archiveDir string, satelliteID storj.NodeID, createdA
 // it expects the file name to be in the format "unsent-orders-<satelliteID>-<createdAtHour>.<version>".
 // V0 will not have ".<version>" at the end of the filename.
 func getUnsentFileInfo(filename string) (satellite storj.NodeID, createdHour time.Time, version Version, err error) {
-	filename, version = getVersion(filename)
+	filename, version, err = getVersion(filename)
+	if err != nil {
+		return storj.NodeID{}, time.Time{}, version, err
+	}
 
 	if !strings.HasPrefix(filename, unsentFilePrefix) {
 		return storj.NodeID{}, time.Time{}, version, Error.New("invalid path: %q", filename)
@@ -178,7 +192,10 @@ func getUnsentFileInfo(filename string) (satellite storj.NodeID, createdHour tim
 // it expects the file name to be in the format "archived-orders-<satelliteID>-<createdAtHour>-<archviedAtTime>-<status>.<version>".
 // V0 will not have ".<version>" at the end of the filename.
 func getArchivedFileInfo(name string) (satelliteID storj.NodeID, createdAtHour, archivedAt time.Time, status string, version Version, err error) {
-	name, version = getVersion(name)
+	name, version, err = getVersion(name)
+	if err != nil {
+		return storj.NodeID{}, time.Time{}, time.Time{}, "", version, err
+	}
 
 	if !strings.HasPrefix(name, archiveFilePrefix) {
 		return storj.NodeID{}, time.Time{}, time.Time{}, "", version, Error.New("invalid path: %q", name)
@@ -249,12 +266,18 @@ func getCreationHourString(t time.Time) string {
 	return timeStr
 }
 
-func getVersion(filename string) (trimmed string, version Version) {
+// getVersion returns the file name without the version extension and the
+// version. V0 files don't have an extension, any other extension than the
+// known versions is reported as ErrUnknownVersion.
+func getVersion(filename string) (trimmed string, version Version, err error) {
 	ext := filepath.Ext(filename)
-	if ext == "."+string(V1) {
-		version = V1
-		trimmed = strings.TrimSuffix(filename, ext)
-		return trimmed, V1
+	switch ext {
+	case "":
+		return filename, V0, nil
+	case "." + string(V1):
+		return strings.TrimSuffix(filename, ext), V1, nil
+	case "." + string(V2):
+		return strings.TrimSuffix(filename, ext), V2, nil
 	}
-	return filename, V0
+	return strings.TrimSuffix(filename, ext), Version(ext[1:]), ErrUnknownVersion.New("%q", filename)
 }
@@ -0,0 +1,76 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ordersfile
+
+import (
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+)
+
+// DowngradeV2 converts the V2 orders file at path, which stores orders of the
+// satellite created at the given hour, into a V1 file next to it, so that
+// releases before V2 are able to read it, and removes the V2 file.
+//
+// Corrupt entries are skipped, the number of them is returned as lost.
+func DowngradeV2(path string, satelliteID storj.NodeID, createdAtHour time.Time) (_ string, lost int, err error) {
+	if !strings.HasSuffix(path, "."+string(V2)) {
+		return "", 0, Error.New("not a %s orders file: %q", V2, path)
+	}
+	downgradedPath := strings.TrimSuffix(path, "."+string(V2)) + "." + string(V1)
+	if _, err := os.Stat(downgradedPath); !os.IsNotExist(err) {
+		return "", 0, Error.New("%s orders file already exists: %q", V1, downgradedPath)
+	}
+
+	reader, err := OpenRecoveryReader(path, V2)
+	if err != nil {
+		return "", 0, err
+	}
+	defer func() { err = errs.Combine(err, reader.Close()) }()
+
+	// the entries are written to a temporary file first, so that an
+	// interrupted downgrade doesn't leave an incomplete V1 file behind.
+	tempPath := downgradedPath + ".tmp"
+	if err := removeTemp(tempPath); err != nil {
+		return "", 0, err
+	}
+	writer, err := OpenWritableV1(tempPath, satelliteID, createdAtHour)
+	if err != nil {
+		return "", 0, errs.Combine(err, removeTemp(tempPath))
+	}
+
+	for {
+		info, err := reader.ReadOne()
+		if errs.Is(err, io.EOF) {
+			break
+		}
+		if err == nil {
+			err = writer.Append(info)
+		}
+		if err != nil {
+			return "", 0, errs.Combine(err, writer.Close(), removeTemp(tempPath))
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", 0, errs.Combine(err, removeTemp(tempPath))
+	}
+	if err := os.Rename(tempPath, downgradedPath); err != nil {
+		return "", 0, errs.Combine(Error.Wrap(err), removeTemp(tempPath))
+	}
+	return downgradedPath, reader.Lost(), Error.Wrap(os.Remove(path))
+}
+
+func removeTemp(path string) error {
+	err := os.Remove(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return Error.Wrap(err)
+}
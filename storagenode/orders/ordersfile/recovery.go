@@ -0,0 +1,121 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ordersfile
+
+import (
+	"io"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+)
+
+// RecoveryReader reads the valid entries of an orders file, skipping corrupt
+// entries and counting how many of them were lost.
+type RecoveryReader struct {
+	readable Readable
+	version  Version
+	lost     int
+	lastErr  error
+}
+
+// OpenRecoveryReader opens for reading the unsent or archived orders file at a given path.
+// It assumes the path has already been validated with GetUnsentInfo or GetArchivedInfo.
+func OpenRecoveryReader(path string, version Version) (*RecoveryReader, error) {
+	readable, err := OpenReadable(path, version)
+	if err != nil {
+		return nil, err
+	}
+	return &RecoveryReader{
+		readable: readable,
+		version:  version,
+	}, nil
+}
+
+// ReadOne returns the next valid entry, or io.EOF when there are no more entries.
+func (reader *RecoveryReader) ReadOne() (*Info, error) {
+	for {
+		info, err := reader.readable.ReadOne()
+		switch {
+		case err == nil:
+			return info, nil
+		case errs.Is(err, io.EOF):
+			return nil, io.EOF
+		case ErrEntryCorrupt.Has(err):
+			reader.lost++
+			reader.lastErr = err
+			// V0 and V1 entries can't be found reliably after an incomplete
+			// entry, while V2 files may contain more records after one.
+			if reader.version != V2 && errs.Is(err, io.ErrUnexpectedEOF) {
+				return nil, io.EOF
+			}
+		default:
+			return nil, err
+		}
+	}
+}
+
+// Lost returns the number of corrupt entries skipped so far.
+func (reader *RecoveryReader) Lost() int {
+	return reader.lost
+}
+
+// LastError returns the error of the last corrupt entry skipped.
+func (reader *RecoveryReader) LastError() error {
+	return reader.lastErr
+}
+
+// Close closes the file.
+func (reader *RecoveryReader) Close() error {
+	return reader.readable.Close()
+}
+
+// VerifyResult contains the result of verifying an orders file.
+type VerifyResult struct {
+	// Valid is the number of entries which could be read.
+	Valid int
+	// Lost is the number of corrupt entries.
+	Lost int
+	// HeaderErr is set when the file header is corrupt or doesn't match the
+	// file name. Only V2 files are checked.
+	HeaderErr error
+}
+
+// Verify reads all entries of the orders file at path, which stores orders
+// of the satellite created at the given hour.
+func Verify(path string, satelliteID storj.NodeID, createdAtHour time.Time, version Version) (_ VerifyResult, err error) {
+	reader, err := OpenRecoveryReader(path, version)
+	if err != nil {
+		return VerifyResult{}, err
+	}
+	defer func() { err = errs.Combine(err, reader.Close()) }()
+
+	var result VerifyResult
+	if file, ok := reader.readable.(*fileV2); ok {
+		header, err := file.Header()
+		switch {
+		case err != nil:
+			result.HeaderErr = err
+		case header.SatelliteID != satelliteID:
+			result.HeaderErr = Error.New("file header satellite %s does not match %s", header.SatelliteID, satelliteID)
+		case !header.CreatedAtHour.Equal(createdAtHour):
+			result.HeaderErr = Error.New("file header window %s does not match %s", header.CreatedAtHour, createdAtHour)
+		}
+	}
+
+	for {
+		_, err := reader.ReadOne()
+		if errs.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return result, err
+		}
+		result.Valid++
+	}
+	result.Lost = reader.Lost()
+
+	return result, nil
+}
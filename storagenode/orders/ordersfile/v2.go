@@ -0,0 +1,326 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ordersfile
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/storj/private/date"
+)
+
+var (
+	// fileMagicV2 identifies the header of a V2 file.
+	// "0ddba11 acc01ade 2".
+	fileMagicV2 = [8]byte{0x0d, 0xdb, 0xa1, 0x1a, 0xcc, 0x01, 0xad, 0x02}
+
+	// recordMarker is 8 bytes that appears before every record in a V2 file.
+	// It's used to find the next record after a corrupt one.
+	// "5ca1ab1e f00dfeed".
+	recordMarker = [8]byte{0x5c, 0xa1, 0xab, 0x1e, 0xf0, 0x0d, 0xfe, 0xed}
+)
+
+const (
+	// headerV2Size is the size of [fileMagicV2][satellite ID][creation hour][checksum].
+	headerV2Size = len(fileMagicV2) + len(storj.NodeID{}) + 8 + 4
+	// recordPrefixSize is the size of [recordMarker][payload size][checksum].
+	recordPrefixSize = len(recordMarker) + 4 + 4
+	// recordPayloadSizeCap is the maximum size of a record payload.
+	recordPayloadSizeCap = 4 + orderLimitSizeCap + orderSizeCap
+)
+
+// Header contains the information stored in the header of a V2 orders file.
+type Header struct {
+	SatelliteID   storj.NodeID
+	CreatedAtHour time.Time
+}
+
+// fileV2 is a version 2 orders file.
+//
+// The file starts with a checksummed header carrying the satellite ID and the
+// creation hour of the window, followed by records framed as
+// [recordMarker][payload size][checksum][payload]. A record which was only
+// partially written, e.g. due to a power loss, fails the checksum and is
+// skipped by the reader, which resumes at the next record marker.
+type fileV2 struct {
+	f  *os.File
+	br *bufio.Reader
+
+	header    *Header
+	headerErr error
+}
+
+// OpenWritableV2 opens for writing the unsent or archived orders file at a given path.
+// If the file is new, the file header is written.
+func OpenWritableV2(path string, satelliteID storj.NodeID, creationTime time.Time) (Writable, error) {
+	// create file if not exists or append
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	of := &fileV2{
+		f: f,
+	}
+
+	currentPos, err := of.f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, errs.Combine(Error.Wrap(err), of.Close())
+	}
+	if currentPos == 0 {
+		err = of.writeHeader(satelliteID, creationTime)
+		if err != nil {
+			return nil, errs.Combine(err, of.Close())
+		}
+	}
+
+	return of, nil
+}
+
+// writeHeader writes file header as [fileMagicV2][satellite ID][creation hour][checksum].
+func (of *fileV2) writeHeader(satelliteID storj.NodeID, creationTime time.Time) error {
+	toWrite := make([]byte, 0, headerV2Size)
+	toWrite = append(toWrite, fileMagicV2[:]...)
+	toWrite = append(toWrite, satelliteID.Bytes()...)
+
+	creationHourBytes := [8]byte{}
+	binary.LittleEndian.PutUint64(creationHourBytes[:], uint64(date.TruncateToHourInNano(creationTime)))
+	toWrite = append(toWrite, creationHourBytes[:]...)
+
+	checksumBytes := [4]byte{}
+	binary.LittleEndian.PutUint32(checksumBytes[:], crc32.ChecksumIEEE(toWrite))
+	toWrite = append(toWrite, checksumBytes[:]...)
+
+	if _, err := of.f.Write(toWrite); err != nil {
+		return Error.New("Couldn't write file header: %w", err)
+	}
+	return nil
+}
+
+// OpenReadableV2 opens for reading the unsent or archived orders file at a given path.
+// A corrupt file header doesn't prevent reading the records.
+func OpenReadableV2(path string) (Readable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	of := &fileV2{
+		f:  f,
+		br: bufio.NewReader(f),
+	}
+	if err := of.readHeader(); err != nil {
+		return nil, errs.Combine(err, of.Close())
+	}
+	return of, nil
+}
+
+// readHeader reads the file header. When the header is corrupt, it's recorded
+// in headerErr and the reader is rewound, so that the records which follow a
+// partially written header can still be found.
+func (of *fileV2) readHeader() error {
+	headerBytes := make([]byte, headerV2Size)
+	_, err := io.ReadFull(of.br, headerBytes)
+	switch {
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		of.headerErr = ErrEntryCorrupt.New("file header is incomplete")
+	case err != nil:
+		return Error.Wrap(err)
+	case !bytes.Equal(headerBytes[:len(fileMagicV2)], fileMagicV2[:]):
+		of.headerErr = ErrEntryCorrupt.New("file header magic does not match")
+	default:
+		checksumOffset := headerV2Size - 4
+		expectedChecksum := binary.LittleEndian.Uint32(headerBytes[checksumOffset:])
+		if crc32.ChecksumIEEE(headerBytes[:checksumOffset]) != expectedChecksum {
+			of.headerErr = ErrEntryCorrupt.New("file header checksum does not match")
+			break
+		}
+
+		satelliteID, err := storj.NodeIDFromBytes(headerBytes[len(fileMagicV2) : len(fileMagicV2)+len(storj.NodeID{})])
+		if err != nil {
+			of.headerErr = ErrEntryCorrupt.Wrap(err)
+			break
+		}
+		creationHour := binary.LittleEndian.Uint64(headerBytes[checksumOffset-8 : checksumOffset])
+		of.header = &Header{
+			SatelliteID:   satelliteID,
+			CreatedAtHour: time.Unix(0, int64(creationHour)),
+		}
+		return nil
+	}
+
+	if _, err := of.f.Seek(0, io.SeekStart); err != nil {
+		return Error.Wrap(err)
+	}
+	of.br.Reset(of.f)
+	return nil
+}
+
+// Header returns the file header, or an error when it's corrupt.
+func (of *fileV2) Header() (*Header, error) {
+	return of.header, of.headerErr
+}
+
+// Append writes limit and order to the file as a single record
+// [recordMarker][payload size][checksum][payload], where the payload is
+// [limit size][limit][order] and the checksum covers the payload size and the payload.
+func (of *fileV2) Append(info *Info) error {
+	limitSerialized, err := pb.Marshal(info.Limit)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	orderSerialized, err := pb.Marshal(info.Order)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	payloadSize := 4 + len(limitSerialized) + len(orderSerialized)
+	toWrite := make([]byte, recordPrefixSize, recordPrefixSize+payloadSize)
+	copy(toWrite, recordMarker[:])
+	binary.LittleEndian.PutUint32(toWrite[len(recordMarker):], uint32(payloadSize))
+
+	limitSizeBytes := [4]byte{}
+	binary.LittleEndian.PutUint32(limitSizeBytes[:], uint32(len(limitSerialized)))
+	toWrite = append(toWrite, limitSizeBytes[:]...)
+	toWrite = append(toWrite, limitSerialized...)
+	toWrite = append(toWrite, orderSerialized...)
+
+	checksum := crc32.ChecksumIEEE(toWrite[len(recordMarker) : len(recordMarker)+4])
+	checksum = crc32.Update(checksum, crc32.IEEETable, toWrite[recordPrefixSize:])
+	binary.LittleEndian.PutUint32(toWrite[len(recordMarker)+4:], checksum)
+
+	// the record is written with a single call, so that a failure can
+	// only leave a partial record at the end of the file.
+	if _, err = of.f.Write(toWrite); err != nil {
+		return Error.New("Couldn't write serialized order and limit: %w", err)
+	}
+	return nil
+}
+
+// ReadOne reads one record from the file.
+// It returns ErrEntryCorrupt upon finding a corrupt record. On next call after a corrupt record, it will find the next valid one.
+func (of *fileV2) ReadOne() (info *Info, err error) {
+	startPosition, err := of.f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	startPosition -= int64(of.br.Buffered())
+
+	recordPosition := int64(-1)
+	defer func() {
+		// Treat all errors after finding a record as corrupt entry errors so that ReadOne is called again.
+		if err != nil && recordPosition >= 0 {
+			// continue searching right after the marker of the corrupt record,
+			// since its size may be corrupt as well.
+			_, seekErr := of.f.Seek(recordPosition+1, io.SeekStart)
+			if seekErr != nil {
+				err = errs.Combine(err, seekErr)
+			}
+			of.br.Reset(of.f)
+
+			err = ErrEntryCorrupt.Wrap(err)
+		}
+	}()
+
+	skipped, err := of.gotoNextRecord()
+	if err != nil {
+		return nil, err
+	}
+	recordPosition = startPosition + skipped
+
+	sizeAndChecksum := [8]byte{}
+	if err = readFull(of.br, sizeAndChecksum[:]); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	payloadSize := binary.LittleEndian.Uint32(sizeAndChecksum[:4])
+	if payloadSize < 4 || payloadSize > uint32(recordPayloadSizeCap) {
+		return nil, Error.New("invalid record size: %d", payloadSize)
+	}
+	expectedChecksum := binary.LittleEndian.Uint32(sizeAndChecksum[4:])
+
+	payload := make([]byte, payloadSize)
+	if err = readFull(of.br, payload); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	actualChecksum := crc32.ChecksumIEEE(sizeAndChecksum[:4])
+	actualChecksum = crc32.Update(actualChecksum, crc32.IEEETable, payload)
+	if expectedChecksum != actualChecksum {
+		return nil, Error.New("checksum does not match")
+	}
+
+	limitSize := binary.LittleEndian.Uint32(payload[:4])
+	if limitSize > payloadSize-4 {
+		return nil, Error.New("invalid limit size: %d is over the record size %d", limitSize, payloadSize-4)
+	}
+
+	limit := &pb.OrderLimit{}
+	if err = pb.Unmarshal(payload[4:4+limitSize], limit); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	order := &pb.Order{}
+	if err = pb.Unmarshal(payload[4+limitSize:], order); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return &Info{
+		Limit: limit,
+		Order: order,
+	}, nil
+}
+
+// gotoNextRecord discards everything up to and including the next record
+// marker and returns the number of bytes discarded before the marker.
+func (of *fileV2) gotoNextRecord() (skipped int64, err error) {
+	for {
+		searchBufSize := 2 * memory.KiB.Int()
+		nextBufferBytes, err := of.br.Peek(searchBufSize)
+		// if the buffered reader hits an EOF, the buffered data may still
+		// contain a full record, so do not return unless there is definitely no record
+		if errors.Is(err, io.EOF) && len(nextBufferBytes) <= len(recordMarker) {
+			return skipped, io.EOF
+		} else if err != nil && !errors.Is(err, io.EOF) {
+			return skipped, Error.Wrap(err)
+		}
+
+		i := bytes.Index(nextBufferBytes, recordMarker[:])
+		if i > -1 {
+			if _, err = of.br.Discard(i + len(recordMarker)); err != nil {
+				return skipped, Error.Wrap(err)
+			}
+			return skipped + int64(i), nil
+		}
+		// record marker not found; discard all but last (len(recordMarker)-1) bytes for next iteration
+		discard := len(nextBufferBytes) - len(recordMarker) + 1
+		if _, err = of.br.Discard(discard); err != nil {
+			return skipped, Error.Wrap(err)
+		}
+		skipped += int64(discard)
+	}
+}
+
+// readFull reads exactly len(buf) bytes. Since it's only used after a record
+// marker has been found, reaching the end of the file is always unexpected.
+func readFull(r io.Reader, buf []byte) error {
+	_, err := io.ReadFull(r, buf)
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// Close closes the file.
+func (of *fileV2) Close() error {
+	return of.f.Close()
+}
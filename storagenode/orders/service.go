@@ -52,6 +52,20 @@ const (
 	StatusRejected
 )
 
+// String returns the string representation of the status.
+func (status Status) String() string {
+	switch status {
+	case StatusUnsent:
+		return "unsent"
+	case StatusAccepted:
+		return "accepted"
+	case StatusRejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
+
 // ArchiveRequest defines arguments for archiving a single order.
 type ArchiveRequest struct {
 	Satellite storj.NodeID
@@ -101,6 +115,10 @@ type Service struct {
 	orders      DB
 	trust       *trust.Pool
 
+	// sendMu ensures that a window is not sent by the sender cycle and
+	// SendSatellite concurrently.
+	sendMu sync.Mutex
+
 	Sender  *sync2.Cycle
 	Cleanup *sync2.Cycle
 }
@@ -381,6 +399,9 @@ func (service *Service) settle(ctx context.Context, log *zap.Logger, satelliteID
 func (service *Service) sendOrdersFromFileStore(ctx context.Context, now time.Time) {
 	defer mon.Task()(&ctx)(nil)
 
+	service.sendMu.Lock()
+	defer service.sendMu.Unlock()
+
 	errorSatellites := make(map[storj.NodeID]struct{})
 	var errorSatellitesMu sync.Mutex
 
@@ -438,6 +459,46 @@ func (service *Service) sendOrdersFromFileStore(ctx context.Context, now time.Ti
 	}
 }
 
+// SendSatellite immediately sends all unsent windows of the satellite which
+// don't accept new orders anymore, using now as the current time. It returns
+// the number of windows which were sent.
+//
+// When some orders files can't be listed, the windows which could be listed
+// are sent anyway and the listing error is returned afterwards.
+func (service *Service) SendSatellite(ctx context.Context, satelliteID storj.NodeID, now time.Time) (windows int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	service.sendMu.Lock()
+	defer service.sendMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, service.config.SenderTimeout)
+	defer cancel()
+
+	log := service.log.Named(satelliteID.String())
+	for {
+		ordersBySatellite, listErr := service.ordersStore.ListUnsentBySatellite(ctx, now)
+		if listErr != nil {
+			log.Error("listing orders", zap.Error(listErr))
+		}
+
+		unsentInfo, ok := ordersBySatellite[satelliteID]
+		if !ok {
+			return windows, listErr
+		}
+
+		status, err := service.settleWindow(ctx, log, satelliteID, unsentInfo.InfoList)
+		if err != nil {
+			return windows, err
+		}
+
+		err = service.ordersStore.Archive(satelliteID, unsentInfo, time.Now().UTC(), status)
+		if err != nil {
+			return windows, err
+		}
+		windows++
+	}
+}
+
 func (service *Service) settleWindow(ctx context.Context, log *zap.Logger, satelliteID storj.NodeID, orders []*ordersfile.Info) (status pb.SettlementWithWindowResponse_Status, err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -124,6 +124,51 @@ func TestOrderFileStoreSettle(t *testing.T) {
 	})
 }
 
+func TestOrderFileStoreSendSatellite(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		uplinkPeer := planet.Uplinks[0]
+		satellite.Audit.Worker.Loop.Pause()
+		node := planet.StorageNodes[0]
+		service := node.Storage2.Orders
+		service.Sender.Pause()
+		service.Cleanup.Pause()
+		tomorrow := time.Now().Add(24 * time.Hour)
+
+		// upload a file to generate an order on the storagenode
+		testData := testrand.Bytes(8 * memory.KiB)
+		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
+		require.NoError(t, err)
+
+		require.NoError(t, planet.WaitForStorageNodeEndpoints(ctx))
+
+		// the window still accepts orders, so nothing is sent
+		windows, err := service.SendSatellite(ctx, satellite.ID(), time.Now())
+		require.NoError(t, err)
+		require.Zero(t, windows)
+
+		// other satellites are not affected
+		windows, err = service.SendSatellite(ctx, testrand.NodeID(), tomorrow)
+		require.NoError(t, err)
+		require.Zero(t, windows)
+
+		windows, err = service.SendSatellite(ctx, satellite.ID(), tomorrow)
+		require.NoError(t, err)
+		require.Equal(t, 1, windows)
+
+		unsent, err := node.OrdersStore.ListUnsentWindows(ctx, tomorrow)
+		require.NoError(t, err)
+		require.Len(t, unsent, 0)
+
+		archived, err := node.OrdersStore.ListArchived()
+		require.NoError(t, err)
+		require.Len(t, archived, 1)
+		require.Equal(t, orders.StatusAccepted, archived[0].Status)
+	})
+}
+
 // TODO remove when db is removed.
 // TestOrderFileStoreAndDBSettle ensures that if orders exist in both DB and filestore, that the DB orders and filestore are both settled.
 func TestOrderFileStoreAndDBSettle(t *testing.T) {
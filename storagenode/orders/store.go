@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 
@@ -28,6 +29,9 @@ type activeWindow struct {
 }
 
 // FileStore implements the orders.Store interface by appending orders to flat files.
+//
+// Files written with a version unknown to this release, e.g. by a newer
+// release before a rollback, are ignored and left as they are.
 type FileStore struct {
 	log *zap.Logger
 
@@ -199,6 +203,9 @@ func (store *FileStore) ListUnsentBySatellite(ctx context.Context, now time.Time
 			return nil
 		}
 		fileInfo, err := ordersfile.GetUnsentInfo(info)
+		if ordersfile.ErrUnknownVersion.Has(err) {
+			return nil
+		}
 		if err != nil {
 			errList = errs.Combine(errList, OrderError.Wrap(err))
 			return nil
@@ -225,7 +232,7 @@ func (store *FileStore) ListUnsentBySatellite(ctx context.Context, now time.Time
 			Version:       fileInfo.Version,
 		}
 
-		of, err := ordersfile.OpenReadable(path, fileInfo.Version)
+		of, err := ordersfile.OpenRecoveryReader(path, fileInfo.Version)
 		if err != nil {
 			return OrderError.Wrap(err)
 		}
@@ -233,30 +240,24 @@ func (store *FileStore) ListUnsentBySatellite(ctx context.Context, now time.Time
 			err = errs.Combine(err, OrderError.Wrap(of.Close()))
 		}()
 
+		// corrupt entries are skipped and we return what orders we could read successfully with no error.
+		// this behavior ensures that we will attempt to archive corrupted files instead of continually failing to read them
 		for {
-			// if at any point we see an unexpected EOF error, return what orders we could read successfully with no error
-			// this behavior ensures that we will attempt to archive corrupted files instead of continually failing to read them
 			newInfo, err := of.ReadOne()
 			if err != nil {
 				if errs.Is(err, io.EOF) {
 					break
 				}
-				// if last entry read is corrupt, attempt to read again
-				if ordersfile.ErrEntryCorrupt.Has(err) {
-					store.log.Warn("Corrupted order detected in orders file", zap.Error(err))
-					mon.Meter("orders_unsent_file_corrupted").Mark64(1)
-					// if the error is unexpected EOF, we want the metrics and logs, but there
-					// is no use in trying to read from the file again.
-					if errs.Is(err, io.ErrUnexpectedEOF) {
-						break
-					}
-					continue
-				}
 				return err
 			}
 
 			newUnsentInfo.InfoList = append(newUnsentInfo.InfoList, newInfo)
 		}
+		if lost := of.Lost(); lost > 0 {
+			store.log.Warn("Corrupted order detected in orders file",
+				zap.String("file", info.Name()), zap.Int("lost", lost), zap.Error(of.LastError()))
+			mon.Meter("orders_unsent_file_corrupted").Mark64(int64(lost))
+		}
 
 		infoMap[fileInfo.SatelliteID] = newUnsentInfo
 		return nil
@@ -268,6 +269,95 @@ func (store *FileStore) ListUnsentBySatellite(ctx context.Context, now time.Time
 	return infoMap, errList
 }
 
+// UnsentWindow contains a summary of a window of unsent orders.
+type UnsentWindow struct {
+	SatelliteID   storj.NodeID
+	CreatedAtHour time.Time
+	Version       ordersfile.Version
+	// Ready is true when no more orders can be added to the window, so it
+	// can be sent.
+	Ready bool
+
+	Count   int
+	Amount  int64
+	Amounts map[pb.PieceAction]int64
+	// Lost is the number of corrupt orders in the window.
+	Lost int
+}
+
+// ListUnsentWindows returns a summary of all windows of orders that haven't
+// been sent yet, ordered by satellite and creation hour.
+func (store *FileStore) ListUnsentWindows(ctx context.Context, now time.Time) (windows []UnsentWindow, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var errList error
+	err = filepath.Walk(store.unsentDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			errList = errs.Combine(errList, OrderError.Wrap(err))
+			return nil
+		}
+		if info.IsDir() {
+			return nil
+		}
+		fileInfo, err := ordersfile.GetUnsentInfo(info)
+		if ordersfile.ErrUnknownVersion.Has(err) {
+			return nil
+		}
+		if err != nil {
+			errList = errs.Combine(errList, OrderError.Wrap(err))
+			return nil
+		}
+
+		window := UnsentWindow{
+			SatelliteID:   fileInfo.SatelliteID,
+			CreatedAtHour: fileInfo.CreatedAtHour,
+			Version:       fileInfo.Version,
+			Ready: now.Sub(fileInfo.CreatedAtHour.Add(time.Hour)) > store.orderLimitGracePeriod &&
+				!store.hasActiveEnqueue(fileInfo.SatelliteID, fileInfo.CreatedAtHour),
+			Amounts: make(map[pb.PieceAction]int64),
+		}
+
+		of, err := ordersfile.OpenRecoveryReader(path, fileInfo.Version)
+		if err != nil {
+			errList = errs.Combine(errList, OrderError.Wrap(err))
+			return nil
+		}
+		defer func() {
+			err = errs.Combine(err, OrderError.Wrap(of.Close()))
+		}()
+
+		for {
+			order, err := of.ReadOne()
+			if err != nil {
+				if errs.Is(err, io.EOF) {
+					break
+				}
+				return OrderError.Wrap(err)
+			}
+
+			window.Count++
+			window.Amount += order.Order.Amount
+			window.Amounts[order.Limit.Action] += order.Order.Amount
+		}
+		window.Lost = of.Lost()
+
+		windows = append(windows, window)
+		return nil
+	})
+	if err != nil {
+		errList = errs.Combine(errList, err)
+	}
+
+	sort.Slice(windows, func(i, k int) bool {
+		if windows[i].SatelliteID != windows[k].SatelliteID {
+			return windows[i].SatelliteID.Less(windows[k].SatelliteID)
+		}
+		return windows[i].CreatedAtHour.Before(windows[k].CreatedAtHour)
+	})
+
+	return windows, errList
+}
+
 // Archive moves a file from "unsent" to "archive".
 func (store *FileStore) Archive(satelliteID storj.NodeID, unsentInfo UnsentInfo, archivedAt time.Time, status pb.SettlementWithWindowResponse_Status) error {
 	store.unsentMu.Lock()
@@ -303,10 +393,13 @@ func (store *FileStore) ListArchived() ([]*ArchivedInfo, error) {
 		}
 
 		fileInfo, err := ordersfile.GetArchivedInfo(info)
+		if ordersfile.ErrUnknownVersion.Has(err) {
+			return nil
+		}
 		if err != nil {
 			return OrderError.Wrap(err)
 		}
-		of, err := ordersfile.OpenReadable(path, fileInfo.Version)
+		of, err := ordersfile.OpenRecoveryReader(path, fileInfo.Version)
 		if err != nil {
 			return OrderError.Wrap(err)
 		}
@@ -328,12 +421,6 @@ func (store *FileStore) ListArchived() ([]*ArchivedInfo, error) {
 				if errs.Is(err, io.EOF) {
 					break
 				}
-				// if last entry read is corrupt, attempt to read again
-				if ordersfile.ErrEntryCorrupt.Has(err) {
-					store.log.Warn("Corrupted order detected in orders file", zap.Error(err))
-					mon.Meter("orders_archive_file_corrupted").Mark64(1)
-					continue
-				}
 				return err
 			}
 
@@ -345,6 +432,11 @@ func (store *FileStore) ListArchived() ([]*ArchivedInfo, error) {
 			}
 			archivedList = append(archivedList, newInfo)
 		}
+		if lost := of.Lost(); lost > 0 {
+			store.log.Warn("Corrupted order detected in orders file",
+				zap.String("file", info.Name()), zap.Int("lost", lost), zap.Error(of.LastError()))
+			mon.Meter("orders_archive_file_corrupted").Mark64(int64(lost))
+		}
 		return nil
 	})
 	if err != nil {
@@ -370,6 +462,9 @@ func (store *FileStore) CleanArchive(deleteBefore time.Time) error {
 			return nil
 		}
 		fileInfo, err := ordersfile.GetArchivedInfo(info)
+		if ordersfile.ErrUnknownVersion.Has(err) {
+			return nil
+		}
 		if err != nil {
 			errList = errs.Combine(errList, err)
 			return nil
@@ -382,6 +477,162 @@ func (store *FileStore) CleanArchive(deleteBefore time.Time) error {
 	return errs.Combine(errList, err)
 }
 
+// FileVerification contains the result of verifying an orders file.
+type FileVerification struct {
+	Path          string
+	Archived      bool
+	SatelliteID   storj.NodeID
+	CreatedAtHour time.Time
+	Version       ordersfile.Version
+	ordersfile.VerifyResult
+}
+
+// Verify reads all unsent and archived orders files and reports how many
+// valid and corrupt entries each of them contains.
+func (store *FileStore) Verify(ctx context.Context) (_ []FileVerification, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var verifications []FileVerification
+	var errList error
+	for _, dir := range []string{store.unsentDir, store.archiveDir} {
+		archived := dir == store.archiveDir
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				errList = errs.Combine(errList, OrderError.Wrap(err))
+				return nil
+			}
+			if info.IsDir() {
+				return nil
+			}
+
+			verification := FileVerification{
+				Path:     path,
+				Archived: archived,
+			}
+			if archived {
+				fileInfo, err := ordersfile.GetArchivedInfo(info)
+				if ordersfile.ErrUnknownVersion.Has(err) {
+					return nil
+				}
+				if err != nil {
+					errList = errs.Combine(errList, OrderError.Wrap(err))
+					return nil
+				}
+				verification.SatelliteID = fileInfo.SatelliteID
+				verification.CreatedAtHour = fileInfo.CreatedAtHour
+				verification.Version = fileInfo.Version
+			} else {
+				fileInfo, err := ordersfile.GetUnsentInfo(info)
+				if ordersfile.ErrUnknownVersion.Has(err) {
+					return nil
+				}
+				if err != nil {
+					errList = errs.Combine(errList, OrderError.Wrap(err))
+					return nil
+				}
+				verification.SatelliteID = fileInfo.SatelliteID
+				verification.CreatedAtHour = fileInfo.CreatedAtHour
+				verification.Version = fileInfo.Version
+			}
+
+			verification.VerifyResult, err = ordersfile.Verify(path, verification.SatelliteID, verification.CreatedAtHour, verification.Version)
+			if err != nil {
+				errList = errs.Combine(errList, OrderError.Wrap(err))
+				return nil
+			}
+
+			verifications = append(verifications, verification)
+			return ctx.Err()
+		})
+		if err != nil {
+			return verifications, errs.Combine(errList, err)
+		}
+	}
+
+	return verifications, errList
+}
+
+// FileDowngrade contains the result of downgrading an orders file.
+type FileDowngrade struct {
+	Path           string
+	DowngradedPath string
+	// Lost is the number of corrupt entries which were not converted.
+	Lost int
+}
+
+// Downgrade converts all unsent and archived V2 orders files into V1 files,
+// so that the orders can be read by releases before V2 after a rollback. The
+// storage node must not be running while the files are converted.
+func (store *FileStore) Downgrade(ctx context.Context) (_ []FileDowngrade, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	store.unsentMu.Lock()
+	defer store.unsentMu.Unlock()
+	store.archiveMu.Lock()
+	defer store.archiveMu.Unlock()
+
+	var downgrades []FileDowngrade
+	var errList error
+	for _, dir := range []string{store.unsentDir, store.archiveDir} {
+		archived := dir == store.archiveDir
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				errList = errs.Combine(errList, OrderError.Wrap(err))
+				return nil
+			}
+			if info.IsDir() {
+				return nil
+			}
+
+			var satelliteID storj.NodeID
+			var createdAtHour time.Time
+			var version ordersfile.Version
+			if archived {
+				fileInfo, err := ordersfile.GetArchivedInfo(info)
+				if ordersfile.ErrUnknownVersion.Has(err) {
+					return nil
+				}
+				if err != nil {
+					errList = errs.Combine(errList, OrderError.Wrap(err))
+					return nil
+				}
+				satelliteID, createdAtHour, version = fileInfo.SatelliteID, fileInfo.CreatedAtHour, fileInfo.Version
+			} else {
+				fileInfo, err := ordersfile.GetUnsentInfo(info)
+				if ordersfile.ErrUnknownVersion.Has(err) {
+					return nil
+				}
+				if err != nil {
+					errList = errs.Combine(errList, OrderError.Wrap(err))
+					return nil
+				}
+				satelliteID, createdAtHour, version = fileInfo.SatelliteID, fileInfo.CreatedAtHour, fileInfo.Version
+			}
+			if version != ordersfile.V2 {
+				return nil
+			}
+
+			downgradedPath, lost, err := ordersfile.DowngradeV2(path, satelliteID, createdAtHour)
+			if err != nil {
+				errList = errs.Combine(errList, OrderError.Wrap(err))
+				return nil
+			}
+
+			downgrades = append(downgrades, FileDowngrade{
+				Path:           path,
+				DowngradedPath: downgradedPath,
+				Lost:           lost,
+			})
+			return ctx.Err()
+		})
+		if err != nil {
+			return downgrades, errs.Combine(errList, err)
+		}
+	}
+
+	return downgrades, errList
+}
+
 // ensureDirectories checks for the existence of the unsent and archived directories, and creates them if they do not exist.
 func (store *FileStore) ensureDirectories() error {
 	if _, err := os.Stat(store.unsentDir); os.IsNotExist(err) {
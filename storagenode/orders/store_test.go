@@ -4,6 +4,7 @@
 package orders_test
 
 import (
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
@@ -293,6 +294,79 @@ func TestOrdersDB_ListUnsentBySatellite_Expired(t *testing.T) {
 	})
 }
 
+func TestOrdersStore_ListUnsentWindows(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+	dirName := ctx.Dir("test-orders")
+	now := time.Now()
+	satellite0, satellite1 := testrand.NodeID(), testrand.NodeID()
+	if satellite1.Less(satellite0) {
+		satellite0, satellite1 = satellite1, satellite0
+	}
+
+	// make order limit grace period 2 hours
+	ordersStore, err := orders.NewFileStore(zaptest.NewLogger(t), dirName, 2*time.Hour)
+	require.NoError(t, err)
+
+	enqueue := func(satelliteID storj.NodeID, createdAt time.Time, action pb.PieceAction, amount int64) {
+		sn := testrand.SerialNumber()
+		require.NoError(t, ordersStore.Enqueue(&ordersfile.Info{
+			Limit: &pb.OrderLimit{
+				SerialNumber:  sn,
+				SatelliteId:   satelliteID,
+				Action:        action,
+				OrderCreation: createdAt,
+			},
+			Order: &pb.Order{
+				SerialNumber: sn,
+				Amount:       amount,
+			},
+		}))
+	}
+
+	// always in the previous window
+	earlier := now.Add(-time.Hour)
+	enqueue(satellite0, earlier, pb.PieceAction_GET, 100)
+	enqueue(satellite0, earlier, pb.PieceAction_PUT, 200)
+	enqueue(satellite0, earlier, pb.PieceAction_GET, 300)
+	enqueue(satellite0, now, pb.PieceAction_GET_AUDIT, 10)
+	enqueue(satellite1, now, pb.PieceAction_GET, 1)
+
+	windows, err := ordersStore.ListUnsentWindows(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, windows, 3)
+
+	require.Equal(t, satellite0, windows[0].SatelliteID)
+	require.True(t, windows[0].CreatedAtHour.Equal(earlier.Truncate(time.Hour)))
+	require.Equal(t, ordersfile.V2, windows[0].Version)
+	require.Equal(t, 3, windows[0].Count)
+	require.EqualValues(t, 600, windows[0].Amount)
+	require.EqualValues(t, 400, windows[0].Amounts[pb.PieceAction_GET])
+	require.EqualValues(t, 200, windows[0].Amounts[pb.PieceAction_PUT])
+
+	require.Equal(t, satellite0, windows[1].SatelliteID)
+	require.True(t, windows[1].CreatedAtHour.Equal(now.Truncate(time.Hour)))
+	require.Equal(t, 1, windows[1].Count)
+	require.EqualValues(t, 10, windows[1].Amounts[pb.PieceAction_GET_AUDIT])
+
+	require.Equal(t, satellite1, windows[2].SatelliteID)
+	require.Equal(t, 1, windows[2].Count)
+
+	// orders can still be added to all windows during the grace period
+	for _, window := range windows {
+		require.False(t, window.Ready)
+	}
+
+	// all windows can be sent after the grace period
+	windows, err = ordersStore.ListUnsentWindows(ctx, now.Add(4*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, windows, 3)
+	for _, window := range windows {
+		require.True(t, window.Ready)
+		require.Zero(t, window.Lost)
+	}
+}
+
 func TestOrdersStore_CorruptUnsentV0(t *testing.T) {
 	ctx := testcontext.New(t)
 	defer ctx.Cleanup()
@@ -395,11 +469,16 @@ func TestOrdersStore_CorruptUnsentV1(t *testing.T) {
 			Amount:       1,
 		},
 	}
-	// store sn1 and sn2 in the same window
-	require.NoError(t, ordersStore.Enqueue(info))
+	// store sn1 and sn2 in the same window using deprecated V1
+	// since the file exists, sn3 should also be stored with V1 even when Enqueue() is used
+	unsentFilePath := filepath.Join(dirName, "unsent", ordersfile.UnsentFileName(satellite, now, ordersfile.V1))
+	of, err := ordersfile.OpenWritableV1(unsentFilePath, satellite, now)
+	require.NoError(t, err)
+	require.NoError(t, of.Append(info))
 	info.Limit.SerialNumber = sn2
 	info.Order.SerialNumber = sn2
-	require.NoError(t, ordersStore.Enqueue(info))
+	require.NoError(t, of.Append(info))
+	require.NoError(t, of.Close())
 
 	// check that we can see both orders tomorrow
 	unsent, err = ordersStore.ListUnsentBySatellite(ctx, tomorrow)
@@ -440,7 +519,170 @@ func TestOrdersStore_CorruptUnsentV1(t *testing.T) {
 	require.EqualValues(t, sn3, unsent[satellite].InfoList[1].Order.SerialNumber)
 }
 
-func TestOrdersStore_V0ToV1(t *testing.T) {
+func TestOrdersStore_CorruptUnsentV2(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+	dirName := ctx.Dir("test-orders")
+	now := time.Now()
+	satellite := testrand.NodeID()
+	tomorrow := now.Add(24 * time.Hour)
+
+	// make order limit grace period 1 hour
+	ordersStore, err := orders.NewFileStore(zaptest.NewLogger(t), dirName, time.Hour)
+	require.NoError(t, err)
+
+	enqueue := func() storj.SerialNumber {
+		sn := testrand.SerialNumber()
+		require.NoError(t, ordersStore.Enqueue(&ordersfile.Info{
+			Limit: &pb.OrderLimit{
+				SerialNumber:  sn,
+				SatelliteId:   satellite,
+				Action:        pb.PieceAction_GET,
+				OrderCreation: now,
+			},
+			Order: &pb.Order{
+				SerialNumber: sn,
+				Amount:       1,
+			},
+		}))
+		return sn
+	}
+
+	unsentFilePath := filepath.Join(dirName, "unsent", ordersfile.UnsentFileName(satellite, now, ordersfile.V2))
+	truncateLastByte := func() {
+		stat, err := os.Stat(unsentFilePath)
+		require.NoError(t, err)
+		require.NoError(t, os.Truncate(unsentFilePath, stat.Size()-1))
+	}
+
+	// sn2 is only partially written, like after a power loss, and more orders are appended after it
+	sn1 := enqueue()
+	enqueue()
+	truncateLastByte()
+	sn3 := enqueue()
+	sn4 := enqueue()
+
+	unsent, err := ordersStore.ListUnsentBySatellite(ctx, tomorrow)
+	require.NoError(t, err)
+	require.Len(t, unsent, 1)
+	require.Equal(t, ordersfile.V2, unsent[satellite].Version)
+	require.Len(t, unsent[satellite].InfoList, 3)
+	require.EqualValues(t, sn1, unsent[satellite].InfoList[0].Order.SerialNumber)
+	require.EqualValues(t, sn3, unsent[satellite].InfoList[1].Order.SerialNumber)
+	require.EqualValues(t, sn4, unsent[satellite].InfoList[2].Order.SerialNumber)
+
+	// a partially written order at the end of the file is lost as well
+	enqueue()
+	truncateLastByte()
+
+	verifications, err := ordersStore.Verify(ctx)
+	require.NoError(t, err)
+	require.Len(t, verifications, 1)
+	require.Equal(t, unsentFilePath, verifications[0].Path)
+	require.False(t, verifications[0].Archived)
+	require.Equal(t, satellite, verifications[0].SatelliteID)
+	require.Equal(t, 3, verifications[0].Valid)
+	require.Equal(t, 2, verifications[0].Lost)
+	require.NoError(t, verifications[0].HeaderErr)
+
+	// corrupting the file header doesn't affect the orders
+	data, err := ioutil.ReadFile(unsentFilePath)
+	require.NoError(t, err)
+	data[10] ^= 0xff
+	require.NoError(t, ioutil.WriteFile(unsentFilePath, data, 0644))
+
+	result, err := ordersfile.Verify(unsentFilePath, satellite, now, ordersfile.V2)
+	require.NoError(t, err)
+	require.Error(t, result.HeaderErr)
+	require.Equal(t, 3, result.Valid)
+	require.Equal(t, 2, result.Lost)
+
+	// a file header which doesn't match the file name is reported
+	otherFilePath := filepath.Join(ctx.Dir("other"), "orders")
+	of, err := ordersfile.OpenWritableV2(otherFilePath, testrand.NodeID(), now)
+	require.NoError(t, err)
+	require.NoError(t, of.Close())
+
+	result, err = ordersfile.Verify(otherFilePath, satellite, now, ordersfile.V2)
+	require.NoError(t, err)
+	require.Error(t, result.HeaderErr)
+	require.Zero(t, result.Valid)
+	require.Zero(t, result.Lost)
+}
+
+func TestOrdersStore_DowngradeV2(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+	dirName := ctx.Dir("test-orders")
+	now := time.Now()
+	satellite := testrand.NodeID()
+	tomorrow := now.Add(24 * time.Hour)
+
+	// make order limit grace period 1 hour
+	ordersStore, err := orders.NewFileStore(zaptest.NewLogger(t), dirName, time.Hour)
+	require.NoError(t, err)
+
+	enqueue := func() storj.SerialNumber {
+		sn := testrand.SerialNumber()
+		require.NoError(t, ordersStore.Enqueue(&ordersfile.Info{
+			Limit: &pb.OrderLimit{
+				SerialNumber:  sn,
+				SatelliteId:   satellite,
+				Action:        pb.PieceAction_GET,
+				OrderCreation: now,
+			},
+			Order: &pb.Order{
+				SerialNumber: sn,
+				Amount:       1,
+			},
+		}))
+		return sn
+	}
+
+	// archive one window and start a new one
+	sn1 := enqueue()
+	unsent, err := ordersStore.ListUnsentBySatellite(ctx, tomorrow)
+	require.NoError(t, err)
+	require.NoError(t, ordersStore.Archive(satellite, unsent[satellite], now, pb.SettlementWithWindowResponse_ACCEPTED))
+	sn2 := enqueue()
+	sn3 := enqueue()
+
+	// files of a newer release are ignored
+	unknownFilePath := filepath.Join(dirName, "unsent", ordersfile.UnsentFileName(satellite, now, ordersfile.Version("v9")))
+	require.NoError(t, ioutil.WriteFile(unknownFilePath, testrand.BytesInt(100), 0644))
+
+	downgrades, err := ordersStore.Downgrade(ctx)
+	require.NoError(t, err)
+	require.Len(t, downgrades, 2)
+	for _, downgrade := range downgrades {
+		require.Zero(t, downgrade.Lost)
+		require.Equal(t, "."+string(ordersfile.V1), filepath.Ext(downgrade.DowngradedPath))
+		_, err := os.Stat(downgrade.Path)
+		require.True(t, os.IsNotExist(err))
+	}
+	_, err = os.Stat(unknownFilePath)
+	require.NoError(t, err)
+
+	unsent, err = ordersStore.ListUnsentBySatellite(ctx, tomorrow)
+	require.NoError(t, err)
+	require.Len(t, unsent, 1)
+	require.Equal(t, ordersfile.V1, unsent[satellite].Version)
+	require.Len(t, unsent[satellite].InfoList, 2)
+	require.EqualValues(t, sn2, unsent[satellite].InfoList[0].Order.SerialNumber)
+	require.EqualValues(t, sn3, unsent[satellite].InfoList[1].Order.SerialNumber)
+
+	archived, err := ordersStore.ListArchived()
+	require.NoError(t, err)
+	require.Len(t, archived, 1)
+	require.EqualValues(t, sn1, archived[0].Order.SerialNumber)
+
+	// downgrading again doesn't find any V2 files
+	downgrades, err = ordersStore.Downgrade(ctx)
+	require.NoError(t, err)
+	require.Empty(t, downgrades)
+}
+
+func TestOrdersStore_V0ToV2(t *testing.T) {
 	ctx := testcontext.New(t)
 	defer ctx.Cleanup()
 	dirName := ctx.Dir("test-orders")
@@ -494,14 +736,14 @@ func TestOrdersStore_V0ToV1(t *testing.T) {
 
 	// archive file to free up window
 	require.NoError(t, ordersStore.Archive(satellite, unsent[satellite], time.Now(), pb.SettlementWithWindowResponse_ACCEPTED))
-	// new file should be created with version V1
+	// new file should be created with version V2
 	require.NoError(t, ordersStore.Enqueue(info))
 
 	unsent, err = ordersStore.ListUnsentBySatellite(ctx, tomorrow)
 	require.NoError(t, err)
 	require.Len(t, unsent, 1)
 	require.Len(t, unsent[satellite].InfoList, 1)
-	require.Equal(t, ordersfile.V1, unsent[satellite].Version)
+	require.Equal(t, ordersfile.V2, unsent[satellite].Version)
 }
 
 func verifyInfosEqual(t *testing.T, a, b *ordersfile.Info) {
@@ -11,11 +11,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"storj.io/common/pb"
 	"storj.io/common/storj"
 	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
 	"storj.io/storj/storagenode"
 	"storj.io/storj/storagenode/payout"
 	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
+	"storj.io/storj/storagenode/storageusage"
 )
 
 func TestHeldAmountDB(t *testing.T) {
@@ -211,7 +214,7 @@ func TestSatellitePayStubPeriodCached(t *testing.T) {
 		heldAmountDB := db.Payout()
 		reputationDB := db.Reputation()
 		satellitesDB := db.Satellites()
-		service, err := payout.NewService(nil, heldAmountDB, reputationDB, satellitesDB, nil)
+		service, err := payout.NewService(nil, heldAmountDB, reputationDB, satellitesDB, db.Bandwidth(), db.StorageUsage(), nil)
 		require.NoError(t, err)
 
 		payStub := payout.PayStub{
@@ -262,7 +265,7 @@ func TestAllPayStubPeriodCached(t *testing.T) {
 		heldAmountDB := db.Payout()
 		reputationDB := db.Reputation()
 		satellitesDB := db.Satellites()
-		service, err := payout.NewService(nil, heldAmountDB, reputationDB, satellitesDB, nil)
+		service, err := payout.NewService(nil, heldAmountDB, reputationDB, satellitesDB, db.Bandwidth(), db.StorageUsage(), nil)
 		require.NoError(t, err)
 
 		payStub := payout.PayStub{
@@ -314,3 +317,70 @@ func TestAllPayStubPeriodCached(t *testing.T) {
 		require.Equal(t, 0, len(payStubs))
 	})
 }
+
+func TestReconcile(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		service, err := payout.NewService(nil, db.Payout(), db.Reputation(), db.Satellites(), db.Bandwidth(), db.StorageUsage(), nil)
+		require.NoError(t, err)
+
+		satelliteID := testrand.NodeID()
+		otherSatelliteID := testrand.NodeID()
+		inPeriod := time.Date(2020, 2, 10, 0, 0, 0, 0, time.UTC)
+		afterPeriod := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+
+		require.NoError(t, db.Bandwidth().Add(ctx, satelliteID, pb.PieceAction_GET, 1000, inPeriod))
+		require.NoError(t, db.Bandwidth().Add(ctx, satelliteID, pb.PieceAction_GET_REPAIR, 200, inPeriod))
+		require.NoError(t, db.Bandwidth().Add(ctx, satelliteID, pb.PieceAction_GET_AUDIT, 10, inPeriod))
+		require.NoError(t, db.Bandwidth().Add(ctx, satelliteID, pb.PieceAction_GET, 5000, afterPeriod))
+		require.NoError(t, db.StorageUsage().Store(ctx, []storageusage.Stamp{
+			{SatelliteID: satelliteID, AtRestTotal: 2000, IntervalStart: inPeriod},
+			{SatelliteID: satelliteID, AtRestTotal: 9000, IntervalStart: afterPeriod},
+		}))
+
+		require.NoError(t, db.Payout().StorePayStub(ctx, payout.PayStub{
+			SatelliteID:    satelliteID,
+			Period:         "2020-02",
+			UsageAtRest:    2000,
+			UsageGet:       900,
+			UsageGetRepair: 200,
+			UsageGetAudit:  0,
+			Held:           1,
+			Owed:           2,
+			Disposed:       3,
+			Paid:           4,
+		}))
+		require.NoError(t, db.Payout().StorePayStub(ctx, payout.PayStub{
+			SatelliteID: otherSatelliteID,
+			Period:      "2020-02",
+			UsageGet:    100,
+		}))
+
+		reconciliations, err := service.Reconcile(ctx, satelliteID, "2020-01", "2020-02")
+		require.NoError(t, err)
+		require.Len(t, reconciliations, 1)
+
+		reconciliation := reconciliations[0]
+		require.Equal(t, satelliteID, reconciliation.SatelliteID)
+		require.Equal(t, "2020-02", reconciliation.Period)
+		require.Equal(t, payout.Discrepancy{Local: 1000, Satellite: 900, Percent: -10}, reconciliation.Egress)
+		require.Equal(t, payout.Discrepancy{Local: 200, Satellite: 200, Percent: 0}, reconciliation.RepairEgress)
+		require.Equal(t, payout.Discrepancy{Local: 10, Satellite: 0, Percent: -100}, reconciliation.AuditEgress)
+		require.Equal(t, payout.Discrepancy{Local: 2000, Satellite: 2000, Percent: 0}, reconciliation.StorageByteHours)
+		require.EqualValues(t, 1, reconciliation.Held)
+		require.EqualValues(t, 2, reconciliation.Owed)
+		require.EqualValues(t, 3, reconciliation.Disposed)
+		require.EqualValues(t, 4, reconciliation.Paid)
+
+		reconciliations, err = service.Reconcile(ctx, storj.NodeID{}, "2020-02", "2020-02")
+		require.NoError(t, err)
+		require.Len(t, reconciliations, 2)
+		for _, reconciliation := range reconciliations {
+			if reconciliation.SatelliteID == otherSatelliteID {
+				require.Equal(t, payout.Discrepancy{Local: 0, Satellite: 100, Percent: 100}, reconciliation.Egress)
+			}
+		}
+
+		_, err = service.Reconcile(ctx, satelliteID, "2020-02", "2020-01")
+		require.True(t, payout.ErrBadPeriod.Has(err))
+	})
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payout
+
+import (
+	"context"
+
+	"storj.io/common/storj"
+	"storj.io/storj/private/date"
+)
+
+// Discrepancy compares a value measured by the node with the one reported by the satellite.
+type Discrepancy struct {
+	Local     float64 `json:"local"`
+	Satellite float64 `json:"satellite"`
+	// Percent is the difference between satellite and local value relative to the local value.
+	Percent float64 `json:"percent"`
+}
+
+// NewDiscrepancy compares local and satellite reported values.
+func NewDiscrepancy(local, satellite float64) Discrepancy {
+	discrepancy := Discrepancy{
+		Local:     local,
+		Satellite: satellite,
+	}
+	switch {
+	case local != 0:
+		discrepancy.Percent = (satellite - local) / local * 100
+	case satellite != 0:
+		discrepancy.Percent = 100
+	}
+	return discrepancy
+}
+
+// Reconciliation compares the usage measured by the node with the paystub of a satellite for a period.
+type Reconciliation struct {
+	SatelliteID  storj.NodeID `json:"satelliteID"`
+	Period       string       `json:"period"`
+	Egress       Discrepancy  `json:"egress"`
+	RepairEgress Discrepancy  `json:"repairEgress"`
+	AuditEgress  Discrepancy  `json:"auditEgress"`
+	// StorageByteHours compares the data stored during the period.
+	StorageByteHours Discrepancy `json:"storageByteHours"`
+	Held             int64       `json:"held"`
+	Owed             int64       `json:"owed"`
+	Disposed         int64       `json:"disposed"`
+	Paid             int64       `json:"paid"`
+}
+
+// Reconcile compares the usage measured by the node with the paystubs for selected range of months
+// for all satellites or, if satelliteID is not zero, for the specified satellite.
+func (service *Service) Reconcile(ctx context.Context, satelliteID storj.NodeID, periodStart, periodEnd string) (_ []Reconciliation, err error) {
+	defer mon.Task()(&ctx, &satelliteID, &periodStart, &periodEnd)(&err)
+
+	periods, err := parsePeriodRange(periodStart, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	var payStubs []PayStub
+	for _, period := range periods {
+		if satelliteID.IsZero() {
+			stubs, err := service.db.AllPayStubs(ctx, period)
+			if err != nil {
+				if ErrNoPayStubForPeriod.Has(err) {
+					continue
+				}
+				return nil, ErrPayoutService.Wrap(err)
+			}
+			payStubs = append(payStubs, stubs...)
+			continue
+		}
+
+		payStub, err := service.db.GetPayStub(ctx, satelliteID, period)
+		if err != nil {
+			if ErrNoPayStubForPeriod.Has(err) {
+				continue
+			}
+			return nil, ErrPayoutService.Wrap(err)
+		}
+		payStubs = append(payStubs, *payStub)
+	}
+
+	reconciliations := make([]Reconciliation, 0, len(payStubs))
+	for _, payStub := range payStubs {
+		reconciliation, err := service.reconcile(ctx, payStub)
+		if err != nil {
+			return nil, err
+		}
+		reconciliations = append(reconciliations, reconciliation)
+	}
+
+	return reconciliations, nil
+}
+
+// reconcile compares the usage measured by the node with a single paystub.
+func (service *Service) reconcile(ctx context.Context, payStub PayStub) (_ Reconciliation, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	periodTime, err := Period(payStub.Period).Time()
+	if err != nil {
+		return Reconciliation{}, ErrBadPeriod.Wrap(err)
+	}
+	from, to := date.MonthBoundary(periodTime.UTC())
+
+	egress, err := service.bandwidthDB.SatelliteEgressSummary(ctx, payStub.SatelliteID, from, to)
+	if err != nil {
+		return Reconciliation{}, ErrPayoutService.Wrap(err)
+	}
+
+	atRest, err := service.storageUsageDB.SatelliteSummary(ctx, payStub.SatelliteID, from, to)
+	if err != nil {
+		return Reconciliation{}, ErrPayoutService.Wrap(err)
+	}
+
+	return Reconciliation{
+		SatelliteID:      payStub.SatelliteID,
+		Period:           payStub.Period,
+		Egress:           NewDiscrepancy(float64(egress.Get), float64(payStub.UsageGet)),
+		RepairEgress:     NewDiscrepancy(float64(egress.GetRepair), float64(payStub.UsageGetRepair)),
+		AuditEgress:      NewDiscrepancy(float64(egress.GetAudit), float64(payStub.UsageGetAudit)),
+		StorageByteHours: NewDiscrepancy(atRest, payStub.UsageAtRest),
+		Held:             payStub.Held,
+		Owed:             payStub.Owed,
+		Disposed:         payStub.Disposed,
+		Paid:             payStub.Paid,
+	}, nil
+}
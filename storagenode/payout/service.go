@@ -18,8 +18,10 @@ import (
 
 	"storj.io/common/storj"
 	"storj.io/storj/private/date"
+	"storj.io/storj/storagenode/bandwidth"
 	"storj.io/storj/storagenode/reputation"
 	"storj.io/storj/storagenode/satellites"
+	"storj.io/storj/storagenode/storageusage"
 	"storj.io/storj/storagenode/trust"
 )
 
@@ -41,14 +43,16 @@ type Service struct {
 
 	stefanSatellite storj.NodeID
 
-	db           DB
-	reputationDB reputation.DB
-	satellitesDB satellites.DB
-	trust        *trust.Pool
+	db             DB
+	reputationDB   reputation.DB
+	satellitesDB   satellites.DB
+	bandwidthDB    bandwidth.DB
+	storageUsageDB storageusage.DB
+	trust          *trust.Pool
 }
 
 // NewService creates new instance of service.
-func NewService(log *zap.Logger, db DB, reputationDB reputation.DB, satelliteDB satellites.DB, trust *trust.Pool) (_ *Service, err error) {
+func NewService(log *zap.Logger, db DB, reputationDB reputation.DB, satelliteDB satellites.DB, bandwidthDB bandwidth.DB, storageUsageDB storageusage.DB, trust *trust.Pool) (_ *Service, err error) {
 	id, err := storj.NodeIDFromString("118UWpMCHzs6CvSgWd9BfFVjw5K9pZbJjkfZJexMtSkmKxvvAW")
 	if err != nil {
 		return &Service{}, err
@@ -60,6 +64,8 @@ func NewService(log *zap.Logger, db DB, reputationDB reputation.DB, satelliteDB
 		db:              db,
 		reputationDB:    reputationDB,
 		satellitesDB:    satelliteDB,
+		bandwidthDB:     bandwidthDB,
+		storageUsageDB:  storageUsageDB,
 		trust:           trust,
 	}, nil
 }
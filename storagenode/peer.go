@@ -26,11 +26,13 @@ import (
 	"storj.io/common/storj"
 	"storj.io/private/debug"
 	"storj.io/private/version"
+	"storj.io/storj/multinodepb"
 	"storj.io/storj/pkg/server"
 	"storj.io/storj/private/lifecycle"
 	"storj.io/storj/private/version/checker"
 	"storj.io/storj/storage"
 	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storagenode/alerts"
 	"storj.io/storj/storagenode/apikeys"
 	"storj.io/storj/storagenode/bandwidth"
 	"storj.io/storj/storagenode/collector"
@@ -42,6 +44,7 @@ import (
 	"storj.io/storj/storagenode/inspector"
 	"storj.io/storj/storagenode/internalpb"
 	"storj.io/storj/storagenode/monitor"
+	"storj.io/storj/storagenode/multinode"
 	"storj.io/storj/storagenode/nodestats"
 	"storj.io/storj/storagenode/notifications"
 	"storj.io/storj/storagenode/orders"
@@ -58,7 +61,9 @@ import (
 	"storj.io/storj/storagenode/satellites"
 	"storj.io/storj/storagenode/storagenodedb"
 	"storj.io/storj/storagenode/storageusage"
+	"storj.io/storj/storagenode/transferstats"
 	"storj.io/storj/storagenode/trust"
+	"storj.io/storj/storagenode/untrusted"
 	version2 "storj.io/storj/storagenode/version"
 )
 
@@ -89,6 +94,9 @@ type DB interface {
 	Payout() payout.DB
 	Pricing() pricing.DB
 	Secret() apikeys.DB
+	RetainReports() retain.DB
+	TransferStats() transferstats.DB
+	Untrusted() untrusted.DB
 
 	Preflight(ctx context.Context) error
 }
@@ -112,17 +120,24 @@ type Config struct {
 	Filestore filestore.Config
 
 	Pieces pieces.Config
+	Trash  pieces.TrashConfig
 
 	Retain retain.Config
 
+	Untrusted untrusted.Config
+
 	Nodestats nodestats.Config
 
 	Console consoleserver.Config
 
+	Alerts alerts.Config
+
 	Version checker.Config
 
 	Bandwidth bandwidth.Config
 
+	TransferStats transferstats.Config
+
 	GracefulExit gracefulexit.Config
 }
 
@@ -138,6 +153,8 @@ func (config *Config) DatabaseConfig() storagenodedb.Config {
 		Info2:     filepath.Join(dbdir, "info.db"),
 		Pieces:    config.Storage.Path,
 		Filestore: config.Filestore,
+
+		PiecesMigrationSource: config.Storage2.MigrationSource,
 	}
 }
 
@@ -232,6 +249,7 @@ type Peer struct {
 		Trust         *trust.Pool
 		Store         *pieces.Store
 		TrashChore    *pieces.TrashChore
+		Untrusted     *untrusted.Chore
 		BlobsCache    *pieces.BlobsUsageCache
 		CacheService  *pieces.CacheService
 		RetainService *retain.Service
@@ -240,6 +258,7 @@ type Peer struct {
 		Inspector     *inspector.Endpoint
 		Monitor       *monitor.Service
 		Orders        *orders.Service
+		TransferStats *transferstats.Service
 	}
 
 	Collector *collector.Service
@@ -269,6 +288,7 @@ type Peer struct {
 
 	Notifications struct {
 		Service *notifications.Service
+		Alerts  *alerts.Service
 	}
 
 	Payout struct {
@@ -279,6 +299,14 @@ type Peer struct {
 	Bandwidth *bandwidth.Service
 
 	Reputation *reputation.Service
+
+	Multinode struct {
+		Storage    *multinode.StorageEndpoint
+		Reputation *multinode.ReputationEndpoint
+		Status     *multinode.StatusEndpoint
+		Payout     *multinode.PayoutEndpoint
+		APIKeys    *multinode.APIKeysEndpoint
+	}
 }
 
 // New creates a new Storage Node.
@@ -293,7 +321,17 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 	}
 
 	{ // setup notification service.
-		peer.Notifications.Service = notifications.NewService(peer.Log, peer.DB.Notifications())
+		var err error
+		peer.Notifications.Alerts, err = alerts.NewService(peer.Log.Named("alerts"), peer.Identity.ID, config.Alerts)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+		peer.Services.Add(lifecycle.Item{
+			Name: "alerts",
+			Run:  peer.Notifications.Alerts.Run,
+		})
+
+		peer.Notifications.Service = notifications.NewService(peer.Log, peer.DB.Notifications(), peer.Notifications.Alerts)
 	}
 
 	{ // setup debug
@@ -436,8 +474,8 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 
 		peer.Storage2.TrashChore = pieces.NewTrashChore(
 			log.Named("pieces:trash"),
-			24*time.Hour,   // choreInterval: how often to run the chore
-			7*24*time.Hour, // trashExpiryInterval: when items in the trash should be deleted
+			config.Trash,
+			config.Storage.AllocatedDiskSpace.Int64(),
 			peer.Storage2.Trust,
 			peer.Storage2.Store,
 		)
@@ -447,6 +485,22 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			Close: peer.Storage2.TrashChore.Close,
 		})
 
+		peer.Storage2.Untrusted = untrusted.NewChore(
+			log.Named("untrusted"),
+			peer.Storage2.Trust,
+			peer.Storage2.Store,
+			peer.DB.Untrusted(),
+			peer.Notifications.Service,
+			config.Untrusted,
+		)
+		peer.Services.Add(lifecycle.Item{
+			Name:  "untrusted",
+			Run:   peer.Storage2.Untrusted.Run,
+			Close: peer.Storage2.Untrusted.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Untrusted Satellites", peer.Storage2.Untrusted.Loop))
+
 		peer.Storage2.CacheService = pieces.NewService(
 			log.Named("piecestore:cache"),
 			peer.Storage2.BlobsCache,
@@ -466,6 +520,8 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			peer.Storage2.Store,
 			peer.Contact.Service,
 			peer.DB.Bandwidth(),
+			monitor.NewSpaceProvider(config.Storage2.Monitor, peer.Storage2.Store),
+			peer.Notifications.Service,
 			config.Storage.AllocatedDiskSpace.Int64(),
 			// TODO: use config.Storage.Monitor.Interval, but for some reason is not set
 			config.Storage.KBucketRefreshInterval,
@@ -483,6 +539,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 		peer.Storage2.RetainService = retain.NewService(
 			peer.Log.Named("retain"),
 			peer.Storage2.Store,
+			peer.DB.RetainReports(),
 			config.Retain,
 		)
 		peer.Services.Add(lifecycle.Item{
@@ -502,6 +559,19 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			return nil, errs.Combine(err, peer.Close())
 		}
 
+		peer.Storage2.TransferStats = transferstats.NewService(
+			peer.Log.Named("transferstats"),
+			peer.DB.TransferStats(),
+			config.TransferStats,
+		)
+		peer.Services.Add(lifecycle.Item{
+			Name:  "transferstats",
+			Run:   peer.Storage2.TransferStats.Run,
+			Close: peer.Storage2.TransferStats.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Transfer Stats", peer.Storage2.TransferStats.Loop))
+
 		peer.Storage2.Endpoint, err = piecestore.NewEndpoint(
 			peer.Log.Named("piecestore"),
 			signing.SignerFromFullIdentity(peer.Identity),
@@ -514,6 +584,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			peer.OrdersStore,
 			peer.DB.Bandwidth(),
 			peer.UsedSerials,
+			peer.Storage2.TransferStats,
 			config.Storage2,
 		)
 		if err != nil {
@@ -560,6 +631,8 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			peer.DB.Payout(),
 			peer.DB.Reputation(),
 			peer.DB.Satellites(),
+			peer.DB.Bandwidth(),
+			peer.DB.StorageUsage(),
 			peer.Storage2.Trust,
 		)
 		if err != nil {
@@ -577,7 +650,6 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 		peer.Reputation = reputation.NewService(
 			peer.Log.Named("reputation:service"),
 			peer.DB.Reputation(),
-			peer.Identity.ID,
 			peer.Notifications.Service,
 		)
 	}
@@ -626,6 +698,66 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 		)
 	}
 
+	{ // setup piecetransfer service
+		peer.PieceTransfer.Service = piecetransfer.NewService(
+			peer.Log.Named("piecetransfer"),
+			peer.Storage2.Store,
+			peer.Storage2.Trust,
+			peer.Dialer,
+			// using GracefulExit config here for historical reasons
+			config.GracefulExit.MinDownloadTimeout,
+			config.GracefulExit.MinBytesPerSecond,
+		)
+	}
+
+	{ // setup graceful exit service
+		peer.GracefulExit.Service = gracefulexit.NewService(
+			peer.Log.Named("gracefulexit:service"),
+			peer.Storage2.Store,
+			peer.Storage2.Trust,
+			peer.DB.Satellites(),
+			peer.Dialer,
+			config.GracefulExit,
+		)
+
+		peer.GracefulExit.Endpoint = gracefulexit.NewEndpoint(
+			peer.Log.Named("gracefulexit:endpoint"),
+			peer.Storage2.Trust,
+			peer.DB.Satellites(),
+			peer.Dialer,
+			peer.Storage2.BlobsCache,
+		)
+		if err := internalpb.DRPCRegisterNodeGracefulExit(peer.Server.PrivateDRPC(), peer.GracefulExit.Endpoint); err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+
+		peer.GracefulExit.Chore = gracefulexit.NewChore(
+			peer.Log.Named("gracefulexit:chore"),
+			peer.GracefulExit.Service,
+			peer.PieceTransfer.Service,
+			peer.Dialer,
+			config.GracefulExit,
+		)
+		peer.GracefulExit.BlobsCleaner = gracefulexit.NewBlobsCleaner(
+			peer.Log.Named("gracefulexit:blobscleaner"),
+			peer.Storage2.Store,
+			peer.Storage2.Trust,
+			peer.DB.Satellites(),
+		)
+		// Runs once on node start to clean blobs from trash that left after successful GE.
+		peer.Services.Add(lifecycle.Item{
+			Name: "gracefulexit:blobscleaner",
+			Run:  peer.GracefulExit.BlobsCleaner.RemoveBlobs,
+		})
+		peer.Services.Add(lifecycle.Item{
+			Name:  "gracefulexit:chore",
+			Run:   peer.GracefulExit.Chore.Run,
+			Close: peer.GracefulExit.Chore.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Graceful Exit", peer.GracefulExit.Chore.Loop))
+	}
+
 	{ // setup storage node operator dashboard
 		peer.Console.Service, err = console.NewService(
 			peer.Log.Named("console:service"),
@@ -644,6 +776,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			peer.Contact.Service,
 			peer.Estimation.Service,
 			peer.Storage2.BlobsCache,
+			peer.Storage2.TransferStats,
 		)
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
@@ -666,7 +799,14 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			peer.Notifications.Service,
 			peer.Console.Service,
 			peer.Payout.Service,
+			peer.Storage2.RetainService,
+			peer.Storage2.Orders,
+			peer.Storage2.Untrusted,
+			peer.GracefulExit.Service,
+			peer.Storage2.TransferStats,
+			peer.DB.Secret(),
 			peer.Console.Listener,
+			config.Console,
 		)
 		peer.Services.Add(lifecycle.Item{
 			Name:  "console:endpoint",
@@ -691,64 +831,58 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 		}
 	}
 
-	{ // setup piecetransfer service
-		peer.PieceTransfer.Service = piecetransfer.NewService(
-			peer.Log.Named("piecetransfer"),
-			peer.Storage2.Store,
-			peer.Storage2.Trust,
-			peer.Dialer,
-			// using GracefulExit config here for historical reasons
-			config.GracefulExit.MinDownloadTimeout,
-			config.GracefulExit.MinBytesPerSecond,
-		)
-	}
+	{ // setup multinode endpoints
+		apiKeys := apikeys.NewService(peer.DB.Secret())
 
-	{ // setup graceful exit service
-		peer.GracefulExit.Service = gracefulexit.NewService(
-			peer.Log.Named("gracefulexit:service"),
-			peer.Storage2.Store,
-			peer.Storage2.Trust,
-			peer.DB.Satellites(),
-			peer.Dialer,
-			config.GracefulExit,
+		peer.Multinode.Storage = multinode.NewStorageEndpoint(
+			peer.Log.Named("multinode:storage-endpoint"),
+			peer.ID(),
+			apiKeys,
+			peer.Storage2.BlobsCache,
+			peer.DB.StorageUsage(),
+			config.Storage.AllocatedDiskSpace,
 		)
+		if err := multinodepb.DRPCRegisterNodeDiskSpace(peer.Server.DRPC(), peer.Multinode.Storage); err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
 
-		peer.GracefulExit.Endpoint = gracefulexit.NewEndpoint(
-			peer.Log.Named("gracefulexit:endpoint"),
-			peer.Storage2.Trust,
-			peer.DB.Satellites(),
-			peer.Dialer,
-			peer.Storage2.BlobsCache,
+		peer.Multinode.Reputation = multinode.NewReputationEndpoint(
+			peer.Log.Named("multinode:reputation-endpoint"),
+			apiKeys,
+			peer.DB.Reputation(),
 		)
-		if err := internalpb.DRPCRegisterNodeGracefulExit(peer.Server.PrivateDRPC(), peer.GracefulExit.Endpoint); err != nil {
+		if err := multinodepb.DRPCRegisterReputation(peer.Server.DRPC(), peer.Multinode.Reputation); err != nil {
 			return nil, errs.Combine(err, peer.Close())
 		}
 
-		peer.GracefulExit.Chore = gracefulexit.NewChore(
-			peer.Log.Named("gracefulexit:chore"),
-			peer.GracefulExit.Service,
-			peer.PieceTransfer.Service,
-			peer.Dialer,
-			config.GracefulExit,
+		peer.Multinode.Status = multinode.NewStatusEndpoint(
+			peer.Log.Named("multinode:status-endpoint"),
+			apiKeys,
+			versionInfo,
 		)
-		peer.GracefulExit.BlobsCleaner = gracefulexit.NewBlobsCleaner(
-			peer.Log.Named("gracefulexit:blobscleaner"),
-			peer.Storage2.Store,
+		if err := multinodepb.DRPCRegisterStatus(peer.Server.DRPC(), peer.Multinode.Status); err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+
+		peer.Multinode.Payout = multinode.NewPayoutEndpoint(
+			peer.Log.Named("multinode:payout-endpoint"),
+			apiKeys,
+			config.Operator.Wallet,
+			peer.Payout.Service,
+			peer.Estimation.Service,
 			peer.Storage2.Trust,
-			peer.DB.Satellites(),
 		)
-		// Runs once on node start to clean blobs from trash that left after successful GE.
-		peer.Services.Add(lifecycle.Item{
-			Name: "gracefulexit:blobscleaner",
-			Run:  peer.GracefulExit.BlobsCleaner.RemoveBlobs,
-		})
-		peer.Services.Add(lifecycle.Item{
-			Name:  "gracefulexit:chore",
-			Run:   peer.GracefulExit.Chore.Run,
-			Close: peer.GracefulExit.Chore.Close,
-		})
-		peer.Debug.Server.Panel.Add(
-			debug.Cycle("Graceful Exit", peer.GracefulExit.Chore.Loop))
+		if err := multinodepb.DRPCRegisterPayout(peer.Server.DRPC(), peer.Multinode.Payout); err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+
+		peer.Multinode.APIKeys = multinode.NewAPIKeysEndpoint(
+			peer.Log.Named("multinode:apikeys-endpoint"),
+			apiKeys,
+		)
+		if err := multinodepb.DRPCRegisterApiKeys(peer.Server.DRPC(), peer.Multinode.APIKeys); err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
 	}
 
 	peer.Collector = collector.NewService(peer.Log.Named("collector"), peer.Storage2.Store, peer.UsedSerials, config.Collector)
@@ -56,11 +56,17 @@ func (service *CacheService) Run(ctx context.Context) (err error) {
 		service.log.Error("error getting current used space: ", zap.Error(err))
 		return err
 	}
-	trashTotal, err := service.usageCache.Blobs.SpaceUsedForTrash(ctx)
+	// the trash is walked once per satellite and the total is derived from
+	// it, so that the trash isn't walked twice on every startup.
+	trashBySatellite, err := service.spaceUsedForTrashBySatellite(ctx)
 	if err != nil {
 		service.log.Error("error getting current used space for trash: ", zap.Error(err))
 		return err
 	}
+	var trashTotal int64
+	for _, trash := range trashBySatellite {
+		trashTotal += trash
+	}
 	service.usageCache.Recalculate(
 		piecesTotal,
 		totalsAtStart.piecesTotal,
@@ -71,6 +77,7 @@ func (service *CacheService) Run(ctx context.Context) (err error) {
 		totalsBySatellite,
 		totalsAtStart.spaceUsedBySatellite,
 	)
+	service.usageCache.initTrashBySatellite(trashBySatellite)
 
 	if err = service.store.spaceUsedDB.Init(ctx); err != nil {
 		service.log.Error("error during init space usage db: ", zap.Error(err))
@@ -90,6 +97,32 @@ func (service *CacheService) Run(ctx context.Context) (err error) {
 	})
 }
 
+// spaceUsedForTrashBySatellite walks the trash of every satellite, bypassing the cache.
+func (service *CacheService) spaceUsedForTrashBySatellite(ctx context.Context) (_ map[storj.NodeID]int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	namespaces, err := service.usageCache.Blobs.ListNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	trashBySatellite := make(map[storj.NodeID]int64)
+	for _, namespace := range namespaces {
+		satelliteID, err := storj.NodeIDFromBytes(namespace)
+		if err != nil {
+			return nil, err
+		}
+		trash, err := service.usageCache.Blobs.SpaceUsedForTrashInNamespace(ctx, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if trash > 0 {
+			trashBySatellite[satelliteID] = trash
+		}
+	}
+	return trashBySatellite, nil
+}
+
 // PersistCacheTotals saves the current totals of the space used cache to the database
 // so that if the storagenode restarts it can retrieve the latest space used
 // values without needing to recalculate since that could take a long time.
@@ -159,6 +192,7 @@ type BlobsUsageCache struct {
 	piecesContentSize    int64
 	trashTotal           int64
 	spaceUsedBySatellite map[storj.NodeID]SatelliteUsage
+	trashBySatellite     map[storj.NodeID]int64
 }
 
 // NewBlobsUsageCache creates a new disk blob store with a space used cache.
@@ -167,6 +201,7 @@ func NewBlobsUsageCache(log *zap.Logger, blob storage.Blobs) *BlobsUsageCache {
 		log:                  log,
 		Blobs:                blob,
 		spaceUsedBySatellite: map[storj.NodeID]SatelliteUsage{},
+		trashBySatellite:     map[storj.NodeID]int64{},
 	}
 }
 
@@ -179,6 +214,7 @@ func NewBlobsUsageCacheTest(log *zap.Logger, blob storage.Blobs, piecesTotal, pi
 		piecesContentSize:    piecesContentSize,
 		trashTotal:           trashTotal,
 		spaceUsedBySatellite: spaceUsedBySatellite,
+		trashBySatellite:     map[storj.NodeID]int64{},
 	}
 }
 
@@ -191,6 +227,13 @@ func (blobs *BlobsUsageCache) init(pieceTotal, contentSize, trashTotal int64, to
 	blobs.spaceUsedBySatellite = totalsBySatellite
 }
 
+// initTrashBySatellite replaces the trash used by every satellite with the given values.
+func (blobs *BlobsUsageCache) initTrashBySatellite(trashBySatellite map[storj.NodeID]int64) {
+	blobs.mu.Lock()
+	defer blobs.mu.Unlock()
+	blobs.trashBySatellite = trashBySatellite
+}
+
 // SpaceUsedBySatellite returns the current total space used for a specific
 // satellite for all pieces.
 func (blobs *BlobsUsageCache) SpaceUsedBySatellite(ctx context.Context, satelliteID storj.NodeID) (piecesTotal int64, piecesContentSize int64, err error) {
@@ -200,6 +243,19 @@ func (blobs *BlobsUsageCache) SpaceUsedBySatellite(ctx context.Context, satellit
 	return values.Total, values.ContentSize, nil
 }
 
+// SpaceUsedForTrashInNamespace returns the current used space for the trash of a specific satellite.
+//
+// The values are only known after the space used cache has been recalculated on startup.
+func (blobs *BlobsUsageCache) SpaceUsedForTrashInNamespace(ctx context.Context, namespace []byte) (int64, error) {
+	satelliteID, err := storj.NodeIDFromBytes(namespace)
+	if err != nil {
+		return 0, err
+	}
+	blobs.mu.Lock()
+	defer blobs.mu.Unlock()
+	return blobs.trashBySatellite[satelliteID], nil
+}
+
 // SpaceUsedForPieces returns the current total used space for all pieces.
 func (blobs *BlobsUsageCache) SpaceUsedForPieces(ctx context.Context) (int64, int64, error) {
 	blobs.mu.Lock()
@@ -269,6 +325,15 @@ func (blobs *BlobsUsageCache) Update(ctx context.Context, satelliteID storj.Node
 	blobs.ensurePositiveCacheValue(&newVals.ContentSize, "satPiecesContentSize")
 	blobs.spaceUsedBySatellite[satelliteID] = newVals
 
+	if trashDelta != 0 {
+		satTrash := blobs.trashBySatellite[satelliteID] + trashDelta
+		blobs.ensurePositiveCacheValue(&satTrash, "satTrashTotal")
+		if satTrash == 0 {
+			delete(blobs.trashBySatellite, satelliteID)
+		} else {
+			blobs.trashBySatellite[satelliteID] = satTrash
+		}
+	}
 }
 
 func (blobs *BlobsUsageCache) ensurePositiveCacheValue(value *int64, name string) {
@@ -317,6 +382,30 @@ func (blobs *BlobsUsageCache) EmptyTrash(ctx context.Context, namespace []byte,
 	return bytesEmptied, keys, nil
 }
 
+// DeleteNamespace deletes the blobs folder of the namespace and removes its
+// pieces from the cache.
+func (blobs *BlobsUsageCache) DeleteNamespace(ctx context.Context, namespace []byte) error {
+	satelliteID, err := storj.NodeIDFromBytes(namespace)
+	if err != nil {
+		return err
+	}
+
+	if err := blobs.Blobs.DeleteNamespace(ctx, namespace); err != nil {
+		return err
+	}
+
+	blobs.mu.Lock()
+	usage := blobs.spaceUsedBySatellite[satelliteID]
+	blobs.mu.Unlock()
+
+	blobs.Update(ctx, satelliteID, -usage.Total, -usage.ContentSize, 0)
+
+	blobs.mu.Lock()
+	delete(blobs.spaceUsedBySatellite, satelliteID)
+	blobs.mu.Unlock()
+	return nil
+}
+
 // RestoreTrash restores the trash for the namespace and updates the cache.
 func (blobs *BlobsUsageCache) RestoreTrash(ctx context.Context, namespace []byte) ([][]byte, error) {
 	satelliteID, err := storj.NodeIDFromBytes(namespace)
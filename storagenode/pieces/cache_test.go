@@ -620,6 +620,9 @@ func TestCacheCreateDeleteAndTrash(t *testing.T) {
 			trashTotal, err := cache.SpaceUsedForTrash(ctx)
 			require.NoError(t, err, msg)
 			assert.Equal(t, expTrash, int(trashTotal), msg)
+			trashTotal, err = cache.SpaceUsedForTrashInNamespace(ctx, satelliteID.Bytes())
+			require.NoError(t, err, msg)
+			assert.Equal(t, expTrash, int(trashTotal), msg)
 		}
 
 		expPieceSize := len(pieceContent) + pieces.V1PieceHeaderReservedArea
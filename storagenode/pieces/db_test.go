@@ -139,7 +139,7 @@ func TestV0PieceInfo(t *testing.T) {
 		assert.Len(t, expired, 3)
 
 		// mark info0 deletion as a failure
-		err = pieceinfos.DeleteFailed(ctx, info0.SatelliteID, info0.PieceID, exp)
+		err = pieceinfos.DeleteFailed(ctx, info0.SatelliteID, info0.PieceID, exp, exp)
 		assert.NoError(t, err)
 
 		// this shouldn't return info0
@@ -191,7 +191,7 @@ func TestPieceinfo_Trivial(t *testing.T) {
 		}
 
 		{ // Ensure DeleteFailed works at all
-			err := pieceinfos.DeleteFailed(ctx, satelliteID, pieceID, time.Now())
+			err := pieceinfos.DeleteFailed(ctx, satelliteID, pieceID, time.Now(), time.Now())
 			require.NoError(t, err)
 		}
 
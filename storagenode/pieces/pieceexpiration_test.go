@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"storj.io/common/storj"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
 	"storj.io/storj/storagenode"
@@ -41,7 +42,7 @@ func TestPieceExpirationDB(t *testing.T) {
 		require.False(t, found)
 
 		// DeleteFailed with no matches
-		err = expireDB.DeleteFailed(ctx, satelliteID, pieceID, time.Now())
+		err = expireDB.DeleteFailed(ctx, satelliteID, pieceID, time.Now(), time.Now())
 		require.NoError(t, err)
 
 		expireAt := time.Now()
@@ -63,10 +64,11 @@ func TestPieceExpirationDB(t *testing.T) {
 		deleteFailedAt := expireAt.Add(2 * time.Microsecond)
 
 		// DeleteFailed normal usage
-		err = expireDB.DeleteFailed(ctx, satelliteID, pieceID, deleteFailedAt)
+		err = expireDB.DeleteFailed(ctx, satelliteID, pieceID, deleteFailedAt, deleteFailedAt)
 		require.NoError(t, err)
+		expectedExpireInfo.DeletionFailures = 1
 
-		// GetExpired filters out rows with deletion_failed_at = t
+		// GetExpired filters out rows with deletion_retry_at >= t
 		expiredPieceIDs, err = expireDB.GetExpired(ctx, deleteFailedAt, 1000)
 		require.NoError(t, err)
 		require.Len(t, expiredPieceIDs, 0)
@@ -75,6 +77,26 @@ func TestPieceExpirationDB(t *testing.T) {
 		require.Len(t, expiredPieceIDs, 1)
 		assert.Equal(t, expiredPieceIDs[0], expectedExpireInfo)
 
+		// DeleteFailed with a retry backoff
+		retryAt := deleteFailedAt.Add(time.Hour)
+		err = expireDB.DeleteFailed(ctx, satelliteID, pieceID, deleteFailedAt, retryAt)
+		require.NoError(t, err)
+		expectedExpireInfo.DeletionFailures = 2
+
+		expiredPieceIDs, err = expireDB.GetExpired(ctx, retryAt, 1000)
+		require.NoError(t, err)
+		require.Len(t, expiredPieceIDs, 0)
+		expiredPieceIDs, err = expireDB.GetExpiredBetween(ctx, time.Time{}, retryAt, retryAt.Add(time.Microsecond), 1000)
+		require.NoError(t, err)
+		require.Len(t, expiredPieceIDs, 1)
+		assert.Equal(t, expiredPieceIDs[0], expectedExpireInfo)
+
+		summary, err := expireDB.GetExpirationSummary(ctx, retryAt)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, summary.Count)
+		require.EqualValues(t, 1, summary.Failed)
+		require.True(t, expireAt.Equal(summary.Oldest))
+
 		// DeleteExpiration normal usage
 		found, err = expireDB.DeleteExpiration(ctx, satelliteID, pieceID)
 		require.NoError(t, err)
@@ -86,3 +108,62 @@ func TestPieceExpirationDB(t *testing.T) {
 		require.Len(t, expiredPieceIDs, 0)
 	})
 }
+
+func TestPieceExpirationBetween(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		expireDB := db.PieceExpirationDB()
+
+		satelliteID := testrand.NodeID()
+		now := time.Now().UTC()
+
+		// one piece per hour, oldest first
+		var pieceIDs []storj.PieceID
+		for i := 10; i > 0; i-- {
+			pieceID := testrand.PieceID()
+			pieceIDs = append(pieceIDs, pieceID)
+			require.NoError(t, expireDB.SetExpiration(ctx, satelliteID, pieceID, now.Add(-time.Duration(i)*time.Hour)))
+		}
+
+		summary, err := expireDB.GetExpirationSummary(ctx, now)
+		require.NoError(t, err)
+		require.EqualValues(t, 10, summary.Count)
+		require.EqualValues(t, 0, summary.Failed)
+		require.True(t, now.Add(-10*time.Hour).Equal(summary.Oldest))
+
+		// range is inclusive at the start and exclusive at the end
+		expired, err := expireDB.GetExpiredBetween(ctx, now.Add(-8*time.Hour), now.Add(-5*time.Hour), now, 1000)
+		require.NoError(t, err)
+		require.Len(t, expired, 3)
+		for i, info := range expired {
+			require.Equal(t, pieceIDs[2+i], info.PieceID)
+		}
+
+		// results are ordered by expiration
+		expired, err = expireDB.GetExpiredBetween(ctx, time.Time{}, now, now, 2)
+		require.NoError(t, err)
+		require.Len(t, expired, 2)
+		require.Equal(t, pieceIDs[0], expired[0].PieceID)
+		require.Equal(t, pieceIDs[1], expired[1].PieceID)
+
+		// pieces with a scheduled retry are skipped until the retry time
+		require.NoError(t, expireDB.DeleteFailed(ctx, satelliteID, pieceIDs[0], now, now.Add(time.Hour)))
+
+		expired, err = expireDB.GetExpiredBetween(ctx, time.Time{}, now, now, 1)
+		require.NoError(t, err)
+		require.Len(t, expired, 1)
+		require.Equal(t, pieceIDs[1], expired[0].PieceID)
+
+		expired, err = expireDB.GetExpiredBetween(ctx, time.Time{}, now, now.Add(2*time.Hour), 1)
+		require.NoError(t, err)
+		require.Len(t, expired, 1)
+		require.Equal(t, pieceIDs[0], expired[0].PieceID)
+		require.Equal(t, 1, expired[0].DeletionFailures)
+
+		// trashed pieces are skipped
+		require.NoError(t, expireDB.Trash(ctx, satelliteID, pieceIDs[1]))
+		summary, err = expireDB.GetExpirationSummary(ctx, now)
+		require.NoError(t, err)
+		require.EqualValues(t, 9, summary.Count)
+		require.EqualValues(t, 1, summary.Failed)
+	})
+}
@@ -48,6 +48,20 @@ type ExpiredInfo struct {
 	// This can be removed when we no longer need to support the pieceinfo db. Its only purpose
 	// is to keep track of whether expired entries came from piece_expirations or pieceinfo.
 	InPieceInfo bool
+
+	// DeletionFailures is the number of previous failed attempts to delete the piece.
+	// It is only tracked for entries from piece_expirations.
+	DeletionFailures int
+}
+
+// ExpirationSummary summarizes pieces which have expired and are waiting to be deleted.
+type ExpirationSummary struct {
+	// Count is the number of expired pieces, including those waiting for a deletion retry.
+	Count int64
+	// Failed is the number of expired pieces which failed deletion at least once.
+	Failed int64
+	// Oldest is the expiration time of the oldest expired piece.
+	Oldest time.Time
 }
 
 // PieceExpirationDB stores information about pieces with expiration dates.
@@ -56,13 +70,18 @@ type ExpiredInfo struct {
 type PieceExpirationDB interface {
 	// GetExpired gets piece IDs that expire or have expired before the given time
 	GetExpired(ctx context.Context, expiresBefore time.Time, limit int64) ([]ExpiredInfo, error)
+	// GetExpiredBetween gets piece IDs that expire or have expired in the range [expiresAfter, expiresBefore),
+	// oldest first. Pieces with a deletion retry scheduled at or after retryBefore are skipped.
+	GetExpiredBetween(ctx context.Context, expiresAfter, expiresBefore, retryBefore time.Time, limit int64) ([]ExpiredInfo, error)
+	// GetExpirationSummary summarizes pieces that expire or have expired before the given time
+	GetExpirationSummary(ctx context.Context, expiresBefore time.Time) (ExpirationSummary, error)
 	// SetExpiration sets an expiration time for the given piece ID on the given satellite
 	SetExpiration(ctx context.Context, satellite storj.NodeID, pieceID storj.PieceID, expiresAt time.Time) error
 	// DeleteExpiration removes an expiration record for the given piece ID on the given satellite
 	DeleteExpiration(ctx context.Context, satellite storj.NodeID, pieceID storj.PieceID) (found bool, err error)
 	// DeleteFailed marks an expiration record as having experienced a failure in deleting the
-	// piece from the disk
-	DeleteFailed(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID, failedAt time.Time) error
+	// piece from the disk. The deletion is not retried until retryAt.
+	DeleteFailed(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID, failedAt, retryAt time.Time) error
 	// Trash marks a piece as in the trash
 	Trash(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID) error
 	// RestoreTrash marks all piece as not being in trash
@@ -79,11 +98,15 @@ type V0PieceInfoDB interface {
 	Get(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID) (*Info, error)
 	// Delete deletes Info about a piece.
 	Delete(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID) error
-	// DeleteFailed marks piece deletion from disk failed
-	DeleteFailed(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID, failedAt time.Time) error
+	// DeleteFailed marks piece deletion from disk failed, the deletion should not be retried
+	// until retryAt
+	DeleteFailed(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID, failedAt, retryAt time.Time) error
 	// GetExpired gets piece IDs stored with storage format V0 that expire or have expired
 	// before the given time
 	GetExpired(ctx context.Context, expiredAt time.Time, limit int64) ([]ExpiredInfo, error)
+	// GetExpiredBefore gets piece IDs stored with storage format V0 that expired before
+	// expiresBefore, skipping the ones with a deletion retry scheduled at or after retryBefore
+	GetExpiredBefore(ctx context.Context, expiresBefore, retryBefore time.Time, limit int64) ([]ExpiredInfo, error)
 	// WalkSatelliteV0Pieces executes walkFunc for each locally stored piece, stored
 	// with storage format V0 in the namespace of the given satellite. If walkFunc returns a
 	// non-nil error, WalkSatelliteV0Pieces will stop iterating and return the error
@@ -531,19 +554,57 @@ func (store *Store) GetExpired(ctx context.Context, expiredAt time.Time, limit i
 	return expired, nil
 }
 
+// GetExpiredBetween gets piece IDs that expired in the range [expiresAfter, expiresBefore),
+// skipping the ones with a deletion retry scheduled at or after retryBefore.
+// Pieces stored with storage format V0 are not included, see GetExpiredV0.
+func (store *Store) GetExpiredBetween(ctx context.Context, expiresAfter, expiresBefore, retryBefore time.Time, limit int64) (_ []ExpiredInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return store.expirationInfo.GetExpiredBetween(ctx, expiresAfter, expiresBefore, retryBefore, limit)
+}
+
+// GetExpiredV0 gets piece IDs stored with storage format V0 that expired before expiresBefore,
+// skipping the ones with a deletion retry scheduled at or after retryBefore.
+func (store *Store) GetExpiredV0(ctx context.Context, expiresBefore, retryBefore time.Time, limit int64) (_ []ExpiredInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if store.v0PieceInfo == nil {
+		return nil, nil
+	}
+	return store.v0PieceInfo.GetExpiredBefore(ctx, expiresBefore, retryBefore, limit)
+}
+
+// GetExpirationSummary summarizes pieces that have expired before the given time.
+func (store *Store) GetExpirationSummary(ctx context.Context, expiresBefore time.Time) (_ ExpirationSummary, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return store.expirationInfo.GetExpirationSummary(ctx, expiresBefore)
+}
+
 // SetExpiration records an expiration time for the specified piece ID owned by the specified satellite.
 func (store *Store) SetExpiration(ctx context.Context, satellite storj.NodeID, pieceID storj.PieceID, expiresAt time.Time) (err error) {
 	return store.expirationInfo.SetExpiration(ctx, satellite, pieceID, expiresAt)
 }
 
-// DeleteFailed marks piece as a failed deletion.
-func (store *Store) DeleteFailed(ctx context.Context, expired ExpiredInfo, when time.Time) (err error) {
+// DeleteFailed marks piece as a failed deletion, which should not be retried until retryAt.
+func (store *Store) DeleteFailed(ctx context.Context, expired ExpiredInfo, when, retryAt time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if expired.InPieceInfo {
+		return store.v0PieceInfo.DeleteFailed(ctx, expired.SatelliteID, expired.PieceID, when, retryAt)
+	}
+	return store.expirationInfo.DeleteFailed(ctx, expired.SatelliteID, expired.PieceID, when, retryAt)
+}
+
+// DeleteExpiration removes the expiration record of a piece, which no longer exists on disk.
+func (store *Store) DeleteExpiration(ctx context.Context, expired ExpiredInfo) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	if expired.InPieceInfo {
-		return store.v0PieceInfo.DeleteFailed(ctx, expired.SatelliteID, expired.PieceID, when)
+		return store.v0PieceInfo.Delete(ctx, expired.SatelliteID, expired.PieceID)
 	}
-	return store.expirationInfo.DeleteFailed(ctx, expired.SatelliteID, expired.PieceID, when)
+	_, err = store.expirationInfo.DeleteExpiration(ctx, expired.SatelliteID, expired.PieceID)
+	return err
 }
 
 // SpaceUsedForPieces returns *an approximation of* the disk space used by all local pieces (both
@@ -582,6 +643,28 @@ func (store *Store) SpaceUsedForTrash(ctx context.Context) (int64, error) {
 	return store.blobs.SpaceUsedForTrash(ctx)
 }
 
+// SpaceUsedForTrashBySatellite returns the space used by the trash of every satellite.
+func (store *Store) SpaceUsedForTrashBySatellite(ctx context.Context) (_ map[storj.NodeID]int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	satelliteIDs, err := store.getAllStoringSatellites(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	trashBySatellite := make(map[storj.NodeID]int64)
+	for _, satelliteID := range satelliteIDs {
+		trash, err := store.blobs.SpaceUsedForTrashInNamespace(ctx, satelliteID.Bytes())
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		if trash > 0 {
+			trashBySatellite[satelliteID] = trash
+		}
+	}
+	return trashBySatellite, nil
+}
+
 // SpaceUsedForPiecesAndTrash returns the total space used by both active
 // pieces and the trash directory.
 func (store *Store) SpaceUsedForPiecesAndTrash(ctx context.Context) (int64, error) {
@@ -598,6 +681,13 @@ func (store *Store) SpaceUsedForPiecesAndTrash(ctx context.Context) (int64, erro
 	return piecesTotal + trashTotal, nil
 }
 
+// StoringSatellites returns the satellites which have a blobs folder.
+func (store *Store) StoringSatellites(ctx context.Context) (_ []storj.NodeID, err error) {
+	defer mon.Task()(&ctx)(&err)
+	satellites, err := store.getAllStoringSatellites(ctx)
+	return satellites, Error.Wrap(err)
+}
+
 func (store *Store) getAllStoringSatellites(ctx context.Context) ([]storj.NodeID, error) {
 	namespaces, err := store.blobs.ListNamespaces(ctx)
 	if err != nil {
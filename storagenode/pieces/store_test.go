@@ -419,7 +419,10 @@ func TestTrashAndRestore(t *testing.T) {
 
 		// Empty trash by running the chore once
 		trashDur := 4 * 24 * time.Hour
-		chore := pieces.NewTrashChore(zaptest.NewLogger(t), 24*time.Hour, trashDur, trust, store)
+		chore := pieces.NewTrashChore(zaptest.NewLogger(t), pieces.TrashConfig{
+			Interval:  24 * time.Hour,
+			Retention: trashDur,
+		}, 0, trust, store)
 		ctx.Go(func() error {
 			return chore.Run(ctx)
 		})
@@ -749,6 +752,26 @@ func TestGetExpired(t *testing.T) {
 		assert.Equal(t, testPieces[0].PieceID, expired[1].PieceID)
 		assert.Equal(t, testPieces[0].SatelliteID, expired[1].SatelliteID)
 		assert.True(t, expired[1].InPieceInfo)
+
+		// GetExpiredV0 only returns pieces from pieceinfo
+		expired, err = store.GetExpiredV0(ctx, now, now, 1000)
+		require.NoError(t, err)
+		require.Len(t, expired, 1)
+		assert.Equal(t, testPieces[0].PieceID, expired[0].PieceID)
+		assert.True(t, expired[0].InPieceInfo)
+
+		// a failed deletion is not retried before the retry time
+		retryAt := now.Add(time.Hour)
+		require.NoError(t, store.DeleteFailed(ctx, expired[0], now, retryAt))
+
+		expired, err = store.GetExpiredV0(ctx, now, now, 1000)
+		require.NoError(t, err)
+		assert.Empty(t, expired)
+
+		expired, err = store.GetExpiredV0(ctx, now, retryAt.Add(time.Second), 1000)
+		require.NoError(t, err)
+		require.Len(t, expired, 1)
+		assert.Equal(t, 1, expired[0].DeletionFailures)
 	})
 }
 
@@ -5,36 +5,170 @@ package pieces
 
 import (
 	"context"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
+	"storj.io/common/memory"
+	"storj.io/common/storj"
 	"storj.io/common/sync2"
 	"storj.io/storj/storagenode/trust"
 )
 
+// trashEvictionStep is how much the trash retention is shortened on every
+// step while evicting the oldest trash early.
+const trashEvictionStep = 6 * time.Hour
+
+// TrashConfig defines the policy for emptying the trash.
+type TrashConfig struct {
+	Interval           time.Duration      `help:"how frequently the trash is checked for pieces to delete" default:"24h0m0s"`
+	Retention          time.Duration      `help:"how long pieces are kept in the trash before they are deleted" default:"168h0m0s"`
+	SatelliteRetention SatelliteRetention `help:"comma separated list of per satellite trash retention overrides, e.g. <satellite id>:72h" default:""`
+	MaxSize            TrashSizeLimit     `help:"maximum size of the trash, either absolute (e.g. 100GB) or relative to the allocated disk space (e.g. 10%), the oldest trash is deleted early when exceeded; empty means no limit" default:""`
+	NearFullThreshold  float64            `help:"fraction of the allocated disk space which, when used by pieces and trash, causes the oldest trash to be deleted early, e.g. 0.95; 0 disables it" default:"0"`
+}
+
+// RetentionFor returns how long pieces of the given satellite are kept in the trash.
+func (config TrashConfig) RetentionFor(satelliteID storj.NodeID) time.Duration {
+	if retention, ok := config.SatelliteRetention[satelliteID]; ok {
+		return retention
+	}
+	return config.Retention
+}
+
+// SatelliteRetention is a set of per satellite trash retentions that implements pflag.Value.
+type SatelliteRetention map[storj.NodeID]time.Duration
+
+// String returns the string representation of the config.
+func (retention SatelliteRetention) String() string {
+	s := make([]string, 0, len(retention))
+	for satelliteID, duration := range retention {
+		s = append(s, satelliteID.String()+":"+duration.String())
+	}
+	sort.Strings(s)
+	return strings.Join(s, ",")
+}
+
+// Set implements pflag.Value by parsing a comma separated list of <satellite id>:<duration> entries.
+func (retention *SatelliteRetention) Set(value string) error {
+	toSet := SatelliteRetention{}
+	if value != "" {
+		for _, entry := range strings.Split(value, ",") {
+			parts := strings.SplitN(entry, ":", 2)
+			if len(parts) != 2 {
+				return Error.New("invalid satellite retention %q: expected <satellite id>:<duration>", entry)
+			}
+			satelliteID, err := storj.NodeIDFromString(parts[0])
+			if err != nil {
+				return Error.New("invalid satellite retention %q: %v", entry, err)
+			}
+			duration, err := time.ParseDuration(parts[1])
+			if err != nil {
+				return Error.New("invalid satellite retention %q: %v", entry, err)
+			}
+			if duration < 0 {
+				return Error.New("invalid satellite retention %q: negative duration", entry)
+			}
+			toSet[satelliteID] = duration
+		}
+	}
+
+	*retention = toSet
+	return nil
+}
+
+// Type returns the type of the pflag.Value.
+func (retention SatelliteRetention) Type() string {
+	return "satellite-retention"
+}
+
+// TrashSizeLimit is a trash size limit, either absolute or relative to the
+// allocated disk space, that implements pflag.Value.
+type TrashSizeLimit struct {
+	Size    memory.Size
+	Percent float64
+}
+
+// String returns the string representation of the config.
+func (limit TrashSizeLimit) String() string {
+	switch {
+	case limit.Percent > 0:
+		return strconv.FormatFloat(limit.Percent, 'f', -1, 64) + "%"
+	case limit.Size > 0:
+		return limit.Size.String()
+	default:
+		return ""
+	}
+}
+
+// Set implements pflag.Value by parsing either a size or a percentage.
+func (limit *TrashSizeLimit) Set(value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		*limit = TrashSizeLimit{}
+		return nil
+	}
+
+	if strings.HasSuffix(value, "%") {
+		percent, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+		if err != nil {
+			return Error.New("invalid trash size limit %q: %v", value, err)
+		}
+		if percent <= 0 || percent > 100 {
+			return Error.New("invalid trash size limit %q: percentage must be in (0, 100]", value)
+		}
+		*limit = TrashSizeLimit{Percent: percent}
+		return nil
+	}
+
+	var size memory.Size
+	if err := size.Set(value); err != nil {
+		return Error.New("invalid trash size limit %q: %v", value, err)
+	}
+	if size < 0 {
+		return Error.New("invalid trash size limit %q: negative size", value)
+	}
+	*limit = TrashSizeLimit{Size: size}
+	return nil
+}
+
+// Type returns the type of the pflag.Value.
+func (limit TrashSizeLimit) Type() string {
+	return "trash-size-limit"
+}
+
+// Bytes returns the limit in bytes for the given allocated disk space, or 0 if there is no limit.
+func (limit TrashSizeLimit) Bytes(allocated int64) int64 {
+	if limit.Percent > 0 {
+		return int64(float64(allocated) * limit.Percent / 100)
+	}
+	return limit.Size.Int64()
+}
+
 // TrashChore is the chore that periodically empties the trash.
 type TrashChore struct {
-	log                 *zap.Logger
-	interval            time.Duration
-	trashExpiryInterval time.Duration
-	store               *Store
-	trust               *trust.Pool
-	cycle               *sync2.Cycle
-	started             sync2.Fence
-}
-
-// NewTrashChore instantiates a new TrashChore. choreInterval is how often this
-// chore runs, and trashExpiryInterval is passed into the EmptyTrash method to
-// determine which trashed pieces should be deleted.
-func NewTrashChore(log *zap.Logger, choreInterval, trashExpiryInterval time.Duration, trust *trust.Pool, store *Store) *TrashChore {
+	log       *zap.Logger
+	config    TrashConfig
+	allocated int64
+	store     *Store
+	trust     *trust.Pool
+	cycle     *sync2.Cycle
+	started   sync2.Fence
+}
+
+// NewTrashChore instantiates a new TrashChore. allocatedDiskSpace is used to
+// compute the trash size limits of the policy.
+func NewTrashChore(log *zap.Logger, config TrashConfig, allocatedDiskSpace int64, trust *trust.Pool, store *Store) *TrashChore {
 	return &TrashChore{
-		log:                 log,
-		interval:            choreInterval,
-		trashExpiryInterval: trashExpiryInterval,
-		store:               store,
-		trust:               trust,
+		log:       log,
+		config:    config,
+		allocated: allocatedDiskSpace,
+		store:     store,
+		trust:     trust,
 	}
 }
 
@@ -42,24 +176,114 @@ func NewTrashChore(log *zap.Logger, choreInterval, trashExpiryInterval time.Dura
 func (chore *TrashChore) Run(ctx context.Context) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	chore.cycle = sync2.NewCycle(chore.interval)
+	chore.cycle = sync2.NewCycle(chore.config.Interval)
 	chore.cycle.Start(ctx, &errgroup.Group{}, func(ctx context.Context) error {
 		chore.log.Debug("starting to empty trash")
 
-		for _, satelliteID := range chore.trust.GetSatellites(ctx) {
-			trashedBefore := time.Now().Add(-chore.trashExpiryInterval)
+		now := time.Now()
+		satellites := chore.trust.GetSatellites(ctx)
+		for _, satelliteID := range satellites {
+			trashedBefore := now.Add(-chore.config.RetentionFor(satelliteID))
 			err := chore.store.EmptyTrash(ctx, satelliteID, trashedBefore)
 			if err != nil {
 				chore.log.Error("emptying trash failed", zap.Error(err))
 			}
 		}
 
+		if err := chore.evict(ctx, satellites, now); err != nil {
+			chore.log.Error("evicting trash failed", zap.Error(err))
+		}
+
 		return nil
 	})
 	chore.started.Release()
 	return err
 }
 
+// evict deletes the oldest trash of all satellites, before its retention
+// expires, until the trash fits into the limits of the policy.
+func (chore *TrashChore) evict(ctx context.Context, satellites []storj.NodeID, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	limit, err := chore.trashLimit(ctx)
+	if err != nil || limit < 0 {
+		return err
+	}
+
+	trash, err := chore.store.SpaceUsedForTrash(ctx)
+	if err != nil {
+		return err
+	}
+	mon.IntVal("trash_limit").Observe(limit)
+	if trash <= limit {
+		return nil
+	}
+
+	chore.log.Info("trash exceeds the limit, deleting the oldest trash",
+		zap.Int64("trash bytes", trash), zap.Int64("limit bytes", limit))
+
+	var maxRetention time.Duration
+	for _, satelliteID := range satellites {
+		if retention := chore.config.RetentionFor(satelliteID); retention > maxRetention {
+			maxRetention = retention
+		}
+	}
+
+	for retention := maxRetention - trashEvictionStep; trash > limit; retention -= trashEvictionStep {
+		if retention < 0 {
+			retention = 0
+		}
+
+		for _, satelliteID := range satellites {
+			if retention >= chore.config.RetentionFor(satelliteID) {
+				continue
+			}
+			err := chore.store.EmptyTrash(ctx, satelliteID, now.Add(-retention))
+			if err != nil {
+				return err
+			}
+		}
+
+		trash, err = chore.store.SpaceUsedForTrash(ctx)
+		if err != nil {
+			return err
+		}
+		if retention == 0 {
+			break
+		}
+	}
+
+	mon.IntVal("trash_after_eviction").Observe(trash)
+	return nil
+}
+
+// trashLimit returns the maximum size of the trash allowed by the policy,
+// or -1 when it's not limited.
+func (chore *TrashChore) trashLimit(ctx context.Context) (_ int64, err error) {
+	limit := int64(-1)
+	if maxSize := chore.config.MaxSize.Bytes(chore.allocated); maxSize > 0 {
+		limit = maxSize
+	}
+
+	if chore.config.NearFullThreshold > 0 && chore.allocated > 0 {
+		piecesTotal, _, err := chore.store.SpaceUsedForPieces(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		nearFull := int64(float64(chore.allocated) * chore.config.NearFullThreshold)
+		available := nearFull - piecesTotal
+		if available < 0 {
+			available = 0
+		}
+		if limit < 0 || available < limit {
+			limit = available
+		}
+	}
+
+	return limit, nil
+}
+
 // TriggerWait ensures that the cycle is done at least once and waits for
 // completion.  If the cycle is currently running it waits for the previous to
 // complete and then runs.
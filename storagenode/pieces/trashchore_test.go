@@ -0,0 +1,171 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pieces_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/rpc"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
+	"storj.io/storj/storagenode/trust"
+)
+
+func TestTrashConfig(t *testing.T) {
+	satelliteID := testrand.NodeID()
+
+	var retention pieces.SatelliteRetention
+	require.NoError(t, retention.Set(satelliteID.String()+":72h"))
+	require.Equal(t, pieces.SatelliteRetention{satelliteID: 72 * time.Hour}, retention)
+	require.Equal(t, satelliteID.String()+":72h0m0s", retention.String())
+
+	require.Error(t, retention.Set("invalid"))
+	require.Error(t, retention.Set(satelliteID.String()+":-1h"))
+
+	config := pieces.TrashConfig{Retention: 7 * 24 * time.Hour, SatelliteRetention: retention}
+	require.Equal(t, 72*time.Hour, config.RetentionFor(satelliteID))
+	require.Equal(t, 7*24*time.Hour, config.RetentionFor(testrand.NodeID()))
+
+	var limit pieces.TrashSizeLimit
+	require.NoError(t, limit.Set("10%"))
+	require.EqualValues(t, 100, limit.Bytes(1000))
+	require.Equal(t, "10%", limit.String())
+
+	require.NoError(t, limit.Set("1KB"))
+	require.EqualValues(t, 1000, limit.Bytes(1e6))
+
+	require.NoError(t, limit.Set(""))
+	require.Zero(t, limit.Bytes(1e6))
+
+	require.Error(t, limit.Set("150%"))
+	require.Error(t, limit.Set("1.5.0GB"))
+	require.Error(t, limit.Set("-5GB"))
+}
+
+func TestTrashChorePolicy(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+
+		dir, err := filestore.NewDir(log, ctx.Dir("store"))
+		require.NoError(t, err)
+		blobs := pieces.NewBlobsUsageCache(log, filestore.New(log, dir, filestore.DefaultConfig))
+		defer ctx.Check(blobs.Close)
+
+		store := pieces.NewStore(log, blobs, nil, db.PieceExpirationDB(), nil, pieces.DefaultConfig)
+
+		satelliteA, satelliteB := testrand.NodeID(), testrand.NodeID()
+
+		type trashedPiece struct {
+			satelliteID storj.NodeID
+			pieceID     storj.PieceID
+			age         time.Duration
+		}
+		day := 24 * time.Hour
+		trashed := map[string]*trashedPiece{
+			"a1": {satelliteID: satelliteA, age: 1 * day},
+			"a3": {satelliteID: satelliteA, age: 3 * day},
+			"a5": {satelliteID: satelliteA, age: 5 * day},
+			"b2": {satelliteID: satelliteB, age: 2 * day},
+			"b4": {satelliteID: satelliteB, age: 4 * day},
+		}
+		for _, piece := range trashed {
+			piece.pieceID = testrand.PieceID()
+
+			w, err := store.Writer(ctx, piece.satelliteID, piece.pieceID)
+			require.NoError(t, err)
+			_, err = w.Write(testrand.Bytes(memory.KiB))
+			require.NoError(t, err)
+			require.NoError(t, w.Commit(ctx, &pb.PieceHeader{}))
+
+			age := piece.age
+			dir.ReplaceTrashnow(func() time.Time { return time.Now().Add(-age) })
+			require.NoError(t, store.Trash(ctx, piece.satelliteID, piece.pieceID))
+		}
+
+		trashTotal, err := store.SpaceUsedForTrash(ctx)
+		require.NoError(t, err)
+		pieceSize := trashTotal / int64(len(trashed))
+
+		trashBySatellite, err := store.SpaceUsedForTrashBySatellite(ctx)
+		require.NoError(t, err)
+		require.Equal(t, map[storj.NodeID]int64{
+			satelliteA: 3 * pieceSize,
+			satelliteB: 2 * pieceSize,
+		}, trashBySatellite)
+
+		pool, err := trust.NewPool(log, trust.Dialer(rpc.Dialer{}), trust.Config{
+			Sources: []trust.Source{
+				&trust.StaticURLSource{URL: trust.SatelliteURL{ID: satelliteA, Host: "localhost", Port: 0}},
+				&trust.StaticURLSource{URL: trust.SatelliteURL{ID: satelliteB, Host: "localhost", Port: 1}},
+			},
+			CachePath: ctx.File("trust-cache.json"),
+		})
+		require.NoError(t, err)
+		require.NoError(t, pool.Refresh(ctx))
+
+		runChore := func(config pieces.TrashConfig, allocated int64) {
+			config.Interval = time.Hour
+			chore := pieces.NewTrashChore(log, config, allocated, pool, store)
+			ctx.Go(func() error {
+				return chore.Run(ctx)
+			})
+			chore.TriggerWait(ctx)
+			require.NoError(t, chore.Close())
+		}
+
+		requireTrashed := func(names ...string) {
+			var expected int64
+			for _, name := range names {
+				expected += pieceSize
+				require.NoError(t, store.RestoreTrash(ctx, trashed[name].satelliteID))
+			}
+
+			trashTotal, err := store.SpaceUsedForTrash(ctx)
+			require.NoError(t, err)
+			require.Zero(t, trashTotal)
+
+			var restored int64
+			for name, piece := range trashed {
+				r, err := store.Reader(ctx, piece.satelliteID, piece.pieceID)
+				if err != nil {
+					continue
+				}
+				require.NoError(t, r.Close())
+				require.Contains(t, names, name)
+				restored += pieceSize
+
+				piece := piece
+				dir.ReplaceTrashnow(func() time.Time { return time.Now().Add(-piece.age) })
+				require.NoError(t, store.Trash(ctx, piece.satelliteID, piece.pieceID))
+			}
+			require.Equal(t, expected, restored)
+		}
+
+		// the per satellite retention removes b4, the size limit evicts the oldest trash of all satellites
+		runChore(pieces.TrashConfig{
+			Retention:          7 * day,
+			SatelliteRetention: pieces.SatelliteRetention{satelliteB: 3 * day},
+			MaxSize:            pieces.TrashSizeLimit{Size: memory.Size(2*pieceSize + pieceSize/2)},
+		}, 0)
+		requireTrashed("a1", "b2")
+
+		// the node is nearly full, so only as much trash as fits into the allocation is kept
+		runChore(pieces.TrashConfig{
+			Retention:         7 * day,
+			NearFullThreshold: 1,
+		}, pieceSize+pieceSize/2)
+		requireTrashed("a1")
+	})
+}
@@ -34,6 +34,7 @@ import (
 	"storj.io/storj/storagenode/pieces"
 	"storj.io/storj/storagenode/piecestore/usedserials"
 	"storj.io/storj/storagenode/retain"
+	"storj.io/storj/storagenode/transferstats"
 	"storj.io/storj/storagenode/trust"
 )
 
@@ -53,6 +54,7 @@ type OldConfig struct {
 // Config defines parameters for piecestore endpoint.
 type Config struct {
 	DatabaseDir             string        `help:"directory to store databases. if empty, uses data path" default:""`
+	MigrationSource         string        `help:"previous data path which pieces are being migrated from by migrate-storage, pieces not migrated yet are read from there" default:""`
 	ExpirationGracePeriod   time.Duration `help:"how soon before expiration date should things be considered expired" default:"48h0m0s"`
 	MaxConcurrentRequests   int           `help:"how many concurrent requests are allowed, before uploads are rejected. 0 represents unlimited." default:"0"`
 	DeleteWorkers           int           `help:"how many piece delete workers" default:"1"`
@@ -93,11 +95,13 @@ type Endpoint struct {
 	usedSerials  *usedserials.Table
 	pieceDeleter *pieces.Deleter
 
+	transferStats *transferstats.Service
+
 	liveRequests int32
 }
 
 // NewEndpoint creates a new piecestore endpoint.
-func NewEndpoint(log *zap.Logger, signer signing.Signer, trust *trust.Pool, monitor *monitor.Service, retain *retain.Service, pingStats pingStatsSource, store *pieces.Store, pieceDeleter *pieces.Deleter, ordersStore *orders.FileStore, usage bandwidth.DB, usedSerials *usedserials.Table, config Config) (*Endpoint, error) {
+func NewEndpoint(log *zap.Logger, signer signing.Signer, trust *trust.Pool, monitor *monitor.Service, retain *retain.Service, pingStats pingStatsSource, store *pieces.Store, pieceDeleter *pieces.Deleter, ordersStore *orders.FileStore, usage bandwidth.DB, usedSerials *usedserials.Table, transferStats *transferstats.Service, config Config) (*Endpoint, error) {
 	return &Endpoint{
 		log:    log,
 		config: config,
@@ -114,12 +118,22 @@ func NewEndpoint(log *zap.Logger, signer signing.Signer, trust *trust.Pool, moni
 		usedSerials:  usedSerials,
 		pieceDeleter: pieceDeleter,
 
+		transferStats: transferStats,
+
 		liveRequests: 0,
 	}, nil
 }
 
 var monLiveRequests = mon.TaskNamed("live-request")
 
+// recordTransfer counts the outcome of a transfer for a verified order limit.
+func (endpoint *Endpoint) recordTransfer(limit *pb.OrderLimit, status transferstats.Status) {
+	if endpoint.transferStats == nil {
+		return
+	}
+	endpoint.transferStats.Record(limit.SatelliteId, limit.Action, status, time.Now())
+}
+
 // Delete handles deleting a piece on piece store requested by uplink.
 //
 // Deprecated: use DeletePieces instead.
@@ -149,8 +163,10 @@ func (endpoint *Endpoint) Delete(ctx context.Context, delete *pb.PieceDeleteRequ
 		// e.g. not found might happen when we get a deletion request after garbage
 		// collection has deleted it
 		endpoint.log.Error("delete failed", zap.Stringer("Satellite ID", delete.Limit.SatelliteId), zap.Stringer("Piece ID", delete.Limit.PieceId), zap.Error(err))
+		endpoint.recordTransfer(delete.Limit, transferstats.StatusFailure)
 	} else {
 		endpoint.log.Info("deleted", zap.Stringer("Satellite ID", delete.Limit.SatelliteId), zap.Stringer("Piece ID", delete.Limit.PieceId))
+		endpoint.recordTransfer(delete.Limit, transferstats.StatusSuccess)
 	}
 
 	return &pb.PieceDeleteResponse{}, nil
@@ -267,18 +283,21 @@ func (endpoint *Endpoint) Upload(stream pb.DRPCPiecestore_UploadStream) (err err
 			mon.IntVal("upload_failure_duration_ns").Observe(uploadDuration)
 			mon.FloatVal("upload_failure_rate_bytes_per_sec").Observe(uploadRate)
 			endpoint.log.Error("upload failed", zap.Stringer("Piece ID", limit.PieceId), zap.Stringer("Satellite ID", limit.SatelliteId), zap.Stringer("Action", limit.Action), zap.Error(err))
+			endpoint.recordTransfer(limit, transferstats.StatusFailure)
 		} else if errs2.IsCanceled(err) && !committed {
 			mon.Meter("upload_cancel_byte_meter").Mark64(uploadSize)
 			mon.IntVal("upload_cancel_size_bytes").Observe(uploadSize)
 			mon.IntVal("upload_cancel_duration_ns").Observe(uploadDuration)
 			mon.FloatVal("upload_cancel_rate_bytes_per_sec").Observe(uploadRate)
 			endpoint.log.Info("upload canceled", zap.Stringer("Piece ID", limit.PieceId), zap.Stringer("Satellite ID", limit.SatelliteId), zap.Stringer("Action", limit.Action))
+			endpoint.recordTransfer(limit, transferstats.StatusCancel)
 		} else {
 			mon.Meter("upload_success_byte_meter").Mark64(uploadSize)
 			mon.IntVal("upload_success_size_bytes").Observe(uploadSize)
 			mon.IntVal("upload_success_duration_ns").Observe(uploadDuration)
 			mon.FloatVal("upload_success_rate_bytes_per_sec").Observe(uploadRate)
 			endpoint.log.Info("uploaded", zap.Stringer("Piece ID", limit.PieceId), zap.Stringer("Satellite ID", limit.SatelliteId), zap.Stringer("Action", limit.Action))
+			endpoint.recordTransfer(limit, transferstats.StatusSuccess)
 		}
 	}()
 
@@ -484,18 +503,21 @@ func (endpoint *Endpoint) Download(stream pb.DRPCPiecestore_DownloadStream) (err
 			mon.IntVal("download_cancel_duration_ns").Observe(downloadDuration)
 			mon.FloatVal("download_cancel_rate_bytes_per_sec").Observe(downloadRate)
 			endpoint.log.Info("download canceled", zap.Stringer("Piece ID", limit.PieceId), zap.Stringer("Satellite ID", limit.SatelliteId), zap.Stringer("Action", limit.Action))
+			endpoint.recordTransfer(limit, transferstats.StatusCancel)
 		} else if err != nil {
 			mon.Meter("download_failure_byte_meter").Mark64(downloadSize)
 			mon.IntVal("download_failure_size_bytes").Observe(downloadSize)
 			mon.IntVal("download_failure_duration_ns").Observe(downloadDuration)
 			mon.FloatVal("download_failure_rate_bytes_per_sec").Observe(downloadRate)
 			endpoint.log.Error("download failed", zap.Stringer("Piece ID", limit.PieceId), zap.Stringer("Satellite ID", limit.SatelliteId), zap.Stringer("Action", limit.Action), zap.Error(err))
+			endpoint.recordTransfer(limit, transferstats.StatusFailure)
 		} else {
 			mon.Meter("download_success_byte_meter").Mark64(downloadSize)
 			mon.IntVal("download_success_size_bytes").Observe(downloadSize)
 			mon.IntVal("download_success_duration_ns").Observe(downloadDuration)
 			mon.FloatVal("download_success_rate_bytes_per_sec").Observe(downloadRate)
 			endpoint.log.Info("downloaded", zap.Stringer("Piece ID", limit.PieceId), zap.Stringer("Satellite ID", limit.SatelliteId), zap.Stringer("Action", limit.Action))
+			endpoint.recordTransfer(limit, transferstats.StatusSuccess)
 		}
 	}()
 
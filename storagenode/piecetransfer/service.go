@@ -20,6 +20,7 @@ import (
 	"storj.io/common/storj"
 	"storj.io/storj/storagenode/pieces"
 	"storj.io/storj/storagenode/piecestore"
+	"storj.io/storj/storagenode/satellites"
 	"storj.io/storj/storagenode/trust"
 	"storj.io/uplink/private/ecclient"
 )
@@ -31,6 +32,29 @@ var (
 	mon = monkit.Package()
 )
 
+// Reasons a piece transfer can fail with.
+const (
+	FailureNotFound     = "not_found"
+	FailureHashMismatch = "hash_mismatch"
+	FailureTimeout      = "timeout"
+	FailureUnavailable  = "storage_node_unavailable"
+	FailureUnknown      = "unknown"
+)
+
+// Result describes the outcome of a piece transfer.
+type Result struct {
+	// Reason is satellites.TransferSucceeded or one of the Failure reasons.
+	Reason   string
+	Bytes    int64
+	Duration time.Duration
+	Err      error
+}
+
+// Succeeded returns whether the piece was transferred.
+func (result Result) Succeeded() bool {
+	return result.Reason == satellites.TransferSucceeded
+}
+
 // Service allows for transfer of pieces from one storage node to
 // another, as directed by the satellite that owns the piece.
 type Service interface {
@@ -38,8 +62,8 @@ type Service interface {
 	// piece, and then (if appropriate) transfers the piece to the specified
 	// destination node, obtaining a signed receipt. TransferPiece returns a
 	// message appropriate for responding to the transfer order (whether the
-	// transfer succeeded or failed).
-	TransferPiece(ctx context.Context, satelliteID storj.NodeID, transferPiece *pb.TransferPiece) *pb.StorageNodeMessage
+	// transfer succeeded or failed) together with the result of the transfer.
+	TransferPiece(ctx context.Context, satelliteID storj.NodeID, transferPiece *pb.TransferPiece) (*pb.StorageNodeMessage, Result)
 }
 
 type service struct {
@@ -69,8 +93,8 @@ func NewService(log *zap.Logger, store *pieces.Store, trust *trust.Pool, dialer
 // piece, and then (if appropriate) transfers the piece to the specified
 // destination node, obtaining a signed receipt. TransferPiece returns a
 // message appropriate for responding to the transfer order (whether the
-// transfer succeeded or failed).
-func (c *service) TransferPiece(ctx context.Context, satelliteID storj.NodeID, transferPiece *pb.TransferPiece) *pb.StorageNodeMessage {
+// transfer succeeded or failed) together with the result of the transfer.
+func (c *service) TransferPiece(ctx context.Context, satelliteID storj.NodeID, transferPiece *pb.TransferPiece) (*pb.StorageNodeMessage, Result) {
 	// errForMonkit doesn't get returned, but we'd still like for monkit to be able
 	// to differentiate between counts of failures returned and successes returned.
 	var errForMonkit error
@@ -79,7 +103,10 @@ func (c *service) TransferPiece(ctx context.Context, satelliteID storj.NodeID, t
 	pieceID := transferPiece.OriginalPieceId
 	logger := c.log.With(zap.Stringer("Satellite ID", satelliteID), zap.Stringer("Piece ID", pieceID))
 
-	failMessage := func(errString string, err error, transferErr pb.TransferFailed_Error) *pb.StorageNodeMessage {
+	start := time.Now()
+	var size int64
+
+	failMessage := func(errString string, err error, transferErr pb.TransferFailed_Error, reason string) (*pb.StorageNodeMessage, Result) {
 		logger.Error(errString, zap.Error(err))
 		errForMonkit = err
 		return &pb.StorageNodeMessage{
@@ -89,16 +116,21 @@ func (c *service) TransferPiece(ctx context.Context, satelliteID storj.NodeID, t
 					Error:           transferErr,
 				},
 			},
+		}, Result{
+			Reason:   reason,
+			Bytes:    size,
+			Duration: time.Since(start),
+			Err:      Error.New("%s: %v", errString, err),
 		}
 	}
 
 	reader, err := c.store.Reader(ctx, satelliteID, pieceID)
 	if err != nil {
-		transferErr := pb.TransferFailed_UNKNOWN
+		transferErr, reason := pb.TransferFailed_UNKNOWN, FailureUnknown
 		if errs.Is(err, os.ErrNotExist) {
-			transferErr = pb.TransferFailed_NOT_FOUND
+			transferErr, reason = pb.TransferFailed_NOT_FOUND, FailureNotFound
 		}
-		return failMessage("failed to get piece reader", err, transferErr)
+		return failMessage("failed to get piece reader", err, transferErr, reason)
 	}
 
 	addrLimit := transferPiece.GetAddressedOrderLimit()
@@ -106,12 +138,13 @@ func (c *service) TransferPiece(ctx context.Context, satelliteID storj.NodeID, t
 
 	originalHash, originalOrderLimit, err := c.store.GetHashAndLimit(ctx, satelliteID, pieceID, reader)
 	if err != nil {
-		return failMessage("failed to get piece hash and order limit.", err, pb.TransferFailed_UNKNOWN)
+		return failMessage("failed to get piece hash and order limit.", err, pb.TransferFailed_UNKNOWN, FailureUnknown)
 	}
+	size = originalHash.PieceSize
 
 	satelliteSigner, err := c.trust.GetSignee(ctx, satelliteID)
 	if err != nil {
-		return failMessage("failed to get satellite signer identity from trust store!", err, pb.TransferFailed_UNKNOWN)
+		return failMessage("failed to get satellite signer identity from trust store!", err, pb.TransferFailed_UNKNOWN, FailureUnknown)
 	}
 
 	// verify the satellite signature on the original order limit; if we hand in something
@@ -120,7 +153,7 @@ func (c *service) TransferPiece(ctx context.Context, satelliteID storj.NodeID, t
 	err = signing.VerifyOrderLimitSignature(ctx, satelliteSigner, &originalOrderLimit)
 	if err != nil {
 		msg := "The order limit stored for this piece does not have a valid signature from the owning satellite! It was verified before storing, so something went wrong in storage. We have to report this to the satellite as a missing piece."
-		return failMessage(msg, err, pb.TransferFailed_NOT_FOUND)
+		return failMessage(msg, err, pb.TransferFailed_NOT_FOUND, FailureNotFound)
 	}
 
 	// verify that the public key on the order limit signed the original piece hash; if we
@@ -129,7 +162,7 @@ func (c *service) TransferPiece(ctx context.Context, satelliteID storj.NodeID, t
 	err = signing.VerifyUplinkPieceHashSignature(ctx, originalOrderLimit.UplinkPublicKey, &originalHash)
 	if err != nil {
 		msg := "The piece hash stored for this piece does not have a valid signature from the public key stored in the order limit! It was verified before storing, so something went wrong in storage. We have to report this to the satellite as a missing piece."
-		return failMessage(msg, err, pb.TransferFailed_NOT_FOUND)
+		return failMessage(msg, err, pb.TransferFailed_NOT_FOUND, FailureNotFound)
 	}
 
 	// after this point, the destination storage node ID is relevant
@@ -149,25 +182,29 @@ func (c *service) TransferPiece(ctx context.Context, satelliteID storj.NodeID, t
 	pieceHash, peerID, err := c.ecClient.PutPiece(putCtx, ctx, addrLimit, pk, reader)
 	if err != nil {
 		if piecestore.ErrVerifyUntrusted.Has(err) {
-			return failMessage("failed hash verification", err, pb.TransferFailed_HASH_VERIFICATION)
+			return failMessage("failed hash verification", err, pb.TransferFailed_HASH_VERIFICATION, FailureHashMismatch)
+		}
+		if errs.Is(putCtx.Err(), context.DeadlineExceeded) {
+			// the satellite has no separate error for slow transfers
+			return failMessage("timed out putting piece", err, pb.TransferFailed_STORAGE_NODE_UNAVAILABLE, FailureTimeout)
 		}
 		// TODO look at error type to decide on the transfer error
-		return failMessage("failed to put piece", err, pb.TransferFailed_STORAGE_NODE_UNAVAILABLE)
+		return failMessage("failed to put piece", err, pb.TransferFailed_STORAGE_NODE_UNAVAILABLE, FailureUnavailable)
 	}
 
 	if !bytes.Equal(originalHash.Hash, pieceHash.Hash) {
 		msg := "piece hash from new storagenode does not match"
-		return failMessage(msg, Error.New(msg), pb.TransferFailed_HASH_VERIFICATION)
+		return failMessage(msg, Error.New(msg), pb.TransferFailed_HASH_VERIFICATION, FailureHashMismatch)
 	}
 	if pieceHash.PieceId != addrLimit.Limit.PieceId {
 		msg := "piece id from new storagenode does not match order limit"
-		return failMessage(msg, Error.New(msg), pb.TransferFailed_HASH_VERIFICATION)
+		return failMessage(msg, Error.New(msg), pb.TransferFailed_HASH_VERIFICATION, FailureHashMismatch)
 	}
 
 	signee := signing.SigneeFromPeerIdentity(peerID)
 	err = signing.VerifyPieceHashSignature(ctx, signee, pieceHash)
 	if err != nil {
-		return failMessage("invalid piece hash signature from new storagenode", err, pb.TransferFailed_HASH_VERIFICATION)
+		return failMessage("invalid piece hash signature from new storagenode", err, pb.TransferFailed_HASH_VERIFICATION, FailureHashMismatch)
 	}
 
 	success := &pb.StorageNodeMessage{
@@ -181,5 +218,9 @@ func (c *service) TransferPiece(ctx context.Context, satelliteID storj.NodeID, t
 		},
 	}
 	logger.Info("piece transferred to new storagenode")
-	return success
+	return success, Result{
+		Reason:   satellites.TransferSucceeded,
+		Bytes:    size,
+		Duration: time.Since(start),
+	}
 }
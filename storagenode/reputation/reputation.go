@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"storj.io/common/storj"
+	"storj.io/storj/private/nodestatsext"
 )
 
 // DB works with reputation database.
@@ -35,10 +36,43 @@ type Stats struct {
 	OfflineSuspendedAt   *time.Time
 	OfflineUnderReviewAt *time.Time
 
+	// VettedAt is when the satellite vetted the node, nil while the node is
+	// still new and receives only a small share of the uploads.
+	VettedAt *time.Time
+	// VettingAuditCount is the number of audits the satellite requires to
+	// vet a node, zero when the satellite does not report it.
+	VettingAuditCount int64
+
+	// AuditHistory holds the recent audit windows the satellite calculates
+	// the online score from, nil when the satellite does not report it.
+	AuditHistory *nodestatsext.AuditHistory
+
 	UpdatedAt time.Time
 	JoinedAt  time.Time
 }
 
+// Vetting encapsulates the vetting progress of the node on a satellite.
+type Vetting struct {
+	Vetted             bool       `json:"vetted"`
+	VettedAt           *time.Time `json:"vettedAt"`
+	AuditCount         int64      `json:"auditCount"`
+	RequiredAuditCount int64      `json:"requiredAuditCount"`
+}
+
+// Vetting returns the vetting progress of the node on the satellite, nil when
+// the satellite does not report it and whether the node is vetted is unknown.
+func (stats Stats) Vetting() *Vetting {
+	if stats.VettingAuditCount == 0 {
+		return nil
+	}
+	return &Vetting{
+		Vetted:             stats.VettedAt != nil,
+		VettedAt:           stats.VettedAt,
+		AuditCount:         stats.Audit.TotalCount,
+		RequiredAuditCount: stats.VettingAuditCount,
+	}
+}
+
 // Metric encapsulates storagenode reputation metrics.
 type Metric struct {
 	TotalCount   int64 `json:"totalCount"`
@@ -4,15 +4,19 @@
 package reputation_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
 
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
+	"storj.io/storj/private/nodestatsext"
 	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/notifications"
 	"storj.io/storj/storagenode/reputation"
 	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
 )
@@ -46,8 +50,17 @@ func TestReputationDBGetInsert(t *testing.T) {
 			OfflineSuspendedAt:   &timestamp,
 			DisqualifiedAt:       &timestamp,
 			SuspendedAt:          &timestamp,
-			UpdatedAt:            timestamp,
-			JoinedAt:             timestamp,
+			VettedAt:             &timestamp,
+			VettingAuditCount:    15,
+			AuditHistory: &nodestatsext.AuditHistory{
+				Windows: []nodestatsext.AuditWindow{
+					{WindowStart: timestamp.Add(-time.Hour), TotalCount: 3, OnlineCount: 2},
+					{WindowStart: timestamp, TotalCount: 1, OnlineCount: 1},
+				},
+				Score: 0.6,
+			},
+			UpdatedAt: timestamp,
+			JoinedAt:  timestamp,
 		}
 
 		t.Run("insert", func(t *testing.T) {
@@ -66,11 +79,38 @@ func TestReputationDBGetInsert(t *testing.T) {
 			assert.True(t, res.JoinedAt.Equal(stats.JoinedAt))
 			assert.True(t, res.OfflineSuspendedAt.Equal(*stats.OfflineSuspendedAt))
 			assert.True(t, res.OfflineUnderReviewAt.Equal(*stats.OfflineUnderReviewAt))
+			assert.True(t, res.VettedAt.Equal(*stats.VettedAt))
+			assert.Equal(t, res.VettingAuditCount, stats.VettingAuditCount)
+			require.NotNil(t, res.AuditHistory)
+			assert.Equal(t, res.AuditHistory.Score, stats.AuditHistory.Score)
+			require.Len(t, res.AuditHistory.Windows, 2)
+			for i, window := range stats.AuditHistory.Windows {
+				assert.True(t, res.AuditHistory.Windows[i].WindowStart.Equal(window.WindowStart))
+				assert.Equal(t, res.AuditHistory.Windows[i].TotalCount, window.TotalCount)
+				assert.Equal(t, res.AuditHistory.Windows[i].OnlineCount, window.OnlineCount)
+			}
 			assert.Equal(t, res.OnlineScore, stats.OnlineScore)
 
 			compareReputationMetric(t, &res.Uptime, &stats.Uptime)
 			compareReputationMetric(t, &res.Audit, &stats.Audit)
 		})
+
+		t.Run("update without audit history", func(t *testing.T) {
+			update := stats
+			update.OnlineScore = 16
+			update.AuditHistory = nil
+
+			err := reputationDB.Store(ctx, update)
+			require.NoError(t, err)
+
+			res, err := reputationDB.Get(ctx, stats.SatelliteID)
+			require.NoError(t, err)
+
+			assert.Equal(t, update.OnlineScore, res.OnlineScore)
+			require.NotNil(t, res.AuditHistory)
+			assert.Equal(t, stats.AuditHistory.Score, res.AuditHistory.Score)
+			assert.Len(t, res.AuditHistory.Windows, 2)
+		})
 	})
 }
 
@@ -149,3 +189,85 @@ func compareReputationMetric(t *testing.T, a, b *reputation.Metric) {
 	assert.Equal(t, a.Beta, b.Beta)
 	assert.Equal(t, a.Score, b.Score)
 }
+
+func TestServiceStoreNotifications(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		sender := &recordingSender{}
+		service := reputation.NewService(zaptest.NewLogger(t), db.Reputation(),
+			notifications.NewService(zaptest.NewLogger(t), db.Notifications(), sender))
+
+		satelliteID := testrand.NodeID()
+		timestamp := time.Now().UTC()
+
+		// failed audits before the stats were first stored aren't reported.
+		stats := reputation.Stats{
+			SatelliteID: satelliteID,
+			Audit:       reputation.Metric{TotalCount: 10, SuccessCount: 8},
+			UpdatedAt:   timestamp,
+		}
+		require.NoError(t, service.Store(ctx, stats, satelliteID))
+		require.Empty(t, sender.types())
+
+		stats.Audit = reputation.Metric{TotalCount: 12, SuccessCount: 9}
+		stats.SuspendedAt = &timestamp
+		require.NoError(t, service.Store(ctx, stats, satelliteID))
+		require.Equal(t, []notifications.Type{notifications.TypeSuspension, notifications.TypeAuditCheckFailure}, sender.types())
+		require.Equal(t, satelliteID, sender.sent[0].SenderID)
+		require.Contains(t, sender.sent[1].Message, "failed 1 audits")
+
+		// unchanged stats don't notify again.
+		require.NoError(t, service.Store(ctx, stats, satelliteID))
+		require.Len(t, sender.sent, 2)
+
+		// offline suspension is only notified when it starts.
+		stats.OfflineSuspendedAt = &timestamp
+		require.NoError(t, service.Store(ctx, stats, satelliteID))
+		require.NoError(t, service.Store(ctx, stats, satelliteID))
+		require.Len(t, sender.sent, 3)
+		require.Equal(t, notifications.TypeSuspension, sender.sent[2].Type)
+		require.Equal(t, satelliteID, sender.sent[2].SenderID)
+		require.Contains(t, sender.sent[2].Message, "is suspended")
+
+		stats.DisqualifiedAt = &timestamp
+		require.NoError(t, service.Store(ctx, stats, satelliteID))
+		require.Equal(t, notifications.TypeDisqualification, sender.sent[3].Type)
+		require.Len(t, sender.sent, 4)
+	})
+}
+
+// recordingSender records the notifications it's asked to send.
+type recordingSender struct {
+	sent []notifications.Notification
+}
+
+func (sender *recordingSender) Send(ctx context.Context, notification notifications.Notification) {
+	sender.sent = append(sender.sent, notification)
+}
+
+func (sender *recordingSender) types() []notifications.Type {
+	var types []notifications.Type
+	for _, notification := range sender.sent {
+		types = append(types, notification.Type)
+	}
+	return types
+}
+
+func TestStatsVetting(t *testing.T) {
+	// satellites which don't report vetting leave it unknown.
+	require.Nil(t, reputation.Stats{Audit: reputation.Metric{TotalCount: 150}}.Vetting())
+
+	vettedAt := time.Now()
+	vetting := reputation.Stats{
+		Audit:             reputation.Metric{TotalCount: 150},
+		VettedAt:          &vettedAt,
+		VettingAuditCount: 100,
+	}.Vetting()
+	require.NotNil(t, vetting)
+	assert.True(t, vetting.Vetted)
+	assert.Equal(t, int64(150), vetting.AuditCount)
+	assert.Equal(t, int64(100), vetting.RequiredAuditCount)
+
+	vetting = reputation.Stats{Audit: reputation.Metric{TotalCount: 20}, VettingAuditCount: 100}.Vetting()
+	require.NotNil(t, vetting)
+	assert.False(t, vetting.Vetted)
+}
@@ -5,6 +5,7 @@ package reputation
 
 import (
 	"context"
+	"strconv"
 
 	"go.uber.org/zap"
 
@@ -19,49 +20,107 @@ type Service struct {
 	log *zap.Logger
 
 	db            DB
-	nodeID        storj.NodeID
 	notifications *notifications.Service
 }
 
 // NewService creates new instance of service.
-func NewService(log *zap.Logger, db DB, nodeID storj.NodeID, notifications *notifications.Service) *Service {
+func NewService(log *zap.Logger, db DB, notifications *notifications.Service) *Service {
 	return &Service{
 		log:           log,
 		db:            db,
-		nodeID:        nodeID,
 		notifications: notifications,
 	}
 }
 
-// Store stores reputation stats into db, and notify's in case of offline suspension.
+// Store stores reputation stats into db and notifies about disqualification,
+// suspension and failed audits since the previously stored stats.
 func (s *Service) Store(ctx context.Context, stats Stats, satelliteID storj.NodeID) error {
-	if err := s.db.Store(ctx, stats); err != nil {
+	previous, err := s.db.Get(ctx, satelliteID)
+	if err != nil {
 		return err
 	}
 
-	if stats.DisqualifiedAt == nil && stats.OfflineSuspendedAt != nil {
-		s.notifyOfflineSuspension(ctx, satelliteID)
+	if err := s.db.Store(ctx, stats); err != nil {
+		return err
 	}
 
+	s.notifyTransitions(ctx, *previous, stats, satelliteID)
+
 	return nil
 }
 
-// NotifyOfflineSuspension notifies storagenode about offline suspension.
-func (s *Service) notifyOfflineSuspension(ctx context.Context, satelliteID storj.NodeID) {
-	notification := NewSuspensionNotification(satelliteID, s.nodeID)
+// notifyTransitions notifies storagenode about changes between the
+// previously stored and the new stats.
+func (s *Service) notifyTransitions(ctx context.Context, previous, stats Stats, satelliteID storj.NodeID) {
+	var pending []notifications.NewNotification
 
-	_, err := s.notifications.Receive(ctx, notification)
-	if err != nil {
-		s.log.Sugar().Errorf("Failed to receive notification", err.Error())
+	if previous.DisqualifiedAt == nil && stats.DisqualifiedAt != nil {
+		pending = append(pending, NewDisqualificationNotification(satelliteID))
+	}
+	if stats.DisqualifiedAt == nil && previous.SuspendedAt == nil && stats.SuspendedAt != nil {
+		pending = append(pending, NewAuditSuspensionNotification(satelliteID))
+	}
+	if stats.DisqualifiedAt == nil && previous.OfflineSuspendedAt == nil && stats.OfflineSuspendedAt != nil {
+		pending = append(pending, NewSuspensionNotification(satelliteID))
+	}
+
+	// failed audits are only counted against stats stored before, so that
+	// the audits failed before they were first stored aren't reported.
+	if !previous.UpdatedAt.IsZero() {
+		failed := (stats.Audit.TotalCount - stats.Audit.SuccessCount) -
+			(previous.Audit.TotalCount - previous.Audit.SuccessCount)
+		if failed > 0 {
+			pending = append(pending, NewAuditFailureNotification(satelliteID, failed))
+		}
+	}
+
+	for _, notification := range pending {
+		if _, err := s.notifications.Receive(ctx, notification); err != nil {
+			s.log.Error("Failed to receive notification", zap.Stringer("Type", notification.Type), zap.Error(err))
+		}
 	}
 }
 
 // NewSuspensionNotification - returns offline suspension notification.
-func NewSuspensionNotification(satelliteID storj.NodeID, senderID storj.NodeID) (_ notifications.NewNotification) {
+func NewSuspensionNotification(satelliteID storj.NodeID) (_ notifications.NewNotification) {
 	return notifications.NewNotification{
-		SenderID: senderID,
-		Type:     notifications.TypeCustom,
+		SenderID: satelliteID,
+		Type:     notifications.TypeSuspension,
 		Title:    "Your Node was suspended!",
 		Message:  "This is a reminder that your Storage Node on " + satelliteID.String() + "Satellite is suspended",
 	}
 }
+
+// NewDisqualificationNotification returns the notification sent when the
+// node was disqualified by the satellite.
+func NewDisqualificationNotification(satelliteID storj.NodeID) notifications.NewNotification {
+	return notifications.NewNotification{
+		SenderID: satelliteID,
+		Type:     notifications.TypeDisqualification,
+		Title:    "Your Node was disqualified!",
+		Message:  "Your Storage Node was disqualified on satellite " + satelliteID.String() + " and no longer receives data from it.",
+	}
+}
+
+// NewAuditSuspensionNotification returns the notification sent when the
+// node was suspended by the satellite for failing audits with unknown errors.
+func NewAuditSuspensionNotification(satelliteID storj.NodeID) notifications.NewNotification {
+	return notifications.NewNotification{
+		SenderID: satelliteID,
+		Type:     notifications.TypeSuspension,
+		Title:    "Your Node was suspended for audit errors!",
+		Message: "Your Storage Node was suspended on satellite " + satelliteID.String() +
+			" because too many audits failed with unknown errors.",
+	}
+}
+
+// NewAuditFailureNotification returns the notification sent when the node
+// failed audits of the satellite.
+func NewAuditFailureNotification(satelliteID storj.NodeID, failed int64) notifications.NewNotification {
+	return notifications.NewNotification{
+		SenderID: satelliteID,
+		Type:     notifications.TypeAuditCheckFailure,
+		Title:    "Your Node failed audits",
+		Message:  "Your Storage Node failed " + strconv.FormatInt(failed, 10) + " audits of satellite " + satelliteID.String() + ".",
+	}
+}
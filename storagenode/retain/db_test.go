@@ -0,0 +1,96 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package retain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/retain"
+	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
+)
+
+func TestReportsDB(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reports := db.RetainReports()
+
+		satelliteID := testrand.NodeID()
+		now := time.Now().UTC()
+
+		old := retain.Report{
+			ID:                testrand.UUID(),
+			SatelliteID:       satelliteID,
+			Status:            retain.Enabled,
+			CreatedBefore:     now.Add(-48 * time.Hour),
+			FilterSize:        1024,
+			FalsePositiveRate: 0.1,
+			ReceivedAt:        now.Add(-time.Hour),
+		}
+		recent := retain.Report{
+			ID:                testrand.UUID(),
+			SatelliteID:       testrand.NodeID(),
+			Status:            retain.Debug,
+			CreatedBefore:     now.Add(-24 * time.Hour),
+			FilterSize:        2048,
+			FalsePositiveRate: 0.05,
+			ReceivedAt:        now,
+		}
+
+		require.NoError(t, reports.Insert(ctx, old))
+		require.NoError(t, reports.Insert(ctx, recent))
+
+		completedAt := now.Add(time.Minute)
+		old.PiecesChecked = 10
+		old.PiecesToTrash = 3
+		old.BytesToTrash = 300
+		old.PiecesTrashed = 2
+		old.BytesTrashed = 200
+		old.Error = "context canceled"
+		old.CompletedAt = &completedAt
+		require.NoError(t, reports.Update(ctx, old))
+
+		err := reports.Update(ctx, retain.Report{ID: testrand.UUID()})
+		require.Error(t, err)
+
+		list, err := reports.List(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		require.Equal(t, recent.ID, list[0].ID)
+		require.Equal(t, old.ID, list[1].ID)
+
+		list, err = reports.List(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+
+		list, err = reports.ListBySatellite(ctx, satelliteID, 10)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+
+		got := list[0]
+		require.Equal(t, old.Status, got.Status)
+		require.Equal(t, old.FilterSize, got.FilterSize)
+		require.Equal(t, old.FalsePositiveRate, got.FalsePositiveRate)
+		require.Equal(t, old.PiecesChecked, got.PiecesChecked)
+		require.Equal(t, old.PiecesToTrash, got.PiecesToTrash)
+		require.Equal(t, old.BytesToTrash, got.BytesToTrash)
+		require.Equal(t, old.PiecesTrashed, got.PiecesTrashed)
+		require.Equal(t, old.BytesTrashed, got.BytesTrashed)
+		require.Equal(t, old.Error, got.Error)
+		require.True(t, old.CreatedBefore.Equal(got.CreatedBefore))
+		require.NotNil(t, got.CompletedAt)
+		require.True(t, completedAt.Equal(*got.CompletedAt))
+
+		require.NoError(t, reports.DeleteBefore(ctx, now.Add(-time.Minute)))
+
+		list, err = reports.List(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		require.Equal(t, recent.ID, list[0].ID)
+	})
+}
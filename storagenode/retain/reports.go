@@ -0,0 +1,80 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package retain
+
+import (
+	"context"
+	"math"
+	"math/bits"
+	"time"
+
+	"storj.io/common/bloomfilter"
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+)
+
+// DB works with the history of retain requests received from satellites.
+//
+// architecture: Database
+type DB interface {
+	// Insert stores a report for a newly received retain request.
+	Insert(ctx context.Context, report Report) error
+	// Update updates the counters and completion state of an existing report.
+	Update(ctx context.Context, report Report) error
+	// List returns the latest reports, newest first, limited to limit items.
+	List(ctx context.Context, limit int) ([]Report, error)
+	// ListBySatellite returns the latest reports for a satellite, newest first, limited to limit items.
+	ListBySatellite(ctx context.Context, satelliteID storj.NodeID, limit int) ([]Report, error)
+	// DeleteBefore removes all reports received before the given time.
+	DeleteBefore(ctx context.Context, before time.Time) error
+}
+
+// Report describes a received retain request and the outcome of processing it.
+type Report struct {
+	ID          uuid.UUID    `json:"id"`
+	SatelliteID storj.NodeID `json:"satelliteId"`
+	// Status is the retain status the node was configured with when the
+	// request was processed. In debug mode pieces are counted but not trashed.
+	Status Status `json:"status"`
+
+	CreatedBefore     time.Time `json:"createdBefore"`
+	FilterSize        int64     `json:"filterSize"`
+	FalsePositiveRate float64   `json:"falsePositiveRate"`
+
+	// PiecesChecked is the number of pieces that were old enough to be checked against the filter.
+	PiecesChecked int64 `json:"piecesChecked"`
+	// PiecesToTrash and BytesToTrash count pieces which are not in the filter.
+	PiecesToTrash int64 `json:"piecesToTrash"`
+	BytesToTrash  int64 `json:"bytesToTrash"`
+	// PiecesTrashed and BytesTrashed count pieces which were actually moved to trash.
+	PiecesTrashed int64 `json:"piecesTrashed"`
+	BytesTrashed  int64 `json:"bytesTrashed"`
+
+	Error       string     `json:"error"`
+	ReceivedAt  time.Time  `json:"receivedAt"`
+	CompletedAt *time.Time `json:"completedAt"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v Status) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + v.String() + `"`), nil
+}
+
+// EstimateFalsePositiveRate estimates the false positive rate of a bloom
+// filter from the share of bits which are set in its table.
+func EstimateFalsePositiveRate(filter *bloomfilter.Filter) float64 {
+	data := filter.Bytes()
+	if len(data) <= 3 {
+		return 1
+	}
+	hashCount, table := int(data[2]), data[3:]
+
+	var set int
+	for _, b := range table {
+		set += bits.OnesCount8(b)
+	}
+
+	fill := float64(set) / float64(len(table)*8)
+	return math.Pow(fill, float64(hashCount))
+}
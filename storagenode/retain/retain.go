@@ -16,6 +16,7 @@ import (
 
 	"storj.io/common/bloomfilter"
 	"storj.io/common/storj"
+	"storj.io/common/uuid"
 	"storj.io/storj/storagenode/pieces"
 )
 
@@ -28,9 +29,10 @@ var (
 
 // Config defines parameters for the retain service.
 type Config struct {
-	MaxTimeSkew time.Duration `help:"allows for small differences in the satellite and storagenode clocks" default:"72h0m0s"`
-	Status      Status        `help:"allows configuration to enable, disable, or test retain requests from the satellite. Options: (disabled/enabled/debug)" default:"enabled"`
-	Concurrency int           `help:"how many concurrent retain requests can be processed at the same time." default:"5"`
+	MaxTimeSkew     time.Duration `help:"allows for small differences in the satellite and storagenode clocks" default:"72h0m0s"`
+	Status          Status        `help:"allows configuration to enable, disable, or test retain requests from the satellite. Options: (disabled/enabled/debug)" default:"enabled"`
+	Concurrency     int           `help:"how many concurrent retain requests can be processed at the same time." default:"5"`
+	ReportRetention time.Duration `help:"how long reports about received retain requests are kept" default:"2160h0m0s"`
 }
 
 // Request contains all the info necessary to process a retain request.
@@ -49,6 +51,7 @@ const (
 	// Enabled means we fully enable retain requests and delete data not defined by bloom filter.
 	Enabled
 	// Debug means we partially enable retain requests, and print out pieces we should delete, without actually deleting them.
+	// A report of what would have been moved to trash is still recorded.
 	Debug
 )
 
@@ -100,11 +103,12 @@ type Service struct {
 	closed     chan struct{}
 	started    bool
 
-	store *pieces.Store
+	store   *pieces.Store
+	reports DB
 }
 
 // NewService creates a new retain service.
-func NewService(log *zap.Logger, store *pieces.Store, config Config) *Service {
+func NewService(log *zap.Logger, store *pieces.Store, reports DB, config Config) *Service {
 	return &Service{
 		log:    log,
 		config: config,
@@ -114,7 +118,8 @@ func NewService(log *zap.Logger, store *pieces.Store, config Config) *Service {
 		working: make(map[storj.NodeID]struct{}),
 		closed:  make(chan struct{}),
 
-		store: store,
+		store:   store,
+		reports: reports,
 	}
 }
 
@@ -295,6 +300,67 @@ func (s *Service) Status() Status {
 	return s.config.Status
 }
 
+// Reports returns the latest reports about processed retain requests, newest first.
+// When satelliteID is zero, reports for all satellites are returned.
+func (s *Service) Reports(ctx context.Context, satelliteID storj.NodeID, limit int) (_ []Report, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if satelliteID.IsZero() {
+		reports, err := s.reports.List(ctx, limit)
+		return reports, Error.Wrap(err)
+	}
+
+	reports, err := s.reports.ListBySatellite(ctx, satelliteID, limit)
+	return reports, Error.Wrap(err)
+}
+
+// startReport stores a report for the request which is about to be processed.
+func (s *Service) startReport(ctx context.Context, req Request, createdBefore time.Time) (Report, error) {
+	id, err := uuid.New()
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{
+		ID:                id,
+		SatelliteID:       req.SatelliteID,
+		Status:            s.config.Status,
+		CreatedBefore:     createdBefore,
+		FilterSize:        req.Filter.Size(),
+		FalsePositiveRate: EstimateFalsePositiveRate(req.Filter),
+		ReceivedAt:        time.Now().UTC(),
+	}
+
+	if err := s.reports.Insert(ctx, report); err != nil {
+		return Report{}, err
+	}
+
+	if s.config.ReportRetention > 0 {
+		if err := s.reports.DeleteBefore(ctx, report.ReceivedAt.Add(-s.config.ReportRetention)); err != nil {
+			s.log.Warn("failed to delete old retain reports", zap.Error(err))
+		}
+	}
+
+	return report, nil
+}
+
+// finishReport stores the final counters of the report.
+func (s *Service) finishReport(ctx context.Context, report Report, retainErr error) {
+	completedAt := time.Now().UTC()
+	report.CompletedAt = &completedAt
+	if retainErr != nil {
+		report.Error = retainErr.Error()
+	}
+
+	// the request context might be already canceled, but we still want to
+	// record how far we got.
+	if err := s.reports.Update(context.Background(), report); err != nil {
+		s.log.Warn("failed to update retain report",
+			zap.Stringer("Satellite ID", report.SatelliteID),
+			zap.Error(err))
+	}
+}
+
 // ------------------------------------------------------------------------------------------------
 // On the correctness of using access.ModTime() in place of the more precise access.CreationTime()
 // in retainPieces():
@@ -356,6 +422,12 @@ func (s *Service) retainPieces(ctx context.Context, req Request) (err error) {
 	// subtract some time to leave room for clock difference between the satellite and storage node
 	createdBefore := req.CreatedBefore.Add(-s.config.MaxTimeSkew)
 
+	report, err := s.startReport(ctx, req, createdBefore)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() { s.finishReport(ctx, report, err) }()
+
 	s.log.Debug("Prepared to run a Retain request.",
 		zap.Time("Created Before", createdBefore),
 		zap.Int64("Filter Size", filter.Size()),
@@ -376,6 +448,7 @@ func (s *Service) retainPieces(ctx context.Context, req Request) (err error) {
 		if !mTime.Before(createdBefore) {
 			return nil
 		}
+		report.PiecesChecked++
 		pieceID := access.PieceID()
 		if !filter.Contains(pieceID) {
 			s.log.Debug("About to move piece to trash",
@@ -383,6 +456,13 @@ func (s *Service) retainPieces(ctx context.Context, req Request) (err error) {
 				zap.Stringer("Piece ID", pieceID),
 				zap.String("Status", s.config.Status.String()))
 
+			size, _, err := access.Size(ctx)
+			if err != nil {
+				s.log.Warn("failed to determine size of blob", zap.Error(err))
+			}
+			report.PiecesToTrash++
+			report.BytesToTrash += size
+
 			// if retain status is enabled, delete pieceid
 			if s.config.Status == Enabled {
 				if err = s.store.Trash(ctx, satelliteID, pieceID); err != nil {
@@ -392,6 +472,8 @@ func (s *Service) retainPieces(ctx context.Context, req Request) (err error) {
 						zap.Error(err))
 					return nil
 				}
+				report.PiecesTrashed++
+				report.BytesTrashed += size
 			}
 			numDeleted++
 		}
@@ -104,19 +104,19 @@ func TestRetainPieces(t *testing.T) {
 			}
 		}
 
-		retainEnabled := retain.NewService(zaptest.NewLogger(t), store, retain.Config{
+		retainEnabled := retain.NewService(zaptest.NewLogger(t), store, db.RetainReports(), retain.Config{
 			Status:      retain.Enabled,
 			Concurrency: 1,
 			MaxTimeSkew: 0,
 		})
 
-		retainDisabled := retain.NewService(zaptest.NewLogger(t), store, retain.Config{
+		retainDisabled := retain.NewService(zaptest.NewLogger(t), store, db.RetainReports(), retain.Config{
 			Status:      retain.Disabled,
 			Concurrency: 1,
 			MaxTimeSkew: 0,
 		})
 
-		retainDebug := retain.NewService(zaptest.NewLogger(t), store, retain.Config{
+		retainDebug := retain.NewService(zaptest.NewLogger(t), store, db.RetainReports(), retain.Config{
 			Status:      retain.Debug,
 			Concurrency: 1,
 			MaxTimeSkew: 0,
@@ -155,6 +155,19 @@ func TestRetainPieces(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, numPieces, len(satellite1Pieces))
 
+		// expect that only debug mode recorded a report, counting pieces it would trash
+		reports, err := retainDebug.Reports(ctx, satellite0.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		require.Equal(t, retain.Debug, reports[0].Status)
+		require.Equal(t, filter.Size(), reports[0].FilterSize)
+		require.EqualValues(t, numPiecesToKeep+numOldPieces, reports[0].PiecesChecked)
+		require.EqualValues(t, numOldPieces, reports[0].PiecesToTrash)
+		require.NotZero(t, reports[0].BytesToTrash)
+		require.Zero(t, reports[0].PiecesTrashed)
+		require.Zero(t, reports[0].BytesTrashed)
+		require.NotNil(t, reports[0].CompletedAt)
+
 		satellite0Pieces, err := getAllPieceIDs(ctx, store, satellite0.ID)
 		require.NoError(t, err)
 		require.Equal(t, numPieces, len(satellite0Pieces))
@@ -187,6 +200,14 @@ func TestRetainPieces(t *testing.T) {
 			require.NotContains(t, satellite0Pieces, id, "piece should have been deleted")
 		}
 
+		// expect that enabled mode recorded what was moved to trash
+		reports, err = retainEnabled.Reports(ctx, storj.NodeID{}, 10)
+		require.NoError(t, err)
+		require.Len(t, reports, 2)
+		require.Equal(t, retain.Enabled, reports[0].Status)
+		require.EqualValues(t, numOldPieces, reports[0].PiecesTrashed)
+		require.Equal(t, reports[0].BytesToTrash, reports[0].BytesTrashed)
+
 		// shut down retain services
 		cancel()
 		err = group.Wait()
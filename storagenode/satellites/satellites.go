@@ -37,6 +37,31 @@ type ExitProgress struct {
 	Status            int32
 }
 
+// TransferSucceeded is the reason recorded for pieces transferred successfully
+// during a graceful exit.
+const TransferSucceeded = "succeeded"
+
+// Transfer is the outcome of transferring a piece during a graceful exit.
+type Transfer struct {
+	PieceID storj.PieceID
+	// Reason is TransferSucceeded or the reason the transfer failed.
+	Reason     string
+	Bytes      int64
+	Duration   time.Duration
+	Error      string
+	FinishedAt time.Time
+}
+
+// TransferStats aggregates the piece transfers of a graceful exit with the same reason.
+type TransferStats struct {
+	Reason    string
+	Pieces    int64
+	Bytes     int64
+	Duration  time.Duration
+	LastError string
+	LastAt    time.Time
+}
+
 // Satellite contains the satellite and status.
 type Satellite struct {
 	SatelliteID storj.NodeID
@@ -60,4 +85,10 @@ type DB interface {
 	CompleteGracefulExit(ctx context.Context, satelliteID storj.NodeID, finishedAt time.Time, exitStatus Status, completionReceipt []byte) error
 	// ListGracefulExits lists all graceful exit records
 	ListGracefulExits(ctx context.Context) ([]ExitProgress, error)
+	// RecordTransfer adds the outcome of a piece transfer to the graceful exit statistics
+	RecordTransfer(ctx context.Context, satelliteID storj.NodeID, transfer Transfer) error
+	// GetTransferStats returns the graceful exit statistics by reason
+	GetTransferStats(ctx context.Context, satelliteID storj.NodeID) ([]TransferStats, error)
+	// ListTransferFailures returns the latest failed piece transfers of a graceful exit
+	ListTransferFailures(ctx context.Context, satelliteID storj.NodeID, limit int) ([]Transfer, error)
 }
@@ -0,0 +1,248 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package storagemigration implements moving pieces of a storage node from
+// one blob storage to another while the node keeps running.
+package storagemigration
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/storj/storage"
+)
+
+var (
+	// Error is the default error class for storage migration.
+	Error = errs.Class("storage migration")
+
+	mon = monkit.Package()
+)
+
+// ensures that Blobs implements storage.Blobs interface.
+var _ storage.Blobs = (*Blobs)(nil)
+
+// Blobs is a blob storage which is being migrated from one location to
+// another.
+//
+// New blobs are always created in the destination. Blobs are read from the
+// destination when they have already been migrated and from the source
+// otherwise, so reads switch to the destination piece by piece as the
+// migration goes. Deletions and trash operations are applied to both.
+//
+// architecture: Database
+type Blobs struct {
+	from storage.Blobs
+	to   storage.Blobs
+}
+
+// NewBlobs returns a blob storage which migrates blobs from one storage to another.
+func NewBlobs(from, to storage.Blobs) *Blobs {
+	return &Blobs{
+		from: from,
+		to:   to,
+	}
+}
+
+// Create creates a new blob in the destination.
+func (blobs *Blobs) Create(ctx context.Context, ref storage.BlobRef, size int64) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return blobs.to.Create(ctx, ref, size)
+}
+
+// Open opens a reader for the blob, preferring the destination.
+func (blobs *Blobs) Open(ctx context.Context, ref storage.BlobRef) (_ storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+	reader, err := blobs.to.Open(ctx, ref)
+	if errs.IsFunc(err, os.IsNotExist) {
+		return blobs.from.Open(ctx, ref)
+	}
+	return reader, err
+}
+
+// OpenWithStorageFormat opens a reader for the already-located blob, preferring the destination.
+func (blobs *Blobs) OpenWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (_ storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+	reader, err := blobs.to.OpenWithStorageFormat(ctx, ref, formatVer)
+	if errs.IsFunc(err, os.IsNotExist) {
+		return blobs.from.OpenWithStorageFormat(ctx, ref, formatVer)
+	}
+	return reader, err
+}
+
+// Delete deletes the blob from both locations.
+func (blobs *Blobs) Delete(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return errs.Combine(blobs.to.Delete(ctx, ref), blobs.from.Delete(ctx, ref))
+}
+
+// DeleteWithStorageFormat deletes the blob with the given storage format from both locations.
+func (blobs *Blobs) DeleteWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return errs.Combine(
+		blobs.to.DeleteWithStorageFormat(ctx, ref, formatVer),
+		blobs.from.DeleteWithStorageFormat(ctx, ref, formatVer),
+	)
+}
+
+// DeleteNamespace deletes the blobs folder of the namespace in both locations.
+func (blobs *Blobs) DeleteNamespace(ctx context.Context, ref []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return errs.Combine(blobs.to.DeleteNamespace(ctx, ref), blobs.from.DeleteNamespace(ctx, ref))
+}
+
+// Trash moves the blob to the trash of the location, or locations, it's stored in.
+func (blobs *Blobs) Trash(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return errs.Combine(blobs.to.Trash(ctx, ref), blobs.from.Trash(ctx, ref))
+}
+
+// RestoreTrash restores the trash of the namespace in both locations.
+func (blobs *Blobs) RestoreTrash(ctx context.Context, namespace []byte) (_ [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	toKeys, toErr := blobs.to.RestoreTrash(ctx, namespace)
+	fromKeys, fromErr := blobs.from.RestoreTrash(ctx, namespace)
+	return append(toKeys, fromKeys...), errs.Combine(toErr, fromErr)
+}
+
+// EmptyTrash empties the trash of the namespace in both locations.
+func (blobs *Blobs) EmptyTrash(ctx context.Context, namespace []byte, trashedBefore time.Time) (_ int64, _ [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	toBytes, toKeys, toErr := blobs.to.EmptyTrash(ctx, namespace, trashedBefore)
+	fromBytes, fromKeys, fromErr := blobs.from.EmptyTrash(ctx, namespace, trashedBefore)
+	return toBytes + fromBytes, append(toKeys, fromKeys...), errs.Combine(toErr, fromErr)
+}
+
+// Stat looks up disk metadata on the blob file, preferring the destination.
+func (blobs *Blobs) Stat(ctx context.Context, ref storage.BlobRef) (_ storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	info, err := blobs.to.Stat(ctx, ref)
+	if errs.IsFunc(err, os.IsNotExist) {
+		return blobs.from.Stat(ctx, ref)
+	}
+	return info, err
+}
+
+// StatWithStorageFormat looks up disk metadata for the blob file with the given storage format
+// version, preferring the destination.
+func (blobs *Blobs) StatWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (_ storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	info, err := blobs.to.StatWithStorageFormat(ctx, ref, formatVer)
+	if errs.IsFunc(err, os.IsNotExist) {
+		return blobs.from.StatWithStorageFormat(ctx, ref, formatVer)
+	}
+	return info, err
+}
+
+// FreeSpace returns how much space is available in the destination.
+func (blobs *Blobs) FreeSpace() (int64, error) {
+	return blobs.to.FreeSpace()
+}
+
+// CheckWritability tests writability of the destination.
+func (blobs *Blobs) CheckWritability() error {
+	return blobs.to.CheckWritability()
+}
+
+// SpaceUsedForTrash returns the total space used by the trash in both locations.
+func (blobs *Blobs) SpaceUsedForTrash(ctx context.Context) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	toTotal, toErr := blobs.to.SpaceUsedForTrash(ctx)
+	fromTotal, fromErr := blobs.from.SpaceUsedForTrash(ctx)
+	return toTotal + fromTotal, errs.Combine(toErr, fromErr)
+}
+
+// SpaceUsedForTrashInNamespace returns the space used by the trash of the namespace in both locations.
+func (blobs *Blobs) SpaceUsedForTrashInNamespace(ctx context.Context, namespace []byte) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	toTotal, toErr := blobs.to.SpaceUsedForTrashInNamespace(ctx, namespace)
+	fromTotal, fromErr := blobs.from.SpaceUsedForTrashInNamespace(ctx, namespace)
+	return toTotal + fromTotal, errs.Combine(toErr, fromErr)
+}
+
+// SpaceUsedForBlobs adds up how much is used in all namespaces of both locations.
+//
+// Blobs which have been copied, but not yet deleted from the source, are counted twice.
+func (blobs *Blobs) SpaceUsedForBlobs(ctx context.Context) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	toTotal, toErr := blobs.to.SpaceUsedForBlobs(ctx)
+	fromTotal, fromErr := blobs.from.SpaceUsedForBlobs(ctx)
+	return toTotal + fromTotal, errs.Combine(toErr, fromErr)
+}
+
+// SpaceUsedForBlobsInNamespace adds up how much is used in the namespace of both locations.
+//
+// Blobs which have been copied, but not yet deleted from the source, are counted twice.
+func (blobs *Blobs) SpaceUsedForBlobsInNamespace(ctx context.Context, namespace []byte) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	toTotal, toErr := blobs.to.SpaceUsedForBlobsInNamespace(ctx, namespace)
+	fromTotal, fromErr := blobs.from.SpaceUsedForBlobsInNamespace(ctx, namespace)
+	return toTotal + fromTotal, errs.Combine(toErr, fromErr)
+}
+
+// ListNamespaces finds all namespaces in which keys might currently be stored in either location.
+func (blobs *Blobs) ListNamespaces(ctx context.Context) (_ [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	toNamespaces, err := blobs.to.ListNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fromNamespaces, err := blobs.from.ListNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(toNamespaces))
+	namespaces := make([][]byte, 0, len(toNamespaces)+len(fromNamespaces))
+	for _, namespace := range append(toNamespaces, fromNamespaces...) {
+		if _, ok := seen[string(namespace)]; ok {
+			continue
+		}
+		seen[string(namespace)] = struct{}{}
+		namespaces = append(namespaces, namespace)
+	}
+	return namespaces, nil
+}
+
+// WalkNamespace executes walkFunc for each blob in the namespace. Blobs which
+// exist in both locations are only visited once, from the destination.
+func (blobs *Blobs) WalkNamespace(ctx context.Context, namespace []byte, walkFunc func(storage.BlobInfo) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = blobs.to.WalkNamespace(ctx, namespace, walkFunc)
+	if err != nil {
+		return err
+	}
+
+	return blobs.from.WalkNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+		_, err := blobs.to.StatWithStorageFormat(ctx, info.BlobRef(), info.StorageFormatVersion())
+		if err == nil {
+			return nil
+		}
+		if !errs.IsFunc(err, os.IsNotExist) {
+			return err
+		}
+		return walkFunc(info)
+	})
+}
+
+// CreateVerificationFile creates a file to be used for storage directory verification in the destination.
+func (blobs *Blobs) CreateVerificationFile(id storj.NodeID) error {
+	return blobs.to.CreateVerificationFile(id)
+}
+
+// VerifyStorageDir verifies the destination storage directory.
+func (blobs *Blobs) VerifyStorageDir(id storj.NodeID) error {
+	return blobs.to.VerifyStorageDir(id)
+}
+
+// Close closes both blob storages.
+func (blobs *Blobs) Close() error {
+	return errs.Combine(blobs.to.Close(), blobs.from.Close())
+}
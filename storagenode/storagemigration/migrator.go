@@ -0,0 +1,330 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storagemigration
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/pkcrypto"
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+	"storj.io/storj/storage"
+	"storj.io/storj/storagenode/pieces"
+)
+
+// Config defines parameters for the storage migration.
+type Config struct {
+	Concurrency  int  `help:"how many pieces are migrated concurrently" default:"4"`
+	DeleteSource bool `help:"delete pieces from the source location once they have been migrated and verified" default:"false"`
+}
+
+// Stats contains counters of a storage migration.
+type Stats struct {
+	// Migrated is the number of pieces copied and verified.
+	Migrated int64
+	// Skipped is the number of pieces which already existed and were verified
+	// in the destination, e.g. because they were migrated by a previous,
+	// interrupted run.
+	Skipped int64
+	// Failed is the number of pieces which could not be copied or verified.
+	// They stay in the source and are retried by the next run.
+	Failed int64
+	// ConvertedV0 is the number of pieces stored with storage format V0,
+	// which were converted to V1 while migrating.
+	ConvertedV0 int64
+	// Bytes is the amount of data copied.
+	Bytes int64
+}
+
+// Migrator copies pieces from one blob storage to another.
+//
+// The migration is resumable, pieces which already exist in the destination
+// are verified instead of copied again. While it's running, the storage node should use
+// Blobs over the same locations, so that it reads already migrated pieces
+// from the destination and stores new pieces only there.
+//
+// A piece deleted by the storage node while it's being copied may be
+// recreated in the destination; it's removed by garbage collection later.
+//
+// architecture: Service
+type Migrator struct {
+	log         *zap.Logger
+	from        storage.Blobs
+	to          storage.Blobs
+	v0PieceInfo pieces.V0PieceInfoDB
+	config      Config
+
+	mu    sync.Mutex
+	stats Stats
+}
+
+// NewMigrator creates a new migrator of pieces from one blob storage to
+// another. When v0PieceInfo isn't nil, pieces stored with storage format V0
+// are converted to V1 in the destination.
+func NewMigrator(log *zap.Logger, from, to storage.Blobs, v0PieceInfo pieces.V0PieceInfoDB, config Config) *Migrator {
+	return &Migrator{
+		log:         log,
+		from:        from,
+		to:          to,
+		v0PieceInfo: v0PieceInfo,
+		config:      config,
+	}
+}
+
+// Stats returns the counters of the migration so far.
+func (migrator *Migrator) Stats() Stats {
+	migrator.mu.Lock()
+	defer migrator.mu.Unlock()
+	return migrator.stats
+}
+
+// MigrateVerificationFile creates the storage directory verification file in
+// the destination, after checking the source belongs to the same node.
+func (migrator *Migrator) MigrateVerificationFile(ctx context.Context, id storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := migrator.from.VerifyStorageDir(id); err != nil {
+		return Error.New("source storage directory verification failed: %v", err)
+	}
+	if err := migrator.to.VerifyStorageDir(id); err == nil {
+		return nil
+	}
+	return Error.Wrap(migrator.to.CreateVerificationFile(id))
+}
+
+// Run migrates all pieces of all satellites from the source to the destination.
+func (migrator *Migrator) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	namespaces, err := migrator.from.ListNamespaces(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	for _, namespace := range namespaces {
+		satelliteID, err := storj.NodeIDFromBytes(namespace)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		migrator.log.Info("migrating pieces", zap.Stringer("Satellite ID", satelliteID))
+		if err := migrator.MigrateSatellite(ctx, satelliteID); err != nil {
+			return err
+		}
+	}
+
+	stats := migrator.Stats()
+	migrator.log.Info("migration finished",
+		zap.Int64("migrated", stats.Migrated),
+		zap.Int64("skipped", stats.Skipped),
+		zap.Int64("failed", stats.Failed),
+		zap.Int64("converted v0", stats.ConvertedV0),
+		zap.Int64("bytes", stats.Bytes),
+	)
+	return nil
+}
+
+// MigrateSatellite migrates all pieces of the satellite.
+func (migrator *Migrator) MigrateSatellite(ctx context.Context, satelliteID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if migrator.v0PieceInfo != nil {
+		if err := migrator.convertV0(ctx, satelliteID); err != nil {
+			return err
+		}
+	}
+
+	concurrency := migrator.config.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	limiter := sync2.NewLimiter(concurrency)
+	defer limiter.Wait()
+
+	err = migrator.from.WalkNamespace(ctx, satelliteID.Bytes(), func(info storage.BlobInfo) error {
+		ref, formatVer := info.BlobRef(), info.StorageFormatVersion()
+		pieceID, err := storj.PieceIDFromBytes(ref.Key)
+		if err != nil {
+			return err
+		}
+
+		log := migrator.log.With(zap.Stringer("Satellite ID", satelliteID), zap.Stringer("Piece ID", pieceID))
+		started := limiter.Go(ctx, func() {
+			migrator.migrate(ctx, log, ref, formatVer)
+		})
+		if !started {
+			return ctx.Err()
+		}
+		return nil
+	})
+	return Error.Wrap(err)
+}
+
+// convertV0 converts pieces stored with storage format V0 into V1 pieces in
+// the destination, since blobs can only be created with the latest format.
+func (migrator *Migrator) convertV0(ctx context.Context, satelliteID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	store := pieces.NewStore(migrator.log.Named("pieces"), NewBlobs(migrator.from, migrator.to),
+		migrator.v0PieceInfo, nil, nil, pieces.DefaultConfig)
+
+	var pieceIDs []storj.PieceID
+	err = migrator.v0PieceInfo.WalkSatelliteV0Pieces(ctx, migrator.from, satelliteID, func(access pieces.StoredPieceAccess) error {
+		pieceIDs = append(pieceIDs, access.PieceID())
+		return nil
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	for _, pieceID := range pieceIDs {
+		if err := store.MigrateV0ToV1(ctx, satelliteID, pieceID); err != nil {
+			migrator.log.Error("failed to convert v0 piece",
+				zap.Stringer("Satellite ID", satelliteID), zap.Stringer("Piece ID", pieceID), zap.Error(err))
+			migrator.count(func(stats *Stats) { stats.Failed++ })
+			continue
+		}
+		migrator.count(func(stats *Stats) { stats.ConvertedV0++ })
+	}
+	return nil
+}
+
+// migrate copies a single blob to the destination and verifies it.
+func (migrator *Migrator) migrate(ctx context.Context, log *zap.Logger, ref storage.BlobRef, formatVer storage.FormatVersion) {
+	_, err := migrator.to.StatWithStorageFormat(ctx, ref, formatVer)
+	switch {
+	case err == nil:
+		// the piece may have been copied partially or damaged by a previous
+		// run, so it's verified before the source is deleted.
+		if err := migrator.verify(ctx, ref, formatVer); err != nil {
+			log.Error("failed to verify already migrated piece", zap.Error(err))
+			if err := migrator.to.DeleteWithStorageFormat(ctx, ref, formatVer); err != nil {
+				log.Error("failed to delete unverified piece", zap.Error(err))
+			}
+			migrator.count(func(stats *Stats) { stats.Failed++ })
+			return
+		}
+		migrator.count(func(stats *Stats) { stats.Skipped++ })
+		migrator.deleteSource(ctx, log, ref, formatVer)
+		return
+	case !errs.IsFunc(err, os.IsNotExist):
+		log.Error("failed to check destination", zap.Error(err))
+		migrator.count(func(stats *Stats) { stats.Failed++ })
+		return
+	}
+
+	size, err := migrator.copy(ctx, ref, formatVer)
+	if err != nil {
+		log.Error("failed to copy piece", zap.Error(err))
+		migrator.count(func(stats *Stats) { stats.Failed++ })
+		return
+	}
+
+	if err := migrator.verify(ctx, ref, formatVer); err != nil {
+		log.Error("failed to verify piece", zap.Error(err))
+		if err := migrator.to.DeleteWithStorageFormat(ctx, ref, formatVer); err != nil {
+			log.Error("failed to delete unverified piece", zap.Error(err))
+		}
+		migrator.count(func(stats *Stats) { stats.Failed++ })
+		return
+	}
+
+	migrator.count(func(stats *Stats) {
+		stats.Migrated++
+		stats.Bytes += size
+	})
+	migrator.deleteSource(ctx, log, ref, formatVer)
+}
+
+// copy copies the raw blob, including the piece header, to the destination.
+func (migrator *Migrator) copy(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	reader, err := migrator.from.OpenWithStorageFormat(ctx, ref, formatVer)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = errs.Combine(err, reader.Close()) }()
+
+	size, err := reader.Size()
+	if err != nil {
+		return 0, err
+	}
+
+	writer, err := migrator.to.Create(ctx, ref, size)
+	if err != nil {
+		return 0, err
+	}
+	if writer.StorageFormatVersion() != formatVer {
+		return 0, errs.Combine(
+			Error.New("destination stores format version %d instead of %d", writer.StorageFormatVersion(), formatVer),
+			writer.Cancel(ctx),
+		)
+	}
+
+	copied, err := io.Copy(writer, reader)
+	if err != nil {
+		return 0, errs.Combine(err, writer.Cancel(ctx))
+	}
+	if copied != size {
+		return 0, errs.Combine(Error.New("copied %d bytes instead of %d", copied, size), writer.Cancel(ctx))
+	}
+
+	return copied, writer.Commit(ctx)
+}
+
+// verify checks that the piece content in the destination matches the hash in its header.
+func (migrator *Migrator) verify(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	blob, err := migrator.to.OpenWithStorageFormat(ctx, ref, formatVer)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, blob.Close()) }()
+
+	reader, err := pieces.NewReader(blob)
+	if err != nil {
+		return err
+	}
+
+	header, err := reader.GetPieceHeader()
+	if err != nil {
+		return err
+	}
+
+	hash := pkcrypto.NewHash()
+	if _, err := io.Copy(hash, reader); err != nil {
+		return err
+	}
+
+	if !bytes.Equal(hash.Sum(nil), header.Hash) {
+		return Error.New("piece hash doesn't match the hash in the header")
+	}
+	return nil
+}
+
+// deleteSource deletes the blob from the source when configured to.
+func (migrator *Migrator) deleteSource(ctx context.Context, log *zap.Logger, ref storage.BlobRef, formatVer storage.FormatVersion) {
+	if !migrator.config.DeleteSource {
+		return
+	}
+	if err := migrator.from.DeleteWithStorageFormat(ctx, ref, formatVer); err != nil {
+		log.Error("failed to delete migrated piece from source", zap.Error(err))
+	}
+}
+
+// count updates the stats.
+func (migrator *Migrator) count(update func(stats *Stats)) {
+	migrator.mu.Lock()
+	defer migrator.mu.Unlock()
+	update(&migrator.stats)
+}
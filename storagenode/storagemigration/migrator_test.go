@@ -0,0 +1,169 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storagemigration_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/storagemigration"
+)
+
+func TestMigrator(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+
+	from, err := filestore.NewAt(log, ctx.Dir("from"), filestore.DefaultConfig)
+	require.NoError(t, err)
+	defer ctx.Check(from.Close)
+
+	to, err := filestore.NewAt(log, ctx.Dir("to"), filestore.DefaultConfig)
+	require.NoError(t, err)
+	defer ctx.Check(to.Close)
+
+	nodeID := testrand.NodeID()
+	require.NoError(t, from.CreateVerificationFile(nodeID))
+
+	satelliteID := testrand.NodeID()
+	fromStore := pieces.NewStore(log, from, nil, nil, nil, pieces.DefaultConfig)
+
+	writePiece := func(store *pieces.Store, corrupt bool) (storj.PieceID, []byte) {
+		pieceID := testrand.PieceID()
+		data := testrand.BytesInt(int(memory.KiB))
+
+		w, err := store.Writer(ctx, satelliteID, pieceID)
+		require.NoError(t, err)
+		_, err = w.Write(data)
+		require.NoError(t, err)
+
+		hash := w.Hash()
+		if corrupt {
+			hash = testrand.BytesInt(len(hash))
+		}
+		require.NoError(t, w.Commit(ctx, &pb.PieceHeader{Hash: hash}))
+		return pieceID, data
+	}
+
+	readPiece := func(store *pieces.Store, pieceID storj.PieceID) ([]byte, error) {
+		r, err := store.Reader(ctx, satelliteID, pieceID)
+		if err != nil {
+			return nil, err
+		}
+		defer ctx.Check(r.Close)
+		return ioutil.ReadAll(r)
+	}
+
+	stored := map[storj.PieceID][]byte{}
+	for i := 0; i < 5; i++ {
+		pieceID, data := writePiece(fromStore, false)
+		stored[pieceID] = data
+	}
+	corruptID, _ := writePiece(fromStore, true)
+
+	// the storage node reads pieces which are not migrated yet from the source
+	// and stores new pieces in the destination.
+	migratingStore := pieces.NewStore(log, storagemigration.NewBlobs(from, to), nil, nil, nil, pieces.DefaultConfig)
+	newID, newData := writePiece(migratingStore, false)
+	for pieceID, data := range stored {
+		read, err := readPiece(migratingStore, pieceID)
+		require.NoError(t, err)
+		require.Equal(t, data, read)
+	}
+	_, err = to.Stat(ctx, storage.BlobRef{Namespace: satelliteID.Bytes(), Key: newID.Bytes()})
+	require.NoError(t, err)
+
+	migrator := storagemigration.NewMigrator(log, from, to, nil, storagemigration.Config{Concurrency: 2})
+	require.NoError(t, migrator.MigrateVerificationFile(ctx, nodeID))
+	require.NoError(t, to.VerifyStorageDir(nodeID))
+	require.Error(t, migrator.MigrateVerificationFile(ctx, testrand.NodeID()))
+
+	require.NoError(t, migrator.Run(ctx))
+	stats := migrator.Stats()
+	require.EqualValues(t, len(stored), stats.Migrated)
+	require.EqualValues(t, 1, stats.Failed)
+	require.Zero(t, stats.Skipped)
+	require.Greater(t, stats.Bytes, int64(len(stored))*memory.KiB.Int64())
+
+	toStore := pieces.NewStore(log, to, nil, nil, nil, pieces.DefaultConfig)
+	for pieceID, data := range stored {
+		read, err := readPiece(toStore, pieceID)
+		require.NoError(t, err)
+		require.Equal(t, data, read)
+	}
+	read, err := readPiece(toStore, newID)
+	require.NoError(t, err)
+	require.Equal(t, newData, read)
+
+	// the piece which failed verification is only in the source.
+	_, err = readPiece(toStore, corruptID)
+	require.Error(t, err)
+	_, err = readPiece(migratingStore, corruptID)
+	require.NoError(t, err)
+
+	// damage one of the migrated pieces in the destination.
+	var damagedID storj.PieceID
+	for pieceID := range stored {
+		damagedID = pieceID
+		break
+	}
+	damagedRef := storage.BlobRef{Namespace: satelliteID.Bytes(), Key: damagedID.Bytes()}
+	damagedInfo, err := to.StatWithStorageFormat(ctx, damagedRef, filestore.FormatV1)
+	require.NoError(t, err)
+	blobPath, err := damagedInfo.FullPath(ctx)
+	require.NoError(t, err)
+	content, err := ioutil.ReadFile(blobPath)
+	require.NoError(t, err)
+	content[len(content)-1]++
+	require.NoError(t, ioutil.WriteFile(blobPath, content, 0644))
+
+	// resuming the migration deletes the verified pieces from the source.
+	migrator = storagemigration.NewMigrator(log, from, to, nil, storagemigration.Config{DeleteSource: true})
+	require.NoError(t, migrator.Run(ctx))
+	stats = migrator.Stats()
+	require.Zero(t, stats.Migrated)
+	require.EqualValues(t, len(stored)-1, stats.Skipped)
+	require.EqualValues(t, 2, stats.Failed)
+
+	for pieceID := range stored {
+		_, err := readPiece(fromStore, pieceID)
+		if pieceID == damagedID {
+			require.NoError(t, err)
+		} else {
+			require.Error(t, err)
+		}
+	}
+	_, err = readPiece(fromStore, corruptID)
+	require.NoError(t, err)
+
+	// the damaged piece is copied again by the next run.
+	migrator = storagemigration.NewMigrator(log, from, to, nil, storagemigration.Config{DeleteSource: true})
+	require.NoError(t, migrator.Run(ctx))
+	stats = migrator.Stats()
+	require.EqualValues(t, 1, stats.Migrated)
+	require.EqualValues(t, 1, stats.Failed)
+
+	read, err = readPiece(toStore, damagedID)
+	require.NoError(t, err)
+	require.Equal(t, stored[damagedID], read)
+	_, err = readPiece(fromStore, damagedID)
+	require.Error(t, err)
+
+	// deleting through the migrating store deletes from both locations.
+	require.NoError(t, migratingStore.Delete(ctx, satelliteID, corruptID))
+	_, err = readPiece(migratingStore, corruptID)
+	require.Error(t, err)
+}
@@ -33,8 +33,12 @@ import (
 	"storj.io/storj/storagenode/pieces"
 	"storj.io/storj/storagenode/pricing"
 	"storj.io/storj/storagenode/reputation"
+	"storj.io/storj/storagenode/retain"
 	"storj.io/storj/storagenode/satellites"
+	"storj.io/storj/storagenode/storagemigration"
 	"storj.io/storj/storagenode/storageusage"
+	"storj.io/storj/storagenode/transferstats"
+	"storj.io/storj/storagenode/untrusted"
 )
 
 // VersionTable is the table that stores the version info in each db.
@@ -83,6 +87,10 @@ type Config struct {
 	Driver    string // if unset, uses sqlite3
 	Pieces    string
 	Filestore filestore.Config
+
+	// PiecesMigrationSource is the previous location of the pieces, when
+	// they are being migrated to Pieces.
+	PiecesMigrationSource string
 }
 
 // DB contains access to different database tables.
@@ -108,6 +116,8 @@ type DB struct {
 	payoutDB          *payoutDB
 	pricingDB         *pricingDB
 	secretDB          *secretDB
+	retainReportsDB   *retainReportsDB
+	transferStatsDB   *transferStatsDB
 
 	SQLDBs map[string]DBContainer
 }
@@ -119,7 +129,10 @@ func OpenNew(ctx context.Context, log *zap.Logger, config Config) (*DB, error) {
 		return nil, err
 	}
 
-	pieces := filestore.New(log, piecesDir, config.Filestore)
+	pieces, err := openMigrationSource(log, config, filestore.New(log, piecesDir, config.Filestore))
+	if err != nil {
+		return nil, err
+	}
 
 	deprecatedInfoDB := &deprecatedInfoDB{}
 	v0PieceInfoDB := &v0PieceInfoDB{}
@@ -135,6 +148,8 @@ func OpenNew(ctx context.Context, log *zap.Logger, config Config) (*DB, error) {
 	payoutDB := &payoutDB{}
 	pricingDB := &pricingDB{}
 	secretDB := &secretDB{}
+	retainReportsDB := &retainReportsDB{}
+	transferStatsDB := &transferStatsDB{}
 
 	db := &DB{
 		log:    log,
@@ -158,6 +173,8 @@ func OpenNew(ctx context.Context, log *zap.Logger, config Config) (*DB, error) {
 		payoutDB:          payoutDB,
 		pricingDB:         pricingDB,
 		secretDB:          secretDB,
+		retainReportsDB:   retainReportsDB,
+		transferStatsDB:   transferStatsDB,
 
 		SQLDBs: map[string]DBContainer{
 			DeprecatedInfoDBName:  deprecatedInfoDB,
@@ -174,6 +191,8 @@ func OpenNew(ctx context.Context, log *zap.Logger, config Config) (*DB, error) {
 			HeldAmountDBName:      payoutDB,
 			PricingDBName:         pricingDB,
 			SecretDBName:          secretDB,
+			RetainReportsDBName:   retainReportsDB,
+			TransferStatsDBName:   transferStatsDB,
 		},
 	}
 
@@ -187,7 +206,10 @@ func OpenExisting(ctx context.Context, log *zap.Logger, config Config) (*DB, err
 		return nil, err
 	}
 
-	pieces := filestore.New(log, piecesDir, config.Filestore)
+	pieces, err := openMigrationSource(log, config, filestore.New(log, piecesDir, config.Filestore))
+	if err != nil {
+		return nil, err
+	}
 
 	deprecatedInfoDB := &deprecatedInfoDB{}
 	v0PieceInfoDB := &v0PieceInfoDB{}
@@ -203,6 +225,8 @@ func OpenExisting(ctx context.Context, log *zap.Logger, config Config) (*DB, err
 	payoutDB := &payoutDB{}
 	pricingDB := &pricingDB{}
 	secretDB := &secretDB{}
+	retainReportsDB := &retainReportsDB{}
+	transferStatsDB := &transferStatsDB{}
 
 	db := &DB{
 		log:    log,
@@ -226,6 +250,8 @@ func OpenExisting(ctx context.Context, log *zap.Logger, config Config) (*DB, err
 		payoutDB:          payoutDB,
 		pricingDB:         pricingDB,
 		secretDB:          secretDB,
+		retainReportsDB:   retainReportsDB,
+		transferStatsDB:   transferStatsDB,
 
 		SQLDBs: map[string]DBContainer{
 			DeprecatedInfoDBName:  deprecatedInfoDB,
@@ -242,6 +268,8 @@ func OpenExisting(ctx context.Context, log *zap.Logger, config Config) (*DB, err
 			HeldAmountDBName:      payoutDB,
 			PricingDBName:         pricingDB,
 			SecretDBName:          secretDB,
+			RetainReportsDBName:   retainReportsDB,
+			TransferStatsDBName:   transferStatsDB,
 		},
 	}
 
@@ -253,6 +281,21 @@ func OpenExisting(ctx context.Context, log *zap.Logger, config Config) (*DB, err
 	return db, nil
 }
 
+// openMigrationSource wraps pieces into a blob storage which migrates them from
+// the previous location, when a migration source is configured.
+func openMigrationSource(log *zap.Logger, config Config, pieces storage.Blobs) (storage.Blobs, error) {
+	if config.PiecesMigrationSource == "" {
+		return pieces, nil
+	}
+
+	sourceDir, err := filestore.OpenDir(log, config.PiecesMigrationSource)
+	if err != nil {
+		return nil, errs.Combine(err, pieces.Close())
+	}
+
+	return storagemigration.NewBlobs(filestore.New(log, sourceDir, config.Filestore), pieces), nil
+}
+
 // openDatabases opens all the SQLite3 storage node databases and returns if any fails to open successfully.
 func (db *DB) openDatabases(ctx context.Context) error {
 	// These objects have a Configure method to allow setting the underlining SQLDB connection
@@ -275,6 +318,8 @@ func (db *DB) openDatabases(ctx context.Context) error {
 		HeldAmountDBName,
 		PricingDBName,
 		SecretDBName,
+		RetainReportsDBName,
+		TransferStatsDBName,
 	}
 
 	for _, dbName := range dbs {
@@ -548,6 +593,21 @@ func (db *DB) Secret() apikeys.DB {
 	return db.secretDB
 }
 
+// RetainReports returns instance of the RetainReports database.
+func (db *DB) RetainReports() retain.DB {
+	return db.retainReportsDB
+}
+
+// Untrusted returns the database tracking satellites which are no longer trusted.
+func (db *DB) Untrusted() untrusted.DB {
+	return &untrustedDB{db: db}
+}
+
+// TransferStats returns instance of the TransferStats database.
+func (db *DB) TransferStats() transferstats.DB {
+	return db.transferStatsDB
+}
+
 // RawDatabases are required for testing purposes.
 func (db *DB) RawDatabases() map[string]DBContainer {
 	return db.SQLDBs
@@ -1830,6 +1890,137 @@ func (db *DB) Migration(ctx context.Context) *migrate.Migration {
 					);`,
 				},
 			},
+			{
+				DB:          &db.retainReportsDB.DB,
+				Description: "Create retain_reports table",
+				Version:     47,
+				CreateDB: func(ctx context.Context, log *zap.Logger) error {
+					if err := db.openDatabase(ctx, RetainReportsDBName); err != nil {
+						return ErrDatabase.Wrap(err)
+					}
+
+					return nil
+				},
+				Action: migrate.SQL{
+					`CREATE TABLE retain_reports (
+						id BLOB NOT NULL,
+						satellite_id BLOB NOT NULL,
+						status INTEGER NOT NULL,
+						created_before TIMESTAMP NOT NULL,
+						filter_size INTEGER NOT NULL,
+						false_positive_rate REAL NOT NULL,
+						pieces_checked INTEGER NOT NULL,
+						pieces_to_trash INTEGER NOT NULL,
+						bytes_to_trash INTEGER NOT NULL,
+						pieces_trashed INTEGER NOT NULL,
+						bytes_trashed INTEGER NOT NULL,
+						error TEXT NOT NULL,
+						received_at TIMESTAMP NOT NULL,
+						completed_at TIMESTAMP,
+						PRIMARY KEY (id)
+					);`,
+					`CREATE INDEX idx_retain_reports_received_at ON retain_reports(received_at);`,
+					`CREATE INDEX idx_retain_reports_satellite_id_received_at ON retain_reports(satellite_id, received_at);`,
+				},
+			},
+			{
+				DB:          &db.pieceExpirationDB.DB,
+				Description: "Add deletion retry columns to pieceExpirationDB",
+				Version:     48,
+				Action: migrate.SQL{
+					`ALTER TABLE piece_expirations ADD COLUMN deletion_failures INTEGER NOT NULL DEFAULT 0`,
+					`ALTER TABLE piece_expirations ADD COLUMN deletion_retry_at TIMESTAMP`,
+				},
+			},
+			{
+				DB:          &db.satellitesDB.DB,
+				Description: "Create untrusted_satellites table",
+				Version:     49,
+				Action: migrate.SQL{
+					`CREATE TABLE untrusted_satellites (
+						satellite_id BLOB NOT NULL,
+						untrusted_at TIMESTAMP NOT NULL,
+						cleaned_up_at TIMESTAMP,
+						PRIMARY KEY (satellite_id)
+					);`,
+				},
+			},
+			{
+				DB:          &db.satellitesDB.DB,
+				Description: "Create graceful exit transfer statistics tables",
+				Version:     50,
+				Action: migrate.SQL{
+					`CREATE TABLE satellite_exit_transfer_stats (
+						satellite_id BLOB NOT NULL,
+						reason TEXT NOT NULL,
+						pieces INTEGER NOT NULL,
+						bytes INTEGER NOT NULL,
+						duration INTEGER NOT NULL,
+						last_error TEXT NOT NULL,
+						last_at TIMESTAMP NOT NULL,
+						PRIMARY KEY (satellite_id, reason)
+					);`,
+					`CREATE TABLE satellite_exit_transfer_failures (
+						satellite_id BLOB NOT NULL,
+						piece_id BLOB NOT NULL,
+						reason TEXT NOT NULL,
+						bytes INTEGER NOT NULL,
+						duration INTEGER NOT NULL,
+						error TEXT NOT NULL,
+						failed_at TIMESTAMP NOT NULL
+					);`,
+				},
+			},
+			{
+				DB:          &db.transferStatsDB.DB,
+				Description: "Create transfer_stats table",
+				Version:     51,
+				CreateDB: func(ctx context.Context, log *zap.Logger) error {
+					if err := db.openDatabase(ctx, TransferStatsDBName); err != nil {
+						return ErrDatabase.Wrap(err)
+					}
+
+					return nil
+				},
+				Action: migrate.SQL{
+					`CREATE TABLE transfer_stats (
+						satellite_id BLOB NOT NULL,
+						action INTEGER NOT NULL,
+						interval_start TIMESTAMP NOT NULL,
+						successes INTEGER NOT NULL,
+						cancels INTEGER NOT NULL,
+						failures INTEGER NOT NULL,
+						PRIMARY KEY (satellite_id, action, interval_start)
+					);`,
+					`CREATE INDEX idx_transfer_stats_interval_start ON transfer_stats(interval_start);`,
+				},
+			},
+			{
+				DB:          &db.reputationDB.DB,
+				Description: "Add vetting progress to reputation table",
+				Version:     52,
+				Action: migrate.SQL{
+					`ALTER TABLE reputation ADD COLUMN vetted_at TIMESTAMP`,
+					`ALTER TABLE reputation ADD COLUMN vetting_audit_count INTEGER NOT NULL DEFAULT 0`,
+				},
+			},
+			{
+				DB:          &db.reputationDB.DB,
+				Description: "Add audit history to reputation table",
+				Version:     53,
+				Action: migrate.SQL{
+					`ALTER TABLE reputation ADD COLUMN audit_history BLOB`,
+				},
+			},
+			{
+				DB:          &db.v0PieceInfoDB.DB,
+				Description: "Add deletion retry columns to pieceinfo_",
+				Version:     54,
+				Action: migrate.SQL{
+					`ALTER TABLE pieceinfo_ ADD COLUMN deletion_failures INTEGER NOT NULL DEFAULT 0`,
+					`ALTER TABLE pieceinfo_ ADD COLUMN deletion_retry_at TIMESTAMP`,
+				},
+			},
 		},
 	}
 }
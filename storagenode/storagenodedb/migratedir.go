@@ -0,0 +1,93 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storagenodedb
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/private/tagsql"
+)
+
+// migratingSuffix is the suffix of a database file which is being migrated.
+const migratingSuffix = ".migrating"
+
+// MigrateDatabaseDir copies all databases from one directory to another and
+// returns the names of the copied database files.
+//
+// Every database is copied with VACUUM INTO, which creates a consistent
+// snapshot even when the database is in use. Databases which already exist
+// in the destination are skipped, so an interrupted migration can be resumed.
+// Changes made to the source databases after they have been copied are not
+// migrated, so the storage node should be restarted with the new directory
+// right after.
+func MigrateDatabaseDir(ctx context.Context, log *zap.Logger, fromDir, toDir string) (migrated []string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := os.MkdirAll(toDir, 0700); err != nil {
+		return nil, ErrDatabase.Wrap(err)
+	}
+
+	sources, err := filepath.Glob(filepath.Join(fromDir, "*.db"))
+	if err != nil {
+		return nil, ErrDatabase.Wrap(err)
+	}
+
+	for _, source := range sources {
+		name := filepath.Base(source)
+		destination := filepath.Join(toDir, name)
+
+		if _, err := os.Stat(destination); err == nil {
+			log.Info("database already migrated", zap.String("database", name))
+			continue
+		} else if !os.IsNotExist(err) {
+			return migrated, ErrDatabase.Wrap(err)
+		}
+
+		if err := migrateDatabaseFile(ctx, source, destination); err != nil {
+			return migrated, ErrDatabase.New("migrating %s: %w", name, err)
+		}
+
+		log.Info("database migrated", zap.String("database", name))
+		migrated = append(migrated, name)
+	}
+
+	return migrated, nil
+}
+
+// migrateDatabaseFile copies a single database file into a temporary file
+// which is renamed to destination once it's complete.
+func migrateDatabaseFile(ctx context.Context, source, destination string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	temporary := destination + migratingSuffix
+	if err := removeIfExists(temporary); err != nil {
+		return err
+	}
+
+	db, err := tagsql.Open(ctx, "sqlite3", "file:"+source+"?_journal=WAL&_busy_timeout=10000")
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, "VACUUM INTO ?", temporary)
+	err = errs.Combine(err, db.Close())
+	if err != nil {
+		return errs.Combine(err, removeIfExists(temporary))
+	}
+
+	return os.Rename(temporary, destination)
+}
+
+// removeIfExists removes the file, ignoring that it doesn't exist.
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
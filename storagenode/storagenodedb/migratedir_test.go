@@ -0,0 +1,72 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storagenodedb_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+func TestMigrateDatabaseDir(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+
+	config := func(dir string) storagenodedb.Config {
+		return storagenodedb.Config{
+			Pieces:    dir,
+			Storage:   dir,
+			Info:      filepath.Join(dir, "piecestore.db"),
+			Info2:     filepath.Join(dir, "info.db"),
+			Filestore: filestore.DefaultConfig,
+		}
+	}
+
+	fromDir, toDir := ctx.Dir("from"), ctx.Dir("to")
+
+	db, err := storagenodedb.OpenNew(ctx, log, config(fromDir))
+	require.NoError(t, err)
+	require.NoError(t, db.MigrateToLatest(ctx))
+
+	satelliteID := testrand.NodeID()
+	require.NoError(t, db.Satellites().InitiateGracefulExit(ctx, satelliteID, time.Now(), 1000))
+
+	migrated, err := storagenodedb.MigrateDatabaseDir(ctx, log, fromDir, toDir)
+	require.NoError(t, err)
+	require.Len(t, migrated, len(db.RawDatabases()))
+
+	// the source database is still in use while it's migrated.
+	require.NoError(t, db.Satellites().InitiateGracefulExit(ctx, testrand.NodeID(), time.Now(), 2000))
+	require.NoError(t, db.Close())
+
+	// databases which already exist are not migrated again.
+	migrated, err = storagenodedb.MigrateDatabaseDir(ctx, log, fromDir, toDir)
+	require.NoError(t, err)
+	require.Empty(t, migrated)
+
+	_, err = filestore.NewDir(log, toDir)
+	require.NoError(t, err)
+
+	db, err = storagenodedb.OpenExisting(ctx, log, config(toDir))
+	require.NoError(t, err)
+	defer ctx.Check(db.Close)
+
+	require.NoError(t, db.CheckVersion(ctx))
+
+	exits, err := db.Satellites().ListGracefulExits(ctx)
+	require.NoError(t, err)
+	require.Len(t, exits, 1)
+	require.Equal(t, satelliteID, exits[0].SatelliteID)
+	require.EqualValues(t, 1000, exits[0].StartingDiskUsage)
+}
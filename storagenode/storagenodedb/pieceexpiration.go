@@ -10,6 +10,7 @@ import (
 	"github.com/zeebo/errs"
 
 	"storj.io/common/storj"
+	"storj.io/storj/private/tagsql"
 	"storj.io/storj/storagenode/pieces"
 )
 
@@ -28,34 +29,96 @@ func (db *pieceExpirationDB) GetExpired(ctx context.Context, expiresBefore time.
 	defer mon.Task()(&ctx)(&err)
 
 	rows, err := db.QueryContext(ctx, `
-		SELECT satellite_id, piece_id
+		SELECT satellite_id, piece_id, deletion_failures
 			FROM piece_expirations
 			WHERE piece_expiration < ?
-				AND ((deletion_failed_at IS NULL) OR deletion_failed_at <> ?)
+				AND ((deletion_retry_at IS NULL) OR deletion_retry_at < ?)
 				AND trash = 0
 			LIMIT ?
 	`, expiresBefore.UTC(), expiresBefore.UTC(), limit)
 	if err != nil {
 		return nil, ErrPieceExpiration.Wrap(err)
 	}
+
+	return scanExpiredInfos(rows)
+}
+
+// GetExpiredBetween gets piece IDs that expire or have expired in the range [expiresAfter, expiresBefore),
+// oldest first. Pieces with a deletion retry scheduled at or after retryBefore are skipped.
+func (db *pieceExpirationDB) GetExpiredBetween(ctx context.Context, expiresAfter, expiresBefore, retryBefore time.Time, limit int64) (expiredPieceIDs []pieces.ExpiredInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT satellite_id, piece_id, deletion_failures
+			FROM piece_expirations
+			WHERE piece_expiration >= ?
+				AND piece_expiration < ?
+				AND ((deletion_retry_at IS NULL) OR deletion_retry_at < ?)
+				AND trash = 0
+			ORDER BY piece_expiration
+			LIMIT ?
+	`, expiresAfter.UTC(), expiresBefore.UTC(), retryBefore.UTC(), limit)
+	if err != nil {
+		return nil, ErrPieceExpiration.Wrap(err)
+	}
+
+	return scanExpiredInfos(rows)
+}
+
+// scanExpiredInfos reads expired pieces from rows and closes them.
+func scanExpiredInfos(rows tagsql.Rows) (expiredPieceIDs []pieces.ExpiredInfo, err error) {
 	defer func() { err = errs.Combine(err, rows.Close()) }()
 
 	for rows.Next() {
 		var satelliteID storj.NodeID
 		var pieceID storj.PieceID
-		err = rows.Scan(&satelliteID, &pieceID)
+		var deletionFailures int
+		err = rows.Scan(&satelliteID, &pieceID, &deletionFailures)
 		if err != nil {
 			return nil, ErrPieceExpiration.Wrap(err)
 		}
 		expiredPieceIDs = append(expiredPieceIDs, pieces.ExpiredInfo{
-			SatelliteID: satelliteID,
-			PieceID:     pieceID,
-			InPieceInfo: false,
+			SatelliteID:      satelliteID,
+			PieceID:          pieceID,
+			InPieceInfo:      false,
+			DeletionFailures: deletionFailures,
 		})
 	}
 	return expiredPieceIDs, rows.Err()
 }
 
+// GetExpirationSummary summarizes pieces that expire or have expired before the given time.
+func (db *pieceExpirationDB) GetExpirationSummary(ctx context.Context, expiresBefore time.Time) (summary pieces.ExpirationSummary, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = db.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(deletion_failures > 0), 0)
+			FROM piece_expirations
+			WHERE piece_expiration < ?
+				AND trash = 0
+	`, expiresBefore.UTC()).Scan(&summary.Count, &summary.Failed)
+	if err != nil {
+		return pieces.ExpirationSummary{}, ErrPieceExpiration.Wrap(err)
+	}
+	if summary.Count == 0 {
+		return summary, nil
+	}
+
+	err = db.QueryRowContext(ctx, `
+		SELECT piece_expiration
+			FROM piece_expirations
+			WHERE piece_expiration < ?
+				AND trash = 0
+			ORDER BY piece_expiration
+			LIMIT 1
+	`, expiresBefore.UTC()).Scan(&summary.Oldest)
+	if err != nil {
+		return pieces.ExpirationSummary{}, ErrPieceExpiration.Wrap(err)
+	}
+
+	return summary, nil
+}
+
 // SetExpiration sets an expiration time for the given piece ID on the given satellite.
 func (db *pieceExpirationDB) SetExpiration(ctx context.Context, satellite storj.NodeID, pieceID storj.PieceID, expiresAt time.Time) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -86,16 +149,18 @@ func (db *pieceExpirationDB) DeleteExpiration(ctx context.Context, satelliteID s
 }
 
 // DeleteFailed marks an expiration record as having experienced a failure in deleting the piece
-// from the disk.
-func (db *pieceExpirationDB) DeleteFailed(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID, when time.Time) (err error) {
+// from the disk. The deletion is not retried until retryAt.
+func (db *pieceExpirationDB) DeleteFailed(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID, when, retryAt time.Time) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	_, err = db.ExecContext(ctx, `
 		UPDATE piece_expirations
-			SET deletion_failed_at = ?
+			SET deletion_failed_at = ?,
+				deletion_retry_at = ?,
+				deletion_failures = deletion_failures + 1
 			WHERE satellite_id = ?
 				AND piece_id = ?
-	`, when.UTC(), satelliteID, pieceID)
+	`, when.UTC(), retryAt.UTC(), satelliteID, pieceID)
 	return ErrPieceExpiration.Wrap(err)
 }
 
@@ -163,16 +163,18 @@ func (db *v0PieceInfoDB) Delete(ctx context.Context, satelliteID storj.NodeID, p
 	return ErrPieceInfo.Wrap(err)
 }
 
-// DeleteFailed marks piece as a failed deletion.
-func (db *v0PieceInfoDB) DeleteFailed(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID, now time.Time) (err error) {
+// DeleteFailed marks piece as a failed deletion, which should not be retried until retryAt.
+func (db *v0PieceInfoDB) DeleteFailed(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID, now, retryAt time.Time) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	_, err = db.ExecContext(ctx, `
 		UPDATE pieceinfo_
-		SET deletion_failed_at = ?
+		SET deletion_failed_at = ?,
+			deletion_retry_at = ?,
+			deletion_failures = deletion_failures + 1
 		WHERE satellite_id = ?
 		  AND piece_id = ?
-	`, now.UTC(), satelliteID, pieceID)
+	`, now.UTC(), retryAt.UTC(), satelliteID, pieceID)
 
 	return ErrPieceInfo.Wrap(err)
 }
@@ -205,6 +207,35 @@ func (db *v0PieceInfoDB) GetExpired(ctx context.Context, expiredAt time.Time, li
 	return infos, rows.Err()
 }
 
+// GetExpiredBefore gets ExpiredInfo records for pieces that expired before expiresBefore,
+// skipping the ones with a deletion retry scheduled at or after retryBefore.
+func (db *v0PieceInfoDB) GetExpiredBefore(ctx context.Context, expiresBefore, retryBefore time.Time, limit int64) (infos []pieces.ExpiredInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT satellite_id, piece_id, deletion_failures
+		FROM pieceinfo_
+		WHERE piece_expiration IS NOT NULL
+		AND piece_expiration < ?
+		AND ((deletion_retry_at IS NULL) OR deletion_retry_at < ?)
+		ORDER BY piece_expiration
+		LIMIT ?
+	`, expiresBefore.UTC(), retryBefore.UTC(), limit)
+	if err != nil {
+		return nil, ErrPieceInfo.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+	for rows.Next() {
+		info := pieces.ExpiredInfo{InPieceInfo: true}
+		err = rows.Scan(&info.SatelliteID, &info.PieceID, &info.DeletionFailures)
+		if err != nil {
+			return infos, ErrPieceInfo.Wrap(err)
+		}
+		infos = append(infos, info)
+	}
+	return infos, rows.Err()
+}
+
 type v0StoredPieceAccess struct {
 	blobStore      storage.Blobs
 	satellite      storj.NodeID
@@ -11,6 +11,7 @@ import (
 	"github.com/zeebo/errs"
 
 	"storj.io/common/storj"
+	"storj.io/storj/private/nodestatsext"
 	"storj.io/storj/storagenode/reputation"
 )
 
@@ -25,11 +26,12 @@ type reputationDB struct {
 	dbContainerImpl
 }
 
-// Store inserts or updates reputation stats into the db.
+// Store inserts or updates reputation stats into the db. Stats without an
+// audit history keep the previously stored one.
 func (db *reputationDB) Store(ctx context.Context, stats reputation.Stats) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	query := `INSERT OR REPLACE INTO reputation (
+	query := `INSERT INTO reputation (
 			satellite_id,
 			uptime_success_count,
 			uptime_total_count,
@@ -49,9 +51,36 @@ func (db *reputationDB) Store(ctx context.Context, stats reputation.Stats) (err
 			suspended_at,
 			offline_suspended_at,
 			offline_under_review_at,
+			vetted_at,
+			vetting_audit_count,
+			audit_history,
 			updated_at,
 			joined_at
-		) VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`
+		) VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)
+		ON CONFLICT(satellite_id) DO UPDATE SET
+			uptime_success_count = excluded.uptime_success_count,
+			uptime_total_count = excluded.uptime_total_count,
+			uptime_reputation_alpha = excluded.uptime_reputation_alpha,
+			uptime_reputation_beta = excluded.uptime_reputation_beta,
+			uptime_reputation_score = excluded.uptime_reputation_score,
+			audit_success_count = excluded.audit_success_count,
+			audit_total_count = excluded.audit_total_count,
+			audit_reputation_alpha = excluded.audit_reputation_alpha,
+			audit_reputation_beta = excluded.audit_reputation_beta,
+			audit_reputation_score = excluded.audit_reputation_score,
+			audit_unknown_reputation_alpha = excluded.audit_unknown_reputation_alpha,
+			audit_unknown_reputation_beta = excluded.audit_unknown_reputation_beta,
+			audit_unknown_reputation_score = excluded.audit_unknown_reputation_score,
+			online_score = excluded.online_score,
+			disqualified_at = excluded.disqualified_at,
+			suspended_at = excluded.suspended_at,
+			offline_suspended_at = excluded.offline_suspended_at,
+			offline_under_review_at = excluded.offline_under_review_at,
+			vetted_at = excluded.vetted_at,
+			vetting_audit_count = excluded.vetting_audit_count,
+			audit_history = COALESCE(excluded.audit_history, audit_history),
+			updated_at = excluded.updated_at,
+			joined_at = excluded.joined_at`
 
 	// ensure we insert utc
 	if stats.DisqualifiedAt != nil {
@@ -70,6 +99,18 @@ func (db *reputationDB) Store(ctx context.Context, stats reputation.Stats) (err
 		utc := stats.OfflineUnderReviewAt.UTC()
 		stats.OfflineUnderReviewAt = &utc
 	}
+	if stats.VettedAt != nil {
+		utc := stats.VettedAt.UTC()
+		stats.VettedAt = &utc
+	}
+
+	var auditHistory []byte
+	if stats.AuditHistory != nil {
+		auditHistory, err = nodestatsext.MarshalAuditHistory(*stats.AuditHistory)
+		if err != nil {
+			return ErrReputation.Wrap(err)
+		}
+	}
 
 	_, err = db.ExecContext(ctx, query,
 		stats.SatelliteID,
@@ -91,6 +132,9 @@ func (db *reputationDB) Store(ctx context.Context, stats reputation.Stats) (err
 		stats.SuspendedAt,
 		stats.OfflineSuspendedAt,
 		stats.OfflineUnderReviewAt,
+		stats.VettedAt,
+		stats.VettingAuditCount,
+		auditHistory,
 		stats.UpdatedAt.UTC(),
 		stats.JoinedAt.UTC(),
 	)
@@ -106,6 +150,7 @@ func (db *reputationDB) Get(ctx context.Context, satelliteID storj.NodeID) (_ *r
 		SatelliteID: satelliteID,
 	}
 
+	var auditHistory []byte
 	row := db.QueryRowContext(ctx,
 		`SELECT uptime_success_count,
 			uptime_total_count,
@@ -125,6 +170,9 @@ func (db *reputationDB) Get(ctx context.Context, satelliteID storj.NodeID) (_ *r
 			suspended_at,
 			offline_suspended_at,
 			offline_under_review_at,
+			vetted_at,
+			vetting_audit_count,
+			audit_history,
 			updated_at,
 			joined_at
 		FROM reputation WHERE satellite_id = ?`,
@@ -150,14 +198,20 @@ func (db *reputationDB) Get(ctx context.Context, satelliteID storj.NodeID) (_ *r
 		&stats.SuspendedAt,
 		&stats.OfflineSuspendedAt,
 		&stats.OfflineUnderReviewAt,
+		&stats.VettedAt,
+		&stats.VettingAuditCount,
+		&auditHistory,
 		&stats.UpdatedAt,
 		&stats.JoinedAt,
 	)
-
 	if errors.Is(err, sql.ErrNoRows) {
-		err = nil
+		return &stats, nil
+	}
+	if err != nil {
+		return &stats, ErrReputation.Wrap(err)
 	}
 
+	stats.AuditHistory, err = unmarshalAuditHistory(auditHistory)
 	return &stats, ErrReputation.Wrap(err)
 }
 
@@ -184,6 +238,9 @@ func (db *reputationDB) All(ctx context.Context) (_ []reputation.Stats, err erro
 			suspended_at,
 			offline_suspended_at,
 			offline_under_review_at,
+			vetted_at,
+			vetting_audit_count,
+			audit_history,
 			updated_at,
 			joined_at
 		FROM reputation`
@@ -198,6 +255,7 @@ func (db *reputationDB) All(ctx context.Context) (_ []reputation.Stats, err erro
 	var statsList []reputation.Stats
 	for rows.Next() {
 		var stats reputation.Stats
+		var auditHistory []byte
 
 		err := rows.Scan(&stats.SatelliteID,
 			&stats.Uptime.SuccessCount,
@@ -218,6 +276,9 @@ func (db *reputationDB) All(ctx context.Context) (_ []reputation.Stats, err erro
 			&stats.SuspendedAt,
 			&stats.OfflineSuspendedAt,
 			&stats.OfflineUnderReviewAt,
+			&stats.VettedAt,
+			&stats.VettingAuditCount,
+			&auditHistory,
 			&stats.UpdatedAt,
 			&stats.JoinedAt,
 		)
@@ -226,8 +287,25 @@ func (db *reputationDB) All(ctx context.Context) (_ []reputation.Stats, err erro
 			return nil, ErrReputation.Wrap(err)
 		}
 
+		stats.AuditHistory, err = unmarshalAuditHistory(auditHistory)
+		if err != nil {
+			return nil, ErrReputation.Wrap(err)
+		}
+
 		statsList = append(statsList, stats)
 	}
 
 	return statsList, rows.Err()
 }
+
+// unmarshalAuditHistory decodes the stored audit history, nil when it was not stored.
+func unmarshalAuditHistory(data []byte) (*nodestatsext.AuditHistory, error) {
+	if data == nil {
+		return nil, nil
+	}
+	history, err := nodestatsext.UnmarshalAuditHistory(data)
+	if err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
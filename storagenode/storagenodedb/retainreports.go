@@ -0,0 +1,205 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storagenodedb
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/storj/private/tagsql"
+	"storj.io/storj/storagenode/retain"
+)
+
+// ensures that retainReportsDB implements retain.DB interface.
+var _ retain.DB = (*retainReportsDB)(nil)
+
+// ErrRetainReports represents errors from the retain reports database.
+var ErrRetainReports = errs.Class("retain reports db error")
+
+// RetainReportsDBName represents the database name.
+const RetainReportsDBName = "retain"
+
+// retainReportsDB stores reports about retain requests received from satellites.
+//
+// architecture: Database
+type retainReportsDB struct {
+	dbContainerImpl
+}
+
+// Insert stores a report for a newly received retain request.
+func (db *retainReportsDB) Insert(ctx context.Context, report retain.Report) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	query := `INSERT INTO retain_reports (
+			id,
+			satellite_id,
+			status,
+			created_before,
+			filter_size,
+			false_positive_rate,
+			pieces_checked,
+			pieces_to_trash,
+			bytes_to_trash,
+			pieces_trashed,
+			bytes_trashed,
+			error,
+			received_at,
+			completed_at
+		) VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?)`
+
+	_, err = db.ExecContext(ctx, query,
+		report.ID[:],
+		report.SatelliteID,
+		report.Status,
+		report.CreatedBefore.UTC(),
+		report.FilterSize,
+		report.FalsePositiveRate,
+		report.PiecesChecked,
+		report.PiecesToTrash,
+		report.BytesToTrash,
+		report.PiecesTrashed,
+		report.BytesTrashed,
+		report.Error,
+		report.ReceivedAt.UTC(),
+		report.CompletedAt,
+	)
+
+	return ErrRetainReports.Wrap(err)
+}
+
+// Update updates the counters and completion state of an existing report.
+func (db *retainReportsDB) Update(ctx context.Context, report retain.Report) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	query := `UPDATE retain_reports SET
+			pieces_checked = ?,
+			pieces_to_trash = ?,
+			bytes_to_trash = ?,
+			pieces_trashed = ?,
+			bytes_trashed = ?,
+			error = ?,
+			completed_at = ?
+		WHERE id = ?`
+
+	result, err := db.ExecContext(ctx, query,
+		report.PiecesChecked,
+		report.PiecesToTrash,
+		report.BytesToTrash,
+		report.PiecesTrashed,
+		report.BytesTrashed,
+		report.Error,
+		report.CompletedAt,
+		report.ID[:],
+	)
+	if err != nil {
+		return ErrRetainReports.Wrap(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return ErrRetainReports.Wrap(err)
+	}
+	if rowsAffected == 0 {
+		return ErrRetainReports.Wrap(ErrNoRows)
+	}
+
+	return nil
+}
+
+// List returns the latest reports, newest first, limited to limit items.
+func (db *retainReportsDB) List(ctx context.Context, limit int) (_ []retain.Report, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	query := `SELECT ` + retainReportsColumns + `
+		FROM retain_reports
+		ORDER BY received_at DESC
+		LIMIT ?`
+
+	rows, err := db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, ErrRetainReports.Wrap(err)
+	}
+
+	return scanRetainReports(rows)
+}
+
+// ListBySatellite returns the latest reports for a satellite, newest first, limited to limit items.
+func (db *retainReportsDB) ListBySatellite(ctx context.Context, satelliteID storj.NodeID, limit int) (_ []retain.Report, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	query := `SELECT ` + retainReportsColumns + `
+		FROM retain_reports
+		WHERE satellite_id = ?
+		ORDER BY received_at DESC
+		LIMIT ?`
+
+	rows, err := db.QueryContext(ctx, query, satelliteID, limit)
+	if err != nil {
+		return nil, ErrRetainReports.Wrap(err)
+	}
+
+	return scanRetainReports(rows)
+}
+
+// DeleteBefore removes all reports received before the given time.
+func (db *retainReportsDB) DeleteBefore(ctx context.Context, before time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.ExecContext(ctx, `DELETE FROM retain_reports WHERE received_at < ?`, before.UTC())
+
+	return ErrRetainReports.Wrap(err)
+}
+
+const retainReportsColumns = `
+	id,
+	satellite_id,
+	status,
+	created_before,
+	filter_size,
+	false_positive_rate,
+	pieces_checked,
+	pieces_to_trash,
+	bytes_to_trash,
+	pieces_trashed,
+	bytes_trashed,
+	error,
+	received_at,
+	completed_at`
+
+// scanRetainReports reads all reports from rows and closes them.
+func scanRetainReports(rows tagsql.Rows) (_ []retain.Report, err error) {
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var reports []retain.Report
+	for rows.Next() {
+		var report retain.Report
+
+		err := rows.Scan(
+			&report.ID,
+			&report.SatelliteID,
+			&report.Status,
+			&report.CreatedBefore,
+			&report.FilterSize,
+			&report.FalsePositiveRate,
+			&report.PiecesChecked,
+			&report.PiecesToTrash,
+			&report.BytesToTrash,
+			&report.PiecesTrashed,
+			&report.BytesTrashed,
+			&report.Error,
+			&report.ReceivedAt,
+			&report.CompletedAt,
+		)
+		if err != nil {
+			return nil, ErrRetainReports.Wrap(err)
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports, ErrRetainReports.Wrap(rows.Err())
+}
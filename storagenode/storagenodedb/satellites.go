@@ -55,6 +55,15 @@ func (db *satellitesDB) InitiateGracefulExit(ctx context.Context, satelliteID st
 		}
 		query = `INSERT INTO satellite_exit_progress (satellite_id, initiated_at, starting_disk_usage, bytes_deleted) VALUES (?,?,?,0)`
 		_, err = tx.ExecContext(ctx, query, satelliteID, intitiatedAt.UTC(), startingDiskUsage)
+		if err != nil {
+			return err
+		}
+		// statistics of a previous graceful exit don't apply to this one
+		_, err = tx.ExecContext(ctx, `DELETE FROM satellite_exit_transfer_stats WHERE satellite_id = ?`, satelliteID)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `DELETE FROM satellite_exit_transfer_failures WHERE satellite_id = ?`, satelliteID)
 		return err
 	}))
 }
@@ -114,3 +123,110 @@ func (db *satellitesDB) ListGracefulExits(ctx context.Context) (exitList []satel
 
 	return exitList, rows.Err()
 }
+
+// maxTransferFailures is the number of failed transfers kept per satellite.
+const maxTransferFailures = 100
+
+// RecordTransfer adds the outcome of a piece transfer to the graceful exit
+// statistics. Failed transfers are additionally kept individually, up to
+// maxTransferFailures per satellite.
+func (db *satellitesDB) RecordTransfer(ctx context.Context, satelliteID storj.NodeID, transfer satellites.Transfer) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return ErrSatellitesDB.Wrap(withTx(ctx, db.GetDB(), func(tx tagsql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO satellite_exit_transfer_stats (satellite_id, reason, pieces, bytes, duration, last_error, last_at)
+			VALUES (?, ?, 1, ?, ?, ?, ?)
+			ON CONFLICT(satellite_id, reason) DO UPDATE SET
+				pieces = pieces + 1,
+				bytes = bytes + excluded.bytes,
+				duration = duration + excluded.duration,
+				last_error = excluded.last_error,
+				last_at = excluded.last_at
+		`, satelliteID, transfer.Reason, transfer.Bytes, int64(transfer.Duration), transfer.Error, transfer.FinishedAt.UTC())
+		if err != nil {
+			return err
+		}
+
+		if transfer.Reason == satellites.TransferSucceeded {
+			return nil
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO satellite_exit_transfer_failures (satellite_id, piece_id, reason, bytes, duration, error, failed_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, satelliteID, transfer.PieceID, transfer.Reason, transfer.Bytes, int64(transfer.Duration), transfer.Error, transfer.FinishedAt.UTC())
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			DELETE FROM satellite_exit_transfer_failures
+			WHERE satellite_id = ? AND rowid NOT IN (
+				SELECT rowid FROM satellite_exit_transfer_failures
+				WHERE satellite_id = ?
+				ORDER BY failed_at DESC, rowid DESC
+				LIMIT ?
+			)
+		`, satelliteID, satelliteID, maxTransferFailures)
+		return err
+	}))
+}
+
+// GetTransferStats returns the graceful exit statistics by reason.
+func (db *satellitesDB) GetTransferStats(ctx context.Context, satelliteID storj.NodeID) (_ []satellites.TransferStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT reason, pieces, bytes, duration, last_error, last_at
+		FROM satellite_exit_transfer_stats
+		WHERE satellite_id = ?
+		ORDER BY reason
+	`, satelliteID)
+	if err != nil {
+		return nil, ErrSatellitesDB.Wrap(err)
+	}
+	defer func() { err = ErrSatellitesDB.Wrap(errs.Combine(err, rows.Close())) }()
+
+	var stats []satellites.TransferStats
+	for rows.Next() {
+		var stat satellites.TransferStats
+		var duration int64
+		err := rows.Scan(&stat.Reason, &stat.Pieces, &stat.Bytes, &duration, &stat.LastError, &stat.LastAt)
+		if err != nil {
+			return nil, err
+		}
+		stat.Duration = time.Duration(duration)
+		stats = append(stats, stat)
+	}
+	return stats, rows.Err()
+}
+
+// ListTransferFailures returns the latest failed piece transfers of a graceful exit.
+func (db *satellitesDB) ListTransferFailures(ctx context.Context, satelliteID storj.NodeID, limit int) (_ []satellites.Transfer, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT piece_id, reason, bytes, duration, error, failed_at
+		FROM satellite_exit_transfer_failures
+		WHERE satellite_id = ?
+		ORDER BY failed_at DESC, rowid DESC
+		LIMIT ?
+	`, satelliteID, limit)
+	if err != nil {
+		return nil, ErrSatellitesDB.Wrap(err)
+	}
+	defer func() { err = ErrSatellitesDB.Wrap(errs.Combine(err, rows.Close())) }()
+
+	var failures []satellites.Transfer
+	for rows.Next() {
+		var failure satellites.Transfer
+		var duration int64
+		err := rows.Scan(&failure.PieceID, &failure.Reason, &failure.Bytes, &duration, &failure.Error, &failure.FinishedAt)
+		if err != nil {
+			return nil, err
+		}
+		failure.Duration = time.Duration(duration)
+		failures = append(failures, failure)
+	}
+	return failures, rows.Err()
+}
@@ -365,6 +365,16 @@ func Schema() map[string]*dbschema.Schema {
 							Type:       "TIMESTAMP",
 							IsNullable: true,
 						},
+						&dbschema.Column{
+							Name:       "deletion_failures",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "deletion_retry_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
 						&dbschema.Column{
 							Name:       "piece_expiration",
 							Type:       "TIMESTAMP",
@@ -431,6 +441,16 @@ func Schema() map[string]*dbschema.Schema {
 							Type:       "TIMESTAMP",
 							IsNullable: true,
 						},
+						&dbschema.Column{
+							Name:       "deletion_failures",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "deletion_retry_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
 						&dbschema.Column{
 							Name:       "order_limit",
 							Type:       "BLOB",
@@ -521,6 +541,11 @@ func Schema() map[string]*dbschema.Schema {
 					Name:       "reputation",
 					PrimaryKey: []string{"satellite_id"},
 					Columns: []*dbschema.Column{
+						&dbschema.Column{
+							Name:       "audit_history",
+							Type:       "BLOB",
+							IsNullable: true,
+						},
 						&dbschema.Column{
 							Name:       "audit_reputation_alpha",
 							Type:       "REAL",
@@ -626,9 +651,103 @@ func Schema() map[string]*dbschema.Schema {
 							Type:       "INTEGER",
 							IsNullable: false,
 						},
+						&dbschema.Column{
+							Name:       "vetted_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
+						&dbschema.Column{
+							Name:       "vetting_audit_count",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+					},
+				},
+			},
+		},
+		"retain": &dbschema.Schema{
+			Tables: []*dbschema.Table{
+				&dbschema.Table{
+					Name:       "retain_reports",
+					PrimaryKey: []string{"id"},
+					Columns: []*dbschema.Column{
+						&dbschema.Column{
+							Name:       "bytes_to_trash",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "bytes_trashed",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "completed_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
+						&dbschema.Column{
+							Name:       "created_before",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "error",
+							Type:       "TEXT",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "false_positive_rate",
+							Type:       "REAL",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "filter_size",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "id",
+							Type:       "BLOB",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "pieces_checked",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "pieces_to_trash",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "pieces_trashed",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "received_at",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "satellite_id",
+							Type:       "BLOB",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "status",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
 					},
 				},
 			},
+			Indexes: []*dbschema.Index{
+				&dbschema.Index{Name: "idx_retain_reports_received_at", Table: "retain_reports", Columns: []string{"received_at"}, Unique: false, Partial: ""},
+				&dbschema.Index{Name: "idx_retain_reports_satellite_id_received_at", Table: "retain_reports", Columns: []string{"satellite_id", "received_at"}, Unique: false, Partial: ""},
+			},
 		},
 		"satellites": &dbschema.Schema{
 			Tables: []*dbschema.Table{
@@ -668,6 +787,87 @@ func Schema() map[string]*dbschema.Schema {
 						},
 					},
 				},
+				&dbschema.Table{
+					Name: "satellite_exit_transfer_failures",
+					Columns: []*dbschema.Column{
+						&dbschema.Column{
+							Name:       "bytes",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "duration",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "error",
+							Type:       "TEXT",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "failed_at",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "piece_id",
+							Type:       "BLOB",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "reason",
+							Type:       "TEXT",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "satellite_id",
+							Type:       "BLOB",
+							IsNullable: false,
+						},
+					},
+				},
+				&dbschema.Table{
+					Name:       "satellite_exit_transfer_stats",
+					PrimaryKey: []string{"reason", "satellite_id"},
+					Columns: []*dbschema.Column{
+						&dbschema.Column{
+							Name:       "bytes",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "duration",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "last_at",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "last_error",
+							Type:       "TEXT",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "pieces",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "reason",
+							Type:       "TEXT",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "satellite_id",
+							Type:       "BLOB",
+							IsNullable: false,
+						},
+					},
+				},
 				&dbschema.Table{
 					Name:       "satellites",
 					PrimaryKey: []string{"node_id"},
@@ -689,6 +889,27 @@ func Schema() map[string]*dbschema.Schema {
 						},
 					},
 				},
+				&dbschema.Table{
+					Name:       "untrusted_satellites",
+					PrimaryKey: []string{"satellite_id"},
+					Columns: []*dbschema.Column{
+						&dbschema.Column{
+							Name:       "cleaned_up_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
+						&dbschema.Column{
+							Name:       "satellite_id",
+							Type:       "BLOB",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "untrusted_at",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+					},
+				},
 			},
 		},
 		"secret": &dbschema.Schema{
@@ -736,6 +957,49 @@ func Schema() map[string]*dbschema.Schema {
 				},
 			},
 		},
+		"transfer_stats": &dbschema.Schema{
+			Tables: []*dbschema.Table{
+				&dbschema.Table{
+					Name:       "transfer_stats",
+					PrimaryKey: []string{"action", "interval_start", "satellite_id"},
+					Columns: []*dbschema.Column{
+						&dbschema.Column{
+							Name:       "action",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "cancels",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "failures",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "interval_start",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "satellite_id",
+							Type:       "BLOB",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "successes",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+					},
+				},
+			},
+			Indexes: []*dbschema.Index{
+				&dbschema.Index{Name: "idx_transfer_stats_interval_start", Table: "transfer_stats", Columns: []string{"interval_start"}, Unique: false, Partial: ""},
+			},
+		},
 		"used_serial": &dbschema.Schema{},
 	}
 }
@@ -61,6 +61,14 @@ var States = MultiDBStates{
 		&v44,
 		&v45,
 		&v46,
+		&v47,
+		&v48,
+		&v49,
+		&v50,
+		&v51,
+		&v52,
+		&v53,
+		&v54,
 	},
 }
 
@@ -0,0 +1,49 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package testdata
+
+import "storj.io/storj/storagenode/storagenodedb"
+
+var v47 = MultiDBState{
+	Version: 47,
+	DBStates: DBStates{
+		storagenodedb.UsedSerialsDBName:     v43.DBStates[storagenodedb.UsedSerialsDBName],
+		storagenodedb.StorageUsageDBName:    v43.DBStates[storagenodedb.StorageUsageDBName],
+		storagenodedb.ReputationDBName:      v45.DBStates[storagenodedb.ReputationDBName],
+		storagenodedb.PieceSpaceUsedDBName:  v43.DBStates[storagenodedb.PieceSpaceUsedDBName],
+		storagenodedb.PieceInfoDBName:       v43.DBStates[storagenodedb.PieceInfoDBName],
+		storagenodedb.PieceExpirationDBName: v43.DBStates[storagenodedb.PieceExpirationDBName],
+		storagenodedb.OrdersDBName:          v43.DBStates[storagenodedb.OrdersDBName],
+		storagenodedb.BandwidthDBName:       v43.DBStates[storagenodedb.BandwidthDBName],
+		storagenodedb.SatellitesDBName:      v43.DBStates[storagenodedb.SatellitesDBName],
+		storagenodedb.DeprecatedInfoDBName:  v43.DBStates[storagenodedb.DeprecatedInfoDBName],
+		storagenodedb.NotificationsDBName:   v43.DBStates[storagenodedb.NotificationsDBName],
+		storagenodedb.HeldAmountDBName:      v43.DBStates[storagenodedb.HeldAmountDBName],
+		storagenodedb.PricingDBName:         v43.DBStates[storagenodedb.PricingDBName],
+		storagenodedb.SecretDBName:          v46.DBStates[storagenodedb.SecretDBName],
+		storagenodedb.RetainReportsDBName: &DBState{
+			SQL: `
+				-- table to hold reports about retain requests received from satellites
+				CREATE TABLE retain_reports (
+					id BLOB NOT NULL,
+					satellite_id BLOB NOT NULL,
+					status INTEGER NOT NULL,
+					created_before TIMESTAMP NOT NULL,
+					filter_size INTEGER NOT NULL,
+					false_positive_rate REAL NOT NULL,
+					pieces_checked INTEGER NOT NULL,
+					pieces_to_trash INTEGER NOT NULL,
+					bytes_to_trash INTEGER NOT NULL,
+					pieces_trashed INTEGER NOT NULL,
+					bytes_trashed INTEGER NOT NULL,
+					error TEXT NOT NULL,
+					received_at TIMESTAMP NOT NULL,
+					completed_at TIMESTAMP,
+					PRIMARY KEY (id)
+				);
+				CREATE INDEX idx_retain_reports_received_at ON retain_reports(received_at);
+				CREATE INDEX idx_retain_reports_satellite_id_received_at ON retain_reports(satellite_id, received_at);`,
+		},
+	},
+}
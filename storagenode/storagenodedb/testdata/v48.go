@@ -0,0 +1,43 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package testdata
+
+import "storj.io/storj/storagenode/storagenodedb"
+
+var v48 = MultiDBState{
+	Version: 48,
+	DBStates: DBStates{
+		storagenodedb.UsedSerialsDBName:    v43.DBStates[storagenodedb.UsedSerialsDBName],
+		storagenodedb.StorageUsageDBName:   v43.DBStates[storagenodedb.StorageUsageDBName],
+		storagenodedb.ReputationDBName:     v45.DBStates[storagenodedb.ReputationDBName],
+		storagenodedb.PieceSpaceUsedDBName: v43.DBStates[storagenodedb.PieceSpaceUsedDBName],
+		storagenodedb.PieceInfoDBName:      v43.DBStates[storagenodedb.PieceInfoDBName],
+		storagenodedb.PieceExpirationDBName: &DBState{
+			SQL: `
+				-- table to hold expiration data (and only expirations. no other pieceinfo)
+				CREATE TABLE piece_expirations (
+					satellite_id       BLOB      NOT NULL,
+					piece_id           BLOB      NOT NULL,
+					piece_expiration   TIMESTAMP NOT NULL, -- date when it can be deleted
+					deletion_failed_at TIMESTAMP,
+					trash              INTEGER NOT NULL DEFAULT 0,
+					deletion_failures  INTEGER NOT NULL DEFAULT 0,
+					deletion_retry_at  TIMESTAMP,
+					PRIMARY KEY ( satellite_id, piece_id )
+				);
+				CREATE INDEX idx_piece_expirations_piece_expiration ON piece_expirations(piece_expiration);
+				CREATE INDEX idx_piece_expirations_deletion_failed_at ON piece_expirations(deletion_failed_at);
+				CREATE INDEX idx_piece_expirations_trashed ON piece_expirations(satellite_id, trash) WHERE trash = 1;`,
+		},
+		storagenodedb.OrdersDBName:         v43.DBStates[storagenodedb.OrdersDBName],
+		storagenodedb.BandwidthDBName:      v43.DBStates[storagenodedb.BandwidthDBName],
+		storagenodedb.SatellitesDBName:     v43.DBStates[storagenodedb.SatellitesDBName],
+		storagenodedb.DeprecatedInfoDBName: v43.DBStates[storagenodedb.DeprecatedInfoDBName],
+		storagenodedb.NotificationsDBName:  v43.DBStates[storagenodedb.NotificationsDBName],
+		storagenodedb.HeldAmountDBName:     v43.DBStates[storagenodedb.HeldAmountDBName],
+		storagenodedb.PricingDBName:        v43.DBStates[storagenodedb.PricingDBName],
+		storagenodedb.SecretDBName:         v46.DBStates[storagenodedb.SecretDBName],
+		storagenodedb.RetainReportsDBName:  v47.DBStates[storagenodedb.RetainReportsDBName],
+	},
+}
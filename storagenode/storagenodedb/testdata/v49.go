@@ -0,0 +1,56 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package testdata
+
+import "storj.io/storj/storagenode/storagenodedb"
+
+var v49 = MultiDBState{
+	Version: 49,
+	DBStates: DBStates{
+		storagenodedb.UsedSerialsDBName:     v43.DBStates[storagenodedb.UsedSerialsDBName],
+		storagenodedb.StorageUsageDBName:    v43.DBStates[storagenodedb.StorageUsageDBName],
+		storagenodedb.ReputationDBName:      v45.DBStates[storagenodedb.ReputationDBName],
+		storagenodedb.PieceSpaceUsedDBName:  v43.DBStates[storagenodedb.PieceSpaceUsedDBName],
+		storagenodedb.PieceInfoDBName:       v43.DBStates[storagenodedb.PieceInfoDBName],
+		storagenodedb.PieceExpirationDBName: v48.DBStates[storagenodedb.PieceExpirationDBName],
+		storagenodedb.OrdersDBName:          v43.DBStates[storagenodedb.OrdersDBName],
+		storagenodedb.BandwidthDBName:       v43.DBStates[storagenodedb.BandwidthDBName],
+		storagenodedb.SatellitesDBName: &DBState{
+			SQL: `
+				CREATE TABLE satellites (
+					node_id BLOB NOT NULL,
+					added_at TIMESTAMP NOT NULL,
+					status INTEGER NOT NULL,
+					PRIMARY KEY (node_id)
+				);
+				CREATE TABLE satellite_exit_progress (
+					satellite_id BLOB NOT NULL,
+					initiated_at TIMESTAMP,
+					finished_at TIMESTAMP,
+					starting_disk_usage INTEGER NOT NULL,
+					bytes_deleted INTEGER NOT NULL,
+					completion_receipt BLOB,
+					FOREIGN KEY (satellite_id) REFERENCES satellites (node_id)
+				);
+				CREATE TABLE untrusted_satellites (
+					satellite_id BLOB NOT NULL,
+					untrusted_at TIMESTAMP NOT NULL,
+					cleaned_up_at TIMESTAMP,
+					PRIMARY KEY (satellite_id)
+				);
+				INSERT INTO satellites VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000','2019-09-10 20:00:00+00:00', 0);
+				INSERT INTO satellite_exit_progress VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000','2019-09-10 20:00:00+00:00', null, 100, 0, null);
+			`,
+			NewData: `
+				INSERT INTO untrusted_satellites VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000','2020-09-10 20:00:00+00:00', null);
+			`,
+		},
+		storagenodedb.DeprecatedInfoDBName: v43.DBStates[storagenodedb.DeprecatedInfoDBName],
+		storagenodedb.NotificationsDBName:  v43.DBStates[storagenodedb.NotificationsDBName],
+		storagenodedb.HeldAmountDBName:     v43.DBStates[storagenodedb.HeldAmountDBName],
+		storagenodedb.PricingDBName:        v43.DBStates[storagenodedb.PricingDBName],
+		storagenodedb.SecretDBName:         v46.DBStates[storagenodedb.SecretDBName],
+		storagenodedb.RetainReportsDBName:  v47.DBStates[storagenodedb.RetainReportsDBName],
+	},
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package testdata
+
+import "storj.io/storj/storagenode/storagenodedb"
+
+var v50 = MultiDBState{
+	Version: 50,
+	DBStates: DBStates{
+		storagenodedb.UsedSerialsDBName:     v43.DBStates[storagenodedb.UsedSerialsDBName],
+		storagenodedb.StorageUsageDBName:    v43.DBStates[storagenodedb.StorageUsageDBName],
+		storagenodedb.ReputationDBName:      v45.DBStates[storagenodedb.ReputationDBName],
+		storagenodedb.PieceSpaceUsedDBName:  v43.DBStates[storagenodedb.PieceSpaceUsedDBName],
+		storagenodedb.PieceInfoDBName:       v43.DBStates[storagenodedb.PieceInfoDBName],
+		storagenodedb.PieceExpirationDBName: v48.DBStates[storagenodedb.PieceExpirationDBName],
+		storagenodedb.OrdersDBName:          v43.DBStates[storagenodedb.OrdersDBName],
+		storagenodedb.BandwidthDBName:       v43.DBStates[storagenodedb.BandwidthDBName],
+		storagenodedb.SatellitesDBName: &DBState{
+			SQL: `
+				CREATE TABLE satellites (
+					node_id BLOB NOT NULL,
+					added_at TIMESTAMP NOT NULL,
+					status INTEGER NOT NULL,
+					PRIMARY KEY (node_id)
+				);
+				CREATE TABLE satellite_exit_progress (
+					satellite_id BLOB NOT NULL,
+					initiated_at TIMESTAMP,
+					finished_at TIMESTAMP,
+					starting_disk_usage INTEGER NOT NULL,
+					bytes_deleted INTEGER NOT NULL,
+					completion_receipt BLOB,
+					FOREIGN KEY (satellite_id) REFERENCES satellites (node_id)
+				);
+				CREATE TABLE untrusted_satellites (
+					satellite_id BLOB NOT NULL,
+					untrusted_at TIMESTAMP NOT NULL,
+					cleaned_up_at TIMESTAMP,
+					PRIMARY KEY (satellite_id)
+				);
+				CREATE TABLE satellite_exit_transfer_stats (
+					satellite_id BLOB NOT NULL,
+					reason TEXT NOT NULL,
+					pieces INTEGER NOT NULL,
+					bytes INTEGER NOT NULL,
+					duration INTEGER NOT NULL,
+					last_error TEXT NOT NULL,
+					last_at TIMESTAMP NOT NULL,
+					PRIMARY KEY (satellite_id, reason)
+				);
+				CREATE TABLE satellite_exit_transfer_failures (
+					satellite_id BLOB NOT NULL,
+					piece_id BLOB NOT NULL,
+					reason TEXT NOT NULL,
+					bytes INTEGER NOT NULL,
+					duration INTEGER NOT NULL,
+					error TEXT NOT NULL,
+					failed_at TIMESTAMP NOT NULL
+				);
+				INSERT INTO satellites VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000','2019-09-10 20:00:00+00:00', 0);
+				INSERT INTO satellite_exit_progress VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000','2019-09-10 20:00:00+00:00', null, 100, 0, null);
+				INSERT INTO untrusted_satellites VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000','2020-09-10 20:00:00+00:00', null);
+			`,
+			NewData: `
+				INSERT INTO satellite_exit_transfer_stats VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000', 'hash_mismatch', 1, 1024, 1000000000, 'hash mismatch', '2020-09-10 20:00:00+00:00');
+				INSERT INTO satellite_exit_transfer_failures VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000', X'0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20', 'hash_mismatch', 1024, 1000000000, 'hash mismatch', '2020-09-10 20:00:00+00:00');
+			`,
+		},
+		storagenodedb.DeprecatedInfoDBName: v43.DBStates[storagenodedb.DeprecatedInfoDBName],
+		storagenodedb.NotificationsDBName:  v43.DBStates[storagenodedb.NotificationsDBName],
+		storagenodedb.HeldAmountDBName:     v43.DBStates[storagenodedb.HeldAmountDBName],
+		storagenodedb.PricingDBName:        v43.DBStates[storagenodedb.PricingDBName],
+		storagenodedb.SecretDBName:         v46.DBStates[storagenodedb.SecretDBName],
+		storagenodedb.RetainReportsDBName:  v47.DBStates[storagenodedb.RetainReportsDBName],
+	},
+}
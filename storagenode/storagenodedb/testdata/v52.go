@@ -0,0 +1,77 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package testdata
+
+import "storj.io/storj/storagenode/storagenodedb"
+
+var v52 = MultiDBState{
+	Version: 52,
+	DBStates: DBStates{
+		storagenodedb.UsedSerialsDBName:  v43.DBStates[storagenodedb.UsedSerialsDBName],
+		storagenodedb.StorageUsageDBName: v43.DBStates[storagenodedb.StorageUsageDBName],
+		storagenodedb.ReputationDBName: &DBState{
+			SQL: `
+				-- tables to store nodestats cache
+				CREATE TABLE reputation (
+					satellite_id BLOB NOT NULL,
+					uptime_success_count INTEGER NOT NULL,
+					uptime_total_count INTEGER NOT NULL,
+					uptime_reputation_alpha REAL NOT NULL,
+					uptime_reputation_beta REAL NOT NULL,
+					uptime_reputation_score REAL NOT NULL,
+					audit_success_count INTEGER NOT NULL,
+					audit_total_count INTEGER NOT NULL,
+					audit_reputation_alpha REAL NOT NULL,
+					audit_reputation_beta REAL NOT NULL,
+					audit_reputation_score REAL NOT NULL,
+					audit_unknown_reputation_alpha REAL NOT NULL,
+					audit_unknown_reputation_beta REAL NOT NULL,
+					audit_unknown_reputation_score REAL NOT NULL,
+					online_score REAL NOT NULL,
+					disqualified_at TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL,
+					suspended_at TIMESTAMP,
+					offline_suspended_at TIMESTAMP,
+					offline_under_review_at TIMESTAMP,
+					joined_at TIMESTAMP NOT NULL,
+					vetted_at TIMESTAMP,
+					vetting_audit_count INTEGER NOT NULL DEFAULT 0,
+					PRIMARY KEY (satellite_id)
+				);
+				INSERT INTO reputation VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000',1,1,1.0,1.0,1.0,1,1,1.0,1.0,1.0,1.0,1.0,1.0,1.0,'2019-07-19 20:00:00+00:00','2019-08-23 20:00:00+00:00',NULL,NULL,NULL,'1970-01-01 00:00:00+00:00',NULL,0);
+			`,
+			NewData: `
+				INSERT INTO reputation VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eadebe5a7a700000000',1,1,1.0,1.0,1.0,100,100,1.0,1.0,1.0,1.0,1.0,1.0,1.0,NULL,'2020-12-01 20:00:00+00:00',NULL,NULL,NULL,'2020-10-01 00:00:00+00:00','2020-11-15 10:00:00+00:00',100);
+			`,
+		},
+		storagenodedb.PieceSpaceUsedDBName:  v43.DBStates[storagenodedb.PieceSpaceUsedDBName],
+		storagenodedb.PieceInfoDBName:       v43.DBStates[storagenodedb.PieceInfoDBName],
+		storagenodedb.PieceExpirationDBName: v48.DBStates[storagenodedb.PieceExpirationDBName],
+		storagenodedb.OrdersDBName:          v43.DBStates[storagenodedb.OrdersDBName],
+		storagenodedb.BandwidthDBName:       v43.DBStates[storagenodedb.BandwidthDBName],
+		storagenodedb.SatellitesDBName:      v51.DBStates[storagenodedb.SatellitesDBName],
+		storagenodedb.DeprecatedInfoDBName:  v43.DBStates[storagenodedb.DeprecatedInfoDBName],
+		storagenodedb.NotificationsDBName:   v43.DBStates[storagenodedb.NotificationsDBName],
+		storagenodedb.HeldAmountDBName:      v43.DBStates[storagenodedb.HeldAmountDBName],
+		storagenodedb.PricingDBName:         v43.DBStates[storagenodedb.PricingDBName],
+		storagenodedb.SecretDBName:          v46.DBStates[storagenodedb.SecretDBName],
+		storagenodedb.RetainReportsDBName:   v47.DBStates[storagenodedb.RetainReportsDBName],
+		storagenodedb.TransferStatsDBName: &DBState{
+			SQL: `
+				-- table to hold hourly counters of transfer outcomes
+				CREATE TABLE transfer_stats (
+					satellite_id BLOB NOT NULL,
+					action INTEGER NOT NULL,
+					interval_start TIMESTAMP NOT NULL,
+					successes INTEGER NOT NULL,
+					cancels INTEGER NOT NULL,
+					failures INTEGER NOT NULL,
+					PRIMARY KEY (satellite_id, action, interval_start)
+				);
+				CREATE INDEX idx_transfer_stats_interval_start ON transfer_stats(interval_start);
+				INSERT INTO transfer_stats VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eadebe5a7a700000000',1,'2020-12-01 10:00:00+00:00',5,2,1);
+			`,
+		},
+	},
+}
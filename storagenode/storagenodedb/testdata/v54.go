@@ -0,0 +1,104 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package testdata
+
+import "storj.io/storj/storagenode/storagenodedb"
+
+var v54 = MultiDBState{
+	Version: 54,
+	DBStates: DBStates{
+		storagenodedb.UsedSerialsDBName:  v43.DBStates[storagenodedb.UsedSerialsDBName],
+		storagenodedb.StorageUsageDBName: v43.DBStates[storagenodedb.StorageUsageDBName],
+		storagenodedb.ReputationDBName: &DBState{
+			SQL: `
+				-- tables to store nodestats cache
+				CREATE TABLE reputation (
+					satellite_id BLOB NOT NULL,
+					uptime_success_count INTEGER NOT NULL,
+					uptime_total_count INTEGER NOT NULL,
+					uptime_reputation_alpha REAL NOT NULL,
+					uptime_reputation_beta REAL NOT NULL,
+					uptime_reputation_score REAL NOT NULL,
+					audit_success_count INTEGER NOT NULL,
+					audit_total_count INTEGER NOT NULL,
+					audit_reputation_alpha REAL NOT NULL,
+					audit_reputation_beta REAL NOT NULL,
+					audit_reputation_score REAL NOT NULL,
+					audit_unknown_reputation_alpha REAL NOT NULL,
+					audit_unknown_reputation_beta REAL NOT NULL,
+					audit_unknown_reputation_score REAL NOT NULL,
+					online_score REAL NOT NULL,
+					disqualified_at TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL,
+					suspended_at TIMESTAMP,
+					offline_suspended_at TIMESTAMP,
+					offline_under_review_at TIMESTAMP,
+					joined_at TIMESTAMP NOT NULL,
+					vetted_at TIMESTAMP,
+					vetting_audit_count INTEGER NOT NULL DEFAULT 0,
+					audit_history BLOB,
+					PRIMARY KEY (satellite_id)
+				);
+				INSERT INTO reputation VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000',1,1,1.0,1.0,1.0,1,1,1.0,1.0,1.0,1.0,1.0,1.0,1.0,'2019-07-19 20:00:00+00:00','2019-08-23 20:00:00+00:00',NULL,NULL,NULL,'1970-01-01 00:00:00+00:00',NULL,0,NULL);
+				INSERT INTO reputation VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eadebe5a7a700000000',1,1,1.0,1.0,1.0,100,100,1.0,1.0,1.0,1.0,1.0,1.0,1.0,NULL,'2020-12-01 20:00:00+00:00',NULL,NULL,NULL,'2020-10-01 00:00:00+00:00','2020-11-15 10:00:00+00:00',100,NULL);
+				INSERT INTO reputation VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eadebe5a7a700000001',1,1,1.0,1.0,1.0,100,100,1.0,1.0,1.0,1.0,1.0,1.0,1.0,NULL,'2020-12-01 20:00:00+00:00',NULL,NULL,NULL,'2020-10-01 00:00:00+00:00','2020-11-15 10:00:00+00:00',100,X'11000000000000f03f');
+			`,
+		},
+		storagenodedb.PieceSpaceUsedDBName: v43.DBStates[storagenodedb.PieceSpaceUsedDBName],
+		storagenodedb.PieceInfoDBName: &DBState{
+			SQL: `
+				-- table for storing piece meta info
+				CREATE TABLE pieceinfo_ (
+					satellite_id     BLOB      NOT NULL,
+					piece_id         BLOB      NOT NULL,
+					piece_size       BIGINT    NOT NULL,
+					piece_expiration TIMESTAMP,
+					order_limit       BLOB    NOT NULL,
+					uplink_piece_hash BLOB    NOT NULL,
+					uplink_cert_id    INTEGER NOT NULL,
+					deletion_failed_at TIMESTAMP,
+					piece_creation TIMESTAMP NOT NULL,
+					deletion_failures INTEGER NOT NULL DEFAULT 0,
+					deletion_retry_at TIMESTAMP,
+					FOREIGN KEY(uplink_cert_id) REFERENCES certificate(cert_id)
+				);
+				-- primary key by satellite id and piece id
+				CREATE UNIQUE INDEX pk_pieceinfo_ ON pieceinfo_(satellite_id, piece_id);
+				-- fast queries for expiration for pieces that have one
+				CREATE INDEX idx_pieceinfo__expiration ON pieceinfo_(piece_expiration) WHERE piece_expiration IS NOT NULL;
+				INSERT INTO pieceinfo_ VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000',X'd5e757fd8d207d1c46583fb58330f803dc961b71147308ff75ff1e72a0df6b0b',1000,'2019-05-09 00:00:00.000000+00:00', X'', X'0a20d5e757fd8d207d1c46583fb58330f803dc961b71147308ff75ff1e72a0df6b0b120501020304051a47304502201c16d76ecd9b208f7ad9f1edf66ce73dce50da6bde6bbd7d278415099a727421022100ca730450e7f6506c2647516f6e20d0641e47c8270f58dde2bb07d1f5a3a45673',1,NULL,'epoch',0,NULL);
+				INSERT INTO pieceinfo_ VALUES(X'2b3a5863a41f25408a8f5348839d7a1361dbd886d75786bb139a8ca0bdf41000',X'd5e757fd8d207d1c46583fb58330f803dc961b71147308ff75ff1e72a0df6b0b',337,'2019-05-09 00:00:00.000000+00:00', X'', X'0a20d5e757fd8d207d1c46583fb58330f803dc961b71147308ff75ff1e72a0df6b0b120501020304051a483046022100e623cf4705046e2c04d5b42d5edbecb81f000459713ad460c691b3361817adbf022100993da2a5298bb88de6c35b2e54009d1bf306cda5d441c228aa9eaf981ceb0f3d',2,NULL,'epoch',0,NULL);
+			`,
+			NewData: `
+				INSERT INTO pieceinfo_ VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000',X'9b4cf2ca1bb4e9d3e0ddba3c8e4ea7dbdf1c5ed1c7b7a05bbcc0e2b0ef6f7e19',2000,'2020-10-01 00:00:00.000000+00:00', X'', X'',1,'2020-10-02 00:00:00.000000+00:00','2020-09-01 00:00:00.000000+00:00',2,'2020-10-02 04:00:00.000000+00:00');
+			`,
+		},
+		storagenodedb.PieceExpirationDBName: v48.DBStates[storagenodedb.PieceExpirationDBName],
+		storagenodedb.OrdersDBName:          v43.DBStates[storagenodedb.OrdersDBName],
+		storagenodedb.BandwidthDBName:       v43.DBStates[storagenodedb.BandwidthDBName],
+		storagenodedb.SatellitesDBName:      v51.DBStates[storagenodedb.SatellitesDBName],
+		storagenodedb.DeprecatedInfoDBName:  v43.DBStates[storagenodedb.DeprecatedInfoDBName],
+		storagenodedb.NotificationsDBName:   v43.DBStates[storagenodedb.NotificationsDBName],
+		storagenodedb.HeldAmountDBName:      v43.DBStates[storagenodedb.HeldAmountDBName],
+		storagenodedb.PricingDBName:         v43.DBStates[storagenodedb.PricingDBName],
+		storagenodedb.SecretDBName:          v46.DBStates[storagenodedb.SecretDBName],
+		storagenodedb.RetainReportsDBName:   v47.DBStates[storagenodedb.RetainReportsDBName],
+		storagenodedb.TransferStatsDBName: &DBState{
+			SQL: `
+				-- table to hold hourly counters of transfer outcomes
+				CREATE TABLE transfer_stats (
+					satellite_id BLOB NOT NULL,
+					action INTEGER NOT NULL,
+					interval_start TIMESTAMP NOT NULL,
+					successes INTEGER NOT NULL,
+					cancels INTEGER NOT NULL,
+					failures INTEGER NOT NULL,
+					PRIMARY KEY (satellite_id, action, interval_start)
+				);
+				CREATE INDEX idx_transfer_stats_interval_start ON transfer_stats(interval_start);
+				INSERT INTO transfer_stats VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eadebe5a7a700000000',1,'2020-12-01 10:00:00+00:00',5,2,1);
+			`,
+		},
+	},
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storagenodedb
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/storj/private/tagsql"
+	"storj.io/storj/storagenode/transferstats"
+)
+
+// ensures that transferStatsDB implements transferstats.DB interface.
+var _ transferstats.DB = (*transferStatsDB)(nil)
+
+// ErrTransferStats represents errors from the transfer stats database.
+var ErrTransferStats = errs.Class("transfer stats db error")
+
+// TransferStatsDBName represents the database name.
+const TransferStatsDBName = "transfer_stats"
+
+// transferStatsDB stores hourly counters of transfer outcomes.
+//
+// architecture: Database
+type transferStatsDB struct {
+	dbContainerImpl
+}
+
+// Add adds the counts to the stored hourly buckets.
+func (db *transferStatsDB) Add(ctx context.Context, buckets []transferstats.Bucket) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return ErrTransferStats.Wrap(withTx(ctx, db.GetDB(), func(tx tagsql.Tx) error {
+		for _, bucket := range buckets {
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO transfer_stats (satellite_id, action, interval_start, successes, cancels, failures)
+				VALUES (?, ?, ?, ?, ?, ?)
+				ON CONFLICT(satellite_id, action, interval_start) DO UPDATE SET
+					successes = successes + excluded.successes,
+					cancels = cancels + excluded.cancels,
+					failures = failures + excluded.failures
+			`, bucket.SatelliteID, bucket.Action, bucket.IntervalStart.UTC(), bucket.Success, bucket.Cancel, bucket.Failure)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+// Summary returns the counts summed per satellite and action for buckets
+// starting in [from, to). A zero satelliteID returns all satellites.
+func (db *transferStatsDB) Summary(ctx context.Context, satelliteID storj.NodeID, from, to time.Time) (_ []transferstats.Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	query := `SELECT satellite_id, action, SUM(successes), SUM(cancels), SUM(failures)
+		FROM transfer_stats
+		WHERE interval_start >= ? AND interval_start < ?`
+	args := []interface{}{from.UTC(), to.UTC()}
+	if !satelliteID.IsZero() {
+		query += ` AND satellite_id = ?`
+		args = append(args, satelliteID)
+	}
+	query += `
+		GROUP BY satellite_id, action
+		ORDER BY satellite_id, action`
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, ErrTransferStats.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var stats []transferstats.Stats
+	for rows.Next() {
+		var stat transferstats.Stats
+		err := rows.Scan(&stat.SatelliteID, &stat.Action, &stat.Success, &stat.Cancel, &stat.Failure)
+		if err != nil {
+			return nil, ErrTransferStats.Wrap(err)
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, ErrTransferStats.Wrap(rows.Err())
+}
+
+// DeleteBefore removes all buckets which started before the given time.
+func (db *transferStatsDB) DeleteBefore(ctx context.Context, before time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.ExecContext(ctx, `DELETE FROM transfer_stats WHERE interval_start < ?`, before.UTC())
+	return ErrTransferStats.Wrap(err)
+}
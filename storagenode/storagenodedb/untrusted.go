@@ -0,0 +1,113 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storagenodedb
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/storj/storagenode/untrusted"
+)
+
+// ErrUntrustedDB represents errors from the untrusted satellites database.
+var ErrUntrustedDB = errs.Class("untrusteddb error")
+
+// untrustedDB tracks untrusted satellites in the satellites database and
+// deletes their rows from the other databases.
+type untrustedDB struct {
+	db *DB
+}
+
+// SetUntrusted records that the satellite is no longer trusted. An existing
+// record is left unchanged.
+func (db *untrustedDB) SetUntrusted(ctx context.Context, satelliteID storj.NodeID, untrustedAt time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.db.satellitesDB.ExecContext(ctx, `
+		INSERT OR IGNORE INTO untrusted_satellites (satellite_id, untrusted_at) VALUES (?, ?)
+	`, satelliteID, untrustedAt.UTC())
+	return ErrUntrustedDB.Wrap(err)
+}
+
+// SetCleanedUp records that the data of the satellite was deleted.
+func (db *untrustedDB) SetCleanedUp(ctx context.Context, satelliteID storj.NodeID, cleanedUpAt time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.db.satellitesDB.ExecContext(ctx, `
+		UPDATE untrusted_satellites SET cleaned_up_at = ? WHERE satellite_id = ?
+	`, cleanedUpAt.UTC(), satelliteID)
+	return ErrUntrustedDB.Wrap(err)
+}
+
+// Delete removes the record of the satellite.
+func (db *untrustedDB) Delete(ctx context.Context, satelliteID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.db.satellitesDB.ExecContext(ctx, `DELETE FROM untrusted_satellites WHERE satellite_id = ?`, satelliteID)
+	return ErrUntrustedDB.Wrap(err)
+}
+
+// List returns the records of all satellites which are no longer trusted.
+func (db *untrustedDB) List(ctx context.Context) (_ []untrusted.Satellite, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := db.db.satellitesDB.QueryContext(ctx, `
+		SELECT satellite_id, untrusted_at, cleaned_up_at
+		FROM untrusted_satellites
+		ORDER BY satellite_id
+	`)
+	if err != nil {
+		return nil, ErrUntrustedDB.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var satellites []untrusted.Satellite
+	for rows.Next() {
+		var satellite untrusted.Satellite
+		err := rows.Scan(&satellite.SatelliteID, &satellite.UntrustedAt, &satellite.CleanedUpAt)
+		if err != nil {
+			return nil, ErrUntrustedDB.Wrap(err)
+		}
+		satellites = append(satellites, satellite)
+	}
+	return satellites, ErrUntrustedDB.Wrap(rows.Err())
+}
+
+// DeleteSatelliteData deletes the rows stored for the satellite in the node
+// databases. Payout and pricing rows are kept for the payout history.
+func (db *untrustedDB) DeleteSatelliteData(ctx context.Context, satelliteID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for _, tables := range []struct {
+		db     *dbContainerImpl
+		tables []string
+	}{
+		{&db.db.v0PieceInfoDB.dbContainerImpl, []string{"pieceinfo_"}},
+		{&db.db.pieceExpirationDB.dbContainerImpl, []string{"piece_expirations"}},
+		{&db.db.pieceSpaceUsedDB.dbContainerImpl, []string{"piece_space_used"}},
+		{&db.db.bandwidthDB.dbContainerImpl, []string{"bandwidth_usage", "bandwidth_usage_rollups"}},
+		{&db.db.ordersDB.dbContainerImpl, []string{"unsent_order", "order_archive_"}},
+		{&db.db.reputationDB.dbContainerImpl, []string{"reputation"}},
+		{&db.db.storageUsageDB.dbContainerImpl, []string{"storage_usage"}},
+		{&db.db.retainReportsDB.dbContainerImpl, []string{"retain_reports"}},
+	} {
+		for _, table := range tables.tables {
+			_, err := tables.db.ExecContext(ctx, `DELETE FROM `+table+` WHERE satellite_id = ?`, satelliteID)
+			if err != nil {
+				return ErrUntrustedDB.New("deleting from %s: %w", table, err)
+			}
+		}
+	}
+
+	// invalidate the cached bandwidth usage of the month, so that it's
+	// recalculated without the deleted rows.
+	db.db.bandwidthDB.usedMu.Lock()
+	db.db.bandwidthDB.usedSince = time.Time{}
+	db.db.bandwidthDB.usedMu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package transferstats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
+	"storj.io/storj/storagenode/transferstats"
+)
+
+func TestDB(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		stats := db.TransferStats()
+
+		satellite1, satellite2 := testrand.NodeID(), testrand.NodeID()
+		hour := time.Now().UTC().Truncate(time.Hour)
+
+		require.NoError(t, stats.Add(ctx, []transferstats.Bucket{
+			{SatelliteID: satellite1, Action: pb.PieceAction_PUT, IntervalStart: hour.Add(-2 * time.Hour), Counts: transferstats.Counts{Success: 3, Cancel: 1}},
+			{SatelliteID: satellite1, Action: pb.PieceAction_PUT, IntervalStart: hour, Counts: transferstats.Counts{Success: 1, Failure: 1}},
+			{SatelliteID: satellite1, Action: pb.PieceAction_GET_AUDIT, IntervalStart: hour, Counts: transferstats.Counts{Success: 2}},
+			{SatelliteID: satellite2, Action: pb.PieceAction_GET, IntervalStart: hour, Counts: transferstats.Counts{Cancel: 4}},
+		}))
+		// adding to an existing bucket increments it
+		require.NoError(t, stats.Add(ctx, []transferstats.Bucket{
+			{SatelliteID: satellite1, Action: pb.PieceAction_PUT, IntervalStart: hour, Counts: transferstats.Counts{Success: 1}},
+		}))
+
+		summary, err := stats.Summary(ctx, satellite1, hour.Add(-3*time.Hour), hour.Add(time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, []transferstats.Stats{
+			{SatelliteID: satellite1, Action: pb.PieceAction_PUT, Counts: transferstats.Counts{Success: 5, Cancel: 1, Failure: 1}},
+			{SatelliteID: satellite1, Action: pb.PieceAction_GET_AUDIT, Counts: transferstats.Counts{Success: 2}},
+		}, summary)
+
+		summary, err = stats.Summary(ctx, storj.NodeID{}, hour, hour.Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, summary, 3)
+
+		require.NoError(t, stats.DeleteBefore(ctx, hour.Add(-time.Hour)))
+
+		summary, err = stats.Summary(ctx, satellite1, hour.Add(-3*time.Hour), hour.Add(time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, []transferstats.Stats{
+			{SatelliteID: satellite1, Action: pb.PieceAction_PUT, Counts: transferstats.Counts{Success: 2, Failure: 1}},
+			{SatelliteID: satellite1, Action: pb.PieceAction_GET_AUDIT, Counts: transferstats.Counts{Success: 2}},
+		}, summary)
+	})
+}
+
+func TestService(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		service := transferstats.NewService(zaptest.NewLogger(t), db.TransferStats(), transferstats.Config{
+			FlushInterval: time.Hour,
+			Retention:     24 * time.Hour,
+		})
+
+		satelliteID := testrand.NodeID()
+		now := time.Now()
+
+		service.Record(satelliteID, pb.PieceAction_GET, transferstats.StatusSuccess, now)
+		service.Record(satelliteID, pb.PieceAction_GET, transferstats.StatusSuccess, now)
+		service.Record(satelliteID, pb.PieceAction_GET, transferstats.StatusCancel, now)
+		service.Record(satelliteID, pb.PieceAction_GET, transferstats.StatusFailure, now)
+
+		summary, err := service.Summary(ctx, satelliteID, now.Add(-time.Hour), now.Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, summary, 1)
+		require.Equal(t, transferstats.Counts{Success: 2, Cancel: 1, Failure: 1}, summary[0].Counts)
+		require.Equal(t, 0.5, summary[0].SuccessRate())
+
+		// counters are only written once
+		require.NoError(t, service.Flush(ctx))
+		summary, err = service.Summary(ctx, satelliteID, now.Add(-time.Hour), now.Add(time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, int64(4), summary[0].Total())
+	})
+}
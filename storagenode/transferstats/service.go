@@ -0,0 +1,148 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package transferstats
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+)
+
+// Config defines parameters for the transfer stats service.
+type Config struct {
+	FlushInterval time.Duration `help:"how frequently transfer outcome counters are written to the database" default:"1m0s"`
+	Retention     time.Duration `help:"how long hourly transfer outcome counters are kept" default:"720h0m0s"`
+}
+
+// bucketKey identifies an hourly bucket.
+type bucketKey struct {
+	satelliteID   storj.NodeID
+	action        pb.PieceAction
+	intervalStart time.Time
+}
+
+// Service counts transfer outcomes in memory and periodically writes them
+// to the database in hourly buckets.
+//
+// architecture: Service
+type Service struct {
+	log    *zap.Logger
+	db     DB
+	config Config
+
+	mu      sync.Mutex
+	pending map[bucketKey]Counts
+
+	Loop *sync2.Cycle
+}
+
+// NewService creates a new transfer stats service.
+func NewService(log *zap.Logger, db DB, config Config) *Service {
+	return &Service{
+		log:     log,
+		db:      db,
+		config:  config,
+		pending: map[bucketKey]Counts{},
+		Loop:    sync2.NewCycle(config.FlushInterval),
+	}
+}
+
+// Record counts a transfer outcome at the given time.
+func (service *Service) Record(satelliteID storj.NodeID, action pb.PieceAction, status Status, at time.Time) {
+	key := bucketKey{
+		satelliteID:   satelliteID,
+		action:        action,
+		intervalStart: at.UTC().Truncate(time.Hour),
+	}
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	counts := service.pending[key]
+	counts.add(status)
+	service.pending[key] = counts
+}
+
+// Run periodically writes the counters and removes expired buckets.
+func (service *Service) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return service.Loop.Run(ctx, func(ctx context.Context) error {
+		if err := service.Flush(ctx); err != nil {
+			service.log.Error("failed to write transfer stats", zap.Error(err))
+		}
+
+		if service.config.Retention > 0 {
+			if err := service.db.DeleteBefore(ctx, time.Now().Add(-service.config.Retention)); err != nil {
+				service.log.Error("failed to delete expired transfer stats", zap.Error(err))
+			}
+		}
+		return nil
+	})
+}
+
+// Flush writes the pending counters to the database. Counters which could
+// not be written are kept for the next attempt.
+func (service *Service) Flush(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	service.mu.Lock()
+	pending := service.pending
+	service.pending = map[bucketKey]Counts{}
+	service.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	buckets := make([]Bucket, 0, len(pending))
+	for key, counts := range pending {
+		buckets = append(buckets, Bucket{
+			SatelliteID:   key.satelliteID,
+			Action:        key.action,
+			IntervalStart: key.intervalStart,
+			Counts:        counts,
+		})
+	}
+
+	if err := service.db.Add(ctx, buckets); err != nil {
+		service.mu.Lock()
+		for key, counts := range pending {
+			current := service.pending[key]
+			current.Success += counts.Success
+			current.Cancel += counts.Cancel
+			current.Failure += counts.Failure
+			service.pending[key] = current
+		}
+		service.mu.Unlock()
+		return Error.Wrap(err)
+	}
+	return nil
+}
+
+// Summary returns the counts per satellite and action for transfers in [from, to).
+// Pending counters are written first, so that the summary includes them.
+// A zero satelliteID returns all satellites.
+func (service *Service) Summary(ctx context.Context, satelliteID storj.NodeID, from, to time.Time) (_ []Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := service.Flush(ctx); err != nil {
+		return nil, err
+	}
+
+	stats, err := service.db.Summary(ctx, satelliteID, from.UTC().Truncate(time.Hour), to.UTC())
+	return stats, Error.Wrap(err)
+}
+
+// Close stops the background process and writes the remaining counters.
+func (service *Service) Close() (err error) {
+	service.Loop.Close()
+	return service.Flush(context.Background())
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package transferstats tracks the outcomes of piece transfers per satellite and action.
+package transferstats
+
+import (
+	"context"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+)
+
+var (
+	// Error is the default error class for transferstats package.
+	Error = errs.Class("transferstats")
+
+	mon = monkit.Package()
+)
+
+// Status is the outcome of a transfer.
+type Status int
+
+const (
+	// StatusSuccess is a transfer which completed successfully.
+	StatusSuccess Status = iota
+	// StatusCancel is a transfer which was canceled by the remote side, usually
+	// because other nodes were faster (long-tail cancellation).
+	StatusCancel
+	// StatusFailure is a transfer which failed.
+	StatusFailure
+)
+
+// String returns the name of the status.
+func (status Status) String() string {
+	switch status {
+	case StatusSuccess:
+		return "success"
+	case StatusCancel:
+		return "cancel"
+	case StatusFailure:
+		return "failure"
+	default:
+		return "unknown"
+	}
+}
+
+// DB works with the hourly transfer outcome counters.
+//
+// architecture: Database
+type DB interface {
+	// Add adds the counts to the stored hourly buckets.
+	Add(ctx context.Context, buckets []Bucket) error
+	// Summary returns the counts summed per satellite and action for buckets
+	// starting in [from, to). A zero satelliteID returns all satellites.
+	Summary(ctx context.Context, satelliteID storj.NodeID, from, to time.Time) ([]Stats, error)
+	// DeleteBefore removes all buckets which started before the given time.
+	DeleteBefore(ctx context.Context, before time.Time) error
+}
+
+// Counts contains the number of transfers per status.
+type Counts struct {
+	Success int64
+	Cancel  int64
+	Failure int64
+}
+
+// Total returns the number of all transfers.
+func (counts Counts) Total() int64 {
+	return counts.Success + counts.Cancel + counts.Failure
+}
+
+// SuccessRate returns the share of successful transfers, 0 when there were none.
+func (counts Counts) SuccessRate() float64 {
+	total := counts.Total()
+	if total == 0 {
+		return 0
+	}
+	return float64(counts.Success) / float64(total)
+}
+
+// add increments the counter of the status.
+func (counts *Counts) add(status Status) {
+	switch status {
+	case StatusSuccess:
+		counts.Success++
+	case StatusCancel:
+		counts.Cancel++
+	default:
+		counts.Failure++
+	}
+}
+
+// Bucket contains the counts of a satellite and action for the hour starting at IntervalStart.
+type Bucket struct {
+	SatelliteID   storj.NodeID
+	Action        pb.PieceAction
+	IntervalStart time.Time
+	Counts
+}
+
+// Stats contains the counts of a satellite and action summed over a period.
+type Stats struct {
+	SatelliteID storj.NodeID
+	Action      pb.PieceAction
+	Counts
+}
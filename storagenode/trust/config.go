@@ -4,6 +4,8 @@
 package trust
 
 import (
+	"crypto/ed25519"
+	"encoding/base64"
 	"strings"
 	"time"
 
@@ -14,6 +16,8 @@ import (
 type Config struct {
 	Sources         Sources       `help:"list of trust sources" devDefault:"" releaseDefault:"https://tardigrade.io/trusted-satellites"`
 	Exclusions      Exclusions    `help:"list of trust exclusions" devDefault:"" releaseDefault:""`
+	Pins            Pins          `help:"list of satellite IDs pinned to hosts as id@host; satellites on a pinned host are only trusted with a pinned ID" default:""`
+	PublicKey       PublicKey     `help:"base64 encoded ed25519 public key that trust lists fetched over HTTP(S) must be signed with; unsigned lists are rejected" default:""`
 	RefreshInterval time.Duration `help:"how often the trust pool should be refreshed" default:"6h"`
 	CachePath       string        `help:"file path where trust lists should be cached" default:"${CONFDIR}/trust-cache.json"`
 }
@@ -93,3 +97,101 @@ func (exclusions *Exclusions) Set(value string) error {
 func (exclusions Exclusions) Type() string {
 	return "trust-exclusions"
 }
+
+// Pins is a list of host pinning rules that implements pflag.Value.
+type Pins struct {
+	Rules Rules
+}
+
+// String returns the string representation of the config.
+func (pins *Pins) String() string {
+	s := make([]string, 0, len(pins.Rules))
+	for _, rule := range pins.Rules {
+		s = append(s, rule.String())
+	}
+	return strings.Join(s, ",")
+}
+
+// Set implements pflag.Value by parsing a comma separated list of pins.
+func (pins *Pins) Set(value string) error {
+	var entries []string
+	if value != "" {
+		entries = strings.Split(value, ",")
+	}
+
+	pinners, err := NewHostPinners(entries...)
+	if err != nil {
+		return Error.New("invalid pin: %w", errs.Unwrap(err))
+	}
+
+	var rules Rules
+	for _, pinner := range pinners {
+		rules = append(rules, pinner)
+	}
+
+	pins.Rules = rules
+	return nil
+}
+
+// Type returns the type of the pflag.Value.
+func (pins Pins) Type() string {
+	return "trust-pins"
+}
+
+// PublicKey is an ed25519 public key that implements pflag.Value.
+type PublicKey struct {
+	Key ed25519.PublicKey
+}
+
+// String returns the base64 encoding of the key.
+func (key *PublicKey) String() string {
+	if key.Key == nil {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(key.Key)
+}
+
+// Set implements pflag.Value by parsing a base64 encoded key.
+func (key *PublicKey) Set(value string) error {
+	if value == "" {
+		key.Key = nil
+		return nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return Error.New("invalid public key %q: %w", value, err)
+	}
+	if len(decoded) != ed25519.PublicKeySize {
+		return Error.New("invalid public key %q: expected %d bytes, got %d", value, ed25519.PublicKeySize, len(decoded))
+	}
+
+	key.Key = ed25519.PublicKey(decoded)
+	return nil
+}
+
+// Type returns the type of the pflag.Value.
+func (key PublicKey) Type() string {
+	return "trust-public-key"
+}
+
+// signed returns the sources, where HTTP(S) sources only accept lists signed
+// by the key. The sources are returned unchanged when no key is configured.
+func (sources Sources) signed(key PublicKey) (Sources, error) {
+	if key.Key == nil {
+		return sources, nil
+	}
+
+	signed := make(Sources, 0, len(sources))
+	for _, source := range sources {
+		if httpSource, ok := source.(*HTTPSource); ok {
+			var err error
+			source, err = httpSource.WithPublicKey(key.Key)
+			if err != nil {
+				return nil, err
+			}
+		}
+		signed = append(signed, source)
+	}
+	return signed, nil
+}
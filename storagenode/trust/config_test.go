@@ -4,6 +4,8 @@
 package trust_test
 
 import (
+	"crypto/ed25519"
+	"encoding/base64"
 	"fmt"
 	"strings"
 	"testing"
@@ -61,3 +63,44 @@ func TestExclusionsConfig(t *testing.T) {
 		assert.Equal(t, exclusion2, exclusions.Rules[2].String())
 	}
 }
+
+func TestPinsConfig(t *testing.T) {
+	id0, id1, id2 := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+
+	var pins trust.Pins
+	assert.Equal(t, "trust-pins", pins.Type())
+	assert.Equal(t, "", pins.String())
+
+	// Assert that pins are grouped by host
+	require.NoError(t, pins.Set(fmt.Sprintf("%s@foo.test,%s@bar.test,%s@FOO.test", id0, id1, id2)))
+	assert.Equal(t, fmt.Sprintf("%s@bar.test,%s@foo.test,%s@foo.test", id1, id0, id2), pins.String())
+	assert.Len(t, pins.Rules, 2)
+
+	// Assert that a failure to set does not modify the current pins
+	for _, invalid := range []string{fmt.Sprintf("%s@", id0), "foo.test", fmt.Sprintf("%s@foo.test:7777", id0)} {
+		require.Error(t, pins.Set(invalid), invalid)
+	}
+	assert.Len(t, pins.Rules, 2)
+}
+
+func TestPublicKeyConfig(t *testing.T) {
+	publicKey, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	encoded := base64.StdEncoding.EncodeToString(publicKey)
+
+	var key trust.PublicKey
+	assert.Equal(t, "trust-public-key", key.Type())
+	assert.Equal(t, "", key.String())
+
+	require.NoError(t, key.Set(encoded))
+	assert.Equal(t, encoded, key.String())
+	assert.Equal(t, publicKey, key.Key)
+
+	// Assert that a failure to set does not modify the current key
+	require.Error(t, key.Set("not base64"))
+	require.Error(t, key.Set(base64.StdEncoding.EncodeToString(publicKey[:16])))
+	assert.Equal(t, encoded, key.String())
+
+	require.NoError(t, key.Set(""))
+	assert.Nil(t, key.Key)
+}
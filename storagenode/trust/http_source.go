@@ -5,8 +5,12 @@ package trust
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"crypto/ed25519"
+	"encoding/base64"
 	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/url"
@@ -20,9 +24,19 @@ var (
 	ErrHTTPSource = errs.Class("HTTP source")
 )
 
+// maxHTTPSourceSize is the maximum size of a trust list or signature fetched
+// over HTTP(S).
+const maxHTTPSourceSize = 1 << 20
+
 // HTTPSource represents a trust source at a http:// or https:// URL.
+//
+// If a public key is configured, the list must be accompanied by a detached
+// ed25519 signature of the list, base64 encoded and served at the list URL
+// with a ".sig" suffix. Lists that are unsigned or fail verification are
+// rejected.
 type HTTPSource struct {
-	url *url.URL
+	url       *url.URL
+	publicKey ed25519.PublicKey
 }
 
 // NewHTTPSource constructs a new HTTPSource from a URL. The URL must be
@@ -44,11 +58,59 @@ func NewHTTPSource(httpURL string) (*HTTPSource, error) {
 	return &HTTPSource{url: u}, nil
 }
 
+// NewSignedHTTPSource constructs a new HTTPSource from a URL, like
+// NewHTTPSource, which only accepts lists signed by publicKey.
+func NewSignedHTTPSource(httpURL string, publicKey ed25519.PublicKey) (*HTTPSource, error) {
+	source, err := NewHTTPSource(httpURL)
+	if err != nil {
+		return nil, err
+	}
+	return source.WithPublicKey(publicKey)
+}
+
+// WithPublicKey returns a copy of the source which only accepts lists signed
+// by publicKey.
+func (source *HTTPSource) WithPublicKey(publicKey ed25519.PublicKey) (*HTTPSource, error) {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return nil, ErrHTTPSource.New("%q: invalid public key size %d", source.url, len(publicKey))
+	}
+	return &HTTPSource{
+		url:       source.url,
+		publicKey: publicKey,
+	}, nil
+}
+
+// Signed returns true if the source only accepts signed lists.
+func (source *HTTPSource) Signed() bool {
+	return source.publicKey != nil
+}
+
+// SignatureURL returns the URL the detached signature of the list is fetched from.
+func (source *HTTPSource) SignatureURL() string {
+	u := *source.url
+	u.Path += ".sig"
+	u.RawPath = ""
+	return u.String()
+}
+
 // String implements the Source interface and returns the URL.
 func (source *HTTPSource) String() string {
 	return source.url.String()
 }
 
+// CacheKey returns the key the entries of the source are cached under. The
+// entries of signed sources are cached per public key, so that a list cached
+// without verification, or verified with another key, is never used in place
+// of a list which fails to be fetched or verified.
+func (source *HTTPSource) CacheKey() string {
+	if !source.Signed() {
+		return source.String()
+	}
+	// the fragment can't be part of the source URL, so the key doesn't clash
+	// with the key of any unsigned source.
+	return source.String() + "#ed25519:" + base64.StdEncoding.EncodeToString(source.publicKey)
+}
+
 // Static implements the Source interface. It returns false for this source.
 func (source *HTTPSource) Static() bool { return false }
 
@@ -58,20 +120,18 @@ func (source *HTTPSource) Static() bool { return false }
 func (source *HTTPSource) FetchEntries(ctx context.Context) (_ []Entry, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	resp, err := http.Get(source.url.String())
+	list, err := fetch(ctx, source.url.String())
 	if err != nil {
-		return nil, ErrHTTPSource.Wrap(err)
+		return nil, err
 	}
-	defer func() {
-		// Errors closing the response body can be ignored since they don't
-		// impact the correctness of the function.
-		_ = resp.Body.Close()
-	}()
-	if resp.StatusCode != http.StatusOK {
-		return nil, ErrHTTPSource.New("%q: unexpected status code %d: %q", source.url, resp.StatusCode, tryReadLine(resp.Body))
+
+	if source.Signed() {
+		if err := source.verify(ctx, list); err != nil {
+			return nil, err
+		}
 	}
 
-	urls, err := ParseSatelliteURLList(ctx, resp.Body)
+	urls, err := ParseSatelliteURLList(ctx, bytes.NewReader(list))
 	if err != nil {
 		return nil, ErrHTTPSource.New("cannot parse list at %q: %w", source.url, err)
 	}
@@ -88,6 +148,55 @@ func (source *HTTPSource) FetchEntries(ctx context.Context) (_ []Entry, err erro
 	return entries, nil
 }
 
+// verify fetches the detached signature of the list and verifies it.
+func (source *HTTPSource) verify(ctx context.Context, list []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	encoded, err := fetch(ctx, source.SignatureURL())
+	if err != nil {
+		return err
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(encoded)))
+	if err != nil {
+		return ErrHTTPSource.New("invalid signature for %q: %w", source.url, err)
+	}
+	if !ed25519.Verify(source.publicKey, list, signature) {
+		return ErrHTTPSource.New("signature verification failed for %q", source.url)
+	}
+	return nil
+}
+
+// fetch retrieves the body at the given URL.
+func fetch(ctx context.Context, fetchURL string) (_ []byte, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fetchURL, nil)
+	if err != nil {
+		return nil, ErrHTTPSource.Wrap(err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, ErrHTTPSource.Wrap(err)
+	}
+	defer func() {
+		// Errors closing the response body can be ignored since they don't
+		// impact the correctness of the function.
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, ErrHTTPSource.New("%q: unexpected status code %d: %q", fetchURL, resp.StatusCode, tryReadLine(resp.Body))
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxHTTPSourceSize+1))
+	if err != nil {
+		return nil, ErrHTTPSource.Wrap(err)
+	}
+	if len(body) > maxHTTPSourceSize {
+		return nil, ErrHTTPSource.New("%q: response exceeds %d bytes", fetchURL, maxHTTPSourceSize)
+	}
+	return body, nil
+}
+
 // URLMatchesHTTPSourceHost takes the Satellite URL host and the host of the
 // HTTPSource URL and determines if the SatelliteURL matches or is in the
 // same domain as the HTTPSource URL.
@@ -5,6 +5,8 @@ package trust_test
 
 import (
 	"context"
+	"crypto/ed25519"
+	"encoding/base64"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -69,6 +71,26 @@ func TestHTTPSourceString(t *testing.T) {
 	require.Equal(t, "http://localhost:1234/path", source.String())
 }
 
+func TestHTTPSourceCacheKey(t *testing.T) {
+	publicKey, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	otherPublicKey, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	source, err := trust.NewHTTPSource("http://localhost:1234/path")
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:1234/path", source.CacheKey())
+
+	signed, err := source.WithPublicKey(publicKey)
+	require.NoError(t, err)
+	require.Equal(t, source.String(), signed.String())
+	require.NotEqual(t, source.CacheKey(), signed.CacheKey())
+
+	other, err := source.WithPublicKey(otherPublicKey)
+	require.NoError(t, err)
+	require.NotEqual(t, signed.CacheKey(), other.CacheKey())
+}
+
 func TestHTTPSourceIsNotStatic(t *testing.T) {
 	source, err := trust.NewHTTPSource("http://localhost/path")
 	require.NoError(t, err)
@@ -147,6 +169,89 @@ func TestHTTPSourceFetchEntries(t *testing.T) {
 	}
 }
 
+func TestHTTPSourceFetchEntriesSigned(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	otherPublicKey, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	url1 := makeSatelliteURL("domain.test")
+	list := url1.String() + "\n"
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, []byte(list)))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/signed", "/unsigned", "/tampered", "/garbled":
+			fmt.Fprint(w, list)
+		case "/signed.sig":
+			fmt.Fprintln(w, signature)
+		case "/tampered.sig":
+			fmt.Fprintln(w, base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, []byte("something else"))))
+		case "/garbled.sig":
+			fmt.Fprintln(w, "NOT BASE64!")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	for _, tt := range []struct {
+		name      string
+		path      string
+		publicKey ed25519.PublicKey
+		err       string
+	}{
+		{
+			name:      "list with a valid signature",
+			path:      "/signed",
+			publicKey: publicKey,
+		},
+		{
+			name:      "list signed by another key",
+			path:      "/signed",
+			publicKey: otherPublicKey,
+			err:       fmt.Sprintf("HTTP source: signature verification failed for %q", server.URL+"/signed"),
+		},
+		{
+			name:      "list without a signature",
+			path:      "/unsigned",
+			publicKey: publicKey,
+			err:       fmt.Sprintf("HTTP source: %q: unexpected status code 404: \"404 page not found\"", server.URL+"/unsigned.sig"),
+		},
+		{
+			name:      "list with a signature of other data",
+			path:      "/tampered",
+			publicKey: publicKey,
+			err:       fmt.Sprintf("HTTP source: signature verification failed for %q", server.URL+"/tampered"),
+		},
+		{
+			name:      "list with a malformed signature",
+			path:      "/garbled",
+			publicKey: publicKey,
+			err:       fmt.Sprintf("HTTP source: invalid signature for %q: illegal base64 data at input byte 3", server.URL+"/garbled"),
+		},
+	} {
+		tt := tt // quiet linting
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := trust.NewSignedHTTPSource(server.URL+tt.path, tt.publicKey)
+			require.NoError(t, err)
+			require.True(t, source.Signed())
+			require.Equal(t, server.URL+tt.path+".sig", source.SignatureURL())
+
+			entries, err := source.FetchEntries(context.Background())
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []trust.Entry{{SatelliteURL: url1}}, entries)
+		})
+	}
+
+	_, err = trust.NewSignedHTTPSource(server.URL+"/signed", publicKey[:16])
+	require.Error(t, err)
+}
+
 func TestURLMatchesHTTPSourceHost(t *testing.T) {
 	for _, tt := range []struct {
 		name       string
@@ -115,7 +115,7 @@ func (list *List) lookupCache(source Source) ([]Entry, bool) {
 	if source.Static() {
 		return nil, false
 	}
-	return list.cache.Lookup(source.String())
+	return list.cache.Lookup(cacheKey(source))
 }
 
 func (list *List) updateCache(source Source, entries []Entry) {
@@ -123,7 +123,15 @@ func (list *List) updateCache(source Source, entries []Entry) {
 	if source.Static() {
 		return
 	}
-	list.cache.Set(source.String(), entries)
+	list.cache.Set(cacheKey(source), entries)
+}
+
+// cacheKey returns the key the entries of the source are cached under.
+func cacheKey(source Source) string {
+	if keyed, ok := source.(interface{ CacheKey() string }); ok {
+		return keyed.CacheKey()
+	}
+	return source.String()
 }
 
 func (list *List) saveCache(ctx context.Context) error {
@@ -6,7 +6,11 @@ package trust_test
 import (
 	"bytes"
 	"context"
+	"crypto/ed25519"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -324,6 +328,53 @@ func TestListCacheInteraction(t *testing.T) {
 	}
 }
 
+func TestListSignedSourceCache(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	publicKey, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	entry := trust.Entry{
+		SatelliteURL: trust.SatelliteURL{
+			Host: "host1",
+			Port: 7777,
+		},
+	}
+
+	// the list is served without a signature, so the verification fails.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/list" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = fmt.Fprintln(w, entry.SatelliteURL.String())
+	}))
+	defer server.Close()
+
+	source, err := trust.NewSignedHTTPSource(server.URL+"/list", publicKey)
+	require.NoError(t, err)
+
+	// a list cached before the source was signed isn't used.
+	cache := newTestCache(t, ctx.Dir("unsigned"), map[string][]trust.Entry{
+		source.String(): {entry},
+	})
+	list, err := trust.NewList(zaptest.NewLogger(t), []trust.Source{source}, nil, cache)
+	require.NoError(t, err)
+	_, err = list.FetchURLs(ctx)
+	require.Error(t, err)
+
+	// a list cached after it was verified is used.
+	cache = newTestCache(t, ctx.Dir("signed"), map[string][]trust.Entry{
+		source.CacheKey(): {entry},
+	})
+	list, err = trust.NewList(zaptest.NewLogger(t), []trust.Source{source}, nil, cache)
+	require.NoError(t, err)
+	urls, err := list.FetchURLs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []storj.NodeURL{entry.SatelliteURL.NodeURL()}, urls)
+}
+
 func newTestCache(t *testing.T, dir string, entries map[string][]trust.Entry) *trust.Cache {
 	cachePath := filepath.Join(dir, "cache.json")
 
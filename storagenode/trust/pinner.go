@@ -0,0 +1,103 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package trust
+
+import (
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+)
+
+var (
+	// ErrPin is an error class for pin related errors.
+	ErrPin = errs.Class("pin")
+)
+
+// HostPinner only trusts Satellites on a host if they have one of the pinned
+// IDs. Satellites on other hosts are not affected. Unlike the HostExcluder,
+// subdomains of the host are not matched.
+type HostPinner struct {
+	host string
+	ids  []storj.NodeID
+}
+
+// NewHostPinner returns a new HostPinner pinning the IDs to the host.
+func NewHostPinner(host string, ids ...storj.NodeID) *HostPinner {
+	pinned := append([]storj.NodeID(nil), ids...)
+	return &HostPinner{
+		host: normalizeHost(host),
+		ids:  pinned,
+	}
+}
+
+// Host returns the pinned host.
+func (pinner *HostPinner) Host() string {
+	return pinner.host
+}
+
+// IsTrusted returns true if the given Satellite is trusted and false otherwise.
+func (pinner *HostPinner) IsTrusted(url SatelliteURL) bool {
+	if normalizeHost(url.Host) != pinner.host {
+		return true
+	}
+	for _, id := range pinner.ids {
+		if id == url.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a string representation of the pinner.
+func (pinner *HostPinner) String() string {
+	s := make([]string, 0, len(pinner.ids))
+	for _, id := range pinner.ids {
+		s = append(s, id.String()+"@"+pinner.host)
+	}
+	return strings.Join(s, ",")
+}
+
+// NewHostPinners takes pin configuration strings of the form id@host and
+// returns a HostPinner for every host. IDs pinned to the same host are
+// combined so that any of them is trusted.
+func NewHostPinners(configs ...string) ([]*HostPinner, error) {
+	var hosts []string
+	byHost := make(map[string][]storj.NodeID)
+	for _, config := range configs {
+		id, host, err := parsePinConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := byHost[host]; !ok {
+			hosts = append(hosts, host)
+		}
+		byHost[host] = append(byHost[host], id)
+	}
+	sort.Strings(hosts)
+
+	pinners := make([]*HostPinner, 0, len(hosts))
+	for _, host := range hosts {
+		pinners = append(pinners, NewHostPinner(host, byHost[host]...))
+	}
+	return pinners, nil
+}
+
+// parsePinConfig parses a pin configuration of the form id@host.
+func parsePinConfig(s string) (storj.NodeID, string, error) {
+	url, err := storj.ParseNodeURL(s)
+	if err != nil {
+		return storj.NodeID{}, "", ErrPin.Wrap(err)
+	}
+	if url.ID.IsZero() || url.Address == "" {
+		return storj.NodeID{}, "", ErrPin.New("pin must be of the form id@host")
+	}
+	if _, _, err := net.SplitHostPort(url.Address); err == nil {
+		return storj.NodeID{}, "", ErrPin.New("pin must not include a port")
+	}
+	return url.ID, normalizeHost(url.Address), nil
+}
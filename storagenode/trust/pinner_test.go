@@ -0,0 +1,45 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package trust_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/trust"
+)
+
+func TestHostPinner(t *testing.T) {
+	pinned := makeSatelliteURL("foo.test")
+	other := testrand.NodeID()
+
+	pinner := trust.NewHostPinner("FOO.test.", pinned.ID)
+	assert.Equal(t, "foo.test", pinner.Host())
+	assert.Equal(t, pinned.ID.String()+"@foo.test", pinner.String())
+
+	assert.True(t, pinner.IsTrusted(pinned), "pinned ID on the pinned host")
+	assert.False(t, pinner.IsTrusted(trust.SatelliteURL{ID: other, Host: "foo.test", Port: 7777}), "other ID on the pinned host")
+	assert.False(t, pinner.IsTrusted(trust.SatelliteURL{ID: other, Host: "Foo.Test", Port: 7777}), "other ID on the pinned host with different case")
+	assert.True(t, pinner.IsTrusted(trust.SatelliteURL{ID: other, Host: "bar.test", Port: 7777}), "other host")
+	assert.True(t, pinner.IsTrusted(trust.SatelliteURL{ID: other, Host: "sub.foo.test", Port: 7777}), "subdomain of the pinned host")
+}
+
+func TestNewHostPinners(t *testing.T) {
+	id0, id1 := testrand.NodeID(), testrand.NodeID()
+
+	pinners, err := trust.NewHostPinners(id0.String()+"@foo.test", id1.String()+"@foo.test")
+	require.NoError(t, err)
+	require.Len(t, pinners, 1)
+	assert.True(t, pinners[0].IsTrusted(trust.SatelliteURL{ID: id0, Host: "foo.test", Port: 7777}))
+	assert.True(t, pinners[0].IsTrusted(trust.SatelliteURL{ID: id1, Host: "foo.test", Port: 7777}))
+	assert.False(t, pinners[0].IsTrusted(trust.SatelliteURL{ID: testrand.NodeID(), Host: "foo.test", Port: 7777}))
+
+	for _, invalid := range []string{"://", "foo.test", id0.String() + "@", id0.String() + "@foo.test:7777"} {
+		_, err := trust.NewHostPinners(invalid)
+		require.Error(t, err, invalid)
+	}
+}
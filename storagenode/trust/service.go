@@ -88,7 +88,16 @@ func NewPool(log *zap.Logger, resolver IdentityResolver, config Config) (*Pool,
 		return nil, err
 	}
 
-	list, err := NewList(log, config.Sources, config.Exclusions.Rules, cache)
+	sources, err := config.Sources.signed(config.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make(Rules, 0, len(config.Exclusions.Rules)+len(config.Pins.Rules))
+	rules = append(rules, config.Exclusions.Rules...)
+	rules = append(rules, config.Pins.Rules...)
+
+	list, err := NewList(log, sources, rules, cache)
 	if err != nil {
 		return nil, err
 	}
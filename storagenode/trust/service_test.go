@@ -5,9 +5,13 @@ package trust_test
 
 import (
 	"context"
+	"crypto/ed25519"
 	"crypto/x509"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"sync"
 	"testing"
 
@@ -192,6 +196,70 @@ func TestPoolGetAddress(t *testing.T) {
 	require.Equal(t, "bar.test:7777", nodeurl.Address)
 }
 
+func TestPoolSignedListFallsBackToCache(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	cached := makeSatelliteURL("cached.test")
+	rogue := makeSatelliteURL("rogue.test")
+	pinned := makeSatelliteURL("pinned.test")
+	impostor := trust.SatelliteURL{ID: testrand.NodeID(), Host: "pinned.test", Port: 7777}
+
+	var mu sync.Mutex
+	list, signature := rogue.String()+"\n", "bm90IGEgc2lnbmF0dXJl"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/list":
+			fmt.Fprint(w, list)
+		case "/list.sig":
+			fmt.Fprint(w, signature)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	source, err := trust.NewHTTPSource(server.URL + "/list")
+	require.NoError(t, err)
+	signed, err := source.WithPublicKey(publicKey)
+	require.NoError(t, err)
+
+	// the entries were cached from a list verified with the key.
+	cachePath := ctx.File("trust-cache.json")
+	require.NoError(t, trust.SaveCacheData(cachePath, &trust.CacheData{
+		Entries: map[string][]trust.Entry{
+			signed.CacheKey(): {{SatelliteURL: cached}},
+		},
+	}))
+
+	var config trust.Config
+	config.Sources = trust.Sources{source}
+	config.CachePath = cachePath
+	require.NoError(t, config.PublicKey.Set(base64.StdEncoding.EncodeToString(publicKey)))
+	require.NoError(t, config.Pins.Set(pinned.ID.String()+"@pinned.test"))
+
+	pool, err := trust.NewPool(zaptest.NewLogger(t), newFakeIdentityResolver(), config)
+	require.NoError(t, err)
+
+	// the badly signed list is rejected in favor of the cached entries
+	require.NoError(t, pool.Refresh(ctx))
+	require.Equal(t, []storj.NodeID{cached.ID}, pool.GetSatellites(ctx))
+
+	// a properly signed list is trusted, except for satellites not matching a pin
+	mu.Lock()
+	list = pinned.String() + "\n" + impostor.String() + "\n"
+	signature = base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, []byte(list)))
+	mu.Unlock()
+
+	require.NoError(t, pool.Refresh(ctx))
+	require.Equal(t, []storj.NodeID{pinned.ID}, pool.GetSatellites(ctx))
+}
+
 func newPoolTest(t *testing.T) (*testcontext.Context, *trust.Pool, *fakeSource, *fakeIdentityResolver) {
 	ctx := testcontext.New(t)
 
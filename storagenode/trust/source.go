@@ -23,7 +23,7 @@ type Entry struct {
 // Source is a trust source for trusted Satellites.
 type Source interface {
 	// String is the string representation of the source. It is used as a key
-	// into the cache.
+	// into the cache, unless the source implements CacheKey.
 	String() string
 
 	// Static returns true if the source is static. Static sources are not cached.
@@ -0,0 +1,287 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package untrusted
+
+import (
+	"context"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+	"storj.io/storj/storagenode/notifications"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/trust"
+)
+
+var (
+	// Error is the default error class for the untrusted satellites chore.
+	Error = errs.Class("untrusted satellites")
+
+	mon = monkit.Package()
+)
+
+// Plan describes what happens to the data of a satellite which is no longer trusted.
+type Plan struct {
+	SatelliteID storj.NodeID `json:"satelliteId"`
+	UntrustedAt time.Time    `json:"untrustedAt"`
+	Policy      Policy       `json:"policy"`
+	// DeleteAt is when the data is deleted, nil when it's kept.
+	DeleteAt    *time.Time `json:"deleteAt"`
+	CleanedUpAt *time.Time `json:"cleanedUpAt"`
+	DryRun      bool       `json:"dryRun"`
+	PiecesTotal int64      `json:"piecesTotal"`
+	TrashTotal  int64      `json:"trashTotal"`
+}
+
+// Chore tracks satellites which are removed from the trust pool and applies
+// the configured policy to their data: it's either kept, or deleted together
+// with the rows of the satellite in the node databases once the grace period
+// has passed.
+//
+// architecture: Chore
+type Chore struct {
+	log           *zap.Logger
+	config        Config
+	trust         *trust.Pool
+	store         *pieces.Store
+	db            DB
+	notifications *notifications.Service
+	nowFn         func() time.Time
+
+	Loop *sync2.Cycle
+}
+
+// NewChore instantiates Chore.
+func NewChore(log *zap.Logger, trust *trust.Pool, store *pieces.Store, db DB, notifications *notifications.Service, config Config) *Chore {
+	return &Chore{
+		log:           log,
+		config:        config,
+		trust:         trust,
+		store:         store,
+		db:            db,
+		notifications: notifications,
+		nowFn:         time.Now,
+		Loop:          sync2.NewCycle(config.Interval),
+	}
+}
+
+// Run starts the chore.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return chore.Loop.Run(ctx, func(ctx context.Context) error {
+		if err := chore.RunOnce(ctx); err != nil {
+			chore.log.Error("checking untrusted satellites failed", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// RunOnce records satellites which are no longer trusted, forgets satellites
+// which are trusted again and deletes the data of satellites whose grace
+// period has passed.
+func (chore *Chore) RunOnce(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	trusted := make(map[storj.NodeID]bool)
+	for _, satelliteID := range chore.trust.GetSatellites(ctx) {
+		trusted[satelliteID] = true
+	}
+	// An empty trust pool is much more likely to be a misconfiguration than
+	// the intent to delete all data.
+	if len(trusted) == 0 {
+		chore.log.Warn("no trusted satellites; skipping untrusted satellites check")
+		return nil
+	}
+
+	now := chore.nowFn()
+
+	storing, err := chore.store.StoringSatellites(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	untrusted, err := chore.db.List(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	known := make(map[storj.NodeID]bool, len(untrusted))
+	for _, satellite := range untrusted {
+		known[satellite.SatelliteID] = true
+	}
+
+	for _, satelliteID := range storing {
+		if trusted[satelliteID] || known[satelliteID] {
+			continue
+		}
+		if err := chore.db.SetUntrusted(ctx, satelliteID, now); err != nil {
+			return Error.Wrap(err)
+		}
+		untrusted = append(untrusted, Satellite{SatelliteID: satelliteID, UntrustedAt: now})
+
+		plan := chore.plan(Satellite{SatelliteID: satelliteID, UntrustedAt: now})
+		chore.log.Warn("satellite is no longer trusted",
+			zap.Stringer("Satellite ID", satelliteID),
+			zap.Stringer("Policy", plan.Policy),
+			zap.Timep("Delete At", plan.DeleteAt))
+		chore.notify(ctx, NewUntrustedNotification(satelliteID, plan))
+	}
+
+	var group errs.Group
+	for _, satellite := range untrusted {
+		switch {
+		case trusted[satellite.SatelliteID]:
+			chore.log.Info("satellite is trusted again", zap.Stringer("Satellite ID", satellite.SatelliteID))
+			group.Add(chore.db.Delete(ctx, satellite.SatelliteID))
+		case satellite.CleanedUpAt != nil:
+		default:
+			plan := chore.plan(satellite)
+			if plan.DeleteAt == nil || now.Before(*plan.DeleteAt) {
+				continue
+			}
+			group.Add(chore.cleanup(ctx, plan, now))
+		}
+	}
+	return Error.Wrap(group.Err())
+}
+
+// cleanup deletes the data of the satellite.
+func (chore *Chore) cleanup(ctx context.Context, plan Plan, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	log := chore.log.With(zap.Stringer("Satellite ID", plan.SatelliteID))
+	if plan.DryRun {
+		log.Info("dry run: would delete data of untrusted satellite", zap.Time("Untrusted At", plan.UntrustedAt))
+		return nil
+	}
+
+	log.Info("deleting data of untrusted satellite", zap.Time("Untrusted At", plan.UntrustedAt))
+
+	piecesTotal, _, err := chore.store.SpaceUsedBySatellite(ctx, plan.SatelliteID)
+	if err != nil {
+		log.Warn("failed to get space used by satellite", zap.Error(err))
+	}
+
+	err = chore.store.DeleteSatelliteBlobs(ctx, plan.SatelliteID)
+	if err != nil && !errs.IsFunc(err, os.IsNotExist) {
+		return err
+	}
+	if err := chore.store.EmptyTrash(ctx, plan.SatelliteID, now); err != nil {
+		return err
+	}
+	if err := chore.db.DeleteSatelliteData(ctx, plan.SatelliteID); err != nil {
+		return err
+	}
+	if err := chore.db.SetCleanedUp(ctx, plan.SatelliteID, now); err != nil {
+		return err
+	}
+
+	mon.Meter("untrusted_satellite_cleanup").Mark(1)
+	log.Info("deleted data of untrusted satellite", zap.Stringer("Space Freed", memory.Size(piecesTotal)))
+	chore.notify(ctx, NewCleanedUpNotification(plan.SatelliteID, piecesTotal))
+	return nil
+}
+
+// Preview returns what happens to the data of every satellite which is no
+// longer trusted, without changing anything.
+func (chore *Chore) Preview(ctx context.Context) (_ []Plan, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	untrusted, err := chore.db.List(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	trashBySatellite, err := chore.store.SpaceUsedForTrashBySatellite(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	plans := make([]Plan, 0, len(untrusted))
+	for _, satellite := range untrusted {
+		plan := chore.plan(satellite)
+		if satellite.CleanedUpAt == nil {
+			plan.PiecesTotal, _, err = chore.store.SpaceUsedBySatellite(ctx, satellite.SatelliteID)
+			if err != nil {
+				return nil, Error.Wrap(err)
+			}
+			plan.TrashTotal = trashBySatellite[satellite.SatelliteID]
+		}
+		plans = append(plans, plan)
+	}
+	sort.Slice(plans, func(i, k int) bool {
+		return plans[i].SatelliteID.Less(plans[k].SatelliteID)
+	})
+	return plans, nil
+}
+
+// plan returns what happens to the data of the satellite.
+func (chore *Chore) plan(satellite Satellite) Plan {
+	plan := Plan{
+		SatelliteID: satellite.SatelliteID,
+		UntrustedAt: satellite.UntrustedAt,
+		Policy:      chore.config.PolicyFor(satellite.SatelliteID),
+		CleanedUpAt: satellite.CleanedUpAt,
+		DryRun:      chore.config.DryRun,
+	}
+	if plan.Policy == PolicyDelete {
+		deleteAt := satellite.UntrustedAt.Add(chore.config.GracePeriod)
+		plan.DeleteAt = &deleteAt
+	}
+	return plan
+}
+
+// notify sends a notification to the dashboard.
+func (chore *Chore) notify(ctx context.Context, notification notifications.NewNotification) {
+	if chore.notifications == nil {
+		return
+	}
+	if _, err := chore.notifications.Receive(ctx, notification); err != nil {
+		chore.log.Error("failed to create notification", zap.Error(err))
+	}
+}
+
+// Close stops the chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
+
+// NewUntrustedNotification returns the notification sent when a satellite
+// storing data on the node is no longer trusted.
+func NewUntrustedNotification(satelliteID storj.NodeID, plan Plan) notifications.NewNotification {
+	message := "Your Node no longer trusts satellite " + satelliteID.String() + ". Its data is kept on disk."
+	if plan.DeleteAt != nil {
+		message = "Your Node no longer trusts satellite " + satelliteID.String() +
+			". Its data will be deleted after " + plan.DeleteAt.UTC().Format(time.RFC1123) + " unless it's trusted again."
+		if plan.DryRun {
+			message += " Dry run is enabled, so nothing will be deleted."
+		}
+	}
+	return notifications.NewNotification{
+		SenderID: satelliteID,
+		Type:     notifications.TypeUntrustedSatellite,
+		Title:    "A satellite is no longer trusted",
+		Message:  message,
+	}
+}
+
+// NewCleanedUpNotification returns the notification sent when the data of an
+// untrusted satellite was deleted.
+func NewCleanedUpNotification(satelliteID storj.NodeID, freed int64) notifications.NewNotification {
+	return notifications.NewNotification{
+		SenderID: satelliteID,
+		Type:     notifications.TypeUntrustedSatellite,
+		Title:    "Data of an untrusted satellite was deleted",
+		Message: "The data of satellite " + satelliteID.String() + ", which is no longer trusted, was deleted, freeing " +
+			memory.Size(freed).Base10String() + ".",
+	}
+}
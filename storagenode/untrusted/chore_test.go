@@ -0,0 +1,158 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package untrusted_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/rpc"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/notifications"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/reputation"
+	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
+	"storj.io/storj/storagenode/trust"
+	"storj.io/storj/storagenode/untrusted"
+)
+
+func TestSatellitePolicies(t *testing.T) {
+	satelliteID := testrand.NodeID()
+
+	var policies untrusted.SatellitePolicies
+	require.NoError(t, policies.Set(satelliteID.String()+":delete"))
+	require.Equal(t, satelliteID.String()+":delete", policies.String())
+
+	config := untrusted.Config{SatellitePolicies: policies}
+	require.Equal(t, untrusted.PolicyDelete, config.PolicyFor(satelliteID))
+	require.Equal(t, untrusted.PolicyKeep, config.PolicyFor(testrand.NodeID()))
+
+	for _, invalid := range []string{"delete", satelliteID.String() + ":purge", "invalid:keep"} {
+		require.Error(t, policies.Set(invalid), invalid)
+	}
+
+	var policy untrusted.Policy
+	require.Error(t, policy.Set("purge"))
+	require.NoError(t, policy.Set("delete"))
+	require.Equal(t, untrusted.PolicyDelete, policy)
+}
+
+func TestChore(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+
+		blobs, err := filestore.NewAt(log, ctx.Dir("store"), filestore.DefaultConfig)
+		require.NoError(t, err)
+		defer ctx.Check(blobs.Close)
+
+		cache := pieces.NewBlobsUsageCache(log, blobs)
+		store := pieces.NewStore(log, cache, nil, db.PieceExpirationDB(), db.PieceSpaceUsedDB(), pieces.DefaultConfig)
+
+		trusted := trust.SatelliteURL{ID: testrand.NodeID(), Host: "trusted.test", Port: 7777}
+		dropped := trust.SatelliteURL{ID: testrand.NodeID(), Host: "dropped.test", Port: 7777}
+
+		for _, satelliteID := range []storj.NodeID{trusted.ID, dropped.ID} {
+			w, err := store.Writer(ctx, satelliteID, testrand.PieceID())
+			require.NoError(t, err)
+			_, err = w.Write(testrand.BytesInt(int(memory.KiB)))
+			require.NoError(t, err)
+			require.NoError(t, w.Commit(ctx, &pb.PieceHeader{}))
+
+			require.NoError(t, db.Reputation().Store(ctx, reputation.Stats{SatelliteID: satelliteID}))
+		}
+
+		newPool := func(urls ...trust.SatelliteURL) *trust.Pool {
+			config := trust.Config{CachePath: ctx.File("trust-cache.json")}
+			for _, url := range urls {
+				config.Sources = append(config.Sources, &trust.StaticURLSource{URL: url})
+			}
+			pool, err := trust.NewPool(log, trust.Dialer(rpc.Dialer{}), config)
+			require.NoError(t, err)
+			require.NoError(t, pool.Refresh(ctx))
+			return pool
+		}
+		pool := newPool(trusted)
+		notificationService := notifications.NewService(log, db.Notifications(), nil)
+
+		newChore := func(pool *trust.Pool, config untrusted.Config) *untrusted.Chore {
+			config.Interval = time.Hour
+			return untrusted.NewChore(log, pool, store, db.Untrusted(), notificationService, config)
+		}
+
+		requireStoring := func(expected ...storj.NodeID) {
+			storing, err := store.StoringSatellites(ctx)
+			require.NoError(t, err)
+			require.ElementsMatch(t, expected, storing)
+		}
+
+		// the data is kept during the grace period
+		chore := newChore(pool, untrusted.Config{Policy: untrusted.PolicyDelete, GracePeriod: 24 * time.Hour})
+		require.NoError(t, chore.RunOnce(ctx))
+		requireStoring(trusted.ID, dropped.ID)
+
+		plans, err := chore.Preview(ctx)
+		require.NoError(t, err)
+		require.Len(t, plans, 1)
+		require.Equal(t, dropped.ID, plans[0].SatelliteID)
+		require.Equal(t, untrusted.PolicyDelete, plans[0].Policy)
+		require.NotNil(t, plans[0].DeleteAt)
+		require.Equal(t, plans[0].UntrustedAt.Add(24*time.Hour), *plans[0].DeleteAt)
+		require.Nil(t, plans[0].CleanedUpAt)
+		require.Greater(t, plans[0].PiecesTotal, int64(0))
+
+		unread, err := db.Notifications().UnreadAmount(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, unread)
+
+		// the data is kept with the keep policy and in dry run
+		require.NoError(t, newChore(pool, untrusted.Config{Policy: untrusted.PolicyKeep}).RunOnce(ctx))
+		require.NoError(t, newChore(pool, untrusted.Config{Policy: untrusted.PolicyDelete, DryRun: true}).RunOnce(ctx))
+		requireStoring(trusted.ID, dropped.ID)
+
+		// the data is deleted after the grace period
+		chore = newChore(pool, untrusted.Config{Policy: untrusted.PolicyDelete})
+		require.NoError(t, chore.RunOnce(ctx))
+		requireStoring(trusted.ID)
+
+		piecesTotal, _, err := store.SpaceUsedBySatellite(ctx, dropped.ID)
+		require.NoError(t, err)
+		require.Zero(t, piecesTotal)
+
+		stats, err := db.Reputation().All(ctx)
+		require.NoError(t, err)
+		require.Len(t, stats, 1)
+		require.Equal(t, trusted.ID, stats[0].SatelliteID)
+
+		plans, err = chore.Preview(ctx)
+		require.NoError(t, err)
+		require.Len(t, plans, 1)
+		require.NotNil(t, plans[0].CleanedUpAt)
+
+		unread, err = db.Notifications().UnreadAmount(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, unread)
+
+		// a satellite which is trusted again is forgotten
+		require.NoError(t, newChore(newPool(trusted, dropped), untrusted.Config{}).RunOnce(ctx))
+		plans, err = chore.Preview(ctx)
+		require.NoError(t, err)
+		require.Empty(t, plans)
+
+		// an empty trust pool doesn't cause any data to be deleted
+		require.NoError(t, newChore(newPool(), untrusted.Config{Policy: untrusted.PolicyDelete}).RunOnce(ctx))
+		requireStoring(trusted.ID)
+		plans, err = chore.Preview(ctx)
+		require.NoError(t, err)
+		require.Empty(t, plans)
+	})
+}
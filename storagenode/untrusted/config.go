@@ -0,0 +1,119 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package untrusted
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"storj.io/common/storj"
+)
+
+// Policy is what happens to the data of a satellite which is no longer trusted.
+type Policy string
+
+const (
+	// PolicyKeep keeps the data on disk.
+	PolicyKeep Policy = "keep"
+	// PolicyDelete deletes the data after the grace period.
+	PolicyDelete Policy = "delete"
+)
+
+// String returns the string representation of the policy.
+func (policy Policy) String() string {
+	if policy == "" {
+		return string(PolicyKeep)
+	}
+	return string(policy)
+}
+
+// Set implements pflag.Value by parsing the policy name.
+func (policy *Policy) Set(value string) error {
+	parsed, err := parsePolicy(value)
+	if err != nil {
+		return err
+	}
+	*policy = parsed
+	return nil
+}
+
+// Type returns the type of the pflag.Value.
+func (policy Policy) Type() string {
+	return "untrusted-policy"
+}
+
+func parsePolicy(value string) (Policy, error) {
+	switch Policy(value) {
+	case "", PolicyKeep:
+		return PolicyKeep, nil
+	case PolicyDelete:
+		return PolicyDelete, nil
+	default:
+		return "", Error.New("invalid policy %q: expected keep or delete", value)
+	}
+}
+
+// Config defines what happens to the data of satellites removed from the trust pool.
+type Config struct {
+	Interval          time.Duration     `help:"how often to check for satellites which are no longer trusted" default:"1h0m0s"`
+	Policy            Policy            `help:"what to do with the data of satellites which are no longer trusted: keep or delete" default:"keep"`
+	GracePeriod       time.Duration     `help:"how long the data of satellites which are no longer trusted is kept before it's deleted" default:"720h0m0s"`
+	SatellitePolicies SatellitePolicies `help:"comma separated list of per satellite policy overrides, e.g. <satellite id>:delete" default:""`
+	DryRun            bool              `help:"only log and notify which satellite data would be deleted, without deleting anything" default:"false"`
+}
+
+// PolicyFor returns the policy for the data of the given satellite.
+func (config Config) PolicyFor(satelliteID storj.NodeID) Policy {
+	if policy, ok := config.SatellitePolicies[satelliteID]; ok {
+		return policy
+	}
+	if config.Policy == "" {
+		return PolicyKeep
+	}
+	return config.Policy
+}
+
+// SatellitePolicies is a set of per satellite policies that implements pflag.Value.
+type SatellitePolicies map[storj.NodeID]Policy
+
+// String returns the string representation of the config.
+func (policies SatellitePolicies) String() string {
+	s := make([]string, 0, len(policies))
+	for satelliteID, policy := range policies {
+		s = append(s, satelliteID.String()+":"+policy.String())
+	}
+	sort.Strings(s)
+	return strings.Join(s, ",")
+}
+
+// Set implements pflag.Value by parsing a comma separated list of <satellite id>:<policy> entries.
+func (policies *SatellitePolicies) Set(value string) error {
+	toSet := SatellitePolicies{}
+	if value != "" {
+		for _, entry := range strings.Split(value, ",") {
+			parts := strings.SplitN(entry, ":", 2)
+			if len(parts) != 2 {
+				return Error.New("invalid satellite policy %q: expected <satellite id>:<policy>", entry)
+			}
+			satelliteID, err := storj.NodeIDFromString(parts[0])
+			if err != nil {
+				return Error.New("invalid satellite policy %q: %v", entry, err)
+			}
+			policy, err := parsePolicy(parts[1])
+			if err != nil {
+				return err
+			}
+			toSet[satelliteID] = policy
+		}
+	}
+
+	*policies = toSet
+	return nil
+}
+
+// Type returns the type of the pflag.Value.
+func (policies SatellitePolicies) Type() string {
+	return "satellite-policies"
+}
@@ -0,0 +1,39 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package untrusted
+
+import (
+	"context"
+	"time"
+
+	"storj.io/common/storj"
+)
+
+// Satellite contains the cleanup state of a satellite that is no longer trusted.
+type Satellite struct {
+	SatelliteID storj.NodeID
+	// UntrustedAt is when the satellite was first found missing from the trust pool.
+	UntrustedAt time.Time
+	// CleanedUpAt is when the data of the satellite was deleted, if it was.
+	CleanedUpAt *time.Time
+}
+
+// DB tracks satellites which are no longer trusted and deletes their data.
+//
+// architecture: Database
+type DB interface {
+	// SetUntrusted records that the satellite is no longer trusted. An existing
+	// record is left unchanged.
+	SetUntrusted(ctx context.Context, satelliteID storj.NodeID, untrustedAt time.Time) error
+	// SetCleanedUp records that the data of the satellite was deleted.
+	SetCleanedUp(ctx context.Context, satelliteID storj.NodeID, cleanedUpAt time.Time) error
+	// Delete removes the record of the satellite, e.g. when it's trusted again.
+	Delete(ctx context.Context, satelliteID storj.NodeID) error
+	// List returns the records of all satellites which are no longer trusted.
+	List(ctx context.Context) ([]Satellite, error)
+
+	// DeleteSatelliteData deletes the rows stored for the satellite in the
+	// node databases, except for payout and pricing information.
+	DeleteSatelliteData(ctx context.Context, satelliteID storj.NodeID) error
+}
@@ -0,0 +1,219 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package versioncontrol
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// Rollout is the rollout of a process on a release channel, as returned by
+// the admin endpoint.
+type Rollout struct {
+	Channel string `json:"channel"`
+	Process string `json:"process"`
+	Seed    string `json:"seed"`
+	Cursor  int    `json:"cursor"`
+}
+
+// Rollout returns the current rollout of the process on the named release channel.
+func (peer *Peer) Rollout(channelName, process string) (_ Rollout, err error) {
+	peer.mu.Lock()
+	defer peer.mu.Unlock()
+
+	channel, ok := peer.channels[channelName]
+	if !ok {
+		return Rollout{}, NotFoundErr.New("release channel %q", channelName)
+	}
+
+	binary := channel.config
+	config, ok := processConfig(&binary, process)
+	if !ok {
+		return Rollout{}, NotFoundErr.New("process %q", process)
+	}
+
+	return Rollout{
+		Channel: channelName,
+		Process: process,
+		Seed:    config.Rollout.Seed,
+		Cursor:  config.Rollout.Cursor,
+	}, nil
+}
+
+// SetRolloutCursor changes the rollout cursor percentage of the process on
+// the named release channel. The change is not persisted, the configuration
+// has to be updated as well for the cursor to survive a restart.
+func (peer *Peer) SetRolloutCursor(channelName, process string, cursor int) (_ Rollout, err error) {
+	peer.mu.Lock()
+	defer peer.mu.Unlock()
+
+	current, ok := peer.channels[channelName]
+	if !ok {
+		return Rollout{}, NotFoundErr.New("release channel %q", channelName)
+	}
+
+	binary := current.config
+	config, ok := processConfig(&binary, process)
+	if !ok {
+		return Rollout{}, NotFoundErr.New("process %q", process)
+	}
+
+	previous := config.Rollout.Cursor
+	config.Rollout.Cursor = cursor
+	if err := config.Rollout.Validate(); err != nil {
+		return Rollout{}, err
+	}
+
+	updated, err := peer.newChannel(channelName, binary)
+	if err != nil {
+		return Rollout{}, err
+	}
+	peer.channels[channelName] = updated
+
+	peer.Log.Info("Rollout cursor changed.",
+		zap.String("Channel", channelName),
+		zap.String("Process", process),
+		zap.Int("Previous Cursor", previous),
+		zap.Int("Cursor", cursor),
+	)
+
+	return Rollout{
+		Channel: channelName,
+		Process: process,
+		Seed:    config.Rollout.Seed,
+		Cursor:  config.Rollout.Cursor,
+	}, nil
+}
+
+// processConfig returns the configuration of the process named like the
+// configuration keys, e.g. storagenode-updater.
+func processConfig(binary *ProcessesConfig, process string) (*ProcessConfig, bool) {
+	name := strings.ReplaceAll(process, "-", "")
+
+	value := reflect.ValueOf(binary).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		if !strings.EqualFold(value.Type().Field(i).Name, name) {
+			continue
+		}
+		config, ok := value.Field(i).Addr().Interface().(*ProcessConfig)
+		return config, ok
+	}
+	return nil, false
+}
+
+// adminHandler serves the admin endpoint.
+type adminHandler struct {
+	log  *zap.Logger
+	peer *Peer
+
+	allowedAuthorization string
+	mux                  *mux.Router
+}
+
+// newAdminHandler creates the handler of the admin endpoint.
+func newAdminHandler(peer *Peer, config AdminConfig) *adminHandler {
+	handler := &adminHandler{
+		log:  peer.Log.Named("admin"),
+		peer: peer,
+
+		allowedAuthorization: config.AuthorizationToken,
+		mux:                  mux.NewRouter(),
+	}
+
+	handler.mux.HandleFunc("/api/rollout/{channel}/{process}", handler.getRollout).Methods("GET")
+	handler.mux.HandleFunc("/api/rollout/{channel}/{process}", handler.putRollout).Methods("PUT", "POST")
+
+	return handler
+}
+
+// ServeHTTP checks the authorization of the request before serving it.
+func (handler *adminHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if handler.allowedAuthorization == "" {
+		handler.jsonError(w, "Authorization not enabled.", http.StatusForbidden)
+		return
+	}
+
+	equality := subtle.ConstantTimeCompare(
+		[]byte(r.Header.Get("Authorization")),
+		[]byte(handler.allowedAuthorization),
+	)
+	if equality != 1 {
+		handler.jsonError(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	handler.mux.ServeHTTP(w, r)
+}
+
+func (handler *adminHandler) getRollout(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	rollout, err := handler.peer.Rollout(vars["channel"], vars["process"])
+	if err != nil {
+		handler.serveError(w, err)
+		return
+	}
+
+	handler.serveJSON(w, rollout)
+}
+
+func (handler *adminHandler) putRollout(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var request struct {
+		Cursor *int `json:"cursor"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		handler.jsonError(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if request.Cursor == nil {
+		handler.jsonError(w, "cursor is missing", http.StatusBadRequest)
+		return
+	}
+
+	rollout, err := handler.peer.SetRolloutCursor(vars["channel"], vars["process"], *request.Cursor)
+	if err != nil {
+		handler.serveError(w, err)
+		return
+	}
+
+	handler.serveJSON(w, rollout)
+}
+
+// serveError responds with the status matching the error.
+func (handler *adminHandler) serveError(w http.ResponseWriter, err error) {
+	switch {
+	case NotFoundErr.Has(err):
+		handler.jsonError(w, err.Error(), http.StatusNotFound)
+	case RolloutErr.Has(err):
+		handler.jsonError(w, err.Error(), http.StatusBadRequest)
+	default:
+		handler.log.Error("Admin request failed.", zap.Error(err))
+		handler.jsonError(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (handler *adminHandler) serveJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		handler.log.Error("Error writing response to client.", zap.Error(err))
+	}
+}
+
+func (handler *adminHandler) jsonError(w http.ResponseWriter, message string, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	err := json.NewEncoder(w).Encode(map[string]string{"error": message})
+	if err != nil {
+		handler.log.Error("Error writing response to client.", zap.Error(err))
+	}
+}
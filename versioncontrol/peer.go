@@ -11,6 +11,8 @@ import (
 	"net"
 	"net/http"
 	"reflect"
+	"sync"
+	"time"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
@@ -18,6 +20,7 @@ import (
 
 	"storj.io/common/errs2"
 	"storj.io/private/version"
+	"storj.io/storj/private/version/checker"
 )
 
 // seedLength is the number of bytes in a rollout seed.
@@ -28,6 +31,16 @@ var (
 	RolloutErr = errs.Class("rollout config error")
 	// EmptySeedErr is used when the rollout contains an empty seed value.
 	EmptySeedErr = RolloutErr.New("empty seed")
+	// NotFoundErr is used when a release channel or process does not exist.
+	NotFoundErr = errs.Class("not found")
+)
+
+// release channels served by the server.
+const (
+	// StableChannel is the default release channel.
+	StableChannel = checker.DefaultChannel
+	// BetaChannel is the release channel of early adopters.
+	BetaChannel = "beta"
 )
 
 // Config is all the configuration parameters for a Version Control Server.
@@ -36,6 +49,30 @@ type Config struct {
 	Versions OldVersionConfig
 
 	Binary ProcessesConfig
+	Beta   ChannelConfig
+
+	Signing SigningConfig
+	Admin   AdminConfig
+}
+
+// ChannelConfig represents versions configuration of an additional release
+// channel. Binary is the configuration of the stable channel.
+type ChannelConfig struct {
+	Enabled bool `user:"true" help:"serve the release channel" default:"false"`
+	Binary  ProcessesConfig
+}
+
+// SigningConfig configures the offline signed release manifests served with
+// the versions. The manifests are created with the sign-manifest command.
+type SigningConfig struct {
+	StableManifest string `user:"true" help:"path to the signed release manifest of the stable channel, responses are not signed when empty" default:""`
+	BetaManifest   string `user:"true" help:"path to the signed release manifest of the beta channel, responses are not signed when empty" default:""`
+}
+
+// AdminConfig configures the admin endpoint used to change rollouts at runtime.
+type AdminConfig struct {
+	Address            string `user:"true" help:"private address the admin endpoint listens on, disabled when empty" default:""`
+	AuthorizationToken string `user:"true" help:"token required in the Authorization header of admin requests" default:""`
 }
 
 // OldVersionConfig provides a list of allowed Versions per process.
@@ -90,24 +127,55 @@ type Peer struct {
 		Endpoint http.Server
 		Listener net.Listener
 	}
-	Versions version.AllowedVersions
 
-	// response contains the byte version of current allowed versions
+	// Admin server, the listener is nil when the admin endpoint is disabled.
+	Admin struct {
+		Endpoint http.Server
+		Listener net.Listener
+	}
+
+	oldVersions OldVersionConfig
+	manifests   map[string]*checker.SignedManifest
+
+	mu       sync.Mutex
+	channels map[string]*channel
+}
+
+// channel is the state of a single release channel.
+type channel struct {
+	config   ProcessesConfig
+	versions version.AllowedVersions
+
+	// response contains the byte version of current allowed versions and
+	// the signed manifest of the channel.
 	response []byte
 }
 
 // HandleGet contains the request handler for the version control web server.
+//
+// It serves the stable release channel.
 func (peer *Peer) HandleGet(w http.ResponseWriter, r *http.Request) {
-	// Only handle GET Requests
-	if r.Method != http.MethodGet {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
+	peer.handleChannel(StableChannel)(w, r)
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	_, err := w.Write(peer.response)
-	if err != nil {
-		peer.Log.Error("Error writing response to client.", zap.Error(err))
+// handleChannel returns the request handler serving the named release channel.
+func (peer *Peer) handleChannel(name string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Only handle GET Requests
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		peer.mu.Lock()
+		response := peer.channels[name].response
+		peer.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write(response)
+		if err != nil {
+			peer.Log.Error("Error writing response to client.", zap.Error(err))
+		}
 	}
 }
 
@@ -116,87 +184,126 @@ func New(log *zap.Logger, config *Config) (peer *Peer, err error) {
 	if err := config.Binary.ValidateRollouts(log); err != nil {
 		return nil, RolloutErr.Wrap(err)
 	}
+	if config.Beta.Enabled {
+		if err := config.Beta.Binary.ValidateRollouts(log); err != nil {
+			return nil, RolloutErr.Wrap(err)
+		}
+	}
 
 	peer = &Peer{
-		Log: log,
+		Log:         log,
+		oldVersions: config.Versions,
+		manifests:   map[string]*checker.SignedManifest{},
+		channels:    map[string]*channel{},
 	}
 
-	// Convert each Service's VersionConfig String to SemVer
-	peer.Versions.Satellite, err = version.NewOldSemVer(config.Versions.Satellite)
-	if err != nil {
-		return &Peer{}, err
+	for name, path := range map[string]string{
+		StableChannel: config.Signing.StableManifest,
+		BetaChannel:   config.Signing.BetaManifest,
+	} {
+		if path == "" {
+			continue
+		}
+		peer.manifests[name], err = LoadManifest(path)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	peer.Versions.Storagenode, err = version.NewOldSemVer(config.Versions.Storagenode)
-	if err != nil {
-		return &Peer{}, err
+	if err := peer.setChannel(StableChannel, config.Binary); err != nil {
+		return nil, err
 	}
 
-	peer.Versions.Uplink, err = version.NewOldSemVer(config.Versions.Uplink)
-	if err != nil {
-		return &Peer{}, err
-	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", peer.HandleGet)
 
-	peer.Versions.Gateway, err = version.NewOldSemVer(config.Versions.Gateway)
-	if err != nil {
-		return &Peer{}, err
+	if config.Beta.Enabled {
+		if err := peer.setChannel(BetaChannel, config.Beta.Binary); err != nil {
+			return nil, err
+		}
+		mux.HandleFunc("/"+BetaChannel, peer.handleChannel(BetaChannel))
 	}
 
-	peer.Versions.Identity, err = version.NewOldSemVer(config.Versions.Identity)
-	if err != nil {
-		return &Peer{}, err
+	peer.Server.Endpoint = http.Server{
+		Handler: mux,
 	}
 
-	peer.Versions.Processes = version.Processes{}
-	peer.Versions.Processes.Satellite, err = configToProcess(config.Binary.Satellite)
+	peer.Server.Listener, err = net.Listen("tcp", config.Address)
 	if err != nil {
-		return nil, RolloutErr.Wrap(err)
+		return nil, errs.Combine(err, peer.Close())
 	}
 
-	peer.Versions.Processes.Storagenode, err = configToProcess(config.Binary.Storagenode)
-	if err != nil {
-		return nil, RolloutErr.Wrap(err)
+	if config.Admin.Address != "" {
+		peer.Admin.Endpoint = http.Server{
+			Handler: newAdminHandler(peer, config.Admin),
+		}
+
+		peer.Admin.Listener, err = net.Listen("tcp", config.Admin.Address)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Server.Listener.Close(), peer.Close())
+		}
 	}
 
-	peer.Versions.Processes.StoragenodeUpdater, err = configToProcess(config.Binary.StoragenodeUpdater)
-	if err != nil {
-		return nil, RolloutErr.Wrap(err)
+	return peer, nil
+}
+
+// Versions returns the versions currently served on the named release channel.
+func (peer *Peer) Versions(name string) (_ version.AllowedVersions, ok bool) {
+	peer.mu.Lock()
+	defer peer.mu.Unlock()
+
+	channel, ok := peer.channels[name]
+	if !ok {
+		return version.AllowedVersions{}, false
 	}
+	return channel.versions, true
+}
 
-	peer.Versions.Processes.Uplink, err = configToProcess(config.Binary.Uplink)
+// setChannel sets the versions served on the named release channel.
+func (peer *Peer) setChannel(name string, binary ProcessesConfig) (err error) {
+	channel, err := peer.newChannel(name, binary)
 	if err != nil {
-		return nil, RolloutErr.Wrap(err)
+		return err
 	}
 
-	peer.Versions.Processes.Gateway, err = configToProcess(config.Binary.Gateway)
+	peer.mu.Lock()
+	defer peer.mu.Unlock()
+
+	peer.channels[name] = channel
+	return nil
+}
+
+// newChannel creates the state of the named release channel from the configuration.
+func (peer *Peer) newChannel(name string, binary ProcessesConfig) (_ *channel, err error) {
+	versions, err := allowedVersions(peer.oldVersions, binary)
 	if err != nil {
-		return nil, RolloutErr.Wrap(err)
+		return nil, err
 	}
 
-	peer.Versions.Processes.Identity, err = configToProcess(config.Binary.Identity)
-	if err != nil {
-		return nil, RolloutErr.Wrap(err)
+	// the manifest is served as-is, it only has to match the configuration.
+	signed := peer.manifests[name]
+	if signed != nil {
+		if err := checkManifest(signed, name, versions, time.Now()); err != nil {
+			return nil, err
+		}
 	}
 
-	peer.response, err = json.Marshal(peer.Versions)
+	response, err := json.Marshal(checker.SignedVersions{
+		AllowedVersions: versions,
+		Signed:          signed,
+	})
 	if err != nil {
 		peer.Log.Error("Error marshalling version info.", zap.Error(err))
 		return nil, RolloutErr.Wrap(err)
 	}
 
-	peer.Log.Debug("Setting version info.", zap.ByteString("Value", peer.response))
+	peer.Log.Debug("Setting version info.", zap.String("Channel", name), zap.ByteString("Value", response))
 
-	mux := http.NewServeMux()
-	mux.HandleFunc("/", peer.HandleGet)
-	peer.Server.Endpoint = http.Server{
-		Handler: mux,
-	}
-
-	peer.Server.Listener, err = net.Listen("tcp", config.Address)
-	if err != nil {
-		return nil, errs.Combine(err, peer.Close())
-	}
-	return peer, nil
+	return &channel{
+		config:   binary,
+		versions: versions,
+		response: response,
+	}, nil
 }
 
 // Run runs versioncontrol server until it's either closed or it errors.
@@ -218,17 +325,44 @@ func (peer *Peer) Run(ctx context.Context) (err error) {
 		}
 		return err
 	})
+
+	if peer.Admin.Listener != nil {
+		group.Go(func() error {
+			<-ctx.Done()
+			return errs2.IgnoreCanceled(peer.Admin.Endpoint.Shutdown(ctx))
+		})
+		group.Go(func() error {
+			defer cancel()
+			peer.Log.Info("Versioning admin server started.", zap.String("Address", peer.Admin.Listener.Addr().String()))
+			err := peer.Admin.Endpoint.Serve(peer.Admin.Listener)
+			if errs2.IsCanceled(err) || errors.Is(err, http.ErrServerClosed) {
+				err = nil
+			}
+			return err
+		})
+	}
 	return group.Wait()
 }
 
 // Close closes all the resources.
 func (peer *Peer) Close() (err error) {
-	return peer.Server.Endpoint.Close()
+	return errs.Combine(
+		peer.Server.Endpoint.Close(),
+		peer.Admin.Endpoint.Close(),
+	)
 }
 
 // Addr returns the public address.
 func (peer *Peer) Addr() string { return peer.Server.Listener.Addr().String() }
 
+// AdminAddr returns the address of the admin endpoint, empty when it is disabled.
+func (peer *Peer) AdminAddr() string {
+	if peer.Admin.Listener == nil {
+		return ""
+	}
+	return peer.Admin.Listener.Addr().String()
+}
+
 // ValidateRollouts validates the rollout field of each field in the Versions struct.
 func (versions ProcessesConfig) ValidateRollouts(log *zap.Logger) error {
 	value := reflect.ValueOf(versions)
@@ -272,6 +406,69 @@ func (rollout RolloutConfig) Validate() error {
 	return nil
 }
 
+// allowedVersions converts the configuration to the allowed versions served
+// by the server.
+func allowedVersions(old OldVersionConfig, binary ProcessesConfig) (versions version.AllowedVersions, err error) {
+	// Convert each Service's VersionConfig String to SemVer
+	versions.Satellite, err = version.NewOldSemVer(old.Satellite)
+	if err != nil {
+		return version.AllowedVersions{}, err
+	}
+
+	versions.Storagenode, err = version.NewOldSemVer(old.Storagenode)
+	if err != nil {
+		return version.AllowedVersions{}, err
+	}
+
+	versions.Uplink, err = version.NewOldSemVer(old.Uplink)
+	if err != nil {
+		return version.AllowedVersions{}, err
+	}
+
+	versions.Gateway, err = version.NewOldSemVer(old.Gateway)
+	if err != nil {
+		return version.AllowedVersions{}, err
+	}
+
+	versions.Identity, err = version.NewOldSemVer(old.Identity)
+	if err != nil {
+		return version.AllowedVersions{}, err
+	}
+
+	versions.Processes = version.Processes{}
+	versions.Processes.Satellite, err = configToProcess(binary.Satellite)
+	if err != nil {
+		return version.AllowedVersions{}, RolloutErr.Wrap(err)
+	}
+
+	versions.Processes.Storagenode, err = configToProcess(binary.Storagenode)
+	if err != nil {
+		return version.AllowedVersions{}, RolloutErr.Wrap(err)
+	}
+
+	versions.Processes.StoragenodeUpdater, err = configToProcess(binary.StoragenodeUpdater)
+	if err != nil {
+		return version.AllowedVersions{}, RolloutErr.Wrap(err)
+	}
+
+	versions.Processes.Uplink, err = configToProcess(binary.Uplink)
+	if err != nil {
+		return version.AllowedVersions{}, RolloutErr.Wrap(err)
+	}
+
+	versions.Processes.Gateway, err = configToProcess(binary.Gateway)
+	if err != nil {
+		return version.AllowedVersions{}, RolloutErr.Wrap(err)
+	}
+
+	versions.Processes.Identity, err = configToProcess(binary.Identity)
+	if err != nil {
+		return version.AllowedVersions{}, RolloutErr.Wrap(err)
+	}
+
+	return versions, nil
+}
+
 func configToProcess(binary ProcessConfig) (version.Process, error) {
 	process := version.Process{
 		Minimum: version.Version{
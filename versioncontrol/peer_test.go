@@ -4,14 +4,23 @@
 package versioncontrol_test
 
 import (
+	"crypto/ed25519"
 	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
 	"math/rand"
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 
+	"storj.io/common/testcontext"
+	"storj.io/private/version"
+	"storj.io/storj/private/version/checker"
 	"storj.io/storj/versioncontrol"
 )
 
@@ -154,6 +163,202 @@ func TestPeer_Run_error(t *testing.T) {
 	}
 }
 
+func TestPeer_Admin(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	keyPath := ctx.File("signing.key")
+	publicKey, err := versioncontrol.GenerateSigningKey(keyPath)
+	require.NoError(t, err)
+
+	binary := validRandVersions(t)
+	binary.StoragenodeUpdater.Rollout = randRollout(t)
+	binary.Storagenode.Rollout.Cursor = 10
+
+	beta := validRandVersions(t)
+	beta.StoragenodeUpdater.Rollout = randRollout(t)
+	beta.Storagenode.Rollout.Cursor = 50
+
+	config := versioncontrol.Config{
+		Address: "127.0.0.1:0",
+		Versions: versioncontrol.OldVersionConfig{
+			Satellite:   "v0.0.1",
+			Storagenode: "v0.0.1",
+			Uplink:      "v0.0.1",
+			Gateway:     "v0.0.1",
+			Identity:    "v0.0.1",
+		},
+		Binary: binary,
+		Beta: versioncontrol.ChannelConfig{
+			Enabled: true,
+			Binary:  beta,
+		},
+		Admin: versioncontrol.AdminConfig{
+			Address:            "127.0.0.1:0",
+			AuthorizationToken: "secret",
+		},
+	}
+
+	// the manifest is signed offline with the configured versions.
+	key, err := versioncontrol.LoadSigningKey(keyPath)
+	require.NoError(t, err)
+	manifest, err := versioncontrol.NewManifest(&config, versioncontrol.BetaChannel, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	signed, err := versioncontrol.SignManifest(key, manifest)
+	require.NoError(t, err)
+	config.Signing.BetaManifest = ctx.File("beta.manifest")
+	require.NoError(t, versioncontrol.SaveManifest(config.Signing.BetaManifest, signed))
+
+	peer, err := versioncontrol.New(zaptest.NewLogger(t), &config)
+	require.NoError(t, err)
+	ctx.Go(func() error { return peer.Run(ctx) })
+	defer ctx.Check(peer.Close)
+
+	request := func(method, path, token, body string) (int, versioncontrol.Rollout) {
+		req, err := http.NewRequestWithContext(ctx, method, "http://"+peer.AdminAddr()+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", token)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, resp.Body.Close()) }()
+
+		var rollout versioncontrol.Rollout
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&rollout))
+		}
+		return resp.StatusCode, rollout
+	}
+
+	status, _ := request(http.MethodGet, "/api/rollout/beta/storagenode", "", "")
+	require.Equal(t, http.StatusForbidden, status)
+
+	status, rollout := request(http.MethodGet, "/api/rollout/beta/storagenode", "secret", "")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, beta.Storagenode.Rollout.Seed, rollout.Seed)
+	require.Equal(t, 50, rollout.Cursor)
+
+	status, _ = request(http.MethodGet, "/api/rollout/alpha/storagenode", "secret", "")
+	require.Equal(t, http.StatusNotFound, status)
+	status, _ = request(http.MethodGet, "/api/rollout/beta/unknown", "secret", "")
+	require.Equal(t, http.StatusNotFound, status)
+	status, _ = request(http.MethodPut, "/api/rollout/beta/storagenode", "secret", `{"cursor":101}`)
+	require.Equal(t, http.StatusBadRequest, status)
+	status, _ = request(http.MethodPut, "/api/rollout/beta/storagenode", "secret", `{}`)
+	require.Equal(t, http.StatusBadRequest, status)
+
+	status, rollout = request(http.MethodPut, "/api/rollout/beta/storagenode-updater", "secret", `{"cursor":75}`)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, 75, rollout.Cursor)
+
+	// the channels are served with independent cursors.
+	betaVersions, ok := peer.Versions(versioncontrol.BetaChannel)
+	require.True(t, ok)
+	require.Equal(t, version.PercentageToCursor(75), betaVersions.Processes.StoragenodeUpdater.Rollout.Cursor)
+	require.Equal(t, version.PercentageToCursor(50), betaVersions.Processes.Storagenode.Rollout.Cursor)
+
+	stableVersions, ok := peer.Versions(versioncontrol.StableChannel)
+	require.True(t, ok)
+	require.Equal(t, version.PercentageToCursor(binary.StoragenodeUpdater.Rollout.Cursor), stableVersions.Processes.StoragenodeUpdater.Rollout.Cursor)
+	require.Equal(t, version.PercentageToCursor(10), stableVersions.Processes.Storagenode.Rollout.Cursor)
+
+	// the manifest is served as-is with the updated cursors.
+	resp, err := http.Get("http://" + peer.Addr() + "/beta")
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	var served checker.SignedVersions
+	require.NoError(t, json.Unmarshal(body, &served))
+	require.Equal(t, betaVersions.Processes, served.Processes)
+	require.NotNil(t, served.Signed)
+	require.Equal(t, signed, *served.Signed)
+	require.True(t, ed25519.Verify(publicKey, served.Signed.Manifest, served.Signed.Signature))
+
+	// the stable channel is served without a manifest.
+	resp, err = http.Get("http://" + peer.Addr())
+	require.NoError(t, err)
+	body, err = ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	served = checker.SignedVersions{}
+	require.NoError(t, json.Unmarshal(body, &served))
+	require.Nil(t, served.Signed)
+}
+
+func TestPeer_Manifest(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	keyPath := ctx.File("signing.key")
+	_, err := versioncontrol.GenerateSigningKey(keyPath)
+	require.NoError(t, err)
+	key, err := versioncontrol.LoadSigningKey(keyPath)
+	require.NoError(t, err)
+
+	config := versioncontrol.Config{
+		Address: "127.0.0.1:0",
+		Versions: versioncontrol.OldVersionConfig{
+			Satellite:   "v0.0.1",
+			Storagenode: "v0.0.1",
+			Uplink:      "v0.0.1",
+			Gateway:     "v0.0.1",
+			Identity:    "v0.0.1",
+		},
+		Binary: validRandVersions(t),
+	}
+
+	sign := func(channel string, validUntil time.Time) string {
+		manifest, err := versioncontrol.NewManifest(&config, channel, validUntil)
+		require.NoError(t, err)
+		signed, err := versioncontrol.SignManifest(key, manifest)
+		require.NoError(t, err)
+
+		path := ctx.File(channel + "-" + validUntil.Format("20060102150405") + ".manifest")
+		require.NoError(t, versioncontrol.SaveManifest(path, signed))
+		return path
+	}
+
+	valid := sign(versioncontrol.StableChannel, time.Now().Add(time.Hour))
+	expired := sign(versioncontrol.StableChannel, time.Now().Add(-time.Hour))
+
+	config.Beta.Binary = validRandVersions(t)
+	otherChannel := sign(versioncontrol.BetaChannel, time.Now().Add(time.Hour))
+
+	// the cursor is not part of the manifest.
+	config.Binary.Storagenode.Rollout.Cursor = 42
+
+	for _, test := range []struct {
+		name     string
+		manifest string
+		valid    bool
+	}{
+		{"valid", valid, true},
+		{"expired", expired, false},
+		{"other channel", otherChannel, false},
+	} {
+		config := config
+		config.Signing.StableManifest = test.manifest
+
+		peer, err := versioncontrol.New(zaptest.NewLogger(t), &config)
+		if !test.valid {
+			require.True(t, versioncontrol.ManifestErr.Has(err), test.name)
+			continue
+		}
+		require.NoError(t, err, test.name)
+		ctx.Go(func() error { return peer.Run(ctx) })
+		require.NoError(t, peer.Close())
+	}
+
+	// the manifest has to match the configured versions.
+	config.Signing.StableManifest = valid
+	config.Binary.Storagenode.Suggested.Version = "v9.9.9"
+	_, err = versioncontrol.New(zaptest.NewLogger(t), &config)
+	require.True(t, versioncontrol.ManifestErr.Has(err))
+}
+
 func TestVersions_ValidateRollouts(t *testing.T) {
 	versions := validRandVersions(t)
 	err := versions.ValidateRollouts(zaptest.NewLogger(t))
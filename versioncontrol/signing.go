@@ -0,0 +1,163 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package versioncontrol
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/private/version"
+	"storj.io/storj/private/version/checker"
+)
+
+var (
+	// SigningErr defines the signing key error class.
+	SigningErr = errs.Class("signing key error")
+	// ManifestErr defines the release manifest error class.
+	ManifestErr = errs.Class("release manifest error")
+)
+
+// LoadSigningKey loads the base64 encoded ed25519 private key at path.
+func LoadSigningKey(path string) (ed25519.PrivateKey, error) {
+	encoded, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, SigningErr.Wrap(err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(encoded)))
+	if err != nil {
+		return nil, SigningErr.New("invalid key %q: %w", path, err)
+	}
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, SigningErr.New("invalid key %q: expected %d bytes, got %d", path, ed25519.PrivateKeySize, len(key))
+	}
+	return ed25519.PrivateKey(key), nil
+}
+
+// GenerateSigningKey generates a new key, saves it base64 encoded at path
+// and returns the public key which clients verify the manifests with. An
+// existing file is not overwritten.
+func GenerateSigningKey(path string) (ed25519.PublicKey, error) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, SigningErr.Wrap(err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, SigningErr.Wrap(err)
+	}
+
+	_, err = file.WriteString(base64.StdEncoding.EncodeToString(privateKey) + "\n")
+	if err = errs.Combine(err, file.Close()); err != nil {
+		return nil, SigningErr.Wrap(err)
+	}
+	return publicKey, nil
+}
+
+// NewManifest creates the release manifest of the named channel from the
+// configuration, valid until the given time.
+func NewManifest(config *Config, channel string, validUntil time.Time) (checker.Manifest, error) {
+	var binary ProcessesConfig
+	switch channel {
+	case StableChannel:
+		binary = config.Binary
+	case BetaChannel:
+		binary = config.Beta.Binary
+	default:
+		return checker.Manifest{}, NotFoundErr.New("release channel %q", channel)
+	}
+
+	if err := binary.ValidateRollouts(zap.NewNop()); err != nil {
+		return checker.Manifest{}, RolloutErr.Wrap(err)
+	}
+	versions, err := allowedVersions(config.Versions, binary)
+	if err != nil {
+		return checker.Manifest{}, err
+	}
+
+	return checker.Manifest{
+		Channel:    channel,
+		ValidUntil: validUntil.UTC(),
+		Versions:   withoutCursors(versions),
+	}, nil
+}
+
+// SignManifest signs the manifest with the key.
+func SignManifest(key ed25519.PrivateKey, manifest checker.Manifest) (checker.SignedManifest, error) {
+	encoded, err := json.Marshal(manifest)
+	if err != nil {
+		return checker.SignedManifest{}, ManifestErr.Wrap(err)
+	}
+
+	return checker.SignedManifest{
+		Manifest:  encoded,
+		Signature: ed25519.Sign(key, encoded),
+	}, nil
+}
+
+// SaveManifest saves the signed manifest at path.
+func SaveManifest(path string, signed checker.SignedManifest) error {
+	data, err := json.MarshalIndent(signed, "", "\t")
+	if err != nil {
+		return ManifestErr.Wrap(err)
+	}
+	return ManifestErr.Wrap(ioutil.WriteFile(path, append(data, '\n'), 0644))
+}
+
+// LoadManifest loads the signed manifest at path.
+func LoadManifest(path string) (*checker.SignedManifest, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, ManifestErr.Wrap(err)
+	}
+
+	var signed checker.SignedManifest
+	if err := json.Unmarshal(data, &signed); err != nil {
+		return nil, ManifestErr.New("invalid manifest %q: %w", path, err)
+	}
+	return &signed, nil
+}
+
+// checkManifest checks that the signed manifest was made for the named
+// channel and matches the versions served on it.
+func checkManifest(signed *checker.SignedManifest, channel string, versions version.AllowedVersions, now time.Time) error {
+	var manifest checker.Manifest
+	if err := json.Unmarshal(signed.Manifest, &manifest); err != nil {
+		return ManifestErr.New("invalid manifest: %w", err)
+	}
+
+	if manifest.Channel != channel {
+		return ManifestErr.New("manifest of release channel %q configured for %q", manifest.Channel, channel)
+	}
+	if !now.Before(manifest.ValidUntil) {
+		return ManifestErr.New("manifest of release channel %q expired at %s", channel, manifest.ValidUntil.Format(time.RFC3339))
+	}
+	if !reflect.DeepEqual(manifest.Versions, withoutCursors(versions)) {
+		return ManifestErr.New("manifest of release channel %q does not match the configured versions", channel)
+	}
+	return nil
+}
+
+// withoutCursors returns the versions with the rollout cursors cleared, as
+// they are not part of the manifest.
+func withoutCursors(versions version.AllowedVersions) version.AllowedVersions {
+	value := reflect.ValueOf(&versions.Processes).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		if process, ok := value.Field(i).Addr().Interface().(*version.Process); ok {
+			process.Rollout.Cursor = version.RolloutBytes{}
+		}
+	}
+	return versions
+}
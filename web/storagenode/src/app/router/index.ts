@@ -8,6 +8,7 @@ import Page404 from '@/app/components/errors/Page404.vue';
 
 import { NavigationLink } from '@/app/types/navigation';
 import DashboardArea from '@/app/views/DashboardArea.vue';
+import LoginArea from '@/app/views/LoginArea.vue';
 import NotificationsArea from '@/app/views/NotificationsArea.vue';
 import PayoutArea from '@/app/views/PayoutArea.vue';
 
@@ -15,6 +16,7 @@ Vue.use(Router);
 
 export abstract class RouteConfig {
     public static Root = new NavigationLink('', 'Root');
+    public static Login = new NavigationLink('/login', 'Login');
     public static Notifications = new NavigationLink('/notifications', 'Notifications');
     public static Payout = new NavigationLink('/payout-information', 'Payout');
 }
@@ -30,6 +32,11 @@ export const router = new Router({
             name: RouteConfig.Root.name,
             component: DashboardArea,
         },
+        {
+            path: RouteConfig.Login.path,
+            name: RouteConfig.Login.name,
+            component: LoginArea,
+        },
         {
             path: RouteConfig.Notifications.path,
             name: RouteConfig.Notifications.name,
@@ -6,15 +6,30 @@ import VueClipboard from 'vue-clipboard2';
 import { DirectiveBinding } from 'vue/types/options';
 
 import App from '@/app/App.vue';
-import { router } from '@/app/router';
+import { RouteConfig, router } from '@/app/router';
 import { store } from '@/app/store';
 import { formatBytes } from '@/app/utils/converter';
+import { HttpClient } from '@/storagenode/utils/httpClient';
 
 Vue.config.productionTip = false;
 VueClipboard.config.autoSetContainer = true;
 
 Vue.use(VueClipboard);
 
+/**
+ * Redirects to the sign in page when the console api requires authentication.
+ */
+HttpClient.onUnauthorized = () => {
+    if (router.currentRoute.name === RouteConfig.Login.name) {
+        return;
+    }
+
+    router.push({
+        name: RouteConfig.Login.name,
+        query: { redirect: router.currentRoute.fullPath },
+    });
+};
+
 let clickOutsideEvent: EventListener;
 
 /**
@@ -0,0 +1,50 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+import { HttpClient } from '@/storagenode/utils/httpClient';
+
+/**
+ * AuthHttpApi is a http implementation of the console authentication API.
+ */
+export class AuthHttpApi {
+    private readonly client: HttpClient = new HttpClient();
+    private readonly ROOT_PATH: string = '/api/auth';
+
+    /**
+     * Signs in to the dashboard with the password, the session is kept in a cookie.
+     *
+     * @throws Error
+     */
+    public async login(password: string): Promise<void> {
+        const response = await this.client.post(`${this.ROOT_PATH}/login`, JSON.stringify({ password }));
+
+        if (response.ok) {
+            return;
+        }
+
+        if (response.status === 401) {
+            throw new Error('Invalid password');
+        }
+
+        if (response.status === 429) {
+            throw new Error('Too many sign in attempts, please try again later');
+        }
+
+        throw new Error('Can not sign in');
+    }
+
+    /**
+     * Ends the dashboard session.
+     *
+     * @throws Error
+     */
+    public async logout(): Promise<void> {
+        const response = await this.client.post(`${this.ROOT_PATH}/logout`, null);
+
+        if (response.ok) {
+            return;
+        }
+
+        throw new Error('Can not sign out');
+    }
+}
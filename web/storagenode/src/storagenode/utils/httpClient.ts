@@ -6,6 +6,12 @@
  * Exposes get, post and delete methods for JSON strings.
  */
 export class HttpClient {
+    /**
+     * Called when the console api responds with 401 Unauthorized, e.g. to
+     * redirect to the sign in page.
+     */
+    public static onUnauthorized: () => void = () => {};
+
     /**
      *
      * @param method holds http method type
@@ -22,7 +28,12 @@ export class HttpClient {
             'Content-Type': 'application/json',
         };
 
-        return await fetch(path, request);
+        const response = await fetch(path, request);
+        if (response.status === 401 && !path.startsWith('/api/auth')) {
+            HttpClient.onUnauthorized();
+        }
+
+        return response;
     }
 
     /**